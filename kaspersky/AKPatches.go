@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // AKPatches service to manage system of autoupdating by patch.exe patches.
@@ -53,18 +50,7 @@ type PatchValue struct {
 //
 // This command is applicable to patches which have no right to be installed without approval of the administrator.
 func (akp *AKPatches) ApprovePatch(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", akp.client.Server+"/api/v1.0/AKPatches.ApprovePatch",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := akp.client.Request(ctx, request, nil)
+	raw, err := akp.client.Call(ctx, "AKPatches.ApprovePatch", params, nil)
 	return raw, err
 }
 
@@ -72,17 +58,6 @@ func (akp *AKPatches) ApprovePatch(ctx context.Context, params interface{}) ([]b
 //
 // After this forbidden will be no more notifications that this patch expecting approval on installation
 func (akp *AKPatches) ForbidPatch(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", akp.client.Server+"/api/v1.0/AKPatches.ForbidPatch",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := akp.client.Request(ctx, request, nil)
+	raw, err := akp.client.Call(ctx, "AKPatches.ForbidPatch", params, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // AdHosts Class service allows to enumerate scanned active directory OU structure.
@@ -55,14 +52,8 @@ type ADHostIterator struct {
 
 // FindAdGroups Enumerates AD groups.
 func (ah *AdHosts) FindAdGroups(ctx context.Context, params FindAdGroupsParams) (*ADHostIterator, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ah.client.Server+"/api/v1.0/AdHosts.FindAdGroups", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	aDHostIterator := new(ADHostIterator)
-	raw, err := ah.client.Request(ctx, request, &aDHostIterator)
+	raw, err := ah.client.Call(ctx, "AdHosts.FindAdGroups", params, &aDHostIterator)
 	return aDHostIterator, raw, err
 }
 
@@ -91,31 +82,15 @@ type AdHstIDParentPxgRetVal struct {
 // GetChildComputer Retrieves AD host attributes.
 func (ah *AdHosts) GetChildComputer(ctx context.Context, params ChildComputerParams) (*AdHstIDParent,
 	[]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", ah.client.Server+"/api/v1.0/AdHosts.GetChildComputer", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	adHstIdParent := new(AdHstIDParent)
-	raw, err := ah.client.Request(ctx, request, &adHstIdParent)
+	raw, err := ah.client.Call(ctx, "AdHosts.GetChildComputer", params, &adHstIdParent)
 	return adHstIdParent, raw, err
 }
 
 // GetChildComputers Returns list of hosts located in "Unassigned computers" for specified organization unit.
 func (ah *AdHosts) GetChildComputers(ctx context.Context, params ChildComputersParams) (*PxgValStr, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ah.client.Server+"/api/v1.0/AdHosts.GetChildComputers", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := ah.client.Request(ctx, request, &pxgValStr)
+	raw, err := ah.client.Call(ctx, "AdHosts.GetChildComputers", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -129,18 +104,8 @@ type ChildOUParams struct {
 // GetChildOUs Returns list of child organization units for specified organization unit
 func (ah *AdHosts) GetChildOUs(ctx context.Context, params ChildOUParams) (*PxgValStr,
 	[]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", ah.client.Server+"/api/v1.0/AdHosts.GetChildOUs", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := ah.client.Request(ctx, request, &pxgValStr)
+	raw, err := ah.client.Call(ctx, "AdHosts.GetChildOUs", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -172,14 +137,8 @@ type AdhstBinOu struct {
 
 // GetOU Returns attributes of specified OU
 func (ah *AdHosts) GetOU(ctx context.Context, params OUAttributesParams) (*OUAttributes, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ah.client.Server+"/api/v1.0/AdHosts.GetOU", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	oUAttributes := new(OUAttributes)
-	raw, err := ah.client.Request(ctx, request, &oUAttributes)
+	raw, err := ah.client.Call(ctx, "AdHosts.GetOU", params, &oUAttributes)
 	return oUAttributes, raw, err
 }
 
@@ -200,16 +159,6 @@ type OUPData struct {
 
 // UpdateOU Updates OU properties.
 func (ah *AdHosts) UpdateOU(ctx context.Context, params UpdateOUParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ah.client.Server+"/api/v1.0/AdHosts.UpdateOU", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ah.client.Request(ctx, request, nil)
+	raw, err := ah.client.Call(ctx, "AdHosts.UpdateOU", params, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // AdSecManager service for Adaptive Security managing.
@@ -63,28 +60,12 @@ type PDetectValue struct {
 
 // ApproveDetect Approves detection results provided by Adaptive Security component.
 func (asm *AdSecManager) ApproveDetect(ctx context.Context, params DetectParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", asm.client.Server+"/api/v1.0/AdSecManager.ApproveDetect", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := asm.client.Request(ctx, request, nil)
+	raw, err := asm.client.Call(ctx, "AdSecManager.ApproveDetect", params, nil)
 	return raw, err
 }
 
 // DisproveDetect Disapprove detection results provided by Adaptive Security component.
 func (asm *AdSecManager) DisproveDetect(ctx context.Context, params DetectParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", asm.client.Server+"/api/v1.0/AdSecManager.DisproveDetect", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := asm.client.Request(ctx, request, nil)
+	raw, err := asm.client.Call(ctx, "AdSecManager.DisproveDetect", params, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // AdfsSso service for working with ADFS SSO. This service allow you to manage ADFS SSO settings
@@ -37,58 +33,34 @@ type AdfsSso service
 
 // GetSettings Returns a ADFS SSO settings.
 func (as *AdfsSso) GetSettings(ctx context.Context, bExtenedSettings bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"bExtenedSettings": %v}`, bExtenedSettings))
-	request, err := http.NewRequest("POST", as.client.Server+"/api/v1.0/AdfsSso.GetSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bExtenedSettings": bExtenedSettings}
 
-	raw, err := as.client.Request(ctx, request, nil)
+	raw, err := as.client.Call(ctx, "AdfsSso.GetSettings", postData, nil)
 	return raw, err
 }
 
 // SetSettings Set a ADFS SSO settings.
 func (as *AdfsSso) SetSettings(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", as.client.Server+"/api/v1.0/AdfsSso.SetSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := as.client.Request(ctx, request, nil)
+	raw, err := as.client.Call(ctx, "AdfsSso.SetSettings", params, nil)
 	return raw, err
 }
 
 // GetAdfsEnabled Get a ADFS SSO enabled/disabled.
 func (as *AdfsSso) GetAdfsEnabled(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", as.client.Server+"/api/v1.0/AdfsSso.GetAdfsEnabled", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := as.client.Request(ctx, request, nil)
+	raw, err := as.client.Call(ctx, "AdfsSso.GetAdfsEnabled", nil, nil)
 	return raw, err
 }
 
 // GetJwks Returns a ADFS JWKS.
 func (as *AdfsSso) GetJwks(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", as.client.Server+"/api/v1.0/AdfsSso.GetJwks", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := as.client.Request(ctx, request, nil)
+	raw, err := as.client.Call(ctx, "AdfsSso.GetJwks", nil, nil)
 	return raw, err
 }
 
 // SetAdfsEnabled Set a ADFS SSO enabled/disabled.
 func (as *AdfsSso) SetAdfsEnabled(ctx context.Context, bEnabled bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"bEnabled": %v}`, bEnabled))
-	request, err := http.NewRequest("POST", as.client.Server+"/api/v1.0/AdfsSso.SetAdfsEnabled", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bEnabled": bEnabled}
 
-	raw, err := as.client.Request(ctx, request, nil)
+	raw, err := as.client.Call(ctx, "AdfsSso.SetAdfsEnabled", postData, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // AdmServerSettings interface. Interface to manage server settings.
@@ -36,13 +33,8 @@ type AdmServerSettings service
 
 // GetSharedFolder Acquire shared folder.
 func (as *AdmServerSettings) GetSharedFolder(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", as.client.Server+"/api/v1.0/AdmServerSettings.GetSharedFolder", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := as.client.Request(ctx, request, &pxgValStr)
+	raw, err := as.client.Call(ctx, "AdmServerSettings.GetSharedFolder", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -54,12 +46,8 @@ func (as *AdmServerSettings) GetSharedFolder(ctx context.Context) (*PxgValStr, [
 //
 // Otherwise, a call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (as *AdmServerSettings) ChangeSharedFolder(ctx context.Context, wstrNetworkPath string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrNetworkPath": "%s"}`, wstrNetworkPath))
-	request, err := http.NewRequest("POST", as.client.Server+"/api/v1.0/AdmServerSettings.ChangeSharedFolder", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrNetworkPath": wstrNetworkPath}
 
-	raw, err := as.client.Request(ctx, request, nil)
+	raw, err := as.client.Call(ctx, "AdmServerSettings.ChangeSharedFolder", postData, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // AppCtrlApi service to get info about execution files.
@@ -50,13 +47,6 @@ type ExePFilter struct {
 // To do this for field 'FieldName' it is needed to add into pFilter the value of any type with name 'FieldName'
 // If NULL than all possible fields will be returned.
 func (ac *AppCtrlApi) GetExeFileInfo(ctx context.Context, params ExeFileInfoParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ac.client.Server+"/api/v1.0/AppCtrlApi.GetExeFileInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ac.client.Request(ctx, request, nil)
+	raw, err := ac.client.Call(ctx, "AppCtrlApi.GetExeFileInfo", params, nil)
 	return raw, err
 }
@@ -25,10 +25,8 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
+	"time"
 )
 
 // AsyncActionStateChecker service to monitor state of async action
@@ -48,6 +46,11 @@ type ActionStateResult struct {
 	LNextCheckDelay    int64       `json:"lNextCheckDelay"`
 }
 
+// NextCheckDelay returns LNextCheckDelay, the delay before the next CheckActionState call.
+func (r *ActionStateResult) NextCheckDelay() time.Duration {
+	return time.Duration(r.LNextCheckDelay) * time.Millisecond
+}
+
 type PStateData struct {
 	KlblagErrorCode    int64  `json:"KLBLAG_ERROR_CODE,omitempty"`
 	KlblagErrorFname   string `json:"KLBLAG_ERROR_FNAME,omitempty"`
@@ -83,13 +86,9 @@ type PStateData struct {
 // if returns bFinalized==true then this action has been removed, and wstrActionGuid is not valid any more.
 // Otherwise in lNextCheckDelay it should be returned delay in msec to Request next call of the CheckActionState
 func (ac *AsyncActionStateChecker) CheckActionState(ctx context.Context, wstrActionGuid string) (*ActionStateResult, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrActionGuid": "%s"}`, wstrActionGuid))
-	request, err := http.NewRequest("POST", ac.client.Server+"/api/v1.0/AsyncActionStateChecker.CheckActionState", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrActionGuid": wstrActionGuid}
 
 	aSResult := new(ActionStateResult)
-	raw, err := ac.client.Request(ctx, request, &aSResult)
+	raw, err := ac.client.Call(ctx, "AsyncActionStateChecker.CheckActionState", postData, &aSResult)
 	return aSResult, raw, err
 }
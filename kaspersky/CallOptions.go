@@ -0,0 +1,178 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// CallOption changes how a single OpenAPI call is made.
+//
+// Options are passed to KscClient.Call directly or to any service method through the context, see WithCallOptions:
+//
+//	ctx = kaspersky.WithCallOptions(ctx, kaspersky.WithVServerSession("tenant1"), kaspersky.WithTimeout(10*time.Second))
+//	hosts, _, err := client.HostGroup.FindHosts(ctx, params)
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	vServer    string
+	timeout    time.Duration
+	idempotent bool
+	noRetry    bool
+}
+
+// WithVServerSession makes the call on the virtual server with the given name instead of the one used by Login.
+//
+// KSC binds a session to a single virtual server, so the client starts a separate X-KSC-Session session
+// on the virtual server with its basic credentials on first use and keeps it until KscClient.Close.
+//
+// Only clients created with Config.XKscSession and logged in with BasicAuth can start such sessions:
+// calls of other clients (including the ones returned by KscClient.Fork and the ones using OAuth2Auth)
+// fail with an error. Use a separate client logged in on the virtual server instead.
+func WithVServerSession(name string) CallOption {
+	return func(o *callOptions) {
+		o.vServer = name
+	}
+}
+
+// WithTimeout limits duration of the call including retries and re-authentication.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// Idempotent marks the call as safe to retry, even if the method isn't read-only (see RetryPolicy).
+func Idempotent() CallOption {
+	return func(o *callOptions) {
+		o.idempotent = true
+	}
+}
+
+// withoutRetry makes a single attempt of the call, overriding RetryPolicy and Idempotent.
+// The call refused because the session has expired is still replayed once after re-authentication,
+// the server hasn't executed it.
+func withoutRetry() CallOption {
+	return func(o *callOptions) {
+		o.noRetry = true
+	}
+}
+
+type callOptionsKey struct{}
+
+// WithCallOptions returns a copy of ctx carrying the call options,
+// which are applied to every OpenAPI call made with it.
+// Options are added to the ones already carried by ctx.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+
+	o := callOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+// callOptionsFrom returns call options carried by ctx.
+func callOptionsFrom(ctx context.Context) callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return o
+}
+
+// errVServerSession the virtual server override is used by a client which can't start sessions on virtual servers.
+var errVServerSession = errors.New("kaspersky: WithVServerSession requires Config.XKscSession and Login with BasicAuth")
+
+// vServerToken returns session token of the virtual server, or "" if there is no session yet.
+func (ksc *KscClient) vServerToken(name string) string {
+	ksc.sessionMu.RLock()
+	defer ksc.sessionMu.RUnlock()
+	return ksc.vServerSessions[name]
+}
+
+// vServerSession starts a session on the virtual server if its current token is stale.
+//
+// stale is the token observed by the caller ("" if there was none): if another goroutine has already
+// replaced it meanwhile, vServerSession returns immediately, so concurrent callers share a single session.
+func (ksc *KscClient) vServerSession(ctx context.Context, name, stale string) error {
+	if !ksc.XKscSession {
+		return errVServerSession
+	}
+
+	ksc.authMu.Lock()
+	defer ksc.authMu.Unlock()
+
+	if !ksc.loggedIn {
+		return ErrSessionExpired
+	}
+
+	if ksc.authType != BasicAuth {
+		return errVServerSession
+	}
+
+	if ksc.vServerToken(name) != stale {
+		return nil
+	}
+
+	creds, err := ksc.credentials.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+	creds.VServerName = name
+
+	s, _, err := ksc.Session.startSession(ctx, creds)
+	if err != nil {
+		return err
+	}
+
+	ksc.sessionMu.Lock()
+	ksc.vServerSessions[name] = s.Str
+	ksc.sessionMu.Unlock()
+	return nil
+}
+
+// endVServerSessions terminates sessions started by WithVServerSession.
+func (ksc *KscClient) endVServerSessions(ctx context.Context) {
+	ksc.sessionMu.Lock()
+	names := make([]string, 0, len(ksc.vServerSessions))
+	for name := range ksc.vServerSessions {
+		names = append(names, name)
+	}
+	ksc.sessionMu.Unlock()
+
+	for _, name := range names {
+		_, err := ksc.Session.EndSession(WithCallOptions(ctx, WithVServerSession(name)))
+		if err != nil && !errors.Is(err, ErrSessionExpired) {
+			ksc.logger.Warn("ksc end virtual server session failed", "vserver", name, "error", err)
+		}
+
+		ksc.sessionMu.Lock()
+		delete(ksc.vServerSessions, name)
+		ksc.sessionMu.Unlock()
+	}
+}
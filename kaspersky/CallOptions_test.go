@@ -0,0 +1,205 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+// vServerServer returns a fakeServer with sessions bound to virtual servers.
+// Test.VServer replies with the virtual server of the session, Test.Expire ends the session.
+func vServerServer(t *testing.T) (srv *fakeServer, ended func() []string) {
+	var mu sync.Mutex
+	sessions := map[string]string{} // token -> virtual server
+	var endedVServers []string
+	var started int
+
+	srv = newFakeServer(t)
+	srv.handle("Session.StartSession", func(w http.ResponseWriter, r *http.Request) {
+		vServer, _ := base64.StdEncoding.DecodeString(r.Header.Get("X-KSC-VServer"))
+
+		mu.Lock()
+		started++
+		token := fmt.Sprintf("token-%d", started)
+		sessions[token] = string(vServer)
+		mu.Unlock()
+
+		fmt.Fprintf(w, `{"PxgRetVal":%q}`, token)
+	})
+
+	withSession := func(h func(w http.ResponseWriter, token, vServer string)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			token := r.Header.Get("X-KSC-Session")
+
+			mu.Lock()
+			defer mu.Unlock()
+			vServer, ok := sessions[token]
+			if !ok {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			h(w, token, vServer)
+		}
+	}
+	srv.handle("Session.Ping", withSession(func(w http.ResponseWriter, token, vServer string) {
+		fmt.Fprint(w, `{}`)
+	}))
+	srv.handle("Test.VServer", withSession(func(w http.ResponseWriter, token, vServer string) {
+		fmt.Fprintf(w, `{"PxgRetVal":%q}`, vServer)
+	}))
+	srv.handle("Test.Expire", withSession(func(w http.ResponseWriter, token, vServer string) {
+		delete(sessions, token)
+		fmt.Fprint(w, `{}`)
+	}))
+	srv.handle("Session.EndSession", withSession(func(w http.ResponseWriter, token, vServer string) {
+		delete(sessions, token)
+		endedVServers = append(endedVServers, vServer)
+		fmt.Fprint(w, `{}`)
+	}))
+
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		vServers := append([]string(nil), endedVServers...)
+		sort.Strings(vServers)
+		return vServers
+	}
+}
+
+func TestWithVServerSession(t *testing.T) {
+	srv, ended := vServerServer(t)
+	c := srv.client(Config{UserName: "user", Password: "pass", XKscSession: true})
+	ctx := context.Background()
+
+	if _, err := c.Call(ctx, "Test.VServer", nil, nil, WithVServerSession("v1")); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Call() before Login = %v, want ErrSessionExpired", err)
+	}
+
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(vServer string) {
+			defer wg.Done()
+
+			out := new(PxgValStr)
+			ctx := WithCallOptions(ctx, WithVServerSession(vServer))
+			if _, err := c.Call(ctx, "Test.VServer", nil, out); err != nil || out.Str != vServer {
+				t.Errorf("Call() on %s = %q, %v", vServer, out.Str, err)
+			}
+		}(fmt.Sprintf("v%d", i%2))
+	}
+	wg.Wait()
+
+	if n := len(srv.received("Session.StartSession")); n != 3 {
+		t.Errorf("%d sessions started, want 3 (main and one per virtual server)", n)
+	}
+
+	out := new(PxgValStr)
+	if _, err := c.Call(ctx, "Test.VServer", nil, out); err != nil || out.Str != "" {
+		t.Errorf("Call() on the main server = %q, %v", out.Str, err)
+	}
+
+	// expired virtual server session is restarted
+	if _, err := c.Call(ctx, "Test.Expire", nil, nil, WithVServerSession("v1")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Call(ctx, "Test.VServer", nil, out, WithVServerSession("v1")); err != nil || out.Str != "v1" {
+		t.Errorf("Call() after the session expired = %q, %v", out.Str, err)
+	}
+
+	if err := c.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(ended()); got != "[ v0 v1]" {
+		t.Errorf("sessions ended on %s, want the main server, v0 and v1", got)
+	}
+}
+
+func TestWithVServerSessionUnsupported(t *testing.T) {
+	srv, _ := vServerServer(t)
+	srv.reply("Session.StartSession", `{"PxgRetVal":"token"}`)
+	ctx := context.Background()
+
+	c := srv.client(Config{UserName: "user", Password: "pass"})
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Call(ctx, "Test.VServer", nil, nil, WithVServerSession("v1")); err != errVServerSession {
+		t.Errorf("Call() without XKscSession = %v, want errVServerSession", err)
+	}
+
+	c = srv.client(Config{XKscSession: true})
+	if err := c.Login(ctx, TokenAuth, "token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Call(ctx, "Test.VServer", nil, nil, WithVServerSession("v1")); err != errVServerSession {
+		t.Errorf("Call() of a client logged in with TokenAuth = %v, want errVServerSession", err)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.handle("Test.Slow", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	c := srv.client(Config{})
+
+	start := time.Now()
+	_, err := c.Call(context.Background(), "Test.Slow", nil, nil, WithTimeout(20*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Call() = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("Call() took %v", d)
+	}
+}
+
+func TestWithCallOptionsAccumulate(t *testing.T) {
+	ctx := WithCallOptions(context.Background(), WithTimeout(time.Second))
+	ctx = WithCallOptions(ctx, Idempotent(), WithVServerSession("v1"))
+
+	o := callOptionsFrom(ctx)
+	if o.timeout != time.Second || !o.idempotent || o.vServer != "v1" {
+		t.Errorf("callOptions = %+v", o)
+	}
+	if o := callOptionsFrom(context.Background()); o != (callOptions{}) {
+		t.Errorf("callOptions of a bare context = %+v", o)
+	}
+}
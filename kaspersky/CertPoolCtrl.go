@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // CertPoolCtrl service to manage the pool of certificates used by the Kaspersky Security Center Server.
@@ -37,29 +33,14 @@ type CertPoolCtrl service
 
 // GetCertificateInfo Returns information about certificate from server's certificates pool.
 func (cpc *CertPoolCtrl) GetCertificateInfo(ctx context.Context, nVServerId, nFunction int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nVServerId": %d, "nFunction" : %d }`, nVServerId, nFunction))
-	request, err := http.NewRequest("POST", cpc.client.Server+"/api/v1.0/CertPoolCtrl.GetCertificateInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nVServerId": nVServerId, "nFunction": nFunction}
 
-	raw, err := cpc.client.Request(ctx, request, nil)
+	raw, err := cpc.client.Call(ctx, "CertPoolCtrl.GetCertificateInfo", postData, nil)
 	return raw, err
 }
 
 // SetCertificate Sets certificate of a given function for a given virtual server.
 func (cpc *CertPoolCtrl) SetCertificate(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", cpc.client.Server+"/api/v1.0/CertPoolCtrl.SetCertificate", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := cpc.client.Request(ctx, request, nil)
+	raw, err := cpc.client.Call(ctx, "CertPoolCtrl.SetCertificate", params, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 //CertPoolCtrl2 2nd service to manage the pool of certificates used by the Kaspersky Security Center Server
@@ -36,13 +33,8 @@ type CertPoolCtrl2 service
 
 // GetCertificateInfoDetails Returns information about certificate from server's certificates pool.
 func (cp *CertPoolCtrl2) GetCertificateInfoDetails(ctx context.Context, nVServerId, nFunction int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nVServerId": %d, "nFunction" : %d }`, nVServerId, nFunction))
-	request, err := http.NewRequest("POST", cp.client.Server+"/api/v1.0/CertPoolCtrl2.GetCertificateInfoDetails",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nVServerId": nVServerId, "nFunction": nFunction}
 
-	raw, err := cp.client.Request(ctx, request, nil)
+	raw, err := cp.client.Call(ctx, "CertPoolCtrl2.GetCertificateInfoDetails", postData, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // CertUtils Helpers for managing certificates.
@@ -63,13 +60,7 @@ type KlsrvCertutilsCERTPPart struct {
 }
 
 func (cu *CertUtils) GenerateSelfSignedCertificate(ctx context.Context, params SelfSignedCERTParams) (*SelfSignedCERTResponse, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", cu.client.Server+"/api/v1.0/CertUtils.GenerateSelfSignedCertificate",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
 	selfSignedCERTResponse := new(SelfSignedCERTResponse)
-	raw, err := cu.client.Request(ctx, request, &selfSignedCERTResponse)
+	raw, err := cu.client.Call(ctx, "CertUtils.GenerateSelfSignedCertificate", params, &selfSignedCERTResponse)
 	return selfSignedCERTResponse, raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // CgwHelper (Connection Gateway) service to work with helper proxy.
@@ -36,14 +33,9 @@ type CgwHelper service
 
 // GetSlaveServerLocation Retrieves Slave Server Location.
 func (cp *CgwHelper) GetSlaveServerLocation(ctx context.Context, nSlaveServerId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nSlaveServerId": %d}`, nSlaveServerId))
-	request, err := http.NewRequest("POST", cp.client.Server+"/api/v1.0/CgwHelper.GetSlaveServerLocation",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nSlaveServerId": nSlaveServerId}
 
-	raw, err := cp.client.Request(ctx, request, nil)
+	raw, err := cp.client.Call(ctx, "CgwHelper.GetSlaveServerLocation", postData, nil)
 	return raw, err
 }
 
@@ -63,14 +55,9 @@ type NagLocation struct {
 
 // GetNagentLocation Retrieves Nagent Location by host name.
 func (cp *CgwHelper) GetNagentLocation(ctx context.Context, wsHostName string) (*NagentLocation, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wsHostName": "%s"}`, wsHostName))
-	request, err := http.NewRequest("POST", cp.client.Server+"/api/v1.0/CgwHelper.GetNagentLocation",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wsHostName": wsHostName}
 
 	nagentLocation := new(NagentLocation)
-	raw, err := cp.client.Request(ctx, request, &nagentLocation)
+	raw, err := cp.client.Call(ctx, "CgwHelper.GetNagentLocation", postData, &nagentLocation)
 	return nagentLocation, raw, err
 }
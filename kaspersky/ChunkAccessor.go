@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // ChunkAccessor service working with host result-set, that is a server-side ordered collection of found hosts.
@@ -37,13 +33,9 @@ type ChunkAccessor service
 
 // Release result-set. Releases the specified result-set and frees associated memory
 func (ca *ChunkAccessor) Release(ctx context.Context, accessor string) bool {
-	postData := []byte(fmt.Sprintf(`{"strAccessor": "%s"}`, accessor))
-	request, err := http.NewRequest("POST", ca.client.Server+"/api/v1.0/ChunkAccessor.Release", bytes.NewBuffer(postData))
-	if err != nil {
-		return false
-	}
+	postData := map[string]interface{}{"strAccessor": accessor}
 
-	raw, err := ca.client.Request(ctx, request, nil)
+	raw, _ := ca.client.Call(ctx, "ChunkAccessor.Release", postData, nil)
 	if raw != nil {
 		return true
 	}
@@ -52,14 +44,10 @@ func (ca *ChunkAccessor) Release(ctx context.Context, accessor string) bool {
 
 // GetItemsCount Acquire count of result-set elements. Returns number of elements contained in the specified result-set.
 func (ca *ChunkAccessor) GetItemsCount(ctx context.Context, accessor string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strAccessor": "%s"}`, accessor))
-	request, err := http.NewRequest("POST", ca.client.Server+"/api/v1.0/ChunkAccessor.GetItemsCount", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strAccessor": accessor}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := ca.client.Request(ctx, request, &pxgValInt)
+	raw, err := ca.client.Call(ctx, "ChunkAccessor.GetItemsCount", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -72,17 +60,6 @@ type ItemsChunkParams struct {
 // GetItemsChunk Acquire subset of result-set elements by range.
 // Returns specified nCount elements contained in the specified result-set beginning from position nStart.
 func (ca *ChunkAccessor) GetItemsChunk(ctx context.Context, params ItemsChunkParams, result interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ca.client.Server+"/api/v1.0/ChunkAccessor.GetItemsChunk", bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ca.client.Request(ctx, request, &result)
+	raw, err := ca.client.Call(ctx, "ChunkAccessor.GetItemsChunk", params, &result)
 	return raw, err
 }
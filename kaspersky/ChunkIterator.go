@@ -0,0 +1,280 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// DefaultPageSize number of rows requested at once by result-set iterators.
+const DefaultPageSize = 100
+
+// releaseTimeout limits the time to release a result-set once its iterator is done,
+// the context of iteration may be already canceled at this point.
+const releaseTimeout = 30 * time.Second
+
+// ChunkIterator iterates over a ChunkAccessor result-set, e.g. created by HostGroup.FindHosts,
+// acquiring rows page by page. The result-set is released when the rows are exhausted,
+// on error, on context cancellation or by Close, whatever happens first.
+//
+//	accessor, _, err := client.HostGroup.FindHosts(ctx, params)
+//	...
+//	it := client.ChunkAccessor.Iterator(accessor.StrAccessor, 500)
+//	defer it.Close()
+//	for it.Next(ctx) {
+//		var host kaspersky.Host
+//		if err := it.Scan(&host); err != nil {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ChunkIterator struct {
+	pager
+}
+
+// Iterator returns iterator over the accessor result-set requesting pageSize rows at once,
+// DefaultPageSize if pageSize is not positive.
+func (ca *ChunkAccessor) Iterator(accessor string, pageSize int64) *ChunkIterator {
+	return &ChunkIterator{pager{
+		pageSize: pageSize,
+		count: func(ctx context.Context) (int64, error) {
+			count, _, err := ca.GetItemsCount(ctx, accessor)
+			if err != nil {
+				return 0, err
+			}
+			return count.Int, nil
+		},
+		fetch: func(ctx context.Context, start, n int64) ([]json.RawMessage, error) {
+			chunk := new(itemsChunk)
+			_, err := ca.client.Call(ctx, "ChunkAccessor.GetItemsChunk", ItemsChunkParams{
+				StrAccessor: accessor,
+				NStart:      start,
+				NCount:      n,
+			}, chunk)
+			return chunk.PChunk.Rows, err
+		},
+		release: func(ctx context.Context) error {
+			_, err := ca.client.Call(ctx, "ChunkAccessor.Release", map[string]interface{}{"strAccessor": accessor}, nil)
+			return err
+		},
+	}}
+}
+
+// itemsChunk result of ChunkAccessor.GetItemsChunk with rows left undecoded.
+type itemsChunk struct {
+	PChunk struct {
+		Rows []json.RawMessage `json:"KLCSP_ITERATOR_ARRAY"`
+	} `json:"pChunk"`
+}
+
+// pager reads a server-side result-set page by page and releases it once done.
+type pager struct {
+	pageSize int64
+	count    func(ctx context.Context) (int64, error)
+	fetch    func(ctx context.Context, start, n int64) ([]json.RawMessage, error)
+	release  func(ctx context.Context) error
+
+	// ctx is the context of the first Next with cancellation detached, to release the result-set.
+	ctx context.Context
+
+	total   int64
+	pos     int64
+	rows    []json.RawMessage
+	row     json.RawMessage
+	started bool
+	closed  bool
+	err     error
+}
+
+// Next advances to the next row, it returns false when rows are exhausted or on error,
+// see Err. The result-set is released at this point.
+func (p *pager) Next(ctx context.Context) bool {
+	if p.closed {
+		return false
+	}
+	if p.ctx == nil {
+		p.ctx = detach(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return p.fail(err)
+	}
+
+	if !p.started {
+		p.started = true
+		if p.pageSize <= 0 {
+			p.pageSize = DefaultPageSize
+		}
+		total, err := p.count(ctx)
+		if err != nil {
+			return p.fail(err)
+		}
+		p.total = total
+	}
+
+	if len(p.rows) == 0 {
+		if p.pos >= p.total {
+			p.Close()
+			return false
+		}
+
+		n := p.pageSize
+		if rest := p.total - p.pos; rest < n {
+			n = rest
+		}
+		rows, err := p.fetch(ctx, p.pos, n)
+		if err != nil {
+			return p.fail(err)
+		}
+		if len(rows) == 0 {
+			// result-set shrunk, e.g. hosts were removed meanwhile
+			p.Close()
+			return false
+		}
+		p.rows = rows
+	}
+
+	p.row, p.rows = p.rows[0], p.rows[1:]
+	p.pos++
+	return true
+}
+
+// Scan decodes the current row into v, see decodeRow.
+func (p *pager) Scan(v interface{}) error {
+	if p.row == nil {
+		return errors.New("kaspersky: Scan called without Next")
+	}
+	return decodeRow(p.row, v)
+}
+
+// Row returns the current row as is.
+func (p *pager) Row() json.RawMessage {
+	return p.row
+}
+
+// Count returns number of rows in the result-set, known after the first Next.
+func (p *pager) Count() int64 {
+	return p.total
+}
+
+// Err returns the error stopped the iteration, if any.
+func (p *pager) Err() error {
+	return p.err
+}
+
+// All reads the rest of rows into out, a pointer to slice, and releases the result-set.
+func (p *pager) All(ctx context.Context, out interface{}) error {
+	defer p.Close()
+	return collectRows(ctx, p, out)
+}
+
+// Close releases the result-set. It is safe to call Close several times.
+func (p *pager) Close() error {
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	p.rows, p.row = nil, nil
+
+	ctx := p.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, releaseTimeout)
+	defer cancel()
+
+	return p.release(ctx)
+}
+
+func (p *pager) fail(err error) bool {
+	p.err = err
+	p.Close()
+	return false
+}
+
+// rowIterator common part of result-set iterators.
+type rowIterator interface {
+	Next(ctx context.Context) bool
+	Scan(v interface{}) error
+	Err() error
+}
+
+// collectRows appends rows of it to the slice pointed to by out.
+func collectRows(ctx context.Context, it rowIterator, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("kaspersky: pointer to slice expected, got %T", out)
+	}
+
+	slice := rv.Elem()
+	for it.Next(ctx) {
+		elem := reflect.New(slice.Type().Elem())
+		if err := it.Scan(elem.Interface()); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, elem.Elem()))
+	}
+	return it.Err()
+}
+
+// decodeRow decodes result-set row, {"type":"params","value":{...}} or plain params, into v.
+// Types implementing json.Unmarshaler decode the row by themselves, other structs are filled
+// with Params.Decode, so time.Time, net.IP and other typed fields get proper values.
+func decodeRow(row json.RawMessage, v interface{}) error {
+	if _, ok := v.(json.Unmarshaler); ok {
+		return json.Unmarshal(row, v)
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return json.Unmarshal(row, v)
+	}
+
+	var p Params
+	if err := json.Unmarshal(row, &p); err != nil {
+		return err
+	}
+	return p.Decode(v)
+}
+
+// detachedContext carries values of the parent context, i.e. call options, but is never canceled.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// detach returns context with values of ctx without its cancellation and deadline.
+func detach(ctx context.Context) context.Context {
+	return detachedContext{ctx}
+}
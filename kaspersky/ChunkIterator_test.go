@@ -0,0 +1,169 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// hostChunks ChunkAccessor result-set of n hosts h0, h1, ... with addresses 192.168.1.0, 192.168.1.1, ...
+func hostChunks(n int) fakeResultSet {
+	return fakeResultSet{
+		CountMethod: "ChunkAccessor.GetItemsCount",
+		RangeMethod: "ChunkAccessor.GetItemsChunk",
+		Array:       "pChunk.KLCSP_ITERATOR_ARRAY",
+		Rows: fakeRows(n, func(i int) Params {
+			return Params{"KLHST_WKS_DN": fmt.Sprintf("h%d", i), "KLHST_WKS_IP_LONG": 3232235776 + i}
+		}),
+	}
+}
+
+func TestChunkIteratorAll(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.resultSet(t, hostChunks(7))
+	c := srv.client(Config{})
+
+	it := c.ChunkAccessor.Iterator("acc", 3)
+	var hosts []Host
+	if err := it.All(context.Background(), &hosts); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(hosts) != 7 || it.Count() != 7 {
+		t.Fatalf("got %d hosts of %d, want 7", len(hosts), it.Count())
+	}
+	if h := hosts[6]; h.DisplayName != "h6" || h.IP.String() != "192.168.1.6" {
+		t.Errorf("hosts[6] = %+v", h)
+	}
+
+	var pages []string
+	for _, r := range srv.received("ChunkAccessor.GetItemsChunk") {
+		var in ItemsChunkParams
+		_ = json.Unmarshal(r.Body, &in)
+		pages = append(pages, fmt.Sprintf("%d+%d", in.NStart, in.NCount))
+	}
+	if got := strings.Join(pages, ","); got != "0+3,3+3,6+1" {
+		t.Errorf("pages = %s, want 0+3,3+3,6+1", got)
+	}
+	if n := len(srv.received("ChunkAccessor.Release")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestChunkIteratorClose(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.resultSet(t, hostChunks(7))
+	c := srv.client(Config{})
+	ctx := context.Background()
+
+	it := c.ChunkAccessor.Iterator("acc", 0)
+	if !it.Next(ctx) {
+		t.Fatal(it.Err())
+	}
+	var row struct {
+		DN string `json:"KLHST_WKS_DN"`
+	}
+	if err := it.Scan(&row); err != nil || row.DN != "h0" {
+		t.Errorf("Scan() = %+v, %v", row, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := it.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if it.Next(ctx) {
+		t.Error("Next() after Close succeeded")
+	}
+	if n := len(srv.received("ChunkAccessor.Release")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestChunkIteratorCanceled(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.resultSet(t, hostChunks(7))
+	c := srv.client(Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.ChunkAccessor.Iterator("acc", 2)
+	if !it.Next(ctx) {
+		t.Fatal(it.Err())
+	}
+	cancel()
+
+	if it.Next(ctx) || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Next() after cancel, Err() = %v, want context.Canceled", it.Err())
+	}
+	// released with the context detached from cancellation
+	if n := len(srv.received("ChunkAccessor.Release")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestChunkIteratorShrunk(t *testing.T) {
+	// the result-set has lost rows since it was counted
+	rs := hostChunks(4)
+	rs.Count = 7
+	srv := newFakeServer(t)
+	srv.resultSet(t, rs)
+	c := srv.client(Config{})
+
+	var rows []Params
+	if err := c.ChunkAccessor.Iterator("acc", 3).All(context.Background(), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 4 || rows[3]["KLHST_WKS_DN"] != "h3" {
+		t.Errorf("rows = %v, want 4 rows", rows)
+	}
+}
+
+func TestChunkIteratorError(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.resultSet(t, hostChunks(7))
+	srv.reply("ChunkAccessor.GetItemsChunk", `{"PxgError":{"code":1183,"module":"KLSTD","message":"Object not found"}}`)
+	c := srv.client(Config{})
+
+	it := c.ChunkAccessor.Iterator("acc", 3)
+	if it.Next(context.Background()) || !errors.Is(it.Err(), ErrObjectNotFound) {
+		t.Errorf("Err() = %v, want ErrObjectNotFound", it.Err())
+	}
+	if n := len(srv.received("ChunkAccessor.Release")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+
+	if err := it.Scan(&Host{}); err == nil {
+		t.Error("Scan() without row succeeded")
+	}
+	var notSlice Host
+	if err := c.ChunkAccessor.Iterator("acc", 3).All(context.Background(), &notSlice); err == nil {
+		t.Error("All() into non-slice succeeded")
+	}
+}
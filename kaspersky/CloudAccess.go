@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // CloudAccess service to check access of public clouds.
@@ -74,18 +71,8 @@ type PKeyPair struct {
 
 // VerifyCredentials Verify credentials.
 func (ca *CloudAccess) VerifyCredentials(ctx context.Context, params Credentials) (*PxgValBool, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, nil
-	}
-
-	request, err := http.NewRequest("POST", ca.client.Server+"/api/v1.0/CloudAccess.VerifyCredentials", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, nil
-	}
-
 	pxgValBool := new(PxgValBool)
-	raw, err := ca.client.Request(ctx, request, &pxgValBool)
+	raw, err := ca.client.Call(ctx, "CloudAccess.VerifyCredentials", params, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
@@ -100,17 +87,7 @@ type KeyPairAccess struct {
 
 // AcquireAccessForKeyPair  Check key-pair access
 func (ca *CloudAccess) AcquireAccessForKeyPair(ctx context.Context, params Credentials) (*KeyPairAccess, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, nil
-	}
-
-	request, err := http.NewRequest("POST", ca.client.Server+"/api/v1.0/CloudAccess.AcquireAccessForKeyPair", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, nil
-	}
-
 	keyPairAccess := new(KeyPairAccess)
-	raw, err := ca.client.Request(ctx, request, &keyPairAccess)
+	raw, err := ca.client.Call(ctx, "CloudAccess.AcquireAccessForKeyPair", params, &keyPairAccess)
 	return keyPairAccess, raw, err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // ConEvents service to server events. This interface allow user to subscribe on server events and retrieve them.
@@ -44,18 +39,8 @@ type EventRetrieve struct {
 
 // Retrieve Use this method to retrieve events.
 func (ce *ConEvents) Retrieve(ctx context.Context) (*EventRetrieve, error) {
-	request, err := http.NewRequest("POST", ce.client.Server+"/api/v1.0/ConEvents.Retrieve", nil)
-
-	if err != nil {
-		return nil, err
-	}
-
 	eventRetrieve := new(EventRetrieve)
-	raw, err := ce.client.Request(ctx, request, &eventRetrieve)
-
-	if ce.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ce.client.Call(ctx, "ConEvents.Retrieve", nil, &eventRetrieve)
 
 	return eventRetrieve, err
 }
@@ -84,59 +69,25 @@ type SubscribeEventResponse struct {
 // Subscribe on event. Use this method to subscribe on events. Method returns period of polling.
 // You should use it between retrieve calls. Also attribute pFilter allow you to cut off unnecessary events.
 func (ce *ConEvents) Subscribe(ctx context.Context, params EventSubscribeParams) (*SubscribeEventResponse, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ce.client.Server+"/api/v1.0/ConEvents.Subscribe", bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
-
 	subscribeEventResponse := new(SubscribeEventResponse)
-	raw, err := ce.client.Request(ctx, request, &subscribeEventResponse)
-
-	if ce.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ce.client.Call(ctx, "ConEvents.Subscribe", params, &subscribeEventResponse)
 
 	return subscribeEventResponse, err
 }
 
 // UnSubscribe from event. Use this method to unsubscribe from an event.
 func (ce *ConEvents) UnSubscribe(ctx context.Context, nSubsId int64) error {
-	postData := []byte(fmt.Sprintf(`{"nSubsId": %d}`, nSubsId))
-	request, err := http.NewRequest("POST", ce.client.Server+"/api/v1.0/ConEvents.UnSubscribe", bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"nSubsId": nSubsId}
 
-	raw, err := ce.client.Request(ctx, request, nil)
-
-	if ce.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ce.client.Call(ctx, "ConEvents.UnSubscribe", postData, nil)
 
 	return err
 }
 
 // IsAnyServiceConsoleAvailable Check any service console availability.
 func (ce *ConEvents) IsAnyServiceConsoleAvailable(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", ce.client.Server+"/api/v1.0/ConEvents.IsAnyServiceConsoleAvailable", nil)
-
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
-	raw, err := ce.client.Request(ctx, request, &result)
-
-	if ce.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ce.client.Call(ctx, "ConEvents.IsAnyServiceConsoleAvailable", nil, &result)
 
 	return result, err
 }
@@ -145,20 +96,9 @@ func (ce *ConEvents) IsAnyServiceConsoleAvailable(ctx context.Context) (*PxgValB
 // Checks whether service console for specified product is connected
 // to KSC server and is able to execute Product backend commands
 func (ce *ConEvents) IsServiceConsoleAvailable(ctx context.Context, wstrProdName, wstrProdVersion string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrProdName" : "%s", "wstrProdVersion" : "%s"}`, wstrProdName, wstrProdVersion))
-
-	request, err := http.NewRequest("POST", ce.client.Server+"/api/v1.0/ConEvents.IsServiceConsoleAvailable",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
-
-	raw, err := ce.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"wstrProdName": wstrProdName, "wstrProdVersion": wstrProdVersion}
 
-	if ce.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ce.client.Call(ctx, "ConEvents.IsServiceConsoleAvailable", postData, nil)
 
 	return err
 }
@@ -0,0 +1,611 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigError is returned by LoadConfig when a configuration value is missing or invalid.
+type ConfigError struct {
+	// Source where the value comes from: the profile file and name, or "environment"
+	Source string
+
+	// Field key of the value in the profile file (e.g. "oauth2.token_url") or environment variable name
+	Field string
+
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("kaspersky: config %s: %v", e.Field, e.Err)
+	}
+	return fmt.Sprintf("kaspersky: config %s: %s: %v", e.Source, e.Field, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// profile configuration profile, nil fields are not set.
+//
+// Keys of the profile file are given by config tags, environment variables are named
+// KSC_<KEY> (KSC_<SECTION>_<KEY> in sections) in upper case.
+type profile struct {
+	Extends *string `config:"extends,noenv"`
+
+	Server          *string `config:"server"`
+	UserName        *string `config:"username"`
+	Password        *string `config:"password"`
+	PasswordFile    *string `config:"password_file"`
+	PasswordCommand *string `config:"password_command"`
+	Domain          *string `config:"domain"`
+	InternalUser    *bool   `config:"internal"`
+	VServerName     *string `config:"vserver"`
+
+	XKscSession        *bool `config:"xksc_session"`
+	InsecureSkipVerify *bool `config:"insecure_skip_verify"`
+	Debug              *bool `config:"debug"`
+
+	CAFile           *string   `config:"ca_file"`
+	PinnedCertSHA256 *[]string `config:"pinned_cert_sha256"`
+
+	DialTimeout         *time.Duration `config:"dial_timeout"`
+	TLSHandshakeTimeout *time.Duration `config:"tls_handshake_timeout"`
+	IdleConnTimeout     *time.Duration `config:"idle_conn_timeout"`
+	KeepAlive           *time.Duration `config:"keep_alive"`
+
+	MaxResponseSize  *int64 `config:"max_response_size"`
+	RetryMaxAttempts *int64 `config:"retry_max_attempts"`
+
+	OAuth2 *oauth2Profile `config:"oauth2"`
+}
+
+// oauth2Profile oauth2 section of configuration profile, see OAuth2Config.
+type oauth2Profile struct {
+	TokenURL         *string   `config:"token_url"`
+	ClientID         *string   `config:"client_id"`
+	ClientSecret     *string   `config:"client_secret"`
+	ClientSecretFile *string   `config:"client_secret_file"`
+	Scopes           *[]string `config:"scopes"`
+	Resource         *string   `config:"resource"`
+	Issuer           *string   `config:"issuer"`
+	Audience         *string   `config:"audience"`
+	JWKSURL          *string   `config:"jwks_url"`
+}
+
+// profileFile content of the profile file.
+type profileFile struct {
+	Default  string                            `json:"default" yaml:"default"`
+	Profiles map[string]map[string]interface{} `json:"profiles" yaml:"profiles"`
+}
+
+// LoadConfig loads configuration of the named profile.
+//
+// Values are merged in order, later ones override earlier:
+//
+//  1. the profile from YAML or JSON file at path (KSC_CONFIG if path is empty, no file if both are empty);
+//  2. KSC_* environment variables, e.g. KSC_SERVER, KSC_USERNAME, KSC_XKSC_SESSION, KSC_OAUTH2_CLIENT_ID;
+//  3. the password read from password_file or printed by password_command (KSC_PASSWORD_FILE, KSC_PASSWORD_COMMAND).
+//
+// password_command is split on white space and run without a shell, quoted arguments are not supported;
+// wrap the command in a script if it needs them.
+//
+// If profile is empty, KSC_PROFILE, then the default profile of the file, then "default" is used.
+// A profile may extend another one, e.g. per virtual server profiles extending the main one:
+//
+//	default: prod
+//	profiles:
+//	  prod:
+//	    server: https://ksc.example.com:13299
+//	    username: svc-ksc
+//	    password_file: /run/secrets/ksc
+//	    xksc_session: true
+//	    ca_file: /etc/ssl/ksc-ca.pem
+//	    retry_max_attempts: 4
+//	    keep_alive: 1m
+//	  tenant1:
+//	    extends: prod
+//	    vserver: tenant1
+//
+// Durations are written as "30s" or in seconds, lists in environment variables are comma separated.
+// Invalid and missing values are reported as *ConfigError naming the field.
+func LoadConfig(path, profileName string) (Config, error) {
+	if path == "" {
+		path = os.Getenv("KSC_CONFIG")
+	}
+	if profileName == "" {
+		profileName = os.Getenv("KSC_PROFILE")
+	}
+
+	p := new(profile)
+	if path != "" {
+		var err error
+		if p, err = loadProfile(path, profileName); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := setFromEnv(reflect.ValueOf(p).Elem(), "KSC_"); err != nil {
+		return Config{}, err
+	}
+
+	return p.config()
+}
+
+// loadProfile reads the named profile from the file, resolving extends.
+func loadProfile(path, name string) (*profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, &ConfigError{Field: "file", Err: err}
+	}
+
+	var file profileFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &file)
+	} else {
+		err = yaml.Unmarshal(data, &file)
+	}
+	if err != nil {
+		return nil, &ConfigError{Source: path, Field: "file", Err: err}
+	}
+
+	if name == "" {
+		name = file.Default
+	}
+	if name == "" {
+		name = "default"
+	}
+
+	return file.resolve(path, name, nil)
+}
+
+// resolve returns the named profile merged over the profiles it extends.
+func (f *profileFile) resolve(path, name string, seen []string) (*profile, error) {
+	source := path + " profile " + strconv.Quote(name)
+
+	for _, s := range seen {
+		if s == name {
+			return nil, &ConfigError{Source: source, Field: "extends",
+				Err: errors.New("circular extends " + strings.Join(append(seen, name), " -> "))}
+		}
+	}
+
+	values, ok := f.Profiles[name]
+	if !ok {
+		src := path
+		if len(seen) > 0 {
+			src = path + " profile " + strconv.Quote(seen[len(seen)-1])
+		}
+		return nil, &ConfigError{Source: src, Field: "profile", Err: errors.New("profile " + strconv.Quote(name) + " not found")}
+	}
+
+	p := new(profile)
+	if err := setFromMap(reflect.ValueOf(p).Elem(), values, ""); err != nil {
+		err.(*ConfigError).Source = source
+		return nil, err
+	}
+
+	if p.Extends == nil {
+		return p, nil
+	}
+
+	base, err := f.resolve(path, *p.Extends, append(seen, name))
+	if err != nil {
+		return nil, err
+	}
+	merge(reflect.ValueOf(base).Elem(), reflect.ValueOf(p).Elem())
+	return base, nil
+}
+
+// configKey returns the key of the profile field and whether it may be set from environment.
+func configKey(field reflect.StructField) (string, bool) {
+	tag := strings.Split(field.Tag.Get("config"), ",")
+	return tag[0], len(tag) < 2 || tag[1] != "noenv"
+}
+
+// setFromMap sets fields of the profile struct s from the decoded file section.
+func setFromMap(s reflect.Value, values map[string]interface{}, prefix string) error {
+	known := make(map[string]bool, s.NumField())
+
+	for i := 0; i < s.NumField(); i++ {
+		key, _ := configKey(s.Type().Field(i))
+		known[key] = true
+
+		raw, ok := values[key]
+		if !ok || raw == nil {
+			continue
+		}
+
+		field := s.Field(i)
+		if field.Type().Elem().Kind() == reflect.Struct {
+			section, ok := raw.(map[string]interface{})
+			if !ok {
+				return &ConfigError{Field: prefix + key, Err: errors.New("expected a section")}
+			}
+			field.Set(reflect.New(field.Type().Elem()))
+			if err := setFromMap(field.Elem(), section, prefix+key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := setValue(field, raw); err != nil {
+			return &ConfigError{Field: prefix + key, Err: err}
+		}
+	}
+
+	for key := range values {
+		if !known[key] {
+			return &ConfigError{Field: prefix + key, Err: errors.New("unknown field")}
+		}
+	}
+	return nil
+}
+
+// setFromEnv sets fields of the profile struct s from environment variables, empty variables are ignored.
+func setFromEnv(s reflect.Value, prefix string) error {
+	for i := 0; i < s.NumField(); i++ {
+		key, env := configKey(s.Type().Field(i))
+		if !env {
+			continue
+		}
+
+		name := prefix + strings.ToUpper(key)
+		field := s.Field(i)
+
+		if field.Type().Elem().Kind() == reflect.Struct {
+			section := reflect.New(field.Type().Elem())
+			if err := setFromEnv(section.Elem(), name+"_"); err != nil {
+				return err
+			}
+			if !section.Elem().IsZero() {
+				if field.IsNil() {
+					field.Set(reflect.New(field.Type().Elem()))
+				}
+				merge(field.Elem(), section.Elem())
+			}
+			continue
+		}
+
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		if err := setValue(field, value); err != nil {
+			return &ConfigError{Source: "environment", Field: name, Err: err}
+		}
+	}
+	return nil
+}
+
+// merge copies fields which are set in src to dst.
+func merge(dst, src reflect.Value) {
+	for i := 0; i < src.NumField(); i++ {
+		from, to := src.Field(i), dst.Field(i)
+		switch {
+		case from.IsNil():
+		case from.Type().Elem().Kind() == reflect.Struct && !to.IsNil():
+			merge(to.Elem(), from.Elem())
+		default:
+			to.Set(from)
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue sets the pointer field from the value decoded from file or read from environment.
+func setValue(field reflect.Value, raw interface{}) error {
+	typ := field.Type().Elem()
+	value := reflect.New(typ)
+
+	switch {
+	case typ == durationType:
+		d, err := parseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.Elem().SetInt(int64(d))
+	case typ.Kind() == reflect.String:
+		switch v := raw.(type) {
+		case string:
+			value.Elem().SetString(v)
+		case int, int64, uint64, float64:
+			value.Elem().SetString(fmt.Sprint(v))
+		default:
+			return fmt.Errorf("expected a string, got %v", raw)
+		}
+	case typ.Kind() == reflect.Bool:
+		switch v := raw.(type) {
+		case bool:
+			value.Elem().SetBool(v)
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return fmt.Errorf("expected a boolean, got %q", v)
+			}
+			value.Elem().SetBool(b)
+		default:
+			return fmt.Errorf("expected a boolean, got %v", raw)
+		}
+	case typ.Kind() == reflect.Int64:
+		n, err := parseInt(raw)
+		if err != nil {
+			return err
+		}
+		value.Elem().SetInt(n)
+	case typ.Kind() == reflect.Slice:
+		var list []string
+		switch v := raw.(type) {
+		case string:
+			for _, s := range strings.Split(v, ",") {
+				if s = strings.TrimSpace(s); s != "" {
+					list = append(list, s)
+				}
+			}
+		case []interface{}:
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("expected a list of strings, got %v", item)
+				}
+				list = append(list, s)
+			}
+		default:
+			return fmt.Errorf("expected a list of strings, got %v", raw)
+		}
+		value.Elem().Set(reflect.ValueOf(list))
+	}
+
+	field.Set(value)
+	return nil
+}
+
+func parseInt(raw interface{}) (int64, error) {
+	switch v := raw.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint64:
+		return int64(v), nil
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v), nil
+		}
+	case string:
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("expected an integer, got %v", raw)
+}
+
+// parseDuration parses duration written as "1m30s" or as a number of seconds.
+func parseDuration(raw interface{}) (time.Duration, error) {
+	if s, ok := raw.(string); ok {
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, nil
+		}
+	}
+
+	if n, err := parseInt(raw); err == nil {
+		return time.Duration(n) * time.Second, nil
+	}
+	if f, ok := raw.(float64); ok {
+		return time.Duration(f * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("expected a duration like \"30s\", got %v", raw)
+}
+
+// config validates the profile and converts it to Config, reading secrets from files and commands.
+func (p *profile) config() (Config, error) {
+	var cfg Config
+
+	str := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	flag := func(b *bool) bool {
+		return b != nil && *b
+	}
+	duration := func(key string, d *time.Duration) (time.Duration, error) {
+		if d == nil {
+			return 0, nil
+		}
+		if *d < 0 {
+			return 0, &ConfigError{Field: key, Err: errors.New("must not be negative")}
+		}
+		return *d, nil
+	}
+
+	cfg.Server = strings.TrimRight(str(p.Server), "/")
+	if cfg.Server == "" {
+		return Config{}, &ConfigError{Field: "server", Err: errors.New("is required")}
+	}
+	if u, err := url.Parse(cfg.Server); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return Config{}, &ConfigError{Field: "server", Err: fmt.Errorf("expected URL like https://host:13299, got %q", cfg.Server)}
+	}
+
+	cfg.UserName, cfg.Domain, cfg.VServerName = str(p.UserName), str(p.Domain), str(p.VServerName)
+	cfg.InternalUser, cfg.XKscSession = flag(p.InternalUser), flag(p.XKscSession)
+	cfg.InsecureSkipVerify, cfg.Debug = flag(p.InsecureSkipVerify), flag(p.Debug)
+
+	password, err := readSecret("password", p.Password, p.PasswordFile, p.PasswordCommand)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Password = password
+
+	if p.OAuth2 != nil {
+		if cfg.OAuth2, err = p.OAuth2.config(); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if cfg.UserName == "" && cfg.OAuth2 == nil {
+		return Config{}, &ConfigError{Field: "username", Err: errors.New("is required unless oauth2 is configured")}
+	}
+	if cfg.UserName != "" && cfg.Password == "" {
+		return Config{}, &ConfigError{Field: "password", Err: errors.New("is required, set password, password_file or password_command")}
+	}
+
+	if ca := str(p.CAFile); ca != "" {
+		if cfg.RootCAs, err = LoadCertPool(ca); err != nil {
+			return Config{}, &ConfigError{Field: "ca_file", Err: err}
+		}
+	}
+
+	if p.PinnedCertSHA256 != nil {
+		for _, fp := range *p.PinnedCertSHA256 {
+			if !sha256Fingerprint.MatchString(strings.ToLower(strings.Replace(fp, ":", "", -1))) {
+				return Config{}, &ConfigError{Field: "pinned_cert_sha256", Err: fmt.Errorf("expected hex encoded SHA-256, got %q", fp)}
+			}
+		}
+		cfg.PinnedCertSHA256 = *p.PinnedCertSHA256
+	}
+
+	if cfg.DialTimeout, err = duration("dial_timeout", p.DialTimeout); err != nil {
+		return Config{}, err
+	}
+	if cfg.TLSHandshakeTimeout, err = duration("tls_handshake_timeout", p.TLSHandshakeTimeout); err != nil {
+		return Config{}, err
+	}
+	if cfg.IdleConnTimeout, err = duration("idle_conn_timeout", p.IdleConnTimeout); err != nil {
+		return Config{}, err
+	}
+	if cfg.KeepAlive, err = duration("keep_alive", p.KeepAlive); err != nil {
+		return Config{}, err
+	}
+
+	if p.MaxResponseSize != nil {
+		if *p.MaxResponseSize < 0 {
+			return Config{}, &ConfigError{Field: "max_response_size", Err: errors.New("must not be negative")}
+		}
+		cfg.MaxResponseSize = *p.MaxResponseSize
+	}
+
+	if p.RetryMaxAttempts != nil {
+		if *p.RetryMaxAttempts < 0 {
+			return Config{}, &ConfigError{Field: "retry_max_attempts", Err: errors.New("must not be negative")}
+		}
+		cfg.RetryPolicy = DefaultRetryPolicy()
+		cfg.RetryPolicy.MaxAttempts = int(*p.RetryMaxAttempts)
+	}
+
+	return cfg, nil
+}
+
+func (p *oauth2Profile) config() (*OAuth2Config, error) {
+	secret, err := readSecret("oauth2.client_secret", p.ClientSecret, p.ClientSecretFile, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &OAuth2Config{ClientSecret: secret}
+	for _, f := range []struct {
+		key   string
+		value *string
+		to    *string
+	}{
+		{"oauth2.token_url", p.TokenURL, &cfg.TokenURL},
+		{"oauth2.client_id", p.ClientID, &cfg.ClientID},
+		{"oauth2.resource", p.Resource, &cfg.Resource},
+		{"oauth2.issuer", p.Issuer, &cfg.Issuer},
+		{"oauth2.audience", p.Audience, &cfg.Audience},
+		{"oauth2.jwks_url", p.JWKSURL, &cfg.JWKSURL},
+	} {
+		if f.value != nil {
+			*f.to = *f.value
+		}
+	}
+	if p.Scopes != nil {
+		cfg.Scopes = *p.Scopes
+	}
+
+	if cfg.TokenURL == "" {
+		return nil, &ConfigError{Field: "oauth2.token_url", Err: errors.New("is required")}
+	}
+	if cfg.ClientID == "" {
+		return nil, &ConfigError{Field: "oauth2.client_id", Err: errors.New("is required")}
+	}
+	return cfg, nil
+}
+
+var sha256Fingerprint = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// readSecret returns the secret value given directly, read from the file or printed by the command.
+// The file and the command take precedence over the value, but can't be used together.
+func readSecret(key string, value, file, command *string) (string, error) {
+	switch {
+	case file != nil && *file != "" && command != nil && *command != "":
+		return "", &ConfigError{Field: key + "_file", Err: errors.New("can't be used together with " + key + "_command")}
+	case file != nil && *file != "":
+		data, err := ioutil.ReadFile(*file)
+		if err != nil {
+			return "", &ConfigError{Field: key + "_file", Err: err}
+		}
+		return strings.TrimRight(string(data), "\r\n"), nil
+	case command != nil && *command != "":
+		args := strings.Fields(*command)
+		if len(args) == 0 {
+			return "", &ConfigError{Field: key + "_command", Err: errors.New("is empty")}
+		}
+
+		var stderr bytes.Buffer
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+			return "", &ConfigError{Field: key + "_command", Err: err}
+		}
+		return strings.TrimRight(string(out), "\r\n"), nil
+	case value != nil:
+		return *value, nil
+	}
+	return "", nil
+}
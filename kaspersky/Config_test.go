@@ -0,0 +1,200 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setenv sets the environment variable for the duration of the test.
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+
+	old, ok := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, old)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+// configFile writes the profile file and a password file containing "filepw" to a temporary directory,
+// {{dir}} in content is replaced with the directory path. Returns path of the profile file.
+func configFile(t *testing.T, content string) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "ksc-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "password"), []byte("filepw\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "ksc.yaml")
+	if err := ioutil.WriteFile(path, []byte(strings.Replace(content, "{{dir}}", dir, -1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const testProfiles = `default: prod
+profiles:
+  prod:
+    server: https://ksc.example.com:13299/
+    username: svc-ksc
+    password_file: {{dir}}/password
+    xksc_session: true
+    keep_alive: 1m
+    dial_timeout: 5
+    retry_max_attempts: 3
+  tenant1:
+    extends: prod
+    vserver: tenant1
+  oauth2:
+    server: https://ksc.example.com:13299
+    oauth2:
+      token_url: https://idp.example.com/token
+      client_id: client
+      scopes: [a, b]
+  bad_duration:
+    server: https://ksc.example.com:13299
+    keep_alive: soon
+  unknown_key:
+    serverr: https://ksc.example.com:13299
+  loop1:
+    extends: loop2
+  loop2:
+    extends: loop1
+  no_username:
+    server: https://ksc.example.com:13299
+  blank_command:
+    server: https://ksc.example.com:13299
+    username: svc-ksc
+    password_command: "  "
+`
+
+func TestLoadConfig(t *testing.T) {
+	path := configFile(t, testProfiles)
+
+	cfg, err := LoadConfig(path, "tenant1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server != "https://ksc.example.com:13299" || cfg.UserName != "svc-ksc" || cfg.Password != "filepw" {
+		t.Errorf("LoadConfig() server, username, password = %q, %q, %q", cfg.Server, cfg.UserName, cfg.Password)
+	}
+	if cfg.VServerName != "tenant1" || !cfg.XKscSession {
+		t.Errorf("LoadConfig() vserver, xksc_session = %q, %v", cfg.VServerName, cfg.XKscSession)
+	}
+	if cfg.KeepAlive != time.Minute || cfg.DialTimeout != 5*time.Second {
+		t.Errorf("LoadConfig() keep_alive, dial_timeout = %v, %v", cfg.KeepAlive, cfg.DialTimeout)
+	}
+	if cfg.RetryPolicy == nil || cfg.RetryPolicy.MaxAttempts != 3 {
+		t.Errorf("LoadConfig() retry policy = %+v", cfg.RetryPolicy)
+	}
+
+	if cfg, err = LoadConfig(path, "oauth2"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.OAuth2 == nil || cfg.OAuth2.ClientID != "client" || len(cfg.OAuth2.Scopes) != 2 {
+		t.Errorf("LoadConfig() oauth2 = %+v", cfg.OAuth2)
+	}
+}
+
+func TestLoadConfigEnvironment(t *testing.T) {
+	path := configFile(t, testProfiles)
+	setenv(t, "KSC_PROFILE", "no_username")
+	setenv(t, "KSC_USERNAME", "admin")
+	setenv(t, "KSC_PASSWORD", "envpw")
+	setenv(t, "KSC_VSERVER", "tenant2")
+
+	cfg, err := LoadConfig(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.UserName != "admin" || cfg.Password != "envpw" || cfg.VServerName != "tenant2" {
+		t.Errorf("LoadConfig() username, password, vserver = %q, %q, %q, want values from environment",
+			cfg.UserName, cfg.Password, cfg.VServerName)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	path := configFile(t, testProfiles)
+
+	tests := []struct {
+		profile string
+		env     map[string]string
+		field   string
+	}{
+		{"bad_duration", nil, "keep_alive"},
+		{"unknown_key", nil, "serverr"},
+		{"loop1", nil, "extends"},
+		{"missing", nil, "profile"},
+		{"no_username", nil, "username"},
+		{"blank_command", nil, "password_command"},
+		{"no_username", map[string]string{"KSC_USERNAME": "admin", "KSC_PASSWORD_COMMAND": " \t"}, "password_command"},
+		{"prod", map[string]string{"KSC_PASSWORD_COMMAND": "echo pw"}, "password_file"},
+		{"prod", map[string]string{"KSC_DEBUG": "maybe"}, "KSC_DEBUG"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.profile+"/"+tt.field, func(t *testing.T) {
+			for k, v := range tt.env {
+				setenv(t, k, v)
+			}
+
+			_, err := LoadConfig(path, tt.profile)
+			var cfgErr *ConfigError
+			if !errors.As(err, &cfgErr) || cfgErr.Field != tt.field {
+				t.Errorf("LoadConfig() error = %v, want *ConfigError of %s", err, tt.field)
+			}
+		})
+	}
+}
+
+func TestReadSecretCommand(t *testing.T) {
+	if _, err := exec.LookPath("echo"); err != nil {
+		t.Skip("echo is not available")
+	}
+
+	command := "echo  cmdpw"
+	got, err := readSecret("password", nil, nil, &command)
+	if err != nil || got != "cmdpw" {
+		t.Errorf("readSecret() = %q, %v, want cmdpw", got, err)
+	}
+}
@@ -0,0 +1,141 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strconv"
+)
+
+// BasicCredentials user credentials for KSCBasic authentication.
+type BasicCredentials struct {
+	// UserName user login name
+	UserName string `json:"username"`
+
+	// Password user password
+	Password string `json:"password"`
+
+	// Domain user domain
+	Domain string `json:"domain,omitempty"`
+
+	// InternalUser true if the user is an internal KSC user
+	InternalUser bool `json:"internal,omitempty"`
+
+	// VServerName virtual server name, empty for the main server
+	VServerName string `json:"vserver,omitempty"`
+}
+
+// basic returns base64 encoded user name, password and virtual server name for KSCBasic authorization.
+func (c BasicCredentials) basic() (user, pass, vServer string) {
+	user = base64.StdEncoding.EncodeToString([]byte(c.UserName))
+	pass = base64.StdEncoding.EncodeToString([]byte(c.Password))
+
+	if len(c.VServerName) != 0 {
+		vServer = base64.StdEncoding.EncodeToString([]byte(c.VServerName))
+	} else {
+		vServer = "x"
+	}
+	return user, pass, vServer
+}
+
+// CredentialProvider supplies credentials for KSCBasic authentication.
+//
+// Credentials is called on every Login and re-authentication, so implementations may rotate them.
+// Implementations must be safe for concurrent use.
+type CredentialProvider interface {
+	Credentials(ctx context.Context) (BasicCredentials, error)
+}
+
+// StaticCredentials provider which always returns the same credentials.
+type StaticCredentials BasicCredentials
+
+// Credentials returns the static credentials.
+func (c StaticCredentials) Credentials(context.Context) (BasicCredentials, error) {
+	return BasicCredentials(c), nil
+}
+
+// EnvCredentials provider which reads credentials from environment variables on every call:
+//
+//	<Prefix>_USERNAME, <Prefix>_PASSWORD, <Prefix>_DOMAIN, <Prefix>_INTERNAL, <Prefix>_VSERVER
+type EnvCredentials struct {
+	// Prefix variables name prefix, "KSC" by default
+	Prefix string
+}
+
+// Credentials reads credentials from the environment.
+func (e EnvCredentials) Credentials(context.Context) (BasicCredentials, error) {
+	prefix := e.Prefix
+	if prefix == "" {
+		prefix = "KSC"
+	}
+
+	c := BasicCredentials{
+		UserName:    os.Getenv(prefix + "_USERNAME"),
+		Password:    os.Getenv(prefix + "_PASSWORD"),
+		Domain:      os.Getenv(prefix + "_DOMAIN"),
+		VServerName: os.Getenv(prefix + "_VSERVER"),
+	}
+
+	if internal := os.Getenv(prefix + "_INTERNAL"); internal != "" {
+		var err error
+		if c.InternalUser, err = strconv.ParseBool(internal); err != nil {
+			return BasicCredentials{}, errors.New("kaspersky: invalid " + prefix + "_INTERNAL value: " + internal)
+		}
+	}
+
+	if c.UserName == "" {
+		return BasicCredentials{}, errors.New("kaspersky: " + prefix + "_USERNAME is not set")
+	}
+	return c, nil
+}
+
+// FileCredentials provider which reads JSON encoded BasicCredentials from the file on every call.
+//
+// Example:
+//
+//	{"username": "login", "password": "password", "domain": "", "internal": true, "vserver": ""}
+type FileCredentials struct {
+	// Path path to the credentials file
+	Path string
+}
+
+// Credentials reads credentials from the file.
+func (f FileCredentials) Credentials(context.Context) (BasicCredentials, error) {
+	data, err := ioutil.ReadFile(f.Path)
+	if err != nil {
+		return BasicCredentials{}, err
+	}
+
+	var c BasicCredentials
+	if err := json.Unmarshal(data, &c); err != nil {
+		return BasicCredentials{}, errors.New("kaspersky: invalid credentials file " + f.Path + ": " + err.Error())
+	}
+	return c, nil
+}
@@ -0,0 +1,147 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEnvCredentials(t *testing.T) {
+	for _, kv := range [][2]string{
+		{"KSCTEST_USERNAME", "user"},
+		{"KSCTEST_PASSWORD", "pass"},
+		{"KSCTEST_DOMAIN", "corp"},
+		{"KSCTEST_INTERNAL", "true"},
+		{"KSCTEST_VSERVER", "tenant"},
+	} {
+		os.Setenv(kv[0], kv[1])
+		defer os.Unsetenv(kv[0])
+	}
+
+	got, err := EnvCredentials{Prefix: "KSCTEST"}.Credentials(context.Background())
+	want := BasicCredentials{UserName: "user", Password: "pass", Domain: "corp", InternalUser: true, VServerName: "tenant"}
+	if err != nil || got != want {
+		t.Errorf("Credentials() = %+v, %v, want %+v", got, err, want)
+	}
+
+	os.Setenv("KSCTEST_INTERNAL", "maybe")
+	if _, err := (EnvCredentials{Prefix: "KSCTEST"}).Credentials(context.Background()); err == nil {
+		t.Error("invalid KSCTEST_INTERNAL accepted")
+	}
+
+	os.Unsetenv("KSCTEST_INTERNAL")
+	os.Unsetenv("KSCTEST_USERNAME")
+	if _, err := (EnvCredentials{Prefix: "KSCTEST"}).Credentials(context.Background()); err == nil {
+		t.Error("missing KSCTEST_USERNAME accepted")
+	}
+}
+
+func TestFileCredentials(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ksc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "creds.json")
+	data := `{"username": "user", "password": "pass", "internal": true}`
+	if err := ioutil.WriteFile(path, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FileCredentials{Path: path}.Credentials(context.Background())
+	want := BasicCredentials{UserName: "user", Password: "pass", InternalUser: true}
+	if err != nil || got != want {
+		t.Errorf("Credentials() = %+v, %v, want %+v", got, err, want)
+	}
+
+	if err := ioutil.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (FileCredentials{Path: path}).Credentials(context.Background()); err == nil {
+		t.Error("malformed credentials file accepted")
+	}
+}
+
+// rotatingCredentials returns a new password on every call.
+type rotatingCredentials struct{ calls int32 }
+
+func (r *rotatingCredentials) Credentials(context.Context) (BasicCredentials, error) {
+	n := atomic.AddInt32(&r.calls, 1)
+	return BasicCredentials{UserName: "user", Password: fmt.Sprintf("pass%d", n)}, nil
+}
+
+func TestCredentialProviderOnReLogin(t *testing.T) {
+	srv, expire := sessionServer(t)
+	c := srv.client(Config{Credentials: &rotatingCredentials{}, XKscSession: true})
+
+	ctx := context.Background()
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+	expire()
+	if _, err := c.Call(ctx, "Tasks.GetTask", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	logins := srv.received("Session.StartSession")
+	if len(logins) != 2 {
+		t.Fatalf("Session.StartSession called %d times, want 2", len(logins))
+	}
+	for i, login := range logins {
+		pass := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("pass%d", i+1)))
+		if auth := login.Header.Get("Authorization"); !strings.Contains(auth, `pass="`+pass+`"`) {
+			t.Errorf("login %d Authorization = %s, want password pass%d", i+1, auth, i+1)
+		}
+	}
+}
+
+func TestDeprecatedClientFields(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.reply("Session.StartSession", `{"PxgRetVal":"token"}`)
+
+	c := srv.client(Config{UserName: "user", Password: "pass", Domain: "corp", InternalUser: true, VServerName: "tenant", XKscSession: true})
+	if c.UserName != "user" || c.Password != "pass" || c.Domain != "corp" || !c.InternalUser || c.VServerName != "tenant" {
+		t.Errorf("deprecated fields aren't copied from Config: %+v", c)
+	}
+
+	if err := c.Login(context.Background(), BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+	if c.XKscSessionToken != "token" || c.SessionToken() != "token" {
+		t.Errorf("XKscSessionToken = %q, SessionToken() = %q, want token", c.XKscSessionToken, c.SessionToken())
+	}
+	if h := srv.received("Session.StartSession")[0].Header; h.Get("X-KSC-VServer") != base64.StdEncoding.EncodeToString([]byte("tenant")) {
+		t.Errorf("X-KSC-VServer = %q", h.Get("X-KSC-VServer"))
+	}
+}
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // DataProtectionApi service allows to protect sensitive data in policies, tasks, and/or on specified host.
@@ -54,14 +51,10 @@ type DataProtectionApi service
 // 8 characters minimum and 16 characters maximum
 // Must contain characters at least from any 3 of 4 groups mentioned in the section "Characters allowed"
 func (dpa *DataProtectionApi) CheckPasswordSplPpc(ctx context.Context, szwPassword string) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwPassword": "%s"}`, szwPassword))
-	request, err := http.NewRequest("POST", dpa.client.Server+"/api/v1.0/DataProtectionApi.CheckPasswordSplPpc", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwPassword": szwPassword}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := dpa.client.Request(ctx, request, &pxgValBool)
+	raw, err := dpa.client.Call(ctx, "DataProtectionApi.CheckPasswordSplPpc", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
@@ -72,26 +65,18 @@ type ProtectedData struct {
 
 // ProtectDataForHost Protects sensitive data to store in SettingsStorage or local task.
 func (dpa *DataProtectionApi) ProtectDataForHost(ctx context.Context, szwHostId, pData string) (*ProtectedData, error) {
-	postData := []byte(fmt.Sprintf(`{"szwHostId" : "%s", "pData" : "%s"}`, szwHostId, pData))
-	request, err := http.NewRequest("POST", dpa.client.Server+"/api/v1.0/DataProtectionApi.ProtectDataForHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwHostId": szwHostId, "pData": pData}
 	protectedData := new(ProtectedData)
-	_, err = dpa.client.Request(ctx, request, &protectedData)
+	_, err := dpa.client.Call(ctx, "DataProtectionApi.ProtectDataForHost", postData, &protectedData)
 	return protectedData, err
 }
 
 // ProtectDataGlobally Protects sensitive data to store in policy or global/group task.
 func (dpa *DataProtectionApi) ProtectDataGlobally(ctx context.Context, pData string) (*ProtectedData, error) {
-	postData := []byte(fmt.Sprintf(`{"pData" : "%s"}`, pData))
-	request, err := http.NewRequest("POST", dpa.client.Server+"/api/v1.0/DataProtectionApi.ProtectDataGlobally", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"pData": pData}
 
 	protectedData := new(ProtectedData)
-	_, err = dpa.client.Request(ctx, request, &protectedData)
+	_, err := dpa.client.Call(ctx, "DataProtectionApi.ProtectDataGlobally", postData, &protectedData)
 	return protectedData, err
 }
 
@@ -99,55 +84,39 @@ func (dpa *DataProtectionApi) ProtectDataGlobally(ctx context.Context, pData str
 // Protects the specified text as UTF16 string encrypted with the key of the specified host.
 func (dpa *DataProtectionApi) ProtectUtf16StringForHost(ctx context.Context, szwHostId, szwPlainText string) (*PxgValStr,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"szwHostId" : "%s", "szwPlainText" : "%s"}`, szwHostId, szwPlainText))
-	request, err := http.NewRequest("POST", dpa.client.Server+"/api/v1.0/DataProtectionApi.ProtectUtf16StringForHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwHostId": szwHostId, "szwPlainText": szwPlainText}
 
 	pxgValStr := new(PxgValStr)
-	_, err = dpa.client.Request(ctx, request, &pxgValStr)
+	_, err := dpa.client.Call(ctx, "DataProtectionApi.ProtectUtf16StringForHost", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
 // ProtectUtf16StringGlobally Protects sensitive data to store in policy, global/group task, Administration Server settings.
 // Protects the specified text as UTF16 string encrypted with the key of the Administration Server.
 func (dpa *DataProtectionApi) ProtectUtf16StringGlobally(ctx context.Context, szwPlainText string) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"szwPlainText" : "%s"}`, szwPlainText))
-	request, err := http.NewRequest("POST", dpa.client.Server+"/api/v1.0/DataProtectionApi.ProtectUtf16StringGlobally", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwPlainText": szwPlainText}
 
 	pxgValStr := new(PxgValStr)
-	_, err = dpa.client.Request(ctx, request, &pxgValStr)
+	_, err := dpa.client.Call(ctx, "DataProtectionApi.ProtectUtf16StringGlobally", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
 // ProtectUtf8StringForHost Protects sensitive data for the specified host (to store in its local settings or a local task)
 // Protects the specified text as UTF8 string encrypted with the key of the specified host.
 func (dpa *DataProtectionApi) ProtectUtf8StringForHost(ctx context.Context, szwHostId, szwPlainText string) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"szwHostId" : "%s", "szwPlainText" : "%s"}`, szwHostId, szwPlainText))
-	request, err := http.NewRequest("POST", dpa.client.Server+"/api/v1.0/DataProtectionApi.ProtectUtf8StringForHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwHostId": szwHostId, "szwPlainText": szwPlainText}
 
 	pxgValStr := new(PxgValStr)
-	_, err = dpa.client.Request(ctx, request, &pxgValStr)
+	_, err := dpa.client.Call(ctx, "DataProtectionApi.ProtectUtf8StringForHost", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
 // ProtectUtf8StringGlobally Protects sensitive data to store in policy, global/group task, Administration Server settings.
 // Protects the specified text as UTF8 string encrypted with the key of the Administration Server.
 func (dpa *DataProtectionApi) ProtectUtf8StringGlobally(ctx context.Context, szwPlainText string) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"szwPlainText" : "%s"}`, szwPlainText))
-	request, err := http.NewRequest("POST", dpa.client.Server+"/api/v1.0/DataProtectionApi.ProtectUtf8StringGlobally", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwPlainText": szwPlainText}
 
 	pxgValStr := new(PxgValStr)
-	_, err = dpa.client.Request(ctx, request, &pxgValStr)
+	_, err := dpa.client.Call(ctx, "DataProtectionApi.ProtectUtf8StringGlobally", postData, &pxgValStr)
 	return pxgValStr, err
 }
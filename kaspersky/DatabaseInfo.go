@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // DatabaseInfo service to Database processing. Allow to get information from a Database.
@@ -36,87 +33,55 @@ type DatabaseInfo service
 
 // GetDBSize Get database's files size.
 func (di *DatabaseInfo) GetDBSize(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DatabaseInfo.GetDBSize", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := di.client.Request(ctx, request, &pxgValInt)
+	raw, err := di.client.Call(ctx, "DatabaseInfo.GetDBSize", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // GetDBDataSize Get database's data size.
 func (di *DatabaseInfo) GetDBDataSize(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DatabaseInfo.GetDBDataSize", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := di.client.Request(ctx, request, &pxgValInt)
+	raw, err := di.client.Call(ctx, "DatabaseInfo.GetDBDataSize", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // GetDBEventsCount Get database's events count.
 func (di *DatabaseInfo) GetDBEventsCount(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DatabaseInfo.GetDBEventsCount", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := di.client.Request(ctx, request, &pxgValInt)
+	raw, err := di.client.Call(ctx, "DatabaseInfo.GetDBEventsCount", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // IsCloudSQL Check is current SQL server in cloud (Amazon RDS or Azure SQL)
 func (di *DatabaseInfo) IsCloudSQL(ctx context.Context, nCloudType int64) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nCloudType": %d}`, nCloudType))
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DatabaseInfo.IsCloudSQL", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nCloudType": nCloudType}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := di.client.Request(ctx, request, &pxgValBool)
+	raw, err := di.client.Call(ctx, "DatabaseInfo.IsCloudSQL", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // CheckBackupPath Check the server administration and SQL-server permissions to read and write files along path.
 func (di *DatabaseInfo) CheckBackupPath(ctx context.Context, szwPath string) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwPath": "%s"}`, szwPath))
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DatabaseInfo.CheckBackupPath", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwPath": szwPath}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := di.client.Request(ctx, request, &pxgValBool)
+	raw, err := di.client.Call(ctx, "DatabaseInfo.CheckBackupPath", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // CheckBackupPath2 Check the server administration and SQL-server permissions to read and write files along path.
 func (di *DatabaseInfo) CheckBackupPath2(ctx context.Context, szwWinPath, szwLinuxPath string) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwWinPath": "%s",	"szwLinuxPath": "%s"}`, szwWinPath, szwLinuxPath))
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DatabaseInfo.CheckBackupPath2", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwWinPath": szwWinPath, "szwLinuxPath": szwLinuxPath}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := di.client.Request(ctx, request, &pxgValBool)
+	raw, err := di.client.Call(ctx, "DatabaseInfo.CheckBackupPath2", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // IsLinuxSQL Check is current SQL server in on Linux.
 func (di *DatabaseInfo) IsLinuxSQL(ctx context.Context) (*PxgValBool, []byte, error) {
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DatabaseInfo.IsLinuxSQL", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValBool := new(PxgValBool)
-	raw, err := di.client.Request(ctx, request, &pxgValBool)
+	raw, err := di.client.Call(ctx, "DatabaseInfo.IsLinuxSQL", nil, &pxgValBool)
 	return pxgValBool, raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // DpeKeyService service for working with encrypted devices..
@@ -36,13 +33,8 @@ type DpeKeyService service
 
 // GetDeviceKeys3 Returns information about host and key for chosen encrypted device.
 func (di *DpeKeyService) GetDeviceKeys3(ctx context.Context, wstrDeviceId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrDeviceId": "%s"}`, wstrDeviceId))
+	postData := map[string]interface{}{"wstrDeviceId": wstrDeviceId}
 
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/DpeKeyService.GetDeviceKeys3", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := di.client.Request(ctx, request, nil)
+	raw, err := di.client.Call(ctx, "DpeKeyService.GetDeviceKeys3", postData, nil)
 	return raw, err
 }
@@ -0,0 +1,185 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that KscError unwraps to. Use errors.Is to branch on the failure class.
+var (
+	// ErrAccessDenied the current security context has no rights to perform the operation.
+	ErrAccessDenied = errors.New("kaspersky: access denied")
+
+	// ErrObjectNotFound the requested object (host, group, task, iterator, etc.) does not exist.
+	ErrObjectNotFound = errors.New("kaspersky: object not found")
+
+	// ErrSessionExpired the session is not authenticated anymore (expired token, ended session or server restart).
+	ErrSessionExpired = errors.New("kaspersky: session expired")
+
+	// ErrInvalidArgument the server rejected one of the passed parameters.
+	ErrInvalidArgument = errors.New("kaspersky: invalid argument")
+
+	// ErrResponseTooLarge the response body exceeds Config.MaxResponseSize.
+	ErrResponseTooLarge = errors.New("kaspersky: response too large")
+
+	// ErrInvalidToken the bearer token failed signature or claims validation.
+	ErrInvalidToken = errors.New("kaspersky: invalid token")
+)
+
+// KLSTD error codes which are mapped to the sentinel errors.
+const (
+	KlstdErrAccessDenied    int64 = 1154
+	KlstdErrInvalidArgument int64 = 1171
+	KlstdErrObjectNotFound  int64 = 1183
+)
+
+// KscError is returned by KscClient.Request (and every service method) when KSC reports a failure.
+//
+// It is built either from the PxgError container of the response body
+// or, if the server didn't send one, from the HTTP status of the response.
+// If the body of an error response can't be read or decoded, Unwrap returns the cause.
+type KscError struct {
+	// StatusCode HTTP status code of the response
+	StatusCode int
+
+	// Method called OpenAPI method, e.g. "HostGroup.FindHosts"
+	Method string
+
+	// Code error code
+	Code int64
+
+	// Subcode error subcode
+	Subcode int64
+
+	// Module error module, e.g. "KLSTD"
+	Module string
+
+	// File source file on the server side where the error has been raised
+	File string
+
+	// Line source line on the server side where the error has been raised
+	Line int64
+
+	// Message error message
+	Message string
+
+	// Locdata localization template of the error
+	Locdata Locdata
+
+	// cause error of reading the body of the error response
+	cause error
+
+	// sessionLost the client has verified that 403 is caused by the lost session, see KscClient.sessionExpired
+	sessionLost bool
+}
+
+func newKscError(e *Error) *KscError {
+	ke := &KscError{Subcode: e.Subcode, Locdata: e.Locdata}
+	if e.Code != nil {
+		ke.Code = *e.Code
+	}
+	if e.Module != nil {
+		ke.Module = *e.Module
+	}
+	if e.File != nil {
+		ke.File = *e.File
+	}
+	if e.Line != nil {
+		ke.Line = *e.Line
+	}
+	if e.Message != nil {
+		ke.Message = *e.Message
+	}
+	return ke
+}
+
+func (e *KscError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("kaspersky: ")
+	if e.Method != "" {
+		sb.WriteString(e.Method + ": ")
+	}
+
+	switch {
+	case e.Message != "":
+		sb.WriteString(e.Message)
+	case e.Locdata.Value.Format != "":
+		sb.WriteString(e.Locdata.Value.Format)
+	case e.cause != nil:
+		sb.WriteString(e.cause.Error())
+	case e.StatusCode != 0:
+		sb.WriteString(http.StatusText(e.StatusCode))
+	default:
+		sb.WriteString("unknown error")
+	}
+
+	if e.Module != "" || e.Code != 0 {
+		fmt.Fprintf(&sb, " (module: %s, code: %d, subcode: %d)", e.Module, e.Code, e.Subcode)
+	}
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&sb, " [HTTP %d]", e.StatusCode)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the sentinel error matching the error code or the HTTP status, the cause if there is
+// no such sentinel, or nil.
+//
+// 403 without PxgError maps to ErrAccessDenied: it's also returned by proxies and server ACLs,
+// so the client reports it as ErrSessionExpired only after it has verified the session is lost.
+func (e *KscError) Unwrap() error {
+	if e.Module == "KLSTD" {
+		switch e.Code {
+		case KlstdErrAccessDenied:
+			return ErrAccessDenied
+		case KlstdErrObjectNotFound:
+			return ErrObjectNotFound
+		case KlstdErrInvalidArgument:
+			return ErrInvalidArgument
+		}
+	}
+
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		return ErrSessionExpired
+	case http.StatusForbidden:
+		if e.sessionLost {
+			return ErrSessionExpired
+		}
+		return ErrAccessDenied
+	case http.StatusNotFound:
+		return ErrObjectNotFound
+	}
+	return e.cause
+}
+
+// methodFromPath extracts OpenAPI method name from the request path, e.g. "/api/v1.0/Session.Ping" -> "Session.Ping".
+func methodFromPath(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,148 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestKscErrorUnwrap(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *KscError
+		want error
+	}{
+		{"access denied", &KscError{Module: "KLSTD", Code: KlstdErrAccessDenied}, ErrAccessDenied},
+		{"object not found", &KscError{Module: "KLSTD", Code: KlstdErrObjectNotFound}, ErrObjectNotFound},
+		{"invalid argument", &KscError{Module: "KLSTD", Code: KlstdErrInvalidArgument}, ErrInvalidArgument},
+		{"other module", &KscError{Module: "KLPRCI", Code: KlstdErrObjectNotFound}, nil},
+		{"401", &KscError{StatusCode: http.StatusUnauthorized}, ErrSessionExpired},
+		{"403 without PxgError", &KscError{StatusCode: http.StatusForbidden}, ErrAccessDenied},
+		{"403 of lost session", &KscError{StatusCode: http.StatusForbidden, sessionLost: true}, ErrSessionExpired},
+		{"403 with PxgError", &KscError{StatusCode: http.StatusForbidden, Module: "KLPRCI", Code: 5}, ErrAccessDenied},
+		{"404", &KscError{StatusCode: http.StatusNotFound}, ErrObjectNotFound},
+		{"500", &KscError{StatusCode: http.StatusInternalServerError}, nil},
+		{"500 with cause", &KscError{StatusCode: http.StatusInternalServerError, cause: io.ErrUnexpectedEOF}, io.ErrUnexpectedEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Unwrap(); got != tt.want {
+				t.Errorf("Unwrap() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKscErrorError(t *testing.T) {
+	tests := []struct {
+		err  *KscError
+		want string
+	}{
+		{&KscError{}, "kaspersky: unknown error"},
+		{&KscError{Method: "HostGroup.FindHosts", StatusCode: 500}, "kaspersky: HostGroup.FindHosts: Internal Server Error [HTTP 500]"},
+		{
+			&KscError{Method: "Tasks.GetTask", Module: "KLSTD", Code: 1183, Message: "Object not found"},
+			"kaspersky: Tasks.GetTask: Object not found (module: KLSTD, code: 1183, subcode: 0)",
+		},
+		{
+			&KscError{Locdata: Locdata{Value: Value1{Format: "Task '%1' not found"}}},
+			"kaspersky: Task '%1' not found",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	body := []byte(`{"PxgError":{"code":1183,"module":"KLSTD","message":"Object not found","subcode":2}}`)
+	err := CheckResponse(&body)
+
+	var ke *KscError
+	if !errors.As(err, &ke) {
+		t.Fatalf("CheckResponse() = %v, want *KscError", err)
+	}
+	if ke.Code != 1183 || ke.Subcode != 2 || ke.Module != "KLSTD" || !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("CheckResponse() = %+v", ke)
+	}
+
+	body = []byte(`{"PxgRetVal":1}`)
+	if err := CheckResponse(&body); err != nil {
+		t.Errorf("CheckResponse() = %v, want nil", err)
+	}
+}
+
+func TestRequestKscError(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.reply("Tasks.GetTask", `{"PxgError":{"code":1183,"module":"KLSTD","message":"Object not found"}}`)
+	srv.handle("Tasks.DeleteTask", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	c := srv.client(Config{})
+
+	_, err := c.Call(context.Background(), "Tasks.GetTask", nil, nil)
+	var ke *KscError
+	if !errors.As(err, &ke) || ke.Method != "Tasks.GetTask" || !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Tasks.GetTask error = %v", err)
+	}
+
+	// the session is valid, so 403 is a denial and the call isn't replayed
+	_, err = c.Call(context.Background(), "Tasks.DeleteTask", nil, nil)
+	if !errors.As(err, &ke) || ke.StatusCode != http.StatusForbidden || !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Tasks.DeleteTask error = %v", err)
+	}
+	if n := len(srv.received("Tasks.DeleteTask")); n != 1 {
+		t.Errorf("Tasks.DeleteTask sent %d times, want 1", n)
+	}
+	if n := len(srv.received("Session.Ping")); n != 1 {
+		t.Errorf("session verified %d times, want 1", n)
+	}
+}
+
+func TestRequestUndecodableError(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.handle("Tasks.DeleteTask", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>Bad Gateway</html>"))
+	})
+	c := srv.client(Config{})
+
+	_, err := c.Call(context.Background(), "Tasks.DeleteTask", nil, nil)
+	var ke *KscError
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &ke) || ke.StatusCode != http.StatusBadGateway || !errors.As(err, &syntaxErr) {
+		t.Errorf("Tasks.DeleteTask error = %v, want KscError with the decode error", err)
+	}
+}
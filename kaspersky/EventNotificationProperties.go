@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // EventNotificationProperties service to working with Notification properties.
@@ -169,13 +165,8 @@ type DefaultSettingsVal struct {
 
 // GetDefaultSettings Reads the default notification settings. Reads the default notification settings, such as SMTP server properties, etc.
 func (enp *EventNotificationProperties) GetDefaultSettings(ctx context.Context) (*DefaultSettings, []byte, error) {
-	request, err := http.NewRequest("POST", enp.client.Server+"/api/v1.0/EventNotificationProperties.GetDefaultSettings", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	defaultSettings := new(DefaultSettings)
-	raw, err := enp.client.Request(ctx, request, &defaultSettings)
+	raw, err := enp.client.Call(ctx, "EventNotificationProperties.GetDefaultSettings", nil, &defaultSettings)
 	return defaultSettings, raw, err
 }
 
@@ -203,13 +194,8 @@ type NLimits struct {
 
 // GetNotificationLimits Reads the notification limits.
 func (enp *EventNotificationProperties) GetNotificationLimits(ctx context.Context) (*ENLimits, []byte, error) {
-	request, err := http.NewRequest("POST", enp.client.Server+"/api/v1.0/EventNotificationProperties.GetNotificationLimits", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	enLimits := new(ENLimits)
-	raw, err := enp.client.Request(ctx, request, &enLimits)
+	raw, err := enp.client.Call(ctx, "EventNotificationProperties.GetNotificationLimits", nil, &enLimits)
 	return enLimits, raw, err
 }
 
@@ -217,45 +203,21 @@ func (enp *EventNotificationProperties) GetNotificationLimits(ctx context.Contex
 // Allows to test the notification settings, such as SMTP server properties, etc.
 // by sending a test notification using the provided notification settings.
 func (enp *EventNotificationProperties) TestNotification(ctx context.Context, eType int, pSettings interface{}) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"eType": %d,"pSettings": %v	}`, eType, pSettings))
-	request, err := http.NewRequest("POST", enp.client.Server+"/api/v1.0/EventNotificationProperties.TestNotification", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"eType": eType, "pSettings": pSettings}
 
-	raw, err := enp.client.Request(ctx, request, nil)
+	raw, err := enp.client.Call(ctx, "EventNotificationProperties.TestNotification", postData, nil)
 	return raw, err
 }
 
 // SetNotificationLimits Sets up the notification limits. Allows to setup notification limits.
 func (enp *EventNotificationProperties) SetNotificationLimits(ctx context.Context, params ENLimitsParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", enp.client.Server+"/api/v1.0/EventNotificationProperties.SetNotificationLimits", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := enp.client.Request(ctx, request, nil)
+	raw, err := enp.client.Call(ctx, "EventNotificationProperties.SetNotificationLimits", params, nil)
 	return raw, err
 }
 
 // SetDefaultSettings Sets up the default notification settings.
 // Allows to setup the default notification settings, such as SMTP server properties, etc.
 func (enp *EventNotificationProperties) SetDefaultSettings(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", enp.client.Server+"/api/v1.0/EventNotificationProperties.SetDefaultSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := enp.client.Request(ctx, request, nil)
+	raw, err := enp.client.Call(ctx, "EventNotificationProperties.SetDefaultSettings", params, nil)
 	return raw, err
 }
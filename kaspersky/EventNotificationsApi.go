@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // EventNotificationsApi service allows to publish event with Administration Server as publisher.
@@ -51,12 +48,6 @@ type PEventBody struct {
 
 // PublishEvent Publishes event with Administration Server as publisher
 func (ts *EventNotificationsApi) PublishEvent(ctx context.Context, params EventNotificationParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/EventNotificationsApi.PublishEvent", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "EventNotificationsApi.PublishEvent", params, nil)
 	return raw, err
 }
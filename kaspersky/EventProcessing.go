@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // EventProcessing service implements the functionality for viewing and deleting events.
@@ -37,14 +33,10 @@ type EventProcessing service
 
 // GetRecordCount Get record count in the result-set. Returns number of elements contained in the specified result-set.
 func (ep *EventProcessing) GetRecordCount(ctx context.Context, strIteratorId string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strIteratorId": "%s"}`, strIteratorId))
-	request, err := http.NewRequest("POST", ep.client.Server+"/api/v1.0/EventProcessing.GetRecordCount", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strIteratorId": strIteratorId}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := ep.client.Request(ctx, request, &pxgValInt)
+	raw, err := ep.client.Call(ctx, "EventProcessing.GetRecordCount", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -52,59 +44,29 @@ func (ep *EventProcessing) GetRecordCount(ctx context.Context, strIteratorId str
 // Returns elements contained in the specified result-set in the diapason from position nStart to position nEnd.
 func (ep *EventProcessing) GetRecordRange(ctx context.Context, strIteratorId string, nStart, nEnd int64) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"strIteratorId": "%s", "nStart": %d, "nEnd": %d}`, strIteratorId, nStart, nEnd))
-	request, err := http.NewRequest("POST", ep.client.Server+"/api/v1.0/EventProcessing.GetRecordRange", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strIteratorId": strIteratorId, "nStart": nStart, "nEnd": nEnd}
 
-	raw, err := ep.client.Request(ctx, request, nil)
+	raw, err := ep.client.Call(ctx, "EventProcessing.GetRecordRange", postData, nil)
 	return raw, err
 }
 
 // ReleaseIterator Releases the specified result-set and frees associated memory.
 func (ep *EventProcessing) ReleaseIterator(ctx context.Context, strIteratorId string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strIteratorId": "%s"}`, strIteratorId))
-	request, err := http.NewRequest("POST", ep.client.Server+"/api/v1.0/EventProcessing.ReleaseIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strIteratorId": strIteratorId}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := ep.client.Request(ctx, request, &pxgValInt)
+	raw, err := ep.client.Call(ctx, "EventProcessing.ReleaseIterator", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // InitiateDelete Initiates mass delete of the events specified by pSettings in the result-set.
 func (ep *EventProcessing) InitiateDelete(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ep.client.Server+"/api/v1.0/EventProcessing.InitiateDelete",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ep.client.Request(ctx, request, nil)
+	raw, err := ep.client.Call(ctx, "EventProcessing.InitiateDelete", params, nil)
 	return raw, err
 }
 
 // CancelDelete Cancels mass delete of the events specified by pSettings in the result-set.
 func (ep *EventProcessing) CancelDelete(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ep.client.Server+"/api/v1.0/EventProcessing.CancelDelete",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ep.client.Request(ctx, request, nil)
+	raw, err := ep.client.Call(ctx, "EventProcessing.CancelDelete", params, nil)
 	return raw, err
 }
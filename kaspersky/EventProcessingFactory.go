@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // EventProcessingFactory service to create event processing iterators
@@ -45,30 +42,16 @@ type EventPFP struct {
 // CreateEventProcessing Create event processing iterator.
 func (epf *EventProcessingFactory) CreateEventProcessing(ctx context.Context, params EventPFP) (*StrIteratorId,
 	[]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", epf.client.Server+"/api/v1.0/EventProcessingFactory.CreateEventProcessing",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	strIteratorId := new(StrIteratorId)
-	raw, err := epf.client.Request(ctx, request, &strIteratorId)
+	raw, err := epf.client.Call(ctx, "EventProcessingFactory.CreateEventProcessing", params, &strIteratorId)
 	return strIteratorId, raw, err
 }
 
 // CreateEventProcessing2 Create event processing iterator with filter.
 func (epf *EventProcessingFactory) CreateEventProcessing2(ctx context.Context, params EventPFP) (*StrIteratorId,
 	[]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", epf.client.Server+"/api/v1.0/EventProcessingFactory.CreateEventProcessing2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	strIteratorId := new(StrIteratorId)
-	raw, err := epf.client.Request(ctx, request, &strIteratorId)
+	raw, err := epf.client.Call(ctx, "EventProcessingFactory.CreateEventProcessing2", params, &strIteratorId)
 	return strIteratorId, raw, err
 }
 
@@ -87,29 +70,15 @@ type EventPFH struct {
 // CreateEventProcessingForHost Create event processing iterator for host.
 func (epf *EventProcessingFactory) CreateEventProcessingForHost(ctx context.Context, params EventPFH) (*StrIteratorId,
 	[]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", epf.client.Server+"/api/v1.0/EventProcessingFactory.CreateEventProcessingForHost",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	strIteratorId := new(StrIteratorId)
-	raw, err := epf.client.Request(ctx, request, &strIteratorId)
+	raw, err := epf.client.Call(ctx, "EventProcessingFactory.CreateEventProcessingForHost", params, &strIteratorId)
 	return strIteratorId, raw, err
 }
 
 // CreateEventProcessingForHost2 Create event processing iterator with filter for host.
 func (epf *EventProcessingFactory) CreateEventProcessingForHost2(ctx context.Context, params EventPFH) (*StrIteratorId,
 	[]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", epf.client.Server+"/api/v1.0/EventProcessingFactory.CreateEventProcessingForHost2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	strIteratorId := new(StrIteratorId)
-	raw, err := epf.client.Request(ctx, request, &strIteratorId)
+	raw, err := epf.client.Call(ctx, "EventProcessingFactory.CreateEventProcessingForHost2", params, &strIteratorId)
 	return strIteratorId, raw, err
 }
@@ -0,0 +1,217 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventSeverity severity of event (severity attribute).
+type EventSeverity int64
+
+const (
+	EventSeverityInfo     EventSeverity = 1
+	EventSeverityWarning  EventSeverity = 2
+	EventSeverityError    EventSeverity = 3
+	EventSeverityCritical EventSeverity = 4
+)
+
+func (s EventSeverity) String() string {
+	switch s {
+	case EventSeverityInfo:
+		return "Info"
+	case EventSeverityWarning:
+		return "Warning"
+	case EventSeverityError:
+		return "Error"
+	case EventSeverityCritical:
+		return "Critical"
+	}
+	return fmt.Sprintf("EventSeverity(%d)", int64(s))
+}
+
+// Event event record of EventProcessing result-set.
+type Event struct {
+	// ID event id in the server database
+	ID int64 `json:"event_db_id,omitempty"`
+
+	// Type event type, e.g. "GNRL_EV_VIRUS_FOUND"
+	Type string `json:"event_type,omitempty"`
+
+	// TypeDisplayName localized event type
+	TypeDisplayName string `json:"event_type_display_name,omitempty"`
+
+	// Severity event severity
+	Severity EventSeverity `json:"severity,omitempty"`
+
+	// RiseTime time the event occurred on the host
+	RiseTime time.Time `json:"rise_time,omitempty"`
+
+	// RegistrationTime time the event was registered on the server
+	RegistrationTime time.Time `json:"registration_time,omitempty"`
+
+	// Hostname host name, unique host identifier
+	Hostname string `json:"hostname,omitempty"`
+
+	// HostDN host display name
+	HostDN string `json:"host_dn,omitempty"`
+
+	// GroupID id of the administration group the host is located in
+	GroupID int64 `json:"group_id,omitempty"`
+
+	// Product product name
+	Product string `json:"product_name,omitempty"`
+
+	// ProductVersion product version
+	ProductVersion string `json:"product_version,omitempty"`
+
+	// TaskID task id
+	TaskID int64 `json:"task_id,omitempty"`
+
+	// TaskDisplayName task display name
+	TaskDisplayName string `json:"task_display_name,omitempty"`
+
+	// TaskNewState new state of the task
+	TaskNewState int64 `json:"task_new_state,omitempty"`
+
+	// Description event body
+	Description string `json:"descr,omitempty"`
+}
+
+// EventReader reads events of EventProcessing result-set page by page.
+// The result-set is released when events are exhausted, on error, on context cancellation
+// or by Close, whatever happens first.
+//
+//	events, err := client.EventProcessingFactory.Events(ctx, kaspersky.EventPFP{
+//		PFilter: kaspersky.PFilter{KlevpEventRiseTimeLastDays: 1},
+//	})
+//	if err != nil {
+//		...
+//	}
+//	err = events.ForEach(ctx, func(e kaspersky.Event) error {
+//		fmt.Println(e.RiseTime, e.Severity, e.HostDN, e.Description)
+//		return nil
+//	})
+type EventReader struct {
+	pager
+}
+
+// eventRange result of EventProcessing.GetRecordRange with events left undecoded.
+type eventRange struct {
+	PParamsEvents struct {
+		Rows []json.RawMessage `json:"KLEVP_EVENT_RANGE_ARRAY"`
+	} `json:"pParamsEvents"`
+}
+
+// Events creates event processing result-set with EventProcessingFactory.CreateEventProcessing
+// and returns reader over it. Empty VecFieldsToReturn means all attributes of Event,
+// zero LifetimeSEC means DefaultViewLifetime.
+func (epf *EventProcessingFactory) Events(ctx context.Context, params EventPFP) (*EventReader, error) {
+	if len(params.VecFieldsToReturn) == 0 {
+		params.VecFieldsToReturn = FieldsOf(Event{})
+	}
+	if params.LifetimeSEC <= 0 {
+		params.LifetimeSEC = DurationToSeconds(DefaultViewLifetime)
+	}
+
+	id, _, err := epf.CreateEventProcessing(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return newEventReader(ctx, epf.client.EventProcessing, id.StrIteratorID)
+}
+
+// HostEvents creates event processing result-set for the host with EventProcessingFactory.CreateEventProcessingForHost2
+// and returns reader over it. Empty VecFieldsToReturn means all attributes of Event,
+// zero LifetimeSEC means DefaultViewLifetime.
+func (epf *EventProcessingFactory) HostEvents(ctx context.Context, params EventPFH) (*EventReader, error) {
+	if len(params.VecFieldsToReturn) == 0 {
+		params.VecFieldsToReturn = FieldsOf(Event{})
+	}
+	if params.LifetimeSEC <= 0 {
+		params.LifetimeSEC = DurationToSeconds(DefaultViewLifetime)
+	}
+
+	id, _, err := epf.CreateEventProcessingForHost2(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return newEventReader(ctx, epf.client.EventProcessing, id.StrIteratorID)
+}
+
+func newEventReader(ctx context.Context, ep *EventProcessing, id string) (*EventReader, error) {
+	if id == "" {
+		return nil, errors.New("kaspersky: EventProcessingFactory returned empty iterator id")
+	}
+
+	return &EventReader{pager{
+		ctx: detach(ctx),
+		count: func(ctx context.Context) (int64, error) {
+			count, _, err := ep.GetRecordCount(ctx, id)
+			if err != nil {
+				return 0, err
+			}
+			return count.Int, nil
+		},
+		fetch: func(ctx context.Context, start, n int64) ([]json.RawMessage, error) {
+			events := new(eventRange)
+			postData := map[string]interface{}{"strIteratorId": id, "nStart": start, "nEnd": start + n}
+			_, err := ep.client.Call(ctx, "EventProcessing.GetRecordRange", postData, events)
+			return events.PParamsEvents.Rows, err
+		},
+		release: func(ctx context.Context) error {
+			_, _, err := ep.ReleaseIterator(ctx, id)
+			return err
+		},
+	}}, nil
+}
+
+// Event decodes the current event.
+func (r *EventReader) Event() (Event, error) {
+	var e Event
+	err := r.Scan(&e)
+	return e, err
+}
+
+// ForEach calls fn for each of the rest of events until fn returns error. The result-set is released
+// before ForEach returns, even if fn panics.
+func (r *EventReader) ForEach(ctx context.Context, fn func(e Event) error) error {
+	defer r.Close()
+
+	for r.Next(ctx) {
+		e, err := r.Event()
+		if err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return r.Err()
+}
@@ -0,0 +1,163 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// eventServer serves event processing result-set "ev1" of n events.
+func eventServer(t *testing.T, n int) *fakeServer {
+	t.Helper()
+
+	srv := newFakeServer(t)
+	srv.reply("EventProcessingFactory.CreateEventProcessing", `{"strIteratorId":"ev1"}`)
+	srv.reply("EventProcessingFactory.CreateEventProcessingForHost2", `{"strIteratorId":"ev1"}`)
+	srv.reply("EventProcessing.ReleaseIterator", `{"PxgRetVal":1}`)
+	srv.resultSet(t, fakeResultSet{
+		CountMethod: "EventProcessing.GetRecordCount",
+		RangeMethod: "EventProcessing.GetRecordRange",
+		Array:       "pParamsEvents.KLEVP_EVENT_RANGE_ARRAY",
+		Rows: fakeRows(n, func(i int) Params {
+			return Params{"event_db_id": i, "event_type": "GNRL_EV_VIRUS_FOUND", "severity": 4,
+				"rise_time": time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC), "host_dn": fmt.Sprintf("h%d", i), "descr": "found"}
+		}),
+	})
+	return srv
+}
+
+func TestEventReaderForEach(t *testing.T) {
+	srv := eventServer(t, 250)
+	c := srv.client(Config{})
+	ctx := context.Background()
+
+	r, err := c.EventProcessingFactory.Events(ctx, EventPFP{PFilter: PFilter{KlevpEventRiseTimeLastDays: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events []Event
+	if err := r.ForEach(ctx, func(e Event) error {
+		events = append(events, e)
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 250 {
+		t.Fatalf("got %d events, want 250", len(events))
+	}
+	e := events[249]
+	if e.ID != 249 || e.Severity != EventSeverityCritical || e.HostDN != "h249" || e.RiseTime.Year() != 2021 {
+		t.Errorf("events[249] = %+v", e)
+	}
+	if s := e.Severity.String(); s != "Critical" {
+		t.Errorf("Severity.String() = %q", s)
+	}
+
+	var in EventPFP
+	_ = json.Unmarshal(srv.received("EventProcessingFactory.CreateEventProcessing")[0].Body, &in)
+	if len(in.VecFieldsToReturn) == 0 || in.LifetimeSEC != DurationToSeconds(DefaultViewLifetime) {
+		t.Errorf("CreateEventProcessing(%+v), want default fields and lifetime", in)
+	}
+	ranges := srv.received("EventProcessing.GetRecordRange")
+	var rng struct {
+		ID string `json:"strIteratorId"`
+	}
+	_ = json.Unmarshal(ranges[0].Body, &rng)
+	if len(ranges) != 3 || rng.ID != "ev1" {
+		t.Errorf("GetRecordRange called %d times with %q, want 3 times with ev1", len(ranges), rng.ID)
+	}
+	if n := len(srv.received("EventProcessing.ReleaseIterator")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestEventReaderStop(t *testing.T) {
+	srv := eventServer(t, 5)
+	c := srv.client(Config{})
+	ctx := context.Background()
+
+	r, err := c.EventProcessingFactory.HostEvents(ctx, EventPFH{StrHostName: "h", VecFieldsToReturn: []string{"event_db_id"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := errors.New("stop")
+	n := 0
+	if err := r.ForEach(ctx, func(e Event) error {
+		if n++; n == 2 {
+			return stop
+		}
+		return nil
+	}); err != stop {
+		t.Errorf("ForEach() error = %v, want fn error", err)
+	}
+
+	var in EventPFH
+	_ = json.Unmarshal(srv.received("EventProcessingFactory.CreateEventProcessingForHost2")[0].Body, &in)
+	if in.StrHostName != "h" || len(in.VecFieldsToReturn) != 1 {
+		t.Errorf("CreateEventProcessingForHost2(%+v)", in)
+	}
+	if n := len(srv.received("EventProcessing.ReleaseIterator")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestEventReaderPanic(t *testing.T) {
+	srv := eventServer(t, 5)
+	c := srv.client(Config{})
+	ctx := context.Background()
+
+	r, err := c.EventProcessingFactory.Events(ctx, EventPFP{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("ForEach() swallowed panic")
+			}
+		}()
+		_ = r.ForEach(ctx, func(Event) error { panic("fn") })
+	}()
+
+	if n := len(srv.received("EventProcessing.ReleaseIterator")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestEventReaderEmptyID(t *testing.T) {
+	srv := eventServer(t, 5)
+	srv.reply("EventProcessingFactory.CreateEventProcessing", `{}`)
+	c := srv.client(Config{})
+
+	if _, err := c.EventProcessingFactory.Events(context.Background(), EventPFP{}); err == nil {
+		t.Error("Events() with empty iterator id succeeded")
+	}
+}
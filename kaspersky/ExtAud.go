@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // ExtAud service for working with ExtAudit subsystem. This service allow you to get a revision of an object and update description.
@@ -51,13 +47,13 @@ type ExtAud service
 //	╚════╩══════════════════════╝
 func (ea *ExtAud) GetRevision(ctx context.Context, nObjId, nObjType, nObjRevision int64, out interface{}) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nObjId": %d,"nObjType": %d,"nObjRevision": %d}`, nObjId, nObjType, nObjRevision))
-	request, err := http.NewRequest("POST", ea.client.Server+"/api/v1.0/ExtAud.GetRevision", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"nObjId":       nObjId,
+		"nObjType":     nObjType,
+		"nObjRevision": nObjRevision,
 	}
 
-	raw, err := ea.client.Request(ctx, request, &out)
+	raw, err := ea.client.Call(ctx, "ExtAud.GetRevision", postData, &out)
 	return raw, err
 }
 
@@ -77,16 +73,14 @@ func (ea *ExtAud) GetRevision(ctx context.Context, nObjId, nObjType, nObjRevisio
 //	╚════╩══════════════════════╝
 func (ea *ExtAud) UpdateRevisionDesc(ctx context.Context, nObjId, nObjType, nObjRevision int64, wstrNewDescription string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nObjId": %d,"nObjType": %d,"nObjRevision": %d, "wstrNewDescription": "%s"}`,
-		nObjId,
-		nObjType,
-		nObjRevision, wstrNewDescription))
-	request, err := http.NewRequest("POST", ea.client.Server+"/api/v1.0/ExtAud.UpdateRevisionDesc", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"nObjId":             nObjId,
+		"nObjType":           nObjType,
+		"nObjRevision":       nObjRevision,
+		"wstrNewDescription": wstrNewDescription,
 	}
 
-	raw, err := ea.client.Request(ctx, request, nil)
+	raw, err := ea.client.Call(ctx, "ExtAud.UpdateRevisionDesc", postData, nil)
 	return raw, err
 }
 
@@ -106,12 +100,6 @@ type FDValue struct {
 
 // FinalDelete delete for deleted objects.
 func (ea *ExtAud) FinalDelete(ctx context.Context, params FinalDeleteParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ea.client.Server+"/api/v1.0/ExtAud.FinalDelete", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ea.client.Request(ctx, request, nil)
+	raw, err := ea.client.Call(ctx, "ExtAud.FinalDelete", params, nil)
 	return raw, err
 }
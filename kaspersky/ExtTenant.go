@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // ExtTenant Manage external tenant info interface.
@@ -38,19 +33,10 @@ type ExtTenant service
 
 // GetExternalTenantId Gets external tenant id.
 func (et *ExtTenant) GetExternalTenantId(ctx context.Context, nVServerId int64) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"nVServerId": %d}`, nVServerId))
-	request, err := http.NewRequest("POST", et.client.Server+"/api/v1.0/ExtTenant.GetExternalTenantId",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nVServerId": nVServerId}
 
 	externalTenantId := new(PxgValStr)
-	raw, err := et.client.Request(ctx, request, &externalTenantId)
-
-	if et.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := et.client.Call(ctx, "ExtTenant.GetExternalTenantId", postData, &externalTenantId)
 
 	return externalTenantId, err
 }
@@ -61,21 +47,7 @@ type ExternalTenantIdparams struct {
 }
 
 func (et *ExtTenant) SetExternalTenantId(ctx context.Context, params ExternalTenantIdparams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-	request, err := http.NewRequest("POST", et.client.Server+"/api/v1.0/ExtTenant.SetExternalTenantId",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	raw, err := et.client.Request(ctx, request, nil)
-
-	if et.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := et.client.Call(ctx, "ExtTenant.SetExternalTenantId", params, nil)
 
 	return err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 //FileCategorizer2 service for working with FileCategorizer subsystem.
@@ -45,18 +41,8 @@ type FileCategorizer2 service
 
 // AddExpressions Add some expressions to category.
 func (fc *FileCategorizer2) AddExpressions(ctx context.Context, params interface{}) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.AddExpressions", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := fc.client.Request(ctx, request, &pxgValStr)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.AddExpressions", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -64,13 +50,8 @@ func (fc *FileCategorizer2) AddExpressions(ctx context.Context, params interface
 //
 // Method cancels operation (GetFileMetadata, GetFilesMetadata, GetFilesMetadataFromMSI) initialized using current connection.
 func (fc *FileCategorizer2) CancelFileMetadataOperations(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.CancelFileMetadataOperations", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := fc.client.Request(ctx, request, &pxgValInt)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.CancelFileMetadataOperations", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -79,13 +60,8 @@ func (fc *FileCategorizer2) CancelFileMetadataOperations(ctx context.Context) (*
 // This methode cancels file upload.
 // Call FileCategorizer2.InitFileUpload to start new upload.
 func (fc *FileCategorizer2) CancelFileUpload(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.CancelFileUpload", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := fc.client.Request(ctx, request, &pxgValInt)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.CancelFileUpload", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -146,31 +122,16 @@ type Certificate struct {
 
 // CreateCategory Create category (simple, autoupdate or silverimage)
 func (fc *FileCategorizer2) CreateCategory(ctx context.Context, params CategoryParams) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.CreateCategory", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := fc.client.Request(ctx, request, &pxgValStr)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.CreateCategory", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // DeleteCategory Delete category.
 func (fc *FileCategorizer2) DeleteCategory(ctx context.Context, nCategoryId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nCategoryId": %d}`, nCategoryId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.DeleteCategory",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"nCategoryId": nCategoryId}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.DeleteCategory", postData, nil)
 	return raw, err
 }
 
@@ -183,18 +144,8 @@ type ExpressionParams struct {
 
 // DeleteExpression Delete some expressions from category.
 func (fc *FileCategorizer2) DeleteExpression(ctx context.Context, params ExpressionParams) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.DeleteExpression", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := fc.client.Request(ctx, request, &pxgValStr)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.DeleteExpression", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -202,28 +153,18 @@ func (fc *FileCategorizer2) DeleteExpression(ctx context.Context, params Express
 //
 // Deprecated: Use FileCategorizer2.DoStaticAnalysisAsync2 instead.
 func (fc *FileCategorizer2) DoStaticAnalysisAsync(ctx context.Context, wstrRequestId string, nPolicyId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s", "nPolicyId": %d}`, wstrRequestId, nPolicyId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.DoStaticAnalysisAsync",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId, "nPolicyId": nPolicyId}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.DoStaticAnalysisAsync", postData, nil)
 	return raw, err
 }
 
 // DoStaticAnalysisAsync2 Start Static analysis of application categories
 func (fc *FileCategorizer2) DoStaticAnalysisAsync2(ctx context.Context, nPolicyId int64) (*AsyncID, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicyId": %d}`, nPolicyId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.DoStaticAnalysisAsync2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPolicyId": nPolicyId}
 
 	asyncID := new(AsyncID)
-	raw, err := fc.client.Request(ctx, request, &asyncID)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.DoStaticAnalysisAsync2", postData, &asyncID)
 	return asyncID, raw, err
 }
 
@@ -231,96 +172,51 @@ func (fc *FileCategorizer2) DoStaticAnalysisAsync2(ctx context.Context, nPolicyI
 //
 // Deprecated: Use FileCategorizer2.DoTestStaticAnalysisAsync2 instead.
 func (fc *FileCategorizer2) DoTestStaticAnalysisAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.DoTestStaticAnalysisAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.DoTestStaticAnalysisAsync", params, nil)
 	return raw, err
 }
 
 // DoTestStaticAnalysisAsync2 Start static analysis for test ACL.
 func (fc *FileCategorizer2) DoTestStaticAnalysisAsync2(ctx context.Context, params interface{}) (*WActionGUID, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.DoTestStaticAnalysisAsync2", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	wActionGUID := new(WActionGUID)
-	raw, err := fc.client.Request(ctx, request, &wActionGUID)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.DoTestStaticAnalysisAsync2", params, &wActionGUID)
 	return wActionGUID, raw, err
 }
 
 // FinishStaticAnalysis Inform server that reading of analysis results is finished and server should clean it.
 func (fc *FileCategorizer2) FinishStaticAnalysis(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.FinishStaticAnalysis", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.FinishStaticAnalysis", nil, nil)
 	return raw, err
 }
 
 // ForceCategoryUpdate Force process of automatic update (for autoupdate and silverimage)
 func (fc *FileCategorizer2) ForceCategoryUpdate(ctx context.Context, nCategoryId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nCategoryId": %d}`, nCategoryId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.ForceCategoryUpdate",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"nCategoryId": nCategoryId}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.ForceCategoryUpdate", postData, nil)
 	return raw, err
 }
 
 // GetCategoriesModificationCounter Returns modification counter. It increments on every category change.
 func (fc *FileCategorizer2) GetCategoriesModificationCounter(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetCategoriesModificationCounter", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := fc.client.Request(ctx, request, &pxgValInt)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetCategoriesModificationCounter", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // GetCategory Get category by id.
 func (fc *FileCategorizer2) GetCategory(ctx context.Context, nCategoryId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nCategoryId": %d}`, nCategoryId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetCategory",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"nCategoryId": nCategoryId}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetCategory", postData, nil)
 	return raw, err
 }
 
 // GetCategoryByUUID Get category by uuid.
 func (fc *FileCategorizer2) GetCategoryByUUID(ctx context.Context, pCategoryUUID string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"pCategoryUUID": "%s"}`, pCategoryUUID))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetCategoryByUUID",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"pCategoryUUID": pCategoryUUID}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetCategoryByUUID", postData, nil)
 	return raw, err
 }
 
@@ -330,14 +226,9 @@ func (fc *FileCategorizer2) GetCategoryByUUID(ctx context.Context, pCategoryUUID
 //
 // It returns params with requested attributes.
 func (fc *FileCategorizer2) GetFileMetadata(ctx context.Context, ulFlag int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"ulFlag": %d}`, ulFlag))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetFileMetadata",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"ulFlag": ulFlag}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetFileMetadata", postData, nil)
 	return raw, err
 }
 
@@ -351,27 +242,17 @@ func (fc *FileCategorizer2) GetFileMetadata(ctx context.Context, ulFlag int64) (
 //
 // Each element is a params with requested attributes. See list of attributes File metadata flags.
 func (fc *FileCategorizer2) GetFilesMetadata(ctx context.Context, ulFlag int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"ulFlag": %d}`, ulFlag))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetFilesMetadata",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"ulFlag": ulFlag}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetFilesMetadata", postData, nil)
 	return raw, err
 }
 
 // GetFilesMetadataFromMSI Get files metadata from MSI.
 func (fc *FileCategorizer2) GetFilesMetadataFromMSI(ctx context.Context, ulFlag int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"ulFlag": %d}`, ulFlag))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetFilesMetadataFromMSI",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"ulFlag": ulFlag}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetFilesMetadataFromMSI", postData, nil)
 	return raw, err
 }
 
@@ -401,15 +282,10 @@ type RefPolicyValue struct {
 
 // GetRefPolicies Returns array of policies with references to specified category.
 func (fc *FileCategorizer2) GetRefPolicies(ctx context.Context, nCatId int64) (*RefPolicies, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nCatId": %d}`, nCatId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetRefPolicies",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nCatId": nCatId}
 
 	refPolicies := new(RefPolicies)
-	raw, err := fc.client.Request(ctx, request, &refPolicies)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetRefPolicies", postData, &refPolicies)
 	return refPolicies, raw, err
 }
 
@@ -417,39 +293,24 @@ func (fc *FileCategorizer2) GetRefPolicies(ctx context.Context, nCatId int64) (*
 //
 // Deprecated: Use FileCategorizer2.GetSerializedCategoryBody2 instead.
 func (fc *FileCategorizer2) GetSerializedCategoryBody(ctx context.Context, nCategoryId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nCategoryId": %d}`, nCategoryId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetSerializedCategoryBody",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"nCategoryId": nCategoryId}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetSerializedCategoryBody", postData, nil)
 	return raw, err
 }
 
 // GetSerializedCategoryBody2 Returns serialized category body for plugin.
 func (fc *FileCategorizer2) GetSerializedCategoryBody2(ctx context.Context, nCategoryId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nCategoryId": %d}`, nCategoryId))
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetSerializedCategoryBody2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := fc.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"nCategoryId": nCategoryId}
+
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetSerializedCategoryBody2", postData, nil)
 	return raw, err
 }
 
 // GetSyncId Returns categories synchronization id.
 func (fc *FileCategorizer2) GetSyncId(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.GetSyncId", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := fc.client.Request(ctx, request, &pxgValInt)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.GetSyncId", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -461,46 +322,21 @@ type UploadParams struct {
 //
 // Remark: Only one upload url is allowed for connection.
 func (fc *FileCategorizer2) InitFileUpload(ctx context.Context) (*UploadParams, []byte, error) {
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.InitFileUpload", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	uploadParams := new(UploadParams)
-	raw, err := fc.client.Request(ctx, request, &uploadParams)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.InitFileUpload", nil, &uploadParams)
 	return uploadParams, raw, err
 }
 
 // UpdateCategory Update category.
 func (fc *FileCategorizer2) UpdateCategory(ctx context.Context, params interface{}) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.UpdateCategory", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := fc.client.Request(ctx, request, &pxgValStr)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.UpdateCategory", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // UpdateExpressions Update some expressions in category.
 func (fc *FileCategorizer2) UpdateExpressions(ctx context.Context, params interface{}) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", fc.client.Server+"/api/v1.0/FileCategorizer2.UpdateExpressions", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := fc.client.Request(ctx, request, &pxgValStr)
+	raw, err := fc.client.Call(ctx, "FileCategorizer2.UpdateExpressions", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // FilesAcceptor service to upload files to server.
@@ -39,13 +36,9 @@ type FilesAcceptor service
 // After cancellation provided URL will not be valid anymore and any uploaded by the moment file chunks will be dropped.
 // You should not call this method unless you want to break upload operation.
 func (di *FilesAcceptor) CancelFileUpload(ctx context.Context, wstrFileId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrFileId": "%s"}`, wstrFileId))
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/FilesAcceptor.CancelFileUpload", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"wstrFileId": wstrFileId}
 
-	_, err = di.client.Request(ctx, request, nil)
+	_, err := di.client.Call(ctx, "FilesAcceptor.CancelFileUpload", postData, nil)
 	return err
 }
 
@@ -77,13 +70,9 @@ type FileUploadData struct {
 //
 // All path names inside archive must be in UTF-8 encoding.
 func (di *FilesAcceptor) InitiateFileUpload(ctx context.Context, bIsArchive bool, qwFileSize int64) (*FileUploadData, error) {
-	postData := []byte(fmt.Sprintf(`{"bIsArchive": %v, "qwFileSize": %d}`, bIsArchive, qwFileSize))
-	request, err := http.NewRequest("POST", di.client.Server+"/api/v1.0/FilesAcceptor.InitiateFileUpload", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bIsArchive": bIsArchive, "qwFileSize": qwFileSize}
 
 	fileUploadData := new(FileUploadData)
-	_, err = di.client.Request(ctx, request, &fileUploadData)
+	_, err := di.client.Call(ctx, "FilesAcceptor.InitiateFileUpload", postData, &fileUploadData)
 	return fileUploadData, err
 }
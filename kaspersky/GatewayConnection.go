@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 //	GatewayConnection service for creating gateway connections.
@@ -54,15 +51,8 @@ type AuthKey struct {
 // Remark:
 // StartSession method call in KSCGW authentication scheme. Valid only for 60 seconds.
 func (gc *GatewayConnection) PrepareGatewayConnection(ctx context.Context, params GCParams) (*AuthKey, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", gc.client.Server+"/api/v1.0/GatewayConnection.PrepareGatewayConnection",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	authKey := new(AuthKey)
-	raw, err := gc.client.Request(ctx, request, &authKey)
+	raw, err := gc.client.Call(ctx, "GatewayConnection.PrepareGatewayConnection", params, &authKey)
 	return authKey, raw, err
 }
 
@@ -71,14 +61,7 @@ func (gc *GatewayConnection) PrepareGatewayConnection(ctx context.Context, param
 // Remark:
 // Should be used in 'login' method call in KSCGW authentication scheme. Valid only for 60 seconds.
 func (gc *GatewayConnection) PrepareTunnelConnection(ctx context.Context, params GCParams) (*AuthKey, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", gc.client.Server+"/api/v1.0/GatewayConnection.PrepareTunnelConnection",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	authKey := new(AuthKey)
-	raw, err := gc.client.Request(ctx, request, &authKey)
+	raw, err := gc.client.Call(ctx, "GatewayConnection.PrepareTunnelConnection", params, &authKey)
 	return authKey, raw, err
 }
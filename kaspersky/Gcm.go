@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"log"
-	"net/http"
 )
 
 // Gcm Service to manage settings of using the GCM service.
@@ -38,52 +34,25 @@ type Gcm service
 // CheckIfGcmServerSettingsPresent It checks if GCM server settings are present.
 // true if settings are present; False otherwise.
 func (gm *Gcm) CheckIfGcmServerSettingsPresent(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", gm.client.Server+"/api/v1.0/Gcm.CheckIfGcmServerSettingsPresent", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
 
-	raw, err := gm.client.Request(ctx, request, &result)
-	if gm.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
-
+	_, err := gm.client.Call(ctx, "Gcm.CheckIfGcmServerSettingsPresent", nil, &result)
 	return result, err
 }
 
 // CheckIfGcmServerSettingsShouldBeSet It checks if GCM server settings should be set.
 // true if server settings should be set; False otherwise.
 func (gm *Gcm) CheckIfGcmServerSettingsShouldBeSet(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", gm.client.Server+"/api/v1.0/Gcm.CheckIfGcmServerSettingsShouldBeSet", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
-	raw, err := gm.client.Request(ctx, request, &result)
-
-	if gm.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := gm.client.Call(ctx, "Gcm.CheckIfGcmServerSettingsShouldBeSet", nil, &result)
 
 	return result, err
 }
 
 // DeleteGcmServerSettings Deletes GCM (Google Cloud Messaging) server settings.
 func (gm *Gcm) DeleteGcmServerSettings(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", gm.client.Server+"/api/v1.0/Gcm.DeleteGcmServerSettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
-	raw, err := gm.client.Request(ctx, request, &result)
-
-	if gm.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := gm.client.Call(ctx, "Gcm.DeleteGcmServerSettings", nil, &result)
 
 	return result, err
 }
@@ -95,17 +64,8 @@ type PropagationState struct {
 // GetGcmPropagation2VS Retrieves GCM settings propagation option.
 // GCM settings can be propagated to virtual server in one case only - if it is absent on virtual server.
 func (gm *Gcm) GetGcmPropagation2VS(ctx context.Context) (*PropagationState, error) {
-	request, err := http.NewRequest("POST", gm.client.Server+"/api/v1.0/Gcm.GetGcmPropagation2VS", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	propagationState := new(PropagationState)
-	raw, err := gm.client.Request(ctx, request, &propagationState)
-
-	if gm.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := gm.client.Call(ctx, "Gcm.GetGcmPropagation2VS", nil, &propagationState)
 
 	return propagationState, err
 }
@@ -113,22 +73,7 @@ func (gm *Gcm) GetGcmPropagation2VS(ctx context.Context) (*PropagationState, err
 // SetGcmPropagation2VS Sets possibility for GCM settings propagation from main server to virtual servers.
 // GCM settings can be propagated to virtual server in one case only - if it is absent on virtual server.
 func (gm *Gcm) SetGcmPropagation2VS(ctx context.Context, params PropagationState) (*PropagationState, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", gm.client.Server+"/api/v1.0/Gcm.SetGcmPropagation2VS",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := gm.client.Request(ctx, request, nil)
-
-	if gm.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := gm.client.Call(ctx, "Gcm.SetGcmPropagation2VS", params, nil)
 
 	return nil, err
 }
@@ -144,40 +89,16 @@ type GCM struct {
 
 // UpdateGcmServerSettings Update GCM (Google Cloud Messaging) server settings.
 func (gm *Gcm) UpdateGcmServerSettings(ctx context.Context, params GCM) (*PxgValBool, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", gm.client.Server+"/api/v1.0/Gcm.UpdateGcmServerSettings",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
-	raw, err := gm.client.Request(ctx, request, &result)
-
-	if gm.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := gm.client.Call(ctx, "Gcm.UpdateGcmServerSettings", params, &result)
 
 	return result, err
 }
 
 // GetGcmServerSettings Retrieves GCM (Google Cloud Messaging) server settings.
 func (gm *Gcm) GetGcmServerSettings(ctx context.Context) (*GCM, error) {
-	request, err := http.NewRequest("POST", gm.client.Server+"/api/v1.0/Gcm.GetGcmServerSettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	gcm := new(GCM)
-	raw, err := gm.client.Request(ctx, request, &gcm)
-
-	if gm.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := gm.client.Call(ctx, "Gcm.GetGcmServerSettings", nil, &gcm)
 
 	return gcm, err
 }
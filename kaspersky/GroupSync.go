@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // GroupSync service for access to group synchronization objects.
@@ -56,19 +52,8 @@ type NSyncInfoParams struct {
 // GetSyncHostsInfo Acquire group synchronization state at target hosts.
 // Returns forward iterator to access requested properties of the specified group synchronization at target hosts.
 func (gs *GroupSync) GetSyncHostsInfo(ctx context.Context, params NSyncInfoParams) (*PxgValStr, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", gs.client.Server+"/api/v1.0/GroupSync.GetSyncHostsInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	_, err = gs.client.Request(ctx, request, &pxgValStr)
+	_, err := gs.client.Call(ctx, "GroupSync.GetSyncHostsInfo", params, &pxgValStr)
 	return pxgValStr, err
 }
 
@@ -95,19 +80,8 @@ type SyncInfo struct {
 //
 // Returns requested properties of the specified group synchronization
 func (gs *GroupSync) GetSyncInfo(ctx context.Context, params GroupSyncInfoParams) (*GroupSyncInfo, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", gs.client.Server+"/api/v1.0/GroupSync.GetSyncInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	groupSyncInfo := new(GroupSyncInfo)
-	_, err = gs.client.Request(ctx, request, &groupSyncInfo)
+	_, err := gs.client.Call(ctx, "GroupSync.GetSyncInfo", params, &groupSyncInfo)
 	return groupSyncInfo, err
 }
 
@@ -116,13 +90,9 @@ func (gs *GroupSync) GetSyncInfo(ctx context.Context, params GroupSyncInfoParams
 // Returns UTC time when the specified synchronization has been delivered to the specified host
 func (gs *GroupSync) GetSyncDeliveryTime(ctx context.Context, nSync int64, szwHostId string) (*PxgValInt,
 	[]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nSync": %d, "szwHostId": "%s"}`, nSync, szwHostId))
-	request, err := http.NewRequest("POST", gs.client.Server+"/api/v1.0/GroupSync.GetSyncDeliveryTime", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nSync": nSync, "szwHostId": szwHostId}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := gs.client.Request(ctx, request, &pxgValInt)
+	raw, err := gs.client.Call(ctx, "GroupSync.GetSyncDeliveryTime", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // GroupSyncIterator service for access to the group synchronization forward iterator for the result-set.
@@ -36,16 +33,9 @@ type GroupSyncIterator service
 
 // ReleaseIterator Releases the result-set. Releases the specified result-set and frees associated memory
 func (ca *GroupSyncIterator) ReleaseIterator(ctx context.Context, szwIterator string) error {
-	postData := []byte(fmt.Sprintf(`
-	{
-	"szwIterator": "%s"
-	}`, szwIterator))
-	request, err := http.NewRequest("POST", ca.client.Server+"/api/v1.0/GroupSyncIterator.ReleaseIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"szwIterator": szwIterator}
 
-	_, err = ca.client.Request(ctx, request, nil)
+	_, err := ca.client.Call(ctx, "GroupSyncIterator.ReleaseIterator", postData, nil)
 	if err != nil {
 		return err
 	}
@@ -57,12 +47,8 @@ func (ca *GroupSyncIterator) ReleaseIterator(ctx context.Context, szwIterator st
 // Returns nCount elements contained in the specified result-set beginning from the current position and moves internal pointer to the new position.
 func (ca *GroupSyncIterator) GetNextItems(ctx context.Context, szwIterator string, nCount int64, out interface{}) (
 	[]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwIterator": "%s","nCount": %d}`, szwIterator, nCount))
-	request, err := http.NewRequest("POST", ca.client.Server+"/api/v1.0/GroupSyncIterator.GetNextItems", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwIterator": szwIterator, "nCount": nCount}
 
-	raw, err := ca.client.Request(ctx, request, &out)
+	raw, err := ca.client.Call(ctx, "GroupSyncIterator.GetNextItems", postData, &out)
 	return raw, err
 }
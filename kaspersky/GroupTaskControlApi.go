@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // GroupTaskControlApi service to perform some management actions over group tasks.
@@ -47,15 +43,10 @@ type GroupTaskControlApi service
 func (gtca *GroupTaskControlApi) CommitImportedTask(ctx context.Context, wstrId string, bCommit bool) (*TaskDescribe,
 	[]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"wstrId": "%s", "bCommit": %v}`, wstrId, bCommit))
-	request, err := http.NewRequest("POST", gtca.client.Server+"/api/v1.0/GroupTaskControlApi.CommitImportedTask",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrId": wstrId, "bCommit": bCommit}
 
 	taskDescribe := new(TaskDescribe)
-	raw, err := gtca.client.Request(ctx, request, &taskDescribe)
+	raw, err := gtca.client.Call(ctx, "GroupTaskControlApi.CommitImportedTask", postData, &taskDescribe)
 	return taskDescribe, raw, err
 }
 
@@ -67,32 +58,17 @@ type TasksIDSParams struct {
 // RequestStatistics of the given tasks.
 // Actual statistics for the tasks will be reported by appropriate "KLEVP_EventGroupTaskStats" events publications.
 func (gtca *GroupTaskControlApi) RequestStatistics(ctx context.Context, params TasksIDSParams) ([]byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", gtca.client.Server+"/api/v1.0/GroupTaskControlApi.RequestStatistics", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := gtca.client.Request(ctx, request, nil)
+	raw, err := gtca.client.Call(ctx, "GroupTaskControlApi.RequestStatistics", params, nil)
 	return raw, err
 }
 
 // ExportTask Gets specific task by its identifier and save data to memory chunk.
 // Chunk can be later saved to file or sent over network
 func (gtca *GroupTaskControlApi) ExportTask(ctx context.Context, wstrTaskId string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrTaskId": "%s"}`, wstrTaskId))
-	request, err := http.NewRequest("POST", gtca.client.Server+"/api/v1.0/GroupTaskControlApi.ExportTask",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrTaskId": wstrTaskId}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := gtca.client.Request(ctx, request, &pxgValStr)
+	raw, err := gtca.client.Call(ctx, "GroupTaskControlApi.ExportTask", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -103,37 +79,37 @@ type TaskDescribe struct {
 
 // TaskValue struct
 type TaskValue struct {
-	EventType                    string                     `json:"EVENT_TYPE,omitempty"`
-	FilterEventsComponentName    string                     `json:"FILTER_EVENTS_COMPONENT_NAME,omitempty"`
-	FilterEventsInstanceID       string                     `json:"FILTER_EVENTS_INSTANCE_ID,omitempty"`
-	FilterEventsProductName      string                     `json:"FILTER_EVENTS_PRODUCT_NAME,omitempty"`
-	FilterEventsVersion          string                     `json:"FILTER_EVENTS_VERSION,omitempty"`
-	TaskidComponentName          string                     `json:"TASKID_COMPONENT_NAME,omitempty"`
-	TaskidInstanceID             string                     `json:"TASKID_INSTANCE_ID,omitempty"`
-	TaskidProductName            string                     `json:"TASKID_PRODUCT_NAME,omitempty"`
-	TaskidVersion                string                     `json:"TASKID_VERSION,omitempty"`
-	TaskschEwDay                 int64                      `json:"TASKSCH_EW_DAY,omitempty"`
-	TaskschEwHours               int64                      `json:"TASKSCH_EW_HOURS,omitempty"`
-	TaskschEwMins                int64                      `json:"TASKSCH_EW_MINS,omitempty"`
-	TaskschEwSecs                int64                      `json:"TASKSCH_EW_SECS,omitempty"`
-	TaskschFirstExecutionTime    *TaskschFirstExecutionTime `json:"TASKSCH_FIRST_EXECUTION_TIME,omitempty"`
-	TaskschFirstExecutionTimeSEC int64                      `json:"TASKSCH_FIRST_EXECUTION_TIME_SEC,omitempty"`
-	TaskschLifetime              *TaskschFirstExecutionTime `json:"TASKSCH_LIFETIME,omitempty"`
-	TaskschMSPeriod              int64                      `json:"TASKSCH_MS_PERIOD,omitempty"`
-	TaskschRunMissedFlag         bool                       `json:"TASKSCH_RUN_MISSED_FLAG,omitempty"`
-	TaskschType                  int64                      `json:"TASKSCH_TYPE,omitempty"`
-	TaskAdditionalParams         *TaskAdditionalParams      `json:"TASK_ADDITIONAL_PARAMS,omitempty"`
-	TaskClassID                  int64                      `json:"TASK_CLASS_ID,omitempty"`
-	TaskDelAfterRunFlag          bool                       `json:"TASK_DEL_AFTER_RUN_FLAG,omitempty"`
-	TaskInfoParams               *TaskInfoParams            `json:"TASK_INFO_PARAMS,omitempty"`
-	TaskLastExecTime             *TaskschFirstExecutionTime `json:"TASK_LAST_EXEC_TIME,omitempty"`
-	TaskLastExecTimeSEC          int64                      `json:"TASK_LAST_EXEC_TIME_SEC,omitempty"`
-	TaskMaxExecTime              int64                      `json:"TASK_MAX_EXEC_TIME,omitempty"`
-	TaskName                     string                     `json:"TASK_NAME,omitempty"`
-	TaskPrepStart                int64                      `json:"TASK_PREP_START,omitempty"`
-	TaskPriority                 int64                      `json:"TASK_PRIORITY,omitempty"`
-	TaskStartDelta               int64                      `json:"TASK_START_DELTA,omitempty"`
-	TaskUniqueID                 string                     `json:"TASK_UNIQUE_ID,omitempty"`
+	EventType                    string                `json:"EVENT_TYPE,omitempty"`
+	FilterEventsComponentName    string                `json:"FILTER_EVENTS_COMPONENT_NAME,omitempty"`
+	FilterEventsInstanceID       string                `json:"FILTER_EVENTS_INSTANCE_ID,omitempty"`
+	FilterEventsProductName      string                `json:"FILTER_EVENTS_PRODUCT_NAME,omitempty"`
+	FilterEventsVersion          string                `json:"FILTER_EVENTS_VERSION,omitempty"`
+	TaskidComponentName          string                `json:"TASKID_COMPONENT_NAME,omitempty"`
+	TaskidInstanceID             string                `json:"TASKID_INSTANCE_ID,omitempty"`
+	TaskidProductName            string                `json:"TASKID_PRODUCT_NAME,omitempty"`
+	TaskidVersion                string                `json:"TASKID_VERSION,omitempty"`
+	TaskschEwDay                 int64                 `json:"TASKSCH_EW_DAY,omitempty"`
+	TaskschEwHours               int64                 `json:"TASKSCH_EW_HOURS,omitempty"`
+	TaskschEwMins                int64                 `json:"TASKSCH_EW_MINS,omitempty"`
+	TaskschEwSecs                int64                 `json:"TASKSCH_EW_SECS,omitempty"`
+	TaskschFirstExecutionTime    *DateTime             `json:"TASKSCH_FIRST_EXECUTION_TIME,omitempty"`
+	TaskschFirstExecutionTimeSEC int64                 `json:"TASKSCH_FIRST_EXECUTION_TIME_SEC,omitempty"`
+	TaskschLifetime              *DateTime             `json:"TASKSCH_LIFETIME,omitempty"`
+	TaskschMSPeriod              int64                 `json:"TASKSCH_MS_PERIOD,omitempty"`
+	TaskschRunMissedFlag         bool                  `json:"TASKSCH_RUN_MISSED_FLAG,omitempty"`
+	TaskschType                  int64                 `json:"TASKSCH_TYPE,omitempty"`
+	TaskAdditionalParams         *TaskAdditionalParams `json:"TASK_ADDITIONAL_PARAMS,omitempty"`
+	TaskClassID                  int64                 `json:"TASK_CLASS_ID,omitempty"`
+	TaskDelAfterRunFlag          bool                  `json:"TASK_DEL_AFTER_RUN_FLAG,omitempty"`
+	TaskInfoParams               *TaskInfoParams       `json:"TASK_INFO_PARAMS,omitempty"`
+	TaskLastExecTime             *DateTime             `json:"TASK_LAST_EXEC_TIME,omitempty"`
+	TaskLastExecTimeSEC          int64                 `json:"TASK_LAST_EXEC_TIME_SEC,omitempty"`
+	TaskMaxExecTime              int64                 `json:"TASK_MAX_EXEC_TIME,omitempty"`
+	TaskName                     string                `json:"TASK_NAME,omitempty"`
+	TaskPrepStart                int64                 `json:"TASK_PREP_START,omitempty"`
+	TaskPriority                 int64                 `json:"TASK_PRIORITY,omitempty"`
+	TaskStartDelta               int64                 `json:"TASK_START_DELTA,omitempty"`
+	TaskUniqueID                 string                `json:"TASK_UNIQUE_ID,omitempty"`
 }
 
 type TaskAdditionalParams struct {
@@ -153,20 +129,20 @@ type TaskInfoParams struct {
 }
 
 type TASKINFOPARAMSValue struct {
-	DisplayName                   string                     `json:"DisplayName,omitempty"`
-	KlevpNotificationDescrID      string                     `json:"KLEVP_NOTIFICATION_DESCR_ID,omitempty"`
-	KlhstWksCtype                 int64                      `json:"KLHST_WKS_CTYPE,omitempty"`
-	KLPRSSEVPNotifications        *KLPRSSEVPNotifications    `json:"KLPRSS_EVPNotifications,omitempty"`
-	KlsrvPrtsTaskEnabledFlag      bool                       `json:"KLSRV_PRTS_TASK_ENABLED_FLAG,omitempty"`
-	KltskAllowAutoRandomization   bool                       `json:"KLTSK_ALLOW_AUTO_RANDOMIZATION,omitempty"`
-	NhTaskCreatedByQsw            bool                       `json:"NH_TASK_CREATED_BY_QSW,omitempty"`
-	PrtsExceptGroupids            []interface{}              `json:"PRTS_EXCEPT_GROUPIDS"`
-	PrtsTaskCreationDate          *TaskschFirstExecutionTime `json:"PRTS_TASK_CREATION_DATE,omitempty"`
-	PrtsTaskEnabled               bool                       `json:"PRTS_TASK_ENABLED,omitempty"`
-	PrtsTaskGroupid               int64                      `json:"PRTS_TASK_GROUPID,omitempty"`
-	PrtsTaskGroupname             string                     `json:"PRTS_TASK_GROUPNAME,omitempty"`
-	KlprtsDontApplyToSlaveServers bool                       `json:"klprts-DontApplyToSlaveServers,omitempty"`
-	KlprtsTaskScheduleSubtype     int64                      `json:"klprts-TaskScheduleSubtype,omitempty"`
+	DisplayName                   string                  `json:"DisplayName,omitempty"`
+	KlevpNotificationDescrID      string                  `json:"KLEVP_NOTIFICATION_DESCR_ID,omitempty"`
+	KlhstWksCtype                 int64                   `json:"KLHST_WKS_CTYPE,omitempty"`
+	KLPRSSEVPNotifications        *KLPRSSEVPNotifications `json:"KLPRSS_EVPNotifications,omitempty"`
+	KlsrvPrtsTaskEnabledFlag      bool                    `json:"KLSRV_PRTS_TASK_ENABLED_FLAG,omitempty"`
+	KltskAllowAutoRandomization   bool                    `json:"KLTSK_ALLOW_AUTO_RANDOMIZATION,omitempty"`
+	NhTaskCreatedByQsw            bool                    `json:"NH_TASK_CREATED_BY_QSW,omitempty"`
+	PrtsExceptGroupids            []interface{}           `json:"PRTS_EXCEPT_GROUPIDS"`
+	PrtsTaskCreationDate          *DateTime               `json:"PRTS_TASK_CREATION_DATE,omitempty"`
+	PrtsTaskEnabled               bool                    `json:"PRTS_TASK_ENABLED,omitempty"`
+	PrtsTaskGroupid               int64                   `json:"PRTS_TASK_GROUPID,omitempty"`
+	PrtsTaskGroupname             string                  `json:"PRTS_TASK_GROUPNAME,omitempty"`
+	KlprtsDontApplyToSlaveServers bool                    `json:"klprts-DontApplyToSlaveServers,omitempty"`
+	KlprtsTaskScheduleSubtype     int64                   `json:"klprts-TaskScheduleSubtype,omitempty"`
 }
 
 type KLPRSSEVPNotifications struct {
@@ -203,10 +179,10 @@ type KLEVPNDBODYFILTERValue struct {
 	KLPRCINewState int64 `json:"KLPRCI_newState,omitempty"`
 }
 
-type TaskschFirstExecutionTime struct {
-	Type  string `json:"type,omitempty"`
-	Value string `json:"value,omitempty"`
-}
+// TaskschFirstExecutionTime KSC datetime value of task fields.
+//
+// Deprecated: use DateTime, the fields holding datetime values are of *DateTime type.
+type TaskschFirstExecutionTime = DateTime
 
 // GetTaskByRevision get the task data by revision.
 // Returns all task data for a group/set task with a given object identity and revision.
@@ -214,30 +190,20 @@ type TaskschFirstExecutionTime struct {
 // If Administration Server version is less than "SC 10 SP2 MR1" then nRevision must be zero.
 func (gtca *GroupTaskControlApi) GetTaskByRevision(ctx context.Context, nObjId, nRevision int64) (*TaskDescribe, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nObjId": %d, "nRevision": %d}`, nObjId, nRevision))
-	request, err := http.NewRequest("POST", gtca.client.Server+"/api/v1.0/GroupTaskControlApi.GetTaskByRevision",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nObjId": nObjId, "nRevision": nRevision}
 
 	taskDescribe := new(TaskDescribe)
-	raw, err := gtca.client.Request(ctx, request, &taskDescribe)
+	raw, err := gtca.client.Call(ctx, "GroupTaskControlApi.GetTaskByRevision", postData, &taskDescribe)
 	return taskDescribe, raw, err
 }
 
 // RestoreTaskFromRevision Restore task from revision. Rolls back the group/set task specified by nObjId to the revision nRevision.
 func (gtca *GroupTaskControlApi) RestoreTaskFromRevision(ctx context.Context, nObjId, nRevision int64) (*TaskDescribe, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nObjId": %d, "nRevision": %d}`, nObjId, nRevision))
-	request, err := http.NewRequest("POST", gtca.client.Server+"/api/v1.0/GroupTaskControlApi.RestoreTaskFromRevision",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nObjId": nObjId, "nRevision": nRevision}
 
 	taskDescribe := new(TaskDescribe)
-	raw, err := gtca.client.Request(ctx, request, &taskDescribe)
+	raw, err := gtca.client.Call(ctx, "GroupTaskControlApi.RestoreTaskFromRevision", postData, &taskDescribe)
 	return taskDescribe, raw, err
 }
 
@@ -251,17 +217,7 @@ func (gtca *GroupTaskControlApi) RestoreTaskFromRevision(ctx context.Context, nO
 // To determine these restrictions, one should analyze info, returned via output parameter pCommitInfo
 // (see detailed parameter descriptions in parameter section), and pass analyze result in bCommit parameter of GroupTaskControlApi.CommitImportedTask.
 func (gtca *GroupTaskControlApi) ImportTask(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", gtca.client.Server+"/api/v1.0/GroupTaskControlApi.ImportTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := gtca.client.Request(ctx, request, nil)
+	raw, err := gtca.client.Call(ctx, "GroupTaskControlApi.ImportTask", params, nil)
 	return raw, err
 }
 
@@ -277,16 +233,6 @@ type ResetIterForClusterParams struct {
 
 // ResetTasksIteratorForCluster Reset task iterator for a cluster.
 func (gtca *GroupTaskControlApi) ResetTasksIteratorForCluster(ctx context.Context, params ResetIterForClusterParams) ([]byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", gtca.client.Server+"/api/v1.0/GroupTaskControlApi.ResetTasksIteratorForCluster", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := gtca.client.Request(ctx, request, nil)
+	raw, err := gtca.client.Call(ctx, "GroupTaskControlApi.ResetTasksIteratorForCluster", params, nil)
 	return raw, err
 }
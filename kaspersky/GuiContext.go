@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // GuiContext Gui context storage interface.
@@ -37,12 +34,8 @@ type GuiContext service
 // SetLanguage Sets up language for the current session.
 // pwchIetfLanguageTag IETF language tag (e.g. ru-RU)
 func (gc *GuiContext) SetLanguage(ctx context.Context, pwchIetfLanguageTag string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"pwchIetfLanguageTag": "%s"}`, pwchIetfLanguageTag))
-	request, err := http.NewRequest("POST", gc.client.Server+"/api/v1.0/GuiContext.SetLanguage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"pwchIetfLanguageTag": pwchIetfLanguageTag}
 
-	raw, err := gc.client.Request(ctx, request, nil)
+	raw, err := gc.client.Call(ctx, "GuiContext.SetLanguage", postData, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // HWInvStorage service for working with Hardware storage subsystem.
@@ -37,14 +33,10 @@ type HWInvStorage service
 
 // AddDynColumn Add dynamic column.
 func (hw *HWInvStorage) AddDynColumn(ctx context.Context, wstrColName string) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrColName": "%s"}`, wstrColName))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.AddDynColumn", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrColName": wstrColName}
 
 	pxgValStr := new(PxgValStr)
-	_, err = hw.client.Request(ctx, request, &pxgValStr)
+	_, err := hw.client.Call(ctx, "HWInvStorage.AddDynColumn", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
@@ -99,105 +91,65 @@ type DynColumnValue struct {
 }
 
 func (hw *HWInvStorage) AddHWInvObject(ctx context.Context, params PpObj) (*PxgValInt, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.AddHWInvObject", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	_, err = hw.client.Request(ctx, request, &pxgValInt)
+	_, err := hw.client.Call(ctx, "HWInvStorage.AddHWInvObject", params, &pxgValInt)
 	return pxgValInt, err
 }
 
 // DelDynColumn Delete dynamic column.
 func (hw *HWInvStorage) DelDynColumn(ctx context.Context, wstrColId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrColId": "%s"}`, wstrColId))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.DelDynColumn", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"wstrColId": wstrColId}
 
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.DelDynColumn", postData, nil)
 	return err
 }
 
 // DelHWInvObject Delete hardware inventory object.
 func (hw *HWInvStorage) DelHWInvObject(ctx context.Context, nObjId int64) error {
-	postData := []byte(fmt.Sprintf(`{"nObjId": %d}`, nObjId))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.DelHWInvObject", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"nObjId": nObjId}
 
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.DelHWInvObject", postData, nil)
 	return err
 }
 
 // DelHWInvObject2 Delete array of objects.
 func (hw *HWInvStorage) DelHWInvObject2(ctx context.Context, arrObjId []int64) error {
-	postData := []byte(fmt.Sprintf(`{"arrObjId": %s}`, ToJson(arrObjId)))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.DelHWInvObject2", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"arrObjId": arrObjId}
 
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.DelHWInvObject2", postData, nil)
 	return err
 }
 
 // ExportHWInvStorage2 Start export of hardware inventory.
 func (hw *HWInvStorage) ExportHWInvStorage2(ctx context.Context, eExportType int) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"eExportType": %d}`, eExportType))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.ExportHWInvStorage2", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"eExportType": eExportType}
 
 	pxgValStr := new(PxgValStr)
-	_, err = hw.client.Request(ctx, request, &pxgValStr)
+	_, err := hw.client.Call(ctx, "HWInvStorage.ExportHWInvStorage2", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
 // ExportHWInvStorageCancel Cancel export of hardware inventory.
 func (hw *HWInvStorage) ExportHWInvStorageCancel(ctx context.Context, wstrAsyncId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrAsyncId": "%s"}`, wstrAsyncId))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.ExportHWInvStorageCancel", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"wstrAsyncId": wstrAsyncId}
 
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.ExportHWInvStorageCancel", postData, nil)
 	return err
 }
 
 // EnumDynColumns Start import of hardware inventory.
 func (hw *HWInvStorage) ImportHWInvStorage2(ctx context.Context, eImportType int64) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"eImportType": %d}`, eImportType))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.ImportHWInvStorage2", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"eImportType": eImportType}
 
 	pxgValStr := new(PxgValStr)
-	_, err = hw.client.Request(ctx, request, &pxgValStr)
+	_, err := hw.client.Call(ctx, "HWInvStorage.ImportHWInvStorage2", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
 // ImportHWInvStorageCancel Cancel import of hardware inventory.
 func (hw *HWInvStorage) ImportHWInvStorageCancel(ctx context.Context, params AsyncID) (*PxgValStr, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.ImportHWInvStorageCancel", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	_, err = hw.client.Request(ctx, request, &pxgValStr)
+	_, err := hw.client.Call(ctx, "HWInvStorage.ImportHWInvStorageCancel", params, &pxgValStr)
 	return pxgValStr, err
 }
 
@@ -211,17 +163,7 @@ type StorageSetData struct {
 //	If pChunk is NULL then send data is finished and started data processing and importing to DB.
 //	To get status use AsyncActionStateChecker.CheckActionState, lStateCode "0" means OK.
 func (hw *HWInvStorage) ImportHWInvStorageSetData(ctx context.Context, params StorageSetData) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.ImportHWInvStorageSetData", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.ImportHWInvStorageSetData", params, nil)
 	return err
 }
 
@@ -248,13 +190,8 @@ type DynColumnInfoValue struct {
 
 // EnumDynColumns Return list of dynamic columns.
 func (hw *HWInvStorage) EnumDynColumns(ctx context.Context) (*DynamicColumns, error) {
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.EnumDynColumns", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	dynamicColumns := new(DynamicColumns)
-	_, err = hw.client.Request(ctx, request, &dynamicColumns)
+	_, err := hw.client.Call(ctx, "HWInvStorage.EnumDynColumns", nil, &dynamicColumns)
 	return dynamicColumns, err
 }
 
@@ -271,41 +208,22 @@ type PRules struct {
 
 // GetProcessingRules Get processing rules.
 func (hw *HWInvStorage) GetProcessingRules(ctx context.Context) (*ProcessingRules, error) {
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.GetProcessingRules", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	processingRules := new(ProcessingRules)
-	_, err = hw.client.Request(ctx, request, &processingRules)
+	_, err := hw.client.Call(ctx, "HWInvStorage.GetProcessingRules", nil, &processingRules)
 	return processingRules, err
 }
 
 // SetProcessingRules Set processing rules.
 func (hw *HWInvStorage) SetProcessingRules(ctx context.Context, params ProcessingRules) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.SetProcessingRules", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.SetProcessingRules", params, nil)
 	return err
 }
 
 // GetHWInvObject Get hardware inventory object.
 func (hw *HWInvStorage) GetHWInvObject(ctx context.Context, nObjId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nObjId": %d}`, nObjId))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.GetHWInvObject", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nObjId": nObjId}
 
-	raw, err := hw.client.Request(ctx, request, nil)
+	raw, err := hw.client.Call(ctx, "HWInvStorage.GetHWInvObject", postData, nil)
 	return raw, err
 }
 
@@ -324,15 +242,10 @@ type HWInvStorageResponse struct {
 // ExportHWInvStorageGetData Get exported data. Call this method until nDataSizeRest is not zero.
 func (hw *HWInvStorage) ExportHWInvStorageGetData(ctx context.Context, wstrAsyncId string,
 	nGetDataSize int64) (*HWInvStorageResponse, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrAsyncId": "%s","nGetDataSize": %d}`, wstrAsyncId, nGetDataSize))
-
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.ExportHWInvStorageGetData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrAsyncId": wstrAsyncId, "nGetDataSize": nGetDataSize}
 
 	hwInvStorageResponse := new(HWInvStorageResponse)
-	raw, err := hw.client.Request(ctx, request, &hwInvStorageResponse)
+	raw, err := hw.client.Call(ctx, "HWInvStorage.ExportHWInvStorageGetData", postData, &hwInvStorageResponse)
 	return hwInvStorageResponse, raw, err
 }
 
@@ -347,17 +260,7 @@ type CorpFlagParams struct {
 
 // SetCorpFlag2 Set corporative flag for array of devices.
 func (hw *HWInvStorage) SetCorpFlag2(ctx context.Context, params CorpFlagParams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.SetCorpFlag2", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.SetCorpFlag2", params, nil)
 	return err
 }
 
@@ -369,29 +272,15 @@ type HWInvObjectParams struct {
 
 // SetHWInvObject Set hardware inventory object.
 func (hw *HWInvStorage) SetHWInvObject(ctx context.Context, params HWInvObjectParams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.SetHWInvObject", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.SetHWInvObject", params, nil)
 	return err
 }
 
 // SetWriteOffFlag Set decommissioned flag.
 func (hw *HWInvStorage) SetWriteOffFlag(ctx context.Context, nObjId int64, bFlag bool) error {
-	postData := []byte(fmt.Sprintf(`{"nObjId": %d,"bFlag": %v}`, nObjId, bFlag))
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.SetWriteOffFlag", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"nObjId": nObjId, "bFlag": bFlag}
 
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.SetWriteOffFlag", postData, nil)
 	return err
 }
 
@@ -403,12 +292,6 @@ type WriteOffFlag struct {
 
 // WriteOffFlag Set decommissioned flag for array of devices.
 func (hw *HWInvStorage) SetWriteOffFlag2(ctx context.Context, params WriteOffFlag) error {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", hw.client.Server+"/api/v1.0/HWInvStorage.SetWriteOffFlag2", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = hw.client.Request(ctx, request, nil)
+	_, err := hw.client.Call(ctx, "HWInvStorage.SetWriteOffFlag2", params, nil)
 	return err
 }
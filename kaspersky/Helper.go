@@ -27,7 +27,6 @@ package kaspersky
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"time"
 )
@@ -114,7 +113,7 @@ type Value1 struct {
 }
 
 func (e Error) Error() string {
-	return fmt.Sprintf(`Code: %d, File: %s, Line: %d, Module: %s, Message: %s`, *e.Code, *e.File, *e.Line, *e.Module, *e.Message)
+	return newKscError(&e).Error()
 }
 
 //	AsyncAccessor struct
@@ -139,10 +138,15 @@ type WActionGUID struct {
 }
 
 const (
+	// Deprecated: use time.RFC3339.
 	RFC3339 = "2006-01-02T15:04:05Z07:00"
-	RUS     = "2 Jan 2006 15:04"
+	// Deprecated: use FormatTime with the layout you need.
+	RUS = "2 Jan 2006 15:04"
 )
 
+// ParseTime formats RFC 3339 datetime with RUS layout, invalid values give zero time.
+//
+// Deprecated: use ParseDateTime, which reports errors, and FormatTime.
 func ParseTime(dt string) string {
 	t, _ := time.Parse(RFC3339, dt)
 	return t.Format(RUS)
@@ -218,30 +222,25 @@ func ToJson(a interface{}) string {
 	return ""
 }
 
-func jsonEscape(i string) string {
-	b, err := json.Marshal(i)
-	if err != nil {
-		panic(err)
-	}
-	s := string(b)
-	return s[1 : len(s)-1]
-}
-
-type DateTime struct {
-	Type  *string `json:"type"`
-	Value *string `json:"value"`
-}
-
+// Size typed long value.
+//
+// Deprecated: decode params with Params.Decode into an int64 field, or use Params.GetInt64.
 type Size struct {
 	Type  *string `json:"type,omitempty"`
 	Value *int64  `json:"value,omitempty"`
 }
 
+// CGMobileAuthCERT typed binary value.
+//
+// Deprecated: decode params with Params.Decode into a []byte field, or use Params.GetBinary.
 type CGMobileAuthCERT struct {
 	Type  *string `json:"type,omitempty"`
 	Value *string `json:"value,omitempty"`
 }
 
+// Long typed long value.
+//
+// Deprecated: decode params with Params.Decode into an int64 field, or use Params.GetInt64.
 type Long struct {
 	Type  *string `json:"type,omitempty"`
 	Value *int64  `json:"value,omitempty"`
@@ -0,0 +1,509 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Host attributes (KLHST_WKS_*), used in pFieldsToReturn, search filters and results of host queries.
+const (
+	KlhstWksDN                     = "KLHST_WKS_DN"
+	KlhstWksHostname               = "KLHST_WKS_HOSTNAME"
+	KlhstWksGroupID                = "KLHST_WKS_GROUPID"
+	KlhstWksGroupIDGp              = "KLHST_WKS_GROUPID_GP"
+	KlhstWksCreated                = "KLHST_WKS_CREATED"
+	KlhstWksLastVisible            = "KLHST_WKS_LAST_VISIBLE"
+	KlhstWksLastInfoUpdate         = "KLHST_WKS_LAST_INFOUDATE"
+	KlhstWksLastUpdate             = "KLHST_WKS_LAST_UPDATE"
+	KlhstWksLastNagentConnected    = "KLHST_WKS_LAST_NAGENT_CONNECTED"
+	KlhstWksLastSystemStart        = "KLHST_WKS_LAST_SYSTEM_START"
+	KlhstWksLastFullScan           = "KLHST_WKS_LAST_FULLSCAN"
+	KlhstWksStatus                 = "KLHST_WKS_STATUS"
+	KlhstWksStatusID               = "KLHST_WKS_STATUS_ID"
+	KlhstWksStatusMask0            = "KLHST_WKS_STATUS_MASK_0"
+	KlhstWksStatusMask1            = "KLHST_WKS_STATUS_MASK_1"
+	KlhstWksKeepConnection         = "KLHST_WKS_KEEP_CONNECTION"
+	KlhstWksFromUnassigned         = "KLHST_WKS_FROM_UNASSIGNED"
+	KlhstWksWinHostname            = "KLHST_WKS_WINHOSTNAME"
+	KlhstWksWinDomain              = "KLHST_WKS_WINDOMAIN"
+	KlhstWksWinDomainType          = "KLHST_WKS_WINDOMAIN_TYPE"
+	KlhstWksDNSDomain              = "KLHST_WKS_DNSDOMAIN"
+	KlhstWksDNSName                = "KLHST_WKS_DNSNAME"
+	KlhstWksFQDN                   = "KLHST_WKS_FQDN"
+	KlhstWksIP                     = "KLHST_WKS_IP"
+	KlhstWksIPLong                 = "KLHST_WKS_IP_LONG"
+	KlhstWksConnectIPLong          = "KLHST_WKS_CONNECT_IP_LONG"
+	KlhstWksNlaNetwork             = "KLHST_WKS_NLA_NETWORK"
+	KlhstWksCtype                  = "KLHST_WKS_CTYPE"
+	KlhstWksPtype                  = "KLHST_WKS_PTYPE"
+	KlhstWksOSName                 = "KLHST_WKS_OS_NAME"
+	KlhstWksOSVerMajor             = "KLHST_WKS_OS_VER_MAJOR"
+	KlhstWksOSVerMinor             = "KLHST_WKS_OS_VER_MINOR"
+	KlhstWksOSBuildNumber          = "KLHST_WKS_OS_BUILD_NUMBER"
+	KlhstWksOSReleaseID            = "KLHST_WKS_OS_RELEASE_ID"
+	KlhstWksOSSPVerMajor           = "KLHST_WKS_OSSP_VER_MAJOR"
+	KlhstWksOSSPVerMinor           = "KLHST_WKS_OSSP_VER_MINOR"
+	KlhstWksCPUArch                = "KLHST_WKS_CPU_ARCH"
+	KlhstWksComment                = "KLHST_WKS_COMMENT"
+	KlhstWksNagVersion             = "KLHST_WKS_NAG_VERSION"
+	KlhstWksNagVerID               = "KLHST_WKS_NAG_VER_ID"
+	KlhstWksRtpAvVersion           = "KLHST_WKS_RTP_AV_VERSION"
+	KlhstWksRtpAvBasesTime         = "KLHST_WKS_RTP_AV_BASES_TIME"
+	KlhstWksRtpState               = "KLHST_WKS_RTP_STATE"
+	KlhstWksRtpErrorCode           = "KLHST_WKS_RTP_ERROR_CODE"
+	KlhstWksVirusCount             = "KLHST_WKS_VIRUS_COUNT"
+	KlhstWksUncuredCount           = "KLHST_WKS_UNCURED_COUNT"
+	KlhstWksRbtRequired            = "KLHST_WKS_RBT_REQUIRED"
+	KlhstWksRbtRequestReason       = "KLHST_WKS_RBT_REQUEST_REASON"
+	KlhstWksAntiSpamStatus         = "KLHST_WKS_ANTI_SPAM_STATUS"
+	KlhstWksDlpStatus              = "KLHST_WKS_DLP_STATUS"
+	KlhstWksCollabSrvsStatus       = "KLHST_WKS_COLLAB_SRVS_STATUS"
+	KlhstWksEmailAvStatus          = "KLHST_WKS_EMAIL_AV_STATUS"
+	KlhstWksEdrStatus              = "KLHST_WKS_EDR_STATUS"
+	KlhstWksOwnerID                = "KLHST_WKS_OWNER_ID"
+	KlhstWksOwnerIsCustom          = "KLHST_WKS_OWNER_IS_CUSTOM"
+	KlhstWksCustomOwnerID          = "KLHST_WKS_CUSTOM_OWNER_ID"
+	KlhstWksAnyName                = "KLHST_WKS_ANYNAME"
+	KlhstWksProductName            = "KLHST_WKS_PRODUCT_NAME"
+	KlhstWksProductVersion         = "KLHST_WKS_PRODUCT_VERSION"
+	KlhstWksProductID              = "KLHST_WKS_PRODUCT_ID"
+	KlhstInstanceID                = "KLHST_INSTANCEID"
+	KlhstNagInstID                 = "KLHST_NAG_INSTID"
+	KlhstManagedOtherServer        = "KLHST_MANAGED_OTHER_SERVER"
+	KlhstCloudHostBinID            = "KLHST_CLOUD_HOST_BINID"
+	KlhstAdOrgUnit                 = "KLHST_AD_ORGUNIT"
+	KlhstAdOrgUnitGp               = "KLHST_AD_ORGUNIT_GP"
+	KlhstAdGroup                   = "KLHST_AD_GROUP"
+	KlhstMobHasOwnerCert           = "KLHST_MOB_HAS_OWNER_CERT"
+	KlhstInventoryProductName      = "KLHST_INVENTORY_PRODUCT_NAME"
+	KlhstInventoryProductVersion   = "KLHST_INVENTORY_PRODUCT_DISPLAY_VERSION"
+	KlhstInventoryProductPublisher = "KLHST_INVENTORY_PRODUCT_PUBLISHER"
+	HstVMType                      = "HST_VM_TYPE"
+	HstVMVdi                       = "HST_VM_VDI"
+)
+
+// Host host attributes returned by HostGroup.FindHosts, HostGroup.GetHostInfo and host srvviews.
+//
+// Request only the attributes you need with FieldsOf:
+//
+//	params := kaspersky.HGParams{WstrFilter: filter, VecFieldsToReturn: kaspersky.FieldsOf(kaspersky.Host{}), ...}
+//
+// or declare a smaller struct with the same json tags.
+type Host struct {
+	// DisplayName host display name
+	DisplayName string `json:"KLHST_WKS_DN,omitempty"`
+
+	// Hostname host name, unique host identifier
+	Hostname string `json:"KLHST_WKS_HOSTNAME,omitempty"`
+
+	// GroupID id of the administration group the host is located in
+	GroupID int64 `json:"KLHST_WKS_GROUPID,omitempty"`
+
+	// GroupIDGp id of the grandparent group of the host
+	GroupIDGp int64 `json:"KLHST_WKS_GROUPID_GP,omitempty"`
+
+	// Created time of host creation in the server database
+	Created time.Time `json:"KLHST_WKS_CREATED"`
+
+	// LastVisible time the host was last seen in the network
+	LastVisible time.Time `json:"KLHST_WKS_LAST_VISIBLE"`
+
+	// LastInfoUpdate time the host information was last updated
+	LastInfoUpdate time.Time `json:"KLHST_WKS_LAST_INFOUDATE"`
+
+	// LastUpdate time of the last anti-virus bases update
+	LastUpdate time.Time `json:"KLHST_WKS_LAST_UPDATE"`
+
+	// LastNagentConnected time the Network Agent last connected to the server
+	LastNagentConnected time.Time `json:"KLHST_WKS_LAST_NAGENT_CONNECTED"`
+
+	// LastSystemStart time of the last OS start
+	LastSystemStart time.Time `json:"KLHST_WKS_LAST_SYSTEM_START"`
+
+	// LastFullScan time of the last full scan
+	LastFullScan time.Time `json:"KLHST_WKS_LAST_FULLSCAN"`
+
+	// Status host status bitmask
+	Status HostStatus `json:"KLHST_WKS_STATUS,omitempty"`
+
+	// StatusID host status: OK, critical or warning
+	StatusID HostStatusID `json:"KLHST_WKS_STATUS_ID,omitempty"`
+
+	// StatusMask bitmask of host status reasons
+	StatusMask HostStatusMask `json:"KLHST_WKS_STATUS_MASK_0,omitempty"`
+
+	// StatusMask1 second word of host status reasons bitmask
+	StatusMask1 HostStatusMask1 `json:"KLHST_WKS_STATUS_MASK_1,omitempty"`
+
+	// KeepConnection whether the Network Agent keeps connection with the server
+	KeepConnection bool `json:"KLHST_WKS_KEEP_CONNECTION,omitempty"`
+
+	// FromUnassigned whether the host is in Unassigned devices
+	FromUnassigned bool `json:"KLHST_WKS_FROM_UNASSIGNED,omitempty"`
+
+	// WinHostname NetBIOS host name
+	WinHostname string `json:"KLHST_WKS_WINHOSTNAME,omitempty"`
+
+	// WinDomain NetBIOS domain name
+	WinDomain string `json:"KLHST_WKS_WINDOMAIN,omitempty"`
+
+	// WinDomainType domain type: 0 - workgroup, 1 - domain
+	WinDomainType int64 `json:"KLHST_WKS_WINDOMAIN_TYPE,omitempty"`
+
+	// DNSDomain DNS domain name
+	DNSDomain string `json:"KLHST_WKS_DNSDOMAIN,omitempty"`
+
+	// DNSName DNS host name
+	DNSName string `json:"KLHST_WKS_DNSNAME,omitempty"`
+
+	// FQDN fully qualified domain name
+	FQDN string `json:"KLHST_WKS_FQDN,omitempty"`
+
+	// IP host IPv4 address
+	IP net.IP `json:"KLHST_WKS_IP_LONG,omitempty"`
+
+	// ConnectIP IPv4 address the host connects to the server from
+	ConnectIP net.IP `json:"KLHST_WKS_CONNECT_IP_LONG,omitempty"`
+
+	// NlaNetwork network location awareness (NLA) network
+	NlaNetwork string `json:"KLHST_WKS_NLA_NETWORK,omitempty"`
+
+	// Ctype computer type
+	Ctype int64 `json:"KLHST_WKS_CTYPE,omitempty"`
+
+	// Ptype platform type
+	Ptype int64 `json:"KLHST_WKS_PTYPE,omitempty"`
+
+	// OSName operating system name
+	OSName string `json:"KLHST_WKS_OS_NAME,omitempty"`
+
+	// OSVerMajor operating system major version
+	OSVerMajor int64 `json:"KLHST_WKS_OS_VER_MAJOR,omitempty"`
+
+	// OSVerMinor operating system minor version
+	OSVerMinor int64 `json:"KLHST_WKS_OS_VER_MINOR,omitempty"`
+
+	// OSBuildNumber operating system build number
+	OSBuildNumber int64 `json:"KLHST_WKS_OS_BUILD_NUMBER,omitempty"`
+
+	// OSReleaseID operating system release id, e.g. 1909
+	OSReleaseID int64 `json:"KLHST_WKS_OS_RELEASE_ID,omitempty"`
+
+	// OSSPVerMajor service pack major version
+	OSSPVerMajor int64 `json:"KLHST_WKS_OSSP_VER_MAJOR,omitempty"`
+
+	// OSSPVerMinor service pack minor version
+	OSSPVerMinor int64 `json:"KLHST_WKS_OSSP_VER_MINOR,omitempty"`
+
+	// CPUArch CPU architecture
+	CPUArch int64 `json:"KLHST_WKS_CPU_ARCH,omitempty"`
+
+	// Comment host comment
+	Comment string `json:"KLHST_WKS_COMMENT,omitempty"`
+
+	// NagVersion Network Agent version
+	NagVersion string `json:"KLHST_WKS_NAG_VERSION,omitempty"`
+
+	// RtpAvVersion protection application version
+	RtpAvVersion string `json:"KLHST_WKS_RTP_AV_VERSION,omitempty"`
+
+	// RtpAvBasesTime anti-virus bases time
+	RtpAvBasesTime time.Time `json:"KLHST_WKS_RTP_AV_BASES_TIME"`
+
+	// RtpState real-time protection state
+	RtpState RTPState `json:"KLHST_WKS_RTP_STATE,omitempty"`
+
+	// RtpErrorCode real-time protection error code
+	RtpErrorCode int64 `json:"KLHST_WKS_RTP_ERROR_CODE,omitempty"`
+
+	// VirusCount number of viruses found on the host
+	VirusCount int64 `json:"KLHST_WKS_VIRUS_COUNT,omitempty"`
+
+	// UncuredCount number of uncured objects on the host
+	UncuredCount int64 `json:"KLHST_WKS_UNCURED_COUNT,omitempty"`
+
+	// RbtRequired whether the host must be restarted
+	RbtRequired bool `json:"KLHST_WKS_RBT_REQUIRED,omitempty"`
+
+	// RbtRequestReason reason of the restart request
+	RbtRequestReason int64 `json:"KLHST_WKS_RBT_REQUEST_REASON,omitempty"`
+
+	// OwnerID binary id of the host owner
+	OwnerID []byte `json:"KLHST_WKS_OWNER_ID,omitempty"`
+
+	// InstanceID Network Agent instance id
+	InstanceID string `json:"KLHST_INSTANCEID,omitempty"`
+
+	// ManagedOtherServer whether the host is managed by another server
+	ManagedOtherServer bool `json:"KLHST_MANAGED_OTHER_SERVER,omitempty"`
+}
+
+// OSVersion returns the operating system version as "major.minor.build".
+func (h *Host) OSVersion() string {
+	return fmt.Sprintf("%d.%d.%d", h.OSVerMajor, h.OSVerMinor, h.OSBuildNumber)
+}
+
+// UnmarshalJSON decodes host attributes from params, either plain or wrapped into {"type":"params","value":{...}}.
+func (h *Host) UnmarshalJSON(data []byte) error {
+	var p Params
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+
+	*h = Host{}
+	return p.Decode(h)
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	dateTimeType = reflect.TypeOf(DateTime{})
+	ipType       = reflect.TypeOf(net.IP{})
+)
+
+// Decode stores params into the struct pointed to by v, matching keys with json tags of its fields.
+//
+// Besides the direct mapping of values (see Params), integers are converted to any integer or float type,
+// datetime to time.Time, integer IP addresses to net.IP, nested params to structs and maps,
+// and arrays to slices. Keys without a matching field are ignored.
+func (p Params) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("kaspersky: params decode: pointer to struct expected, got %T", v)
+	}
+	return decodeStruct(p, rv.Elem())
+}
+
+func decodeStruct(p Params, s reflect.Value) error {
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Type().Field(i)
+		// fields of embedded structs are promoted even if the struct type is unexported, like in encoding/json
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := decodeStruct(p, s.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		if field.PkgPath != "" {
+			continue // unexported
+		}
+
+		key := jsonName(field)
+		if key == "" {
+			continue
+		}
+
+		value, ok := p[key]
+		if !ok {
+			continue
+		}
+		if err := assignParamsValue(s.Field(i), value); err != nil {
+			return fmt.Errorf("kaspersky: params decode %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// jsonName returns the key of the struct field given by json tag, or the field name if there is no tag.
+func jsonName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// assignParamsValue stores Go value of params into dst converting it to dst type.
+func assignParamsValue(dst reflect.Value, value interface{}) error {
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	src := reflect.ValueOf(value)
+	typ := dst.Type()
+
+	switch {
+	case typ.Kind() == reflect.Ptr:
+		elem := reflect.New(typ.Elem())
+		if err := assignParamsValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case typ == ipType:
+		if n, ok := toInt64(value); ok {
+			dst.Set(reflect.ValueOf(IPFromLong(n)))
+			return nil
+		}
+		if s, ok := value.(string); ok {
+			dst.Set(reflect.ValueOf(net.ParseIP(s)))
+			return nil
+		}
+	case typ == dateTimeType:
+		if t, ok := value.(time.Time); ok {
+			dst.Set(reflect.ValueOf(DateTime{t}))
+			return nil
+		}
+	case src.Type().AssignableTo(typ):
+		dst.Set(src)
+		return nil
+	case src.Type().ConvertibleTo(typ) && src.Kind() == typ.Kind():
+		dst.Set(src.Convert(typ))
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, ok := toInt64(value); ok {
+			dst.SetInt(n)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := toInt64(value); ok {
+			dst.SetUint(uint64(n))
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, ok := toInt64(value); ok {
+			dst.SetFloat(float64(n))
+			return nil
+		}
+		if f, ok := value.(float32); ok {
+			dst.SetFloat(float64(f))
+			return nil
+		}
+		if n, ok := value.(json.Number); ok {
+			f, err := n.Float64()
+			if err != nil {
+				return err
+			}
+			dst.SetFloat(f)
+			return nil
+		}
+	case reflect.Struct:
+		if params, ok := asParams(value); ok && typ != timeType && typ != dateTimeType {
+			return decodeStruct(params, dst)
+		}
+	case reflect.Map:
+		if params, ok := asParams(value); ok && typ.Key().Kind() == reflect.String {
+			m := reflect.MakeMapWithSize(typ, len(params))
+			for key, item := range params {
+				elem := reflect.New(typ.Elem()).Elem()
+				if err := assignParamsValue(elem, item); err != nil {
+					return fmt.Errorf("%q: %w", key, err)
+				}
+				m.SetMapIndex(reflect.ValueOf(key).Convert(typ.Key()), elem)
+			}
+			dst.Set(m)
+			return nil
+		}
+	case reflect.Slice:
+		if array, ok := value.([]interface{}); ok {
+			slice := reflect.MakeSlice(typ, len(array), len(array))
+			for i, item := range array {
+				if err := assignParamsValue(slice.Index(i), item); err != nil {
+					return fmt.Errorf("[%d]: %w", i, err)
+				}
+			}
+			dst.Set(slice)
+			return nil
+		}
+	}
+
+	// other types, e.g. implementing json.Unmarshaler, are decoded from JSON representation of the value
+	encoded, err := encodeParamsValue(value)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(encoded)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, dst.Addr().Interface()); err != nil {
+		return fmt.Errorf("can't decode %T into %s", value, typ)
+	}
+	return nil
+}
+
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+// IPFromLong converts IPv4 address in KSC integer representation (e.g. KLHST_WKS_IP_LONG) to net.IP.
+func IPFromLong(n int64) net.IP {
+	return net.IPv4(byte(n>>24), byte(n>>16), byte(n>>8), byte(n)).To4()
+}
+
+// IPToLong converts IPv4 address to KSC integer representation, e.g. for search filters.
+func IPToLong(ip net.IP) int64 {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return 0
+	}
+	return int64(ip4[0])<<24 | int64(ip4[1])<<16 | int64(ip4[2])<<8 | int64(ip4[3])
+}
+
+// FieldsOf returns keys of the struct fields given by json tags, e.g. host attributes of Host to pass
+// in pFieldsToReturn, so only the columns the struct is able to hold are requested.
+func FieldsOf(v interface{}) []string {
+	typ := reflect.TypeOf(v)
+	for typ != nil && typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ == nil || typ.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var fields []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			fields = append(fields, FieldsOf(reflect.Zero(field.Type).Interface())...)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name := jsonName(field); name != "" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // HostGroup service allow to Hosts and management groups processing.
@@ -38,13 +33,9 @@ type HostGroup service
 
 // AddDomain Add a new domain to the database.
 func (hg *HostGroup) AddDomain(ctx context.Context, strDomain string, nType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strDomain": "%s", "nType" : %d }`, strDomain, nType))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.AddDomain", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strDomain": strDomain, "nType": nType}
 
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.AddDomain", postData, nil)
 	return raw, err
 }
 
@@ -61,32 +52,18 @@ type GroupPInfo struct {
 // AddGroup Creates new group with the specified attributes and returns its Id.
 // If such group already exists returns Id of existing group.
 func (hg *HostGroup) AddGroup(ctx context.Context, params AddGroupParams) (*PxgValInt, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.AddGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := hg.client.Request(ctx, request, &pxgValInt)
+	raw, err := hg.client.Call(ctx, "HostGroup.AddGroup", params, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // AddGroupHostsForSync Add hosts from specified group to synchronization.
 func (hg *HostGroup) AddGroupHostsForSync(ctx context.Context, nGroupId int64, strSSType string) (*WActionGUID, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(` {"nGroupId": %d , "strSSType": "%s" }`, nGroupId, strSSType))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.AddGroupHostsForSync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nGroupId": nGroupId, "strSSType": strSSType}
 
 	wActionGUID := new(WActionGUID)
-	raw, err := hg.client.Request(ctx, request, &wActionGUID)
+	raw, err := hg.client.Call(ctx, "HostGroup.AddGroupHostsForSync", postData, &wActionGUID)
 	return wActionGUID, raw, err
 }
 
@@ -110,18 +87,8 @@ type NewHost struct {
 
 // AddHost Create new host record.
 func (hg *HostGroup) AddHost(ctx context.Context, params NewHost) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.AddHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := hg.client.Request(ctx, request, &pxgValStr)
+	raw, err := hg.client.Call(ctx, "HostGroup.AddHost", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -136,18 +103,8 @@ type HostsForSyncParams struct {
 
 // AddHostsForSync Performs synchronization of settings between server and host.
 func (hg *HostGroup) AddHostsForSync(ctx context.Context, params HostsForSyncParams) (*WActionGUID, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.AddHostsForSync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	wActionGUID := new(WActionGUID)
-	raw, err := hg.client.Request(ctx, request, &wActionGUID)
+	raw, err := hg.client.Call(ctx, "HostGroup.AddHostsForSync", params, &wActionGUID)
 	return wActionGUID, raw, err
 }
 
@@ -167,42 +124,24 @@ type PData struct {
 
 // AddIncident Create new incident.
 func (hg *HostGroup) AddIncident(ctx context.Context, params AddIncidentsParams) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.AddIncident", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := hg.client.Request(ctx, request, &pxgValStr)
+	raw, err := hg.client.Call(ctx, "HostGroup.AddIncident", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // DelDomain Removes a domain from the database.
 func (hg *HostGroup) DelDomain(ctx context.Context, strDomain string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strDomain": "%s"}`, strDomain))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.DelDomain", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strDomain": strDomain}
 
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.DelDomain", postData, nil)
 	return raw, err
 }
 
 // DeleteIncident Delete incident.
 func (hg *HostGroup) DeleteIncident(ctx context.Context, nId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nId": %d}`, nId))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.DeleteIncident", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nId": nId}
 
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.DeleteIncident", postData, nil)
 	return raw, err
 }
 
@@ -223,35 +162,16 @@ type PParams struct {
 // FindGroups Finds groups that satisfy conditions from filter pParams, and creates a server-side collection of found groups.
 // Search is performed over the hierarchy
 func (hg *HostGroup) FindGroups(ctx context.Context, params HGParams) (*Accessor, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.FindGroups", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	accessor := new(Accessor)
-	raw, err := hg.client.Request(ctx, request, &accessor)
+	raw, err := hg.client.Call(ctx, "HostGroup.FindGroups", params, &accessor)
 	return accessor, raw, err
 }
 
 // FindHosts Finds hosts that satisfy conditions from filter string wstrFilter, and creates a server-side collection of found hosts.
 // Search is performed over the hierarchy
 func (hg *HostGroup) FindHosts(ctx context.Context, params HGParams) (*Accessor, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.FindHosts", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	accessor := new(Accessor)
-	raw, err := hg.client.Request(ctx, request, &accessor)
+	raw, err := hg.client.Call(ctx, "HostGroup.FindHosts", params, &accessor)
 	return accessor, raw, err
 }
 
@@ -262,30 +182,16 @@ func (hg *HostGroup) FindHosts(ctx context.Context, params HGParams) (*Accessor,
 // to get accessor id call HostGroup.FindHostsAsyncGetAccessor
 // to cancel operation call HostGroup.FindHostsAsyncCancel
 func (hg *HostGroup) FindHostsAsync(ctx context.Context, params HGParams) (*RequestID, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.FindHostsAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	requestID := new(RequestID)
-	raw, err := hg.client.Request(ctx, request, &requestID)
+	raw, err := hg.client.Call(ctx, "HostGroup.FindHostsAsync", params, &requestID)
 	return requestID, raw, err
 }
 
 // FindHostsAsyncCancel Cancels asynchronous operation HostGroup.FindHostsAsync
 func (hg *HostGroup) FindHostsAsyncCancel(ctx context.Context, strRequestId string) error {
-	postData := []byte(fmt.Sprintf(`{"strRequestId": "%s"}`, strRequestId))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.FindHostsAsyncCancel", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId}
 
-	_, err = hg.client.Request(ctx, request, nil)
+	_, err := hg.client.Call(ctx, "HostGroup.FindHostsAsyncCancel", postData, nil)
 	if err != nil {
 		return err
 	}
@@ -296,14 +202,10 @@ func (hg *HostGroup) FindHostsAsyncCancel(ctx context.Context, strRequestId stri
 // FindHostsAsyncGetAccessor Gets result of asynchronous operation HostGroup.FindHostsAsync
 func (hg *HostGroup) FindHostsAsyncGetAccessor(ctx context.Context, strRequestId string) (*AsyncAccessor, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"strRequestId" : "%s" }`, strRequestId))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.FindHostsAsyncGetAccessor", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId}
 
 	asyncAccessor := new(AsyncAccessor)
-	raw, err := hg.client.Request(ctx, request, &asyncAccessor)
+	raw, err := hg.client.Call(ctx, "HostGroup.FindHostsAsyncGetAccessor", postData, &asyncAccessor)
 	return asyncAccessor, raw, err
 }
 
@@ -317,35 +219,15 @@ type FindIncidentsParams struct {
 
 // FindIncidents Find incident by filter string. Finds incidents that satisfy conditions from filter string strFilter.
 func (hg *HostGroup) FindIncidents(ctx context.Context, params FindIncidentsParams) (*Accessor, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.FindIncidents", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	accessor := new(Accessor)
-	raw, err := hg.client.Request(ctx, request, &accessor)
+	raw, err := hg.client.Call(ctx, "HostGroup.FindIncidents", params, &accessor)
 	return accessor, raw, err
 }
 
 // FindUsers Finds existing users. Finds users that satisfy conditions from filter string strFilter.
 func (hg *HostGroup) FindUsers(ctx context.Context, params PFindParams) (*Accessor, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.FindUsers", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	accessor := new(Accessor)
-	raw, err := hg.client.Request(ctx, request, &accessor)
+	raw, err := hg.client.Call(ctx, "HostGroup.FindUsers", params, &accessor)
 	return accessor, raw, err
 }
 
@@ -384,12 +266,8 @@ type KLHSTHFPRODUCTValue struct {
 
 // GetAllHostFixes Returns all hotfixes installed in the network.
 func (hg *HostGroup) GetAllHostFixes(ctx context.Context) (*HostFixes, error) {
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetAllHostfixes", nil)
-	if err != nil {
-		return nil, err
-	}
 	hostFixes := new(HostFixes)
-	_, err = hg.client.Request(ctx, request, &hostFixes)
+	_, err := hg.client.Call(ctx, "HostGroup.GetAllHostfixes", nil, &hostFixes)
 	return hostFixes, err
 }
 
@@ -423,15 +301,14 @@ type KlhstPrcstComponentVersion struct {
 // GetComponentsForProductOnHost Return array of product components for specified host and product.
 func (hg *HostGroup) GetComponentsForProductOnHost(ctx context.Context, strHostName, strProductName,
 	strProductVersion string) (*ProductComponents, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strHostName": "%s","strProductName": "%s","strProductVersion": "%s"}`,
-		strHostName, strProductName, strProductVersion))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetComponentsForProductOnHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"strHostName":       strHostName,
+		"strProductName":    strProductName,
+		"strProductVersion": strProductVersion,
 	}
 
 	var productComponents *ProductComponents
-	raw, err := hg.client.Request(ctx, request, &productComponents)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetComponentsForProductOnHost", postData, &productComponents)
 	return productComponents, raw, err
 }
 
@@ -442,13 +319,9 @@ func (hg *HostGroup) GetComponentsForProductOnHost(ctx context.Context, strHostN
 //
 // Deprecated: use either HostGroup.FindHostsAsync or HostGroup.FindHosts instead.
 func (hg *HostGroup) GetDomainHosts(ctx context.Context, domain string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"domain": "%s"}`, domain))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetDomainHosts", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"domain": domain}
 
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetDomainHosts", postData, nil)
 	return raw, err
 }
 
@@ -469,26 +342,17 @@ type DomainValue struct {
 
 // GetDomains List of Windows domain in the network.
 func (hg *HostGroup) GetDomains(ctx context.Context) (*Domains, error) {
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetDomains", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	domains := new(Domains)
-	_, err = hg.client.Request(ctx, request, &domains)
+	_, err := hg.client.Call(ctx, "HostGroup.GetDomains", nil, &domains)
 	return domains, err
 }
 
 // GetGroupId Acquire administration group id by its name and id of parent group.
 func (hg *HostGroup) GetGroupId(ctx context.Context, nParent int64, strName string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nParent": %d, "strName": "%s"}`, nParent, strName))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetGroupId", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nParent": nParent, "strName": strName}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := hg.client.Request(ctx, request, &pxgValInt)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetGroupId", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -553,14 +417,10 @@ type KlsrvHststatValue struct {
 // GetGroupInfo Acquire administration group attributes.
 //	Deprecated: Use HostGroup.GetGroupInfoEx instead
 func (hg *HostGroup) GetGroupInfo(ctx context.Context, nGroupId int64) (*GroupInfo, error) {
-	postData := []byte(fmt.Sprintf(`{"nGroupId": %d}`, nGroupId))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetGroupInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nGroupId": nGroupId}
 
 	groupInfo := new(GroupInfo)
-	_, err = hg.client.Request(ctx, request, &groupInfo)
+	_, err := hg.client.Call(ctx, "HostGroup.GetGroupInfo", postData, &groupInfo)
 	return groupInfo, err
 }
 
@@ -577,18 +437,8 @@ type GroupInfoExParams struct {
 //
 // Remark: not working on KSC 10
 func (hg *HostGroup) GetGroupInfoEx(ctx context.Context, params GroupInfoExParams) (*GroupInfo, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetGroupInfoEx", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	groupInfo := new(GroupInfo)
-	raw, err := hg.client.Request(ctx, request, &groupInfo)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetGroupInfoEx", params, &groupInfo)
 	return groupInfo, raw, err
 }
 
@@ -610,55 +460,37 @@ type FixesValue struct {
 // GetHostfixesForProductOnHost Return array of hotfixes for specified host and product.
 // Array is ordered according hotfix installation order.
 func (hg *HostGroup) GetHostfixesForProductOnHost(ctx context.Context, strHostName, strProductName, strProductVersion string) (*ProductFixes, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strHostName": "%s","strProductName": "%s","strProductVersion": "%s"}`, strHostName, strProductName, strProductVersion))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetHostfixesForProductOnHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"strHostName":       strHostName,
+		"strProductName":    strProductName,
+		"strProductVersion": strProductVersion,
 	}
 
 	productFixes := new(ProductFixes)
-	raw, err := hg.client.Request(ctx, request, &productFixes)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetHostfixesForProductOnHost", postData, &productFixes)
 	return productFixes, raw, err
 }
 
 // GetHostInfo Acquire specified host attributes.
 func (hg *HostGroup) GetHostInfo(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetHostInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetHostInfo", params, nil)
 	return raw, err
 }
 
 // GetHostProducts Return information about installed products on the host.
 func (hg *HostGroup) GetHostProducts(ctx context.Context, strHostName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strHostName": "%s"}`, strHostName))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetHostProducts", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strHostName": strHostName}
 
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetHostProducts", postData, nil)
 	return raw, err
 }
 
 // GetHostTasks Return server specific identity to acquire and manage host tasks.
 func (hg *HostGroup) GetHostTasks(ctx context.Context, hostId string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strHostName": "%s"}`, hostId))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetHostTasks", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strHostName": hostId}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := hg.client.Request(ctx, request, &pxgValStr)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetHostTasks", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -781,22 +613,8 @@ type ServerInstanceStatistics struct {
 //
 // Remark: not working on KSC 10
 func (hg *HostGroup) GetInstanceStatistics(ctx context.Context, params InstanceStatisticsParams) (*ServerInstanceStatistics, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetInstanceStatistics", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(ServerInstanceStatistics)
-	raw, err := hg.client.Request(ctx, request, &result)
-
-	if hg.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := hg.client.Call(ctx, "HostGroup.GetInstanceStatistics", params, &result)
 
 	return result, err
 }
@@ -808,17 +626,7 @@ type StaticInfoParams struct {
 
 // GetRunTimeInfo Return server run-time info.
 func (hg *HostGroup) GetRunTimeInfo(ctx context.Context, params StaticInfoParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetRunTimeInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.GetRunTimeInfo", params, nil)
 	return raw, err
 }
 
@@ -856,22 +664,8 @@ type ServerStaticInfo struct {
 
 // GetStaticInfo Return server static info.
 func (hg *HostGroup) GetStaticInfo(ctx context.Context, params StaticInfoParams) (*ServerStaticInfo, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetStaticInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(ServerStaticInfo)
-	raw, err := hg.client.Request(ctx, request, &result)
-
-	if hg.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := hg.client.Call(ctx, "HostGroup.GetStaticInfo", params, &result)
 
 	return result, err
 }
@@ -894,64 +688,41 @@ type SubGroupValue struct {
 
 // GetSubgroups Acquire administration group subgroups tree.
 func (hg *HostGroup) GetSubgroups(ctx context.Context, nGroupId int64, nDepth int64) (*SubGroups, error) {
-	postData := []byte(fmt.Sprintf(`{"nParent": %d, "nDepth": %d }`, nGroupId, nDepth))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GetSubgroups", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nParent": nGroupId, "nDepth": nDepth}
 
 	subGroups := new(SubGroups)
-	_, err = hg.client.Request(ctx, request, &subGroups)
+	_, err := hg.client.Call(ctx, "HostGroup.GetSubgroups", postData, &subGroups)
 	return subGroups, err
 }
 
 // GroupIdGroups Id of predefined root group "Managed computers".
 func (hg *HostGroup) GroupIdGroups(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GroupIdGroups", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := hg.client.Request(ctx, request, &pxgValInt)
+	raw, err := hg.client.Call(ctx, "HostGroup.GroupIdGroups", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // GroupIdSuper Id of predefined group "Master server".
 func (hg *HostGroup) GroupIdSuper(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GroupIdSuper", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := hg.client.Request(ctx, request, &pxgValInt)
+	raw, err := hg.client.Call(ctx, "HostGroup.GroupIdSuper", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // GroupIdUnassigned Id of predefined group "Unassigned computers".
 func (hg *HostGroup) GroupIdUnassigned(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.GroupIdUnassigned", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := hg.client.Request(ctx, request, &pxgValInt)
+	raw, err := hg.client.Call(ctx, "HostGroup.GroupIdUnassigned", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // MoveHostsFromGroupToGroup Moves hosts from root of source group to root of destination group. Operation is asynchronous.
 func (hg *HostGroup) MoveHostsFromGroupToGroup(ctx context.Context, nSrcGroupId int64,
 	nDstGroupId int64) (*WActionGUID, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nSrcGroupId": %d, "nDstGroupId": %d}`, nSrcGroupId, nDstGroupId))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.MoveHostsFromGroupToGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nSrcGroupId": nSrcGroupId, "nDstGroupId": nDstGroupId}
 
 	wActionGUID := new(WActionGUID)
-	raw, err := hg.client.Request(ctx, request, &wActionGUID)
+	raw, err := hg.client.Call(ctx, "HostGroup.MoveHostsFromGroupToGroup", postData, &wActionGUID)
 	return wActionGUID, raw, err
 }
 
@@ -963,43 +734,24 @@ type HostsToGroupParams struct {
 
 // MoveHostsToGroup Move multiple hosts into specified administration group.
 func (hg *HostGroup) MoveHostsToGroup(ctx context.Context, params HostsToGroupParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.MoveHostsToGroup",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.MoveHostsToGroup", params, nil)
 	return raw, err
 }
 
 // RemoveGroup Delete administration group.
 func (hg *HostGroup) RemoveGroup(ctx context.Context, nGroup, nFlags int64) (*WActionGUID, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{ "nGroup": %d, "nFlags": %d }`, nGroup, nFlags))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.RemoveGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nGroup": nGroup, "nFlags": nFlags}
 
 	wActionGUID := new(WActionGUID)
-	raw, err := hg.client.Request(ctx, request, &wActionGUID)
+	raw, err := hg.client.Call(ctx, "HostGroup.RemoveGroup", postData, &wActionGUID)
 	return wActionGUID, raw, err
 }
 
 // RemoveHost Removes host record.
 func (hg *HostGroup) RemoveHost(ctx context.Context, strHostName string) error {
-	postData := []byte(fmt.Sprintf(`{ "strHostName": "%s" }`, strHostName))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.RemoveHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"strHostName": strHostName}
 
-	_, err = hg.client.Request(ctx, request, nil)
+	_, err := hg.client.Call(ctx, "HostGroup.RemoveHost", postData, nil)
 	if err != nil {
 		return err
 	}
@@ -1023,17 +775,7 @@ type RemoveHostsParams struct {
 //If bForceDestroy is false hosts records will be deleted only for hosts located in group "Unassigned computers"
 // or its subgroups, others will be moved into corresponding subgroups of group "Unassigned computers".
 func (hg *HostGroup) RemoveHosts(ctx context.Context, params RemoveHostsParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.RemoveHosts", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.RemoveHosts", params, nil)
 	return raw, err
 }
 
@@ -1069,47 +811,23 @@ type PInfo struct {
 //
 //4. DNS name (KLHST_WKS_DNSNAME)
 func (hg *HostGroup) ResolveAndMoveToGroup(ctx context.Context, params PInfoRaM) (*KlhstWksResults, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.ResolveAndMoveToGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	klhstWksResults := new(KlhstWksResults)
-	raw, err := hg.client.Request(ctx, request, &klhstWksResults)
+	raw, err := hg.client.Call(ctx, "HostGroup.ResolveAndMoveToGroup", params, &klhstWksResults)
 	return klhstWksResults, raw, err
 }
 
 // RestartNetworkScanning Restarts specified network scanning type.
 func (hg *HostGroup) RestartNetworkScanning(ctx context.Context, nType int64) (*PxgRetError, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nType": %d	}`, nType))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.RestartNetworkScanning", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nType": nType}
 
 	pxgRetError := new(PxgRetError)
-	raw, err := hg.client.Request(ctx, request, &pxgRetError)
+	raw, err := hg.client.Call(ctx, "HostGroup.RestartNetworkScanning", postData, &pxgRetError)
 	return pxgRetError, raw, err
 }
 
 // SetLocInfo Allows to set server localization information.
 func (hg *HostGroup) SetLocInfo(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.SetLocInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.SetLocInfo", params, nil)
 	return raw, err
 }
 
@@ -1142,33 +860,13 @@ type SectionParams struct {
 
 // SSCreateSection Create section in host settings storage.
 func (hg *HostGroup) SSCreateSection(ctx context.Context, params SectionParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.SS_CreateSection", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.SS_CreateSection", params, nil)
 	return raw, err
 }
 
 // SSWrite Write data to host settings storage.
 func (hg *HostGroup) SSWrite(ctx context.Context, params SectionParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.SS_Write", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.SS_Write", params, nil)
 	return raw, err
 }
 
@@ -1178,34 +876,14 @@ func (hg *HostGroup) SSWrite(ctx context.Context, params SectionParams) ([]byte,
 // If product is not empty and version is empty then names will contain all versions for the specified product name.
 // If product is not empty and version is not empty then names will contain all sections for the specified product and version.
 func (hg *HostGroup) SSGetNames(ctx context.Context, params SectionParams) (*PxgValArrayOfString, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.SS_GetNames", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValArrayOfString := new(PxgValArrayOfString)
-	raw, err := hg.client.Request(ctx, request, &pxgValArrayOfString)
+	raw, err := hg.client.Call(ctx, "HostGroup.SS_GetNames", params, &pxgValArrayOfString)
 	return pxgValArrayOfString, raw, err
 }
 
 // SSRead Read data from host settings storage.
 func (hg *HostGroup) SSRead(ctx context.Context, params SectionParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.SS_Read", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.SS_Read", params, nil)
 	return raw, err
 }
 
@@ -1231,49 +909,19 @@ type PInfoGroup struct {
 
 // UpdateGroup Change attributes of existing administration group.
 func (hg *HostGroup) UpdateGroup(ctx context.Context, params UpdateGroupParam) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.UpdateGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.UpdateGroup", params, nil)
 	return raw, err
 }
 
 // UpdateHost Modify specified attributes for host.
 func (hg *HostGroup) UpdateHost(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.UpdateHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.UpdateHost", params, nil)
 	return raw, err
 }
 
 // UpdateHostsMultiple Update attributes of multiple computers.
 func (hg *HostGroup) UpdateHostsMultiple(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.UpdateHostsMultiple", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.UpdateHostsMultiple", params, nil)
 	return raw, err
 }
 
@@ -1297,42 +945,22 @@ type PIncidentData struct {
 
 // UpdateIncident Modify properties of an existing incident.
 func (hg *HostGroup) UpdateIncident(ctx context.Context, params UpdateIncidentParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.UpdateIncident", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hg.client.Request(ctx, request, nil)
+	raw, err := hg.client.Call(ctx, "HostGroup.UpdateIncident", params, nil)
 	return raw, err
 }
 
 // ZeroVirusCountForGroup Zero virus count for hosts in group and all subgroups.
 func (hg *HostGroup) ZeroVirusCountForGroup(ctx context.Context, nParent int64) (*WActionGUID, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nParent": %d}`, nParent))
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.ZeroVirusCountForGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nParent": nParent}
 
 	wActionGUID := new(WActionGUID)
-	raw, err := hg.client.Request(ctx, request, &wActionGUID)
+	raw, err := hg.client.Call(ctx, "HostGroup.ZeroVirusCountForGroup", postData, &wActionGUID)
 	return wActionGUID, raw, err
 }
 
 // ZeroVirusCountForHosts Zero virus count for specified hosts.
 func (hg *HostGroup) ZeroVirusCountForHosts(ctx context.Context, params interface{}) (*WActionGUID, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", hg.client.Server+"/api/v1.0/HostGroup.ZeroVirusCountForHosts", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	wActionGUID := new(WActionGUID)
-	raw, err := hg.client.Request(ctx, request, &wActionGUID)
+	raw, err := hg.client.Call(ctx, "HostGroup.ZeroVirusCountForHosts", params, &wActionGUID)
 	return wActionGUID, raw, err
 }
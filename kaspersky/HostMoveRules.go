@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // HostMoveRules service to Modify and acquire move rules to hosts.
@@ -40,29 +36,15 @@ type HostMoveRules service
 
 // AddRule Creates new extended host moving rule with specified attributes.
 func (hmr *HostMoveRules) AddRule(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hmr.client.Server+"/api/v1.0/HostMoveRules.AddRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hmr.client.Request(ctx, request, nil)
+	raw, err := hmr.client.Call(ctx, "HostMoveRules.AddRule", params, nil)
 	return raw, err
 }
 
 // DeleteRule Removes specified extended host moving rule.
 func (hmr *HostMoveRules) DeleteRule(ctx context.Context, nRule int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nRule": %d}`, nRule))
-	request, err := http.NewRequest("POST", hmr.client.Server+"/api/v1.0/HostMoveRules.DeleteRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nRule": nRule}
 
-	raw, err := hmr.client.Request(ctx, request, nil)
+	raw, err := hmr.client.Call(ctx, "HostMoveRules.DeleteRule", postData, nil)
 	return raw, err
 }
 
@@ -82,17 +64,7 @@ type ExecuteRulesParams struct {
 
 // ExecuteRulesNow Executes rules for a specific group
 func (hmr *HostMoveRules) ExecuteRulesNow(ctx context.Context, params ExecuteRulesParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hmr.client.Server+"/api/v1.0/HostMoveRules.ExecuteRulesNow", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hmr.client.Request(ctx, request, nil)
+	raw, err := hmr.client.Call(ctx, "HostMoveRules.ExecuteRulesNow", params, nil)
 	return raw, err
 }
 
@@ -168,14 +140,10 @@ type HMRules struct {
 
 // GetRule Acquire attributes of specified rule.
 func (hmr *HostMoveRules) GetRule(ctx context.Context, nRule int64) (*HMoveRule, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nRule": %d}`, nRule))
-	request, err := http.NewRequest("POST", hmr.client.Server+"/api/v1.0/HostMoveRules.GetRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nRule": nRule}
 
 	hMoveRule := new(HMoveRule)
-	raw, err := hmr.client.Request(ctx, request, &hMoveRule)
+	raw, err := hmr.client.Call(ctx, "HostMoveRules.GetRule", postData, &hMoveRule)
 	return hMoveRule, raw, err
 }
 
@@ -186,18 +154,8 @@ type Rules struct {
 
 // GetRules Enumerate all extended host moving rules. Enumerates all extended host moving rules.
 func (hmr *HostMoveRules) GetRules(ctx context.Context, params Rules) (*HMoveRules, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hmr.client.Server+"/api/v1.0/HostMoveRules.GetRules", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	hMoveRules := new(HMoveRules)
-	raw, err := hmr.client.Request(ctx, request, &hMoveRules)
+	raw, err := hmr.client.Call(ctx, "HostMoveRules.GetRules", params, &hMoveRules)
 	return hMoveRules, raw, err
 }
 
@@ -209,33 +167,13 @@ type RulesOrderParams struct {
 
 // SetRulesOrder Modifies order of specified rules in the global list. Order of rules not contained in pRules array will be indefinite.
 func (hmr *HostMoveRules) SetRulesOrder(ctx context.Context, params RulesOrderParams) (*HMoveRules, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hmr.client.Server+"/api/v1.0/HostMoveRules.SetRulesOrder", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	hMoveRules := new(HMoveRules)
-	raw, err := hmr.client.Request(ctx, request, &hMoveRules)
+	raw, err := hmr.client.Call(ctx, "HostMoveRules.SetRulesOrder", params, &hMoveRules)
 	return hMoveRules, raw, err
 }
 
 // UpdateRule Modify attributes of specified rule.
 func (hmr *HostMoveRules) UpdateRule(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hmr.client.Server+"/api/v1.0/HostMoveRules.UpdateRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hmr.client.Request(ctx, request, nil)
+	raw, err := hmr.client.Call(ctx, "HostMoveRules.UpdateRule", params, nil)
 	return raw, err
 }
@@ -0,0 +1,296 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// HostStatus host status bitmask (KLHST_WKS_STATUS).
+type HostStatus int64
+
+const (
+	// HostStatusVisible host is visible in the network
+	HostStatusVisible HostStatus = 1 << 0
+	// HostStatusNagentInstalled Network Agent is installed
+	HostStatusNagentInstalled HostStatus = 1 << 2
+	// HostStatusNagentAlive Network Agent is alive
+	HostStatusNagentAlive HostStatus = 1 << 3
+	// HostStatusRtpInstalled real-time protection is installed
+	HostStatusRtpInstalled HostStatus = 1 << 4
+	// HostStatusTemporarilySwitched host has been temporarily switched to the current server by NLA profile switching
+	HostStatusTemporarilySwitched HostStatus = 1 << 5
+)
+
+var hostStatusNames = []struct {
+	flag HostStatus
+	name string
+}{
+	{HostStatusVisible, "Visible"},
+	{HostStatusNagentInstalled, "NagentInstalled"},
+	{HostStatusNagentAlive, "NagentAlive"},
+	{HostStatusRtpInstalled, "RtpInstalled"},
+	{HostStatusTemporarilySwitched, "TemporarilySwitched"},
+}
+
+// Has reports whether all of the flags are set.
+func (s HostStatus) Has(flags HostStatus) bool {
+	return s&flags == flags
+}
+
+// IsVisible reports whether the host is visible in the network.
+func (s HostStatus) IsVisible() bool {
+	return s.Has(HostStatusVisible)
+}
+
+// IsNagentConnected reports whether Network Agent is installed and alive.
+func (s HostStatus) IsNagentConnected() bool {
+	return s.Has(HostStatusNagentInstalled | HostStatusNagentAlive)
+}
+
+// String returns set flags joined by "|", e.g. "Visible|NagentInstalled".
+func (s HostStatus) String() string {
+	var names []string
+	rest := s
+	for _, n := range hostStatusNames {
+		if s&n.flag != 0 {
+			names = append(names, n.name)
+			rest &^= n.flag
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", int64(rest)))
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "|")
+}
+
+// HostStatusID host status (KLHST_WKS_STATUS_ID).
+type HostStatusID int64
+
+const (
+	HostStatusOK       HostStatusID = 0
+	HostStatusCritical HostStatusID = 1
+	HostStatusWarning  HostStatusID = 2
+)
+
+// IsOK reports whether the host status is OK.
+func (id HostStatusID) IsOK() bool {
+	return id == HostStatusOK
+}
+
+// IsCritical reports whether the host status is critical.
+func (id HostStatusID) IsCritical() bool {
+	return id == HostStatusCritical
+}
+
+// IsWarning reports whether the host status is warning.
+func (id HostStatusID) IsWarning() bool {
+	return id == HostStatusWarning
+}
+
+func (id HostStatusID) String() string {
+	switch id {
+	case HostStatusOK:
+		return "OK"
+	case HostStatusCritical:
+		return "Critical"
+	case HostStatusWarning:
+		return "Warning"
+	}
+	return fmt.Sprintf("HostStatusID(%d)", int64(id))
+}
+
+// HostStatusMask bitmask of host status reasons (KLHST_WKS_STATUS_MASK_0).
+//
+// Whether a reason makes the host critical or warning is configured on the server
+// in the administration group settings, see HostStatusMaskCritical for the defaults.
+type HostStatusMask int64
+
+// HostStatusReason single bit of HostStatusMask.
+type HostStatusReason int64
+
+const (
+	ReasonNotVisible              HostStatusReason = 1 << 0
+	ReasonAvNotInstalled          HostStatusReason = 1 << 1
+	ReasonTooManyVirusesDetected  HostStatusReason = 1 << 2
+	ReasonRtpLevelDiffers         HostStatusReason = 1 << 3
+	ReasonAvNotRunning            HostStatusReason = 1 << 4
+	ReasonAvBasesOutdated         HostStatusReason = 1 << 5
+	ReasonFullScanLongAgo         HostStatusReason = 1 << 6
+	ReasonNagentInactive          HostStatusReason = 1 << 7
+	ReasonLicenseExpired          HostStatusReason = 1 << 8
+	ReasonTooManyUncured          HostStatusReason = 1 << 9
+	ReasonRestartRequired         HostStatusReason = 1 << 10
+	ReasonIncompatibleApps        HostStatusReason = 1 << 11
+	ReasonVulnerabilities         HostStatusReason = 1 << 12
+	ReasonOSUpdatesSearchLongAgo  HostStatusReason = 1 << 13
+	ReasonInvalidEncryptionStatus HostStatusReason = 1 << 14
+	ReasonMdmNotCompliant         HostStatusReason = 1 << 15
+	ReasonUnprocessedIncidents    HostStatusReason = 1 << 16
+	ReasonStatusByApplication     HostStatusReason = 1 << 17
+	ReasonOutOfDiskSpace          HostStatusReason = 1 << 18
+)
+
+var hostStatusReasonNames = map[HostStatusReason]string{
+	ReasonNotVisible:              "NotVisible",
+	ReasonAvNotInstalled:          "AvNotInstalled",
+	ReasonTooManyVirusesDetected:  "TooManyVirusesDetected",
+	ReasonRtpLevelDiffers:         "RtpLevelDiffers",
+	ReasonAvNotRunning:            "AvNotRunning",
+	ReasonAvBasesOutdated:         "AvBasesOutdated",
+	ReasonFullScanLongAgo:         "FullScanLongAgo",
+	ReasonNagentInactive:          "NagentInactive",
+	ReasonLicenseExpired:          "LicenseExpired",
+	ReasonTooManyUncured:          "TooManyUncured",
+	ReasonRestartRequired:         "RestartRequired",
+	ReasonIncompatibleApps:        "IncompatibleApps",
+	ReasonVulnerabilities:         "Vulnerabilities",
+	ReasonOSUpdatesSearchLongAgo:  "OSUpdatesSearchLongAgo",
+	ReasonInvalidEncryptionStatus: "InvalidEncryptionStatus",
+	ReasonMdmNotCompliant:         "MdmNotCompliant",
+	ReasonUnprocessedIncidents:    "UnprocessedIncidents",
+	ReasonStatusByApplication:     "StatusByApplication",
+	ReasonOutOfDiskSpace:          "OutOfDiskSpace",
+}
+
+func (r HostStatusReason) String() string {
+	if name, ok := hostStatusReasonNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("HostStatusReason(0x%x)", int64(r))
+}
+
+// HostStatusMaskCritical reasons which make the host critical with the default server settings.
+const HostStatusMaskCritical = HostStatusMask(ReasonAvNotInstalled | ReasonTooManyVirusesDetected |
+	ReasonRtpLevelDiffers | ReasonAvNotRunning | ReasonAvBasesOutdated | ReasonNagentInactive |
+	ReasonLicenseExpired | ReasonTooManyUncured)
+
+// Has reports whether the reason is set.
+func (m HostStatusMask) Has(r HostStatusReason) bool {
+	return int64(m)&int64(r) != 0
+}
+
+// HasAny reports whether any of the reasons of mask is set.
+func (m HostStatusMask) HasAny(mask HostStatusMask) bool {
+	return m&mask != 0
+}
+
+// HasCritical reports whether any of the HostStatusMaskCritical reasons is set.
+//
+// It assumes the default server settings. If the administration group makes other reasons critical,
+// use HasAny with the reasons configured there or check StatusID of the host, which is computed by the server.
+func (m HostStatusMask) HasCritical() bool {
+	return m.HasAny(HostStatusMaskCritical)
+}
+
+// Reasons returns set reasons in ascending bit order.
+func (m HostStatusMask) Reasons() []HostStatusReason {
+	reasons := make([]HostStatusReason, 0, bits.OnesCount64(uint64(m)))
+	for rest := uint64(m); rest != 0; rest &= rest - 1 {
+		reasons = append(reasons, HostStatusReason(rest&-rest))
+	}
+	return reasons
+}
+
+// String returns set reasons joined by "|", e.g. "AvBasesOutdated|RestartRequired".
+func (m HostStatusMask) String() string {
+	reasons := m.Reasons()
+	if len(reasons) == 0 {
+		return "0"
+	}
+
+	names := make([]string, len(reasons))
+	for i, r := range reasons {
+		names[i] = r.String()
+	}
+	return strings.Join(names, "|")
+}
+
+// HostStatusMask1 second word of the host status reasons bitmask (KLHST_WKS_STATUS_MASK_1).
+//
+// KSC reserves it for the reasons which don't fit into HostStatusMask, none of its bits are documented,
+// so the value is kept as is.
+type HostStatusMask1 int64
+
+// Has reports whether the bit with the given number (0-63) is set.
+func (m HostStatusMask1) Has(bit uint) bool {
+	return bit < 64 && uint64(m)&(1<<bit) != 0
+}
+
+// String returns the mask in hex, e.g. "0x1".
+func (m HostStatusMask1) String() string {
+	return fmt.Sprintf("0x%x", uint64(m))
+}
+
+// RTPState state of real-time protection (KLHST_WKS_RTP_STATE).
+type RTPState int64
+
+const (
+	RTPStateUnknown          RTPState = 0
+	RTPStateStopped          RTPState = 1
+	RTPStateSuspended        RTPState = 2
+	RTPStateStarting         RTPState = 3
+	RTPStateRunning          RTPState = 4
+	RTPStateRunningMaxProt   RTPState = 5
+	RTPStateRunningMaxSpeed  RTPState = 6
+	RTPStateRunningRecommend RTPState = 7
+	RTPStateRunningCustom    RTPState = 8
+	RTPStateFailure          RTPState = 9
+)
+
+var rtpStateNames = []string{
+	"Unknown",
+	"Stopped",
+	"Suspended",
+	"Starting",
+	"Running",
+	"RunningMaxProtection",
+	"RunningMaxSpeed",
+	"RunningRecommended",
+	"RunningCustom",
+	"Failure",
+}
+
+// IsRunning reports whether real-time protection is running with any protection level.
+func (s RTPState) IsRunning() bool {
+	return s >= RTPStateRunning && s <= RTPStateRunningCustom
+}
+
+// IsFailed reports whether real-time protection failed.
+func (s RTPState) IsFailed() bool {
+	return s == RTPStateFailure
+}
+
+func (s RTPState) String() string {
+	if s >= 0 && int(s) < len(rtpStateNames) {
+		return rtpStateNames[s]
+	}
+	return fmt.Sprintf("RTPState(%d)", int64(s))
+}
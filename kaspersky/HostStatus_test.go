@@ -0,0 +1,110 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestHostStatus(t *testing.T) {
+	s := HostStatusVisible | HostStatusNagentInstalled | HostStatusNagentAlive | 1<<10
+	if !s.IsVisible() || !s.IsNagentConnected() || s.Has(HostStatusRtpInstalled) {
+		t.Errorf("flags of %v", s)
+	}
+	if got := s.String(); got != "Visible|NagentInstalled|NagentAlive|0x400" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := HostStatus(0).String(); got != "0" {
+		t.Errorf("String() of zero = %q", got)
+	}
+	if (HostStatusNagentInstalled).IsNagentConnected() {
+		t.Error("IsNagentConnected() without alive Network Agent")
+	}
+}
+
+func TestHostStatusID(t *testing.T) {
+	if !HostStatusOK.IsOK() || !HostStatusCritical.IsCritical() || !HostStatusWarning.IsWarning() || HostStatusOK.IsCritical() {
+		t.Error("HostStatusID predicates")
+	}
+	if got := HostStatusID(7).String(); got != "HostStatusID(7)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestHostStatusMask(t *testing.T) {
+	m := HostStatusMask(ReasonAvBasesOutdated | ReasonRestartRequired | 1<<40)
+	want := []HostStatusReason{ReasonAvBasesOutdated, ReasonRestartRequired, 1 << 40}
+	if got := m.Reasons(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Reasons() = %v, want %v", got, want)
+	}
+	if got := m.String(); got != "AvBasesOutdated|RestartRequired|HostStatusReason(0x10000000000)" {
+		t.Errorf("String() = %q", got)
+	}
+	if !m.Has(ReasonRestartRequired) || m.Has(ReasonNotVisible) {
+		t.Error("Has()")
+	}
+
+	if !m.HasCritical() {
+		t.Error("HasCritical() with outdated bases")
+	}
+	if HostStatusMask(ReasonRestartRequired).HasCritical() {
+		t.Error("HasCritical() with restart required only")
+	}
+	custom := HostStatusMask(ReasonRestartRequired | ReasonVulnerabilities)
+	if !HostStatusMask(ReasonRestartRequired).HasAny(custom) || HostStatusMask(ReasonNotVisible).HasAny(custom) {
+		t.Error("HasAny() with custom critical reasons")
+	}
+}
+
+func TestHostStatusMask1(t *testing.T) {
+	var h Host
+	data := `{"KLHST_WKS_STATUS_MASK_0":{"type":"long","value":32},"KLHST_WKS_STATUS_MASK_1":{"type":"long","value":5}}`
+	if err := json.Unmarshal([]byte(data), &h); err != nil {
+		t.Fatal(err)
+	}
+	if h.StatusMask != HostStatusMask(ReasonAvBasesOutdated) || h.StatusMask1 != 5 {
+		t.Errorf("masks = %v, %v", h.StatusMask, h.StatusMask1)
+	}
+	if !h.StatusMask1.Has(0) || h.StatusMask1.Has(1) || !h.StatusMask1.Has(2) || h.StatusMask1.Has(64) {
+		t.Error("HostStatusMask1.Has()")
+	}
+	if got := h.StatusMask1.String(); got != "0x5" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestRTPState(t *testing.T) {
+	if !RTPStateRunningCustom.IsRunning() || RTPStateStarting.IsRunning() || !RTPStateFailure.IsFailed() {
+		t.Error("RTPState predicates")
+	}
+	if got := RTPStateRunningMaxProt.String(); got != "RunningMaxProtection" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := RTPState(42).String(); got != "RTPState(42)" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // HostTagsApi service allows to acquire and manage tags for hosts. It is additional service for common ListTags.
@@ -65,17 +62,7 @@ type HTagValue struct {
 
 // GetHostTags Get tags for the host.
 func (kc *HostTagsApi) GetHostTags(ctx context.Context, params HostTagsParams) (*HostTags, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", kc.client.Server+"/api/v1.0/HostTagsApi.GetHostTags", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	hostTags := new(HostTags)
-	raw, err := kc.client.Request(ctx, request, &hostTags)
+	raw, err := kc.client.Call(ctx, "HostTagsApi.GetHostTags", params, &hostTags)
 	return hostTags, raw, err
 }
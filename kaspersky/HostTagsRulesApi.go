@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // HostTagsRulesApi service allows to acquire and manage host automatic tagging rules
@@ -65,25 +61,15 @@ type HostTagsRulesParams struct {
 //		]
 //	}
 func (htra *HostTagsRulesApi) GetRules(ctx context.Context, params HostTagsRulesParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", htra.client.Server+"/api/v1.0/HostTagsRulesApi.GetRules", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := htra.client.Request(ctx, request, nil)
+	raw, err := htra.client.Call(ctx, "HostTagsRulesApi.GetRules", params, nil)
 	return raw, err
 }
 
 // GetRule Acquire attributes of specified rule. Returns attributes of specified rule.
 func (htra *HostTagsRulesApi) GetRule(ctx context.Context, szwTagValue string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwTagValue": "%s"}`, szwTagValue))
-	request, err := http.NewRequest("POST", htra.client.Server+"/api/v1.0/HostTagsRulesApi.GetRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwTagValue": szwTagValue}
 
-	raw, err := htra.client.Request(ctx, request, nil)
+	raw, err := htra.client.Call(ctx, "HostTagsRulesApi.GetRule", postData, nil)
 	return raw, err
 }
 
@@ -95,14 +81,10 @@ func (htra *HostTagsRulesApi) GetRule(ctx context.Context, szwTagValue string) (
 // After returning from this method it is needed to wait while
 // AsyncActionStateChecker.CheckActionState will return bFinalized or call HostTagsRulesApi.CancelAsyncAction with wstrActionGuid
 func (htra *HostTagsRulesApi) ExecuteRule(ctx context.Context, szwTagValue string) (*WActionGUID, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwTagValue": "%s"}`, szwTagValue))
-	request, err := http.NewRequest("POST", htra.client.Server+"/api/v1.0/HostTagsRulesApi.ExecuteRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwTagValue": szwTagValue}
 
 	wActionGUID := new(WActionGUID)
-	raw, err := htra.client.Request(ctx, request, &wActionGUID)
+	raw, err := htra.client.Call(ctx, "HostTagsRulesApi.ExecuteRule", postData, &wActionGUID)
 	return wActionGUID, raw, err
 }
 
@@ -111,25 +93,17 @@ func (htra *HostTagsRulesApi) ExecuteRule(ctx context.Context, szwTagValue strin
 // This method should be called if there is no wish to wait while
 // AsyncActionStateChecker.CheckActionState will return bFinalized for earlier launched asynchronous operation.
 func (htra *HostTagsRulesApi) CancelAsyncAction(ctx context.Context, wstrActionGuid string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrActionGuid": "%s"}`, wstrActionGuid))
-	request, err := http.NewRequest("POST", htra.client.Server+"/api/v1.0/HostTagsRulesApi.CancelAsyncAction", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrActionGuid": wstrActionGuid}
 
-	raw, err := htra.client.Request(ctx, request, nil)
+	raw, err := htra.client.Call(ctx, "HostTagsRulesApi.CancelAsyncAction", postData, nil)
 	return raw, err
 }
 
 // DeleteRule Remove host automatic tagging rule.
 func (htra *HostTagsRulesApi) DeleteRule(ctx context.Context, szwTagValue string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwTagValue": "%s"}`, szwTagValue))
-	request, err := http.NewRequest("POST", htra.client.Server+"/api/v1.0/HostTagsRulesApi.DeleteRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwTagValue": szwTagValue}
 
-	raw, err := htra.client.Request(ctx, request, nil)
+	raw, err := htra.client.Call(ctx, "HostTagsRulesApi.DeleteRule", postData, nil)
 	return raw, err
 }
 
@@ -201,12 +175,6 @@ type CustomValue struct{}
 
 // UpdateRule Adds/Updates host automatic tagging rule.
 func (htra *HostTagsRulesApi) UpdateRule(ctx context.Context, params UpdateRuleParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", htra.client.Server+"/api/v1.0/HostTagsRulesApi.UpdateRule", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := htra.client.Request(ctx, request, nil)
+	raw, err := htra.client.Call(ctx, "HostTagsRulesApi.UpdateRule", params, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // HostTasks service to basic management operations with host tasks.
@@ -41,16 +38,9 @@ type HostTasks service
 
 // GetNextTask Sequentially get task data.
 func (ht *HostTasks) GetNextTask(ctx context.Context, strSrvObjId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`
-	{
-	"strSrvObjId": "%s"
-	}`, strSrvObjId))
-	request, err := http.NewRequest("POST", ht.client.Server+"/api/v1.0/HostTasks.GetNextTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strSrvObjId": strSrvObjId}
 
-	raw, err := ht.client.Request(ctx, request, nil)
+	raw, err := ht.client.Call(ctx, "HostTasks.GetNextTask", postData, nil)
 	return raw, err
 }
 
@@ -61,20 +51,16 @@ func (ht *HostTasks) GetNextTask(ctx context.Context, strSrvObjId string) ([]byt
 // If one of the parameters is not specified then the filtration will not be performed by this parameter.
 func (ht *HostTasks) ResetTasksIterator(ctx context.Context, strSrvObjId, strProductName, strVersion,
 	strComponentName, strInstanceId, strTaskName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{
-		"strSrvObjId": "%s",
-		"strProductName": "%s",
-		"strVersion": "%s",
-		"strComponentName":	"%s",
-		"strInstanceId": "%s",
-		"strTaskName": "%s"
-	}`, strSrvObjId, strProductName, strVersion, strComponentName, strInstanceId, strTaskName))
-	request, err := http.NewRequest("POST", ht.client.Server+"/api/v1.0/HostTasks.ResetTasksIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"strSrvObjId":      strSrvObjId,
+		"strProductName":   strProductName,
+		"strVersion":       strVersion,
+		"strComponentName": strComponentName,
+		"strInstanceId":    strInstanceId,
+		"strTaskName":      strTaskName,
 	}
 
-	raw, err := ht.client.Request(ctx, request, nil)
+	raw, err := ht.client.Call(ctx, "HostTasks.ResetTasksIterator", postData, nil)
 	return raw, err
 }
 
@@ -0,0 +1,198 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestHostUnmarshalJSON(t *testing.T) {
+	data := `{"KLCSP_ITERATOR_ARRAY":[{"type":"params","value":{
+		"KLHST_WKS_DN":"WS1",
+		"KLHST_WKS_GROUPID":5,
+		"KLHST_WKS_IP_LONG":3232235777,
+		"KLHST_WKS_CONNECT_IP_LONG":{"type":"long","value":167772161},
+		"KLHST_WKS_LAST_VISIBLE":{"type":"datetime","value":"2021-01-02T03:04:05Z"},
+		"KLHST_WKS_STATUS_MASK_0":{"type":"long","value":5},
+		"KLHST_WKS_OS_VER_MAJOR":10,
+		"KLHST_WKS_OS_BUILD_NUMBER":19041,
+		"KLHST_WKS_KEEP_CONNECTION":true,
+		"KLHST_WKS_OWNER_ID":{"type":"binary","value":"AQID"},
+		"KLHST_WKS_RTP_STATE":3,
+		"KLHST_WKS_UNKNOWN":"ignored"
+	}},{"KLHST_WKS_DN":"WS2"}]}`
+
+	var out struct {
+		Hosts []Host `json:"KLCSP_ITERATOR_ARRAY"`
+	}
+	if err := json.Unmarshal([]byte(data), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Hosts) != 2 {
+		t.Fatalf("got %d hosts, want 2", len(out.Hosts))
+	}
+
+	h := out.Hosts[0]
+	want := Host{
+		DisplayName:    "WS1",
+		GroupID:        5,
+		IP:             net.IPv4(192, 168, 1, 1).To4(),
+		ConnectIP:      net.IPv4(10, 0, 0, 1).To4(),
+		LastVisible:    time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+		StatusMask:     5,
+		OSVerMajor:     10,
+		OSBuildNumber:  19041,
+		KeepConnection: true,
+		OwnerID:        []byte{1, 2, 3},
+		RtpState:       RTPState(3),
+	}
+	if !reflect.DeepEqual(h, want) {
+		t.Errorf("host = %+v, want %+v", h, want)
+	}
+	if v := h.OSVersion(); v != "10.0.19041" {
+		t.Errorf("OSVersion() = %q", v)
+	}
+	if out.Hosts[1].DisplayName != "WS2" {
+		t.Errorf("plain params host = %+v", out.Hosts[1])
+	}
+}
+
+func TestParamsDecode(t *testing.T) {
+	type nested struct {
+		N int32 `json:"n"`
+	}
+	type embedded struct {
+		E string `json:"e"`
+	}
+	var out struct {
+		embedded
+		Int     int8              `json:"int"`
+		Uint    uint16            `json:"uint"`
+		Float   float64           `json:"float"`
+		Plain   float32           `json:"plain"`
+		Time    time.Time         `json:"time"`
+		Date    DateTime          `json:"date"`
+		IP      net.IP            `json:"ip"`
+		IPStr   net.IP            `json:"ip_str"`
+		Ptr     *int64            `json:"ptr"`
+		Nested  nested            `json:"nested"`
+		Plainly nested            `json:"plain_object"`
+		Map     map[string]string `json:"map"`
+		Slice   []int64           `json:"slice"`
+		Skipped string            `json:"-"`
+		NoTag   bool
+	}
+
+	var p Params
+	data := `{
+		"e":"embedded",
+		"int":{"type":"long","value":-3},
+		"uint":7,
+		"float":2,
+		"plain":1.5,
+		"time":{"type":"datetime","value":"2021-01-02T03:04:05Z"},
+		"date":{"type":"datetime","value":"2021-01-02T03:04:05Z"},
+		"ip":{"type":"long","value":16909060},
+		"ip_str":"10.0.0.1",
+		"ptr":{"type":"long","value":9},
+		"nested":{"type":"params","value":{"n":4}},
+		"plain_object":{"n":5},
+		"map":{"type":"params","value":{"a":"b"}},
+		"slice":[1,{"type":"long","value":2}],
+		"-":"x",
+		"NoTag":true
+	}`
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	switch {
+	case out.E != "embedded", out.Int != -3, out.Uint != 7, out.Float != 2, out.Plain != 1.5:
+		t.Errorf("scalars = %+v", out)
+	case !out.Time.Equal(ts), !out.Date.Equal(ts):
+		t.Errorf("times = %v, %v", out.Time, out.Date)
+	case out.IP.String() != "1.2.3.4", out.IPStr.String() != "10.0.0.1":
+		t.Errorf("IPs = %v, %v", out.IP, out.IPStr)
+	case out.Ptr == nil || *out.Ptr != 9:
+		t.Errorf("Ptr = %v", out.Ptr)
+	case out.Nested.N != 4, out.Plainly.N != 5, out.Map["a"] != "b":
+		t.Errorf("objects = %+v, %+v, %v", out.Nested, out.Plainly, out.Map)
+	case !reflect.DeepEqual(out.Slice, []int64{1, 2}):
+		t.Errorf("Slice = %v", out.Slice)
+	case out.Skipped != "", !out.NoTag:
+		t.Errorf("tags = %q, %v", out.Skipped, out.NoTag)
+	}
+
+	if err := (Params{"int": "x"}).Decode(&out); err == nil {
+		t.Error("Decode() of string into int succeeded")
+	}
+	if err := p.Decode(out); err == nil {
+		t.Error("Decode() into non-pointer succeeded")
+	}
+}
+
+func TestIPLong(t *testing.T) {
+	ip := net.IPv4(192, 168, 1, 1)
+	if n := IPToLong(ip); n != 3232235777 {
+		t.Errorf("IPToLong(%v) = %d", ip, n)
+	}
+	if got := IPFromLong(3232235777); !got.Equal(ip) {
+		t.Errorf("IPFromLong() = %v, want %v", got, ip)
+	}
+	if n := IPToLong(net.ParseIP("::1")); n != 0 {
+		t.Errorf("IPToLong(IPv6) = %d, want 0", n)
+	}
+}
+
+func TestFieldsOf(t *testing.T) {
+	type base struct {
+		DN string `json:"KLHST_WKS_DN"`
+	}
+	type host struct {
+		base
+		IP       net.IP `json:"KLHST_WKS_IP_LONG,omitempty"`
+		Ignored  string `json:"-"`
+		internal string
+	}
+
+	want := []string{"KLHST_WKS_DN", "KLHST_WKS_IP_LONG"}
+	if got := FieldsOf(&host{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldsOf() = %v, want %v", got, want)
+	}
+	if got := FieldsOf(Host{}); len(got) == 0 || got[0] != KlhstWksDN {
+		t.Errorf("FieldsOf(Host{}) = %v", got)
+	}
+	if got := FieldsOf(1); got != nil {
+		t.Errorf("FieldsOf(1) = %v, want nil", got)
+	}
+}
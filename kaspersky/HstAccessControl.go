@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // HstAccessControl Security policy Allows to specify permissions for administration groups and non-group objects.
@@ -38,71 +34,46 @@ type HstAccessControl service
 // AccessCheckToAdmGroup Checks if current user session has access to the administration group.
 func (hac *HstAccessControl) AccessCheckToAdmGroup(ctx context.Context,
 	lGroupId, dwAccessMask int64, szwFuncArea, szwProduct, szwVersion string) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lGroupId":%d,"dwAccessMask":%d,"szwFuncArea":"%s","szwProduct":"%s", 
-	"szwVersion":"%s"}`, lGroupId, dwAccessMask, szwFuncArea, szwProduct, szwVersion))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.AccessCheckToAdmGroup", bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"lGroupId":     lGroupId,
+		"dwAccessMask": dwAccessMask,
+		"szwFuncArea":  szwFuncArea,
+		"szwProduct":   szwProduct,
+		"szwVersion":   szwVersion,
 	}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := hac.client.Request(ctx, request, &pxgValBool)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.AccessCheckToAdmGroup", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // AddRole A role can be added only at a main server.
 func (hac *HstAccessControl) AddRole(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.AddRole", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.AddRole", params, nil)
 	return raw, err
 }
 
 // DeleteRole Delete user role.
 func (hac *HstAccessControl) DeleteRole(ctx context.Context, nId int64, bProtection bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nId":%d,"bProtection":%v}`, nId, bProtection))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.DeleteRole", bytes.NewBuffer(postData))
+	postData := map[string]interface{}{"nId": nId, "bProtection": bProtection}
 
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.DeleteRole", postData, nil)
 	return raw, err
 }
 
 // DeleteScObjectAcl Deletes ACL for the specified object.
 func (hac *HstAccessControl) DeleteScObjectAcl(ctx context.Context, nObjId, nObjType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nObjId":%d,"nObjType":%d}`, nObjId, nObjType))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.DeleteScObjectAcl", bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nObjId": nObjId, "nObjType": nObjType}
 
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.DeleteScObjectAcl", postData, nil)
 	return raw, err
 }
 
 // DeleteScVServerAcl Deletes ACL for the specified virtual server.
 func (hac *HstAccessControl) DeleteScVServerAcl(ctx context.Context, nId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nId":%d}`, nId))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.DeleteScVServerAcl", bytes.NewBuffer(postData))
+	postData := map[string]interface{}{"nId": nId}
 
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.DeleteScVServerAcl", postData, nil)
 	return raw, err
 }
 
@@ -135,18 +106,8 @@ type RoleValue struct {
 
 // FindRoles Find roles by filter string.
 func (hac *HstAccessControl) FindRoles(ctx context.Context, params PFindParams) (*Accessor, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.FindRoles", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	accessor := new(Accessor)
-	raw, err := hac.client.Request(ctx, request, &accessor)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.FindRoles", params, &accessor)
 	return accessor, raw, err
 }
 
@@ -181,18 +142,8 @@ type KlhstACLTrusteeSid struct {
 
 // FindTrustees Searches for trustees meeting specified criteria.
 func (hac *HstAccessControl) FindTrustees(ctx context.Context, params PFindParams) (*Accessor, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.FindTrustees", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	accessor := new(Accessor)
-	raw, err := hac.client.Request(ctx, request, &accessor)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.FindTrustees", params, &accessor)
 	return accessor, raw, err
 }
 
@@ -206,150 +157,83 @@ type FuncAreas struct {
 // GetAccessibleFuncAreas Returns accessible functional areas.
 func (hac *HstAccessControl) GetAccessibleFuncAreas(ctx context.Context, lGroupId, dwAccessMask int64, szwProduct,
 	szwVersion string, bInvert bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lGroupId" : %d, "dwAccessMask": %d, "szwProduct": "%s", "szwVersion": "%s", 
-	"bInvert" : %v}`,
-		lGroupId, dwAccessMask, szwProduct, szwVersion, bInvert))
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetAccessibleFuncAreas",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"lGroupId":     lGroupId,
+		"dwAccessMask": dwAccessMask,
+		"szwProduct":   szwProduct,
+		"szwVersion":   szwVersion,
+		"bInvert":      bInvert,
 	}
 
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetAccessibleFuncAreas", postData, nil)
 	return raw, err
 }
 
 // GetMappingFuncAreaToPolicies Returns mapping functional area to policies.
 func (hac *HstAccessControl) GetMappingFuncAreaToPolicies(ctx context.Context, szwProduct, szwVersion string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProduct": "%s","szwVersion": "%s"}`, szwProduct, szwVersion))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetMappingFuncAreaToPolicies",
-		bytes.NewBuffer(postData))
+	postData := map[string]interface{}{"szwProduct": szwProduct, "szwVersion": szwVersion}
 
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetMappingFuncAreaToPolicies", postData, nil)
 	return raw, err
 }
 
 // GetMappingFuncAreaToReports Returns mapping functional area to reports.
 func (hac *HstAccessControl) GetMappingFuncAreaToReports(ctx context.Context, szwProduct, szwVersion string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProduct": "%s","szwVersion": "%s"}`, szwProduct, szwVersion))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetMappingFuncAreaToReports",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwProduct": szwProduct, "szwVersion": szwVersion}
 
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetMappingFuncAreaToReports", postData, nil)
 	return raw, err
 }
 
 // GetMappingFuncAreaToSettings Returns mapping functional area to settings.
 func (hac *HstAccessControl) GetMappingFuncAreaToSettings(ctx context.Context, szwProduct, szwVersion string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProduct": "%s","szwVersion": "%s"}`, szwProduct, szwVersion))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetMappingFuncAreaToSettings",
-		bytes.NewBuffer(postData))
+	postData := map[string]interface{}{"szwProduct": szwProduct, "szwVersion": szwVersion}
 
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetMappingFuncAreaToSettings", postData, nil)
 	return raw, err
 }
 
 // GetMappingFuncAreaToTasks Returns mapping functional area to tasks.
 func (hac *HstAccessControl) GetMappingFuncAreaToTasks(ctx context.Context, szwProduct, szwVersion string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProduct": "%s","szwVersion": "%s"}`, szwProduct, szwVersion))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetMappingFuncAreaToTasks",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwProduct": szwProduct, "szwVersion": szwVersion}
 
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetMappingFuncAreaToTasks", postData, nil)
 	return raw, err
 }
 
 // GetPolicyReadonlyNodes Returns array of paths for all nodes actually located in the specified policy section,
 // which are readonly for current user session.
 func (hac *HstAccessControl) GetPolicyReadonlyNodes(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetPolicyReadonlyNodes", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetPolicyReadonlyNodes", params, nil)
 	return raw, err
 }
 
 // GetRole Return parameters of a role.
 func (hac *HstAccessControl) GetRole(ctx context.Context, params TRParams) (*Trustee, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetRole", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	trustee := new(Trustee)
-	raw, err := hac.client.Request(ctx, request, &trustee)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetRole", params, &trustee)
 	return trustee, raw, err
 }
 
 // GetScObjectAcl Returns ACL for the specified object.
 func (hac *HstAccessControl) GetScObjectAcl(ctx context.Context, nObjId, nObjType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nObjId":%d,"nObjType":%d}`, nObjId, nObjType))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetScObjectAcl", bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nObjId": nObjId, "nObjType": nObjType}
 
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetScObjectAcl", postData, nil)
 	return raw, err
 }
 
 // GetScVServerAcl Returns ACL for the server.
 func (hac *HstAccessControl) GetScVServerAcl(ctx context.Context, nId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nId":%d}`, nId))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetScVServerAcl", bytes.NewBuffer(postData))
+	postData := map[string]interface{}{"nId": nId}
 
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetScVServerAcl", postData, nil)
 	return raw, err
 }
 
 // GetSettingsReadonlyNodes Returns array of paths for nodes from product's setting section, which are readonly for current user session.
 func (hac *HstAccessControl) GetSettingsReadonlyNodes(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetSettingsReadonlyNodes", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetSettingsReadonlyNodes", params, nil)
 	return raw, err
 }
 
@@ -369,32 +253,20 @@ type Trustee struct {
 
 // GetTrustee Get trustee data.
 func (hac *HstAccessControl) GetTrustee(ctx context.Context, params TRParams) (*Trustee, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetTrustee", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	trustee := new(Trustee)
-	raw, err := hac.client.Request(ctx, request, &trustee)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetTrustee", params, &trustee)
 	return trustee, raw, err
 }
 
 // GetVisualViewForAccessRights Returns descriptions of visual view for access rights in KSC.
 func (hac *HstAccessControl) GetVisualViewForAccessRights(ctx context.Context, wstrLangCode string, nObjId, nObjType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrLangCode": "%s","nObjId": %d,"nObjType": %d}`, wstrLangCode, nObjId, nObjType))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.GetVisualViewForAccessRights",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"wstrLangCode": wstrLangCode,
+		"nObjId":       nObjId,
+		"nObjType":     nObjType,
 	}
 
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.GetVisualViewForAccessRights", postData, nil)
 	return raw, err
 }
 
@@ -402,81 +274,39 @@ func (hac *HstAccessControl) GetVisualViewForAccessRights(ctx context.Context, w
 func (hac *HstAccessControl) IsTaskTypeReadonly(ctx context.Context, lGroupId int64, szwProduct, szwVersion,
 	szwTaskTypeName string) (*PxgValBool, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"lGroupId": %d,"szwProduct": "%s","szwVersion": "%s","szwTaskTypeName": "%s"}`,
-		lGroupId, szwProduct, szwVersion, szwTaskTypeName))
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.IsTaskTypeReadonly",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"lGroupId":        lGroupId,
+		"szwProduct":      szwProduct,
+		"szwVersion":      szwVersion,
+		"szwTaskTypeName": szwTaskTypeName,
 	}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := hac.client.Request(ctx, request, &pxgValBool)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.IsTaskTypeReadonly", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // ModifyScObjectAcl Modify ACL for the specified object. Method updates only Accounts, permissions and roles which presented in pAclParams.
 // To delete Ace from Acl, it must be added to 'delete' list.
 func (hac *HstAccessControl) ModifyScObjectAcl(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.ModifyScObjectAcl", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.ModifyScObjectAcl", params, nil)
 	return raw, err
 }
 
 // SetScObjectAcl Sets ACL for the specified object.
 func (hac *HstAccessControl) SetScObjectAcl(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.SetScObjectAcl", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.SetScObjectAcl", params, nil)
 	return raw, err
 }
 
 // SetScVServerAcl Set ACL for virtual server.
 func (hac *HstAccessControl) SetScVServerAcl(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.SetScVServerAcl", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.SetScVServerAcl", params, nil)
 	return raw, err
 }
 
 // UpdateRole Update user role.
 func (hac *HstAccessControl) UpdateRole(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", hac.client.Server+"/api/v1.0/HstAccessControl.UpdateRole", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := hac.client.Request(ctx, request, nil)
+	raw, err := hac.client.Call(ctx, "HstAccessControl.UpdateRole", params, nil)
 	return raw, err
 }
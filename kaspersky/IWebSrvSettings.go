@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // IWebSrvSettings service to working with Web server settings proxy.
@@ -40,54 +36,29 @@ type IWebSrvSettings service
 // If cert present then it return params with [["CERT_TYPE"] == 0 (PEM form)] and ["CERT_PUBLIC_PART"] fields.
 // In case if certificate not set, then it returns empty params with no any fields.
 func (iws *IWebSrvSettings) GetCertificateInfo(ctx context.Context) (*PxgValCIFIL, []byte, error) {
-	request, err := http.NewRequest("POST", iws.client.Server+"/api/v1.0/IWebSrvSettings.GetCertificateInfo", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValCIFIL := new(PxgValCIFIL)
-	raw, err := iws.client.Request(ctx, request, &pxgValCIFIL)
+	raw, err := iws.client.Call(ctx, "IWebSrvSettings.GetCertificateInfo", nil, &pxgValCIFIL)
 	return pxgValCIFIL, raw, err
 }
 
 // GetCustomPkgHttpFqdn. Returns custom HTTP FQDN.
 func (iws *IWebSrvSettings) GetCustomPkgHttpFqdn(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", iws.client.Server+"/api/v1.0/IWebSrvSettings.GetCustomPkgHttpFqdn", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := iws.client.Request(ctx, request, &pxgValStr)
+	raw, err := iws.client.Call(ctx, "IWebSrvSettings.GetCustomPkgHttpFqdn", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // SetCustomPkgHttpFqdn. Set's custom HTTP FQDN. It is useful for HTTP link generation.
 func (iws *IWebSrvSettings) SetCustomPkgHttpFqdn(ctx context.Context, wsFqdn string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wsFqdn": "%s"}`, wsFqdn))
-	request, err := http.NewRequest("POST", iws.client.Server+"/api/v1.0/IWebSrvSettings.SetCustomPkgHttpFqdn", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wsFqdn": wsFqdn}
 
-	raw, err := iws.client.Request(ctx, request, nil)
+	raw, err := iws.client.Call(ctx, "IWebSrvSettings.SetCustomPkgHttpFqdn", postData, nil)
 	return raw, err
 }
 
 // SetCustomCertificate. Sets custom certificate for Web Server's SSL listener.
 // FQDN name from certificate are used for HTTPS link generation.
 func (iws *IWebSrvSettings) SetCustomCertificate(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", iws.client.Server+"/api/v1.0/IWebSrvSettings.SetCustomCertificate",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := iws.client.Request(ctx, request, nil)
+	raw, err := iws.client.Call(ctx, "IWebSrvSettings.SetCustomCertificate", params, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // IWebUsersSrv service to operating with emails
@@ -50,13 +47,6 @@ type IWebUsersSrv service
 //
 // You can also css-style that div using its id inside of your html message body.
 func (iwus *IWebUsersSrv) SendEmail(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", iwus.client.Server+"/api/v1.0/IWebUsersSrv.SendEmail",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := iwus.client.Request(ctx, request, nil)
+	raw, err := iwus.client.Call(ctx, "IWebUsersSrv.SendEmail", params, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // IWebUsersSrv2 service to operating with emails from GUI
@@ -36,17 +33,6 @@ type IWebUsersSrv2 service
 
 // SendEmailAsync IWebUsersSrvProxy2::SendEmail GUI bridge.
 func (iwus2 *IWebUsersSrv2) SendEmailAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", iwus2.client.Server+"/api/v1.0/IWebUsersSrv2.SendEmailAsync",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := iwus2.client.Request(ctx, request, nil)
+	raw, err := iwus2.client.Call(ctx, "IWebUsersSrv2.SendEmailAsync", params, nil)
 	return raw, err
 }
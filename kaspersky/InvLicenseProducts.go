@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 //	InvLicenseProducts service to manage License Management (third party) Functionality.
@@ -60,39 +56,26 @@ type KlinvlicData struct {
 
 // GetLicenseProducts Acquire License Products data.
 func (ilp *InvLicenseProducts) GetLicenseProducts(ctx context.Context) (*LicenseKeysResponse, error) {
-	request, err := http.NewRequest("POST", ilp.client.Server+"/api/v1.0/InvLicenseProducts.GetLicenseProducts", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	licenseKeysResponse := new(LicenseKeysResponse)
-	_, err = ilp.client.Request(ctx, request, &licenseKeysResponse)
+	_, err := ilp.client.Call(ctx, "InvLicenseProducts.GetLicenseProducts", nil, &licenseKeysResponse)
 	return licenseKeysResponse, err
 }
 
 // DeleteLicenseKey Removes specified License Key.
 func (ilp *InvLicenseProducts) DeleteLicenseKey(ctx context.Context, nLicKeyId int64) (*PxgRetError, error) {
-	postData := []byte(fmt.Sprintf(`{"nLicKeyId": %d}`, nLicKeyId))
-	request, err := http.NewRequest("POST", ilp.client.Server+"/api/v1.0/InvLicenseProducts.DeleteLicenseKey", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nLicKeyId": nLicKeyId}
 
 	pxgRetError := new(PxgRetError)
-	_, err = ilp.client.Request(ctx, request, &pxgRetError)
+	_, err := ilp.client.Call(ctx, "InvLicenseProducts.DeleteLicenseKey", postData, &pxgRetError)
 	return pxgRetError, err
 }
 
 // DeleteLicenseProduct Removes specified License Product.
 func (ilp *InvLicenseProducts) DeleteLicenseProduct(ctx context.Context, nLicProdId int64) (*PxgRetError, error) {
-	postData := []byte(fmt.Sprintf(`{"nLicProdId": %d}`, nLicProdId))
-	request, err := http.NewRequest("POST", ilp.client.Server+"/api/v1.0/InvLicenseProducts.DeleteLicenseProduct", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nLicProdId": nLicProdId}
 
 	pxgRetError := new(PxgRetError)
-	_, err = ilp.client.Request(ctx, request, &pxgRetError)
+	_, err := ilp.client.Call(ctx, "InvLicenseProducts.DeleteLicenseProduct", postData, &pxgRetError)
 	return pxgRetError, err
 }
 
@@ -126,18 +109,8 @@ type KlinvlicKey struct {
 //	║ "KLINVLIC_KEY_INFO"            ║ paramString   ║ Description                           ║          ║
 //	╚════════════════════════════════╩═══════════════╩═══════════════════════════════════════╩══════════╝
 func (ilp *InvLicenseProducts) AddLicenseKey(ctx context.Context, params LicenseKeyParams) (*PxgValInt, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ilp.client.Server+"/api/v1.0/InvLicenseProducts.AddLicenseKey", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	_, err = ilp.client.Request(ctx, request, &pxgValInt)
+	_, err := ilp.client.Call(ctx, "InvLicenseProducts.AddLicenseKey", params, &pxgValInt)
 	return pxgValInt, err
 }
 
@@ -169,18 +142,8 @@ type KlinvlicMasksValue struct {
 
 // AddLicenseProduct Add a new License Product.
 func (ilp *InvLicenseProducts) AddLicenseProduct(ctx context.Context, params LicenseProductParams) (*PxgValInt, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ilp.client.Server+"/api/v1.0/InvLicenseProducts.AddLicenseProduct", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	_, err = ilp.client.Request(ctx, request, &pxgValInt)
+	_, err := ilp.client.Call(ctx, "InvLicenseProducts.AddLicenseProduct", params, &pxgValInt)
 	return pxgValInt, err
 }
 
@@ -195,17 +158,7 @@ type UpdateLicenseKeyParams struct {
 
 // UpdateLicenseKey Modifies attributes of specified License Key.
 func (ilp *InvLicenseProducts) UpdateLicenseKey(ctx context.Context, params UpdateLicenseKeyParams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", ilp.client.Server+"/api/v1.0/InvLicenseProducts.UpdateLicenseKey", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = ilp.client.Request(ctx, request, nil)
+	_, err := ilp.client.Call(ctx, "InvLicenseProducts.UpdateLicenseKey", params, nil)
 	return err
 }
 
@@ -220,16 +173,6 @@ type UpdateLicenseProductParams struct {
 
 // UpdateLicenseProduct Modifies attributes of specified License Product.
 func (ilp *InvLicenseProducts) UpdateLicenseProduct(ctx context.Context, params UpdateLicenseProductParams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", ilp.client.Server+"/api/v1.0/InvLicenseProducts.UpdateLicenseProduct", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = ilp.client.Request(ctx, request, nil)
+	_, err := ilp.client.Call(ctx, "InvLicenseProducts.UpdateLicenseProduct", params, nil)
 	return err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // InventoryAPI service for working with Software Inventory subsystem.
@@ -77,36 +72,20 @@ type HostProducts struct {
 
 // GetHostInvProducts Acquire all software applications.
 func (ia *InventoryAPI) GetHostInvProducts(ctx context.Context, szwHostID string) (*HostProducts, error) {
-	postData := []byte(fmt.Sprintf(`{"szwHostId": "%s"}`, szwHostID))
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.GetHostInvProducts", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwHostId": szwHostID}
 
 	result := new(HostProducts)
-	raw, err := ia.client.Request(ctx, request, &result)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ia.client.Call(ctx, "InventoryApi.GetHostInvProducts", postData, &result)
 
 	return result, err
 }
 
 // GetHostInvPatches Acquire software application updates which are installed on specified host.
 func (ia *InventoryAPI) GetHostInvPatches(ctx context.Context, szwHostID string) (*InvPatches, error) {
-	postData := []byte(fmt.Sprintf(`{"szwHostId": "%s"}`, szwHostID))
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.GetHostInvPatches", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwHostId": szwHostID}
 
 	result := new(InvPatches)
-	raw, err := ia.client.Request(ctx, request, &result)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ia.client.Call(ctx, "InventoryApi.GetHostInvPatches", postData, &result)
 
 	return result, err
 }
@@ -137,22 +116,8 @@ type InvPatches struct {
 
 // GetInvPatchesList Acquire all software application updates.
 func (ia *InventoryAPI) GetInvPatchesList(ctx context.Context, params Null) (*InvPatches, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.GetInvPatchesList", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(InvPatches)
-	raw, err := ia.client.Request(ctx, request, &result)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ia.client.Call(ctx, "InventoryApi.GetInvPatchesList", params, &result)
 
 	return result, err
 }
@@ -189,38 +154,15 @@ type InvProducts struct {
 
 // GetInvProductsList Acquire all software applications.
 func (ia *InventoryAPI) GetInvProductsList(ctx context.Context, params Null) (*InvProducts, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.GetInvProductsList", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(InvProducts)
-	raw, err := ia.client.Request(ctx, request, &result)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ia.client.Call(ctx, "InventoryApi.GetInvProductsList", params, &result)
 
 	return result, err
 }
 
 // DeleteUninstalledApps Remove from database info about software applications which aren't installed on any host.
 func (ia *InventoryAPI) DeleteUninstalledApps(ctx context.Context) error {
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.DeleteUninstalledApps", nil)
-	if err != nil {
-		return err
-	}
-
-	raw, err := ia.client.Request(ctx, request, nil)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ia.client.Call(ctx, "InventoryApi.DeleteUninstalledApps", nil, nil)
 
 	return err
 }
@@ -229,39 +171,18 @@ func (ia *InventoryAPI) DeleteUninstalledApps(ctx context.Context) error {
 // Returns info about cleaner ini-files of specified type from SC-server.
 // These files are used to detect and uninstall applications which incompatible with KasperskyLab antivirus applications
 func (ia *InventoryAPI) GetSrvCompetitorIniFileInfoList(ctx context.Context, wstrType string) (*PxgValCIFIL, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrType": "%s"}`, wstrType))
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.GetSrvCompetitorIniFileInfoList", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrType": wstrType}
 
 	result := new(PxgValCIFIL)
-	raw, err := ia.client.Request(ctx, request, &result)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ia.client.Call(ctx, "InventoryApi.GetSrvCompetitorIniFileInfoList", postData, &result)
 
 	return result, err
 }
 
 // GetObservedApps Acquire list of observed applications.
 func (ia *InventoryAPI) GetObservedApps(ctx context.Context, params Null) (*PxgValArrayOfString, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.GetObservedApps", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValArrayOfString)
-	raw, err := ia.client.Request(ctx, request, &result)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := ia.client.Call(ctx, "InventoryApi.GetObservedApps", params, &result)
 
 	return result, err
 }
@@ -276,20 +197,7 @@ type ObservedAppsParams struct {
 
 // SetObservedApps Set list of observed applications.
 func (ia *InventoryAPI) SetObservedApps(ctx context.Context, params ObservedAppsParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-	request, err := http.NewRequest("POST", ia.client.Server+"/api/v1.0/InventoryApi.SetObservedApps", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ia.client.Request(ctx, request, nil)
-
-	if ia.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := ia.client.Call(ctx, "InventoryApi.SetObservedApps", params, nil)
 
 	return raw, err
 }
@@ -0,0 +1,335 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // SHA-256 for RS256, PS256 and ES256
+	_ "crypto/sha512" // SHA-384 and SHA-512 for RS384, RS512, PS384, PS512, ES384 and ES512
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// JWK JSON Web Key (RFC 7517), only RSA and EC public keys are supported.
+type JWK struct {
+	Kid string   `json:"kid,omitempty"`
+	Kty string   `json:"kty"`
+	Alg string   `json:"alg,omitempty"`
+	Use string   `json:"use,omitempty"`
+	N   string   `json:"n,omitempty"`
+	E   string   `json:"e,omitempty"`
+	Crv string   `json:"crv,omitempty"`
+	X   string   `json:"x,omitempty"`
+	Y   string   `json:"y,omitempty"`
+	X5c []string `json:"x5c,omitempty"`
+}
+
+// JWKS JSON Web Key Set (RFC 7517).
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// ParseJWKS parses JSON Web Key Set.
+//
+// Besides the plain key set it accepts the response of AdfsSso.GetJwks,
+// where the key set is returned in PxgRetVal either as an object or as a JSON string.
+func ParseJWKS(data []byte) (*JWKS, error) {
+	var raw struct {
+		Keys      []JWK           `json:"keys"`
+		PxgRetVal json.RawMessage `json:"PxgRetVal"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("kaspersky: invalid JWKS: %w", err)
+	}
+
+	if len(raw.PxgRetVal) == 0 {
+		return &JWKS{Keys: raw.Keys}, nil
+	}
+
+	var s string
+	if json.Unmarshal(raw.PxgRetVal, &s) == nil {
+		return ParseJWKS([]byte(s))
+	}
+	return ParseJWKS(raw.PxgRetVal)
+}
+
+// key returns the key with the given id, or the only key of the set if kid is empty.
+func (s *JWKS) key(kid string) (*JWK, bool) {
+	if kid == "" && len(s.Keys) == 1 {
+		return &s.Keys[0], true
+	}
+
+	for i := range s.Keys {
+		if s.Keys[i].Kid == kid {
+			return &s.Keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// PublicKey returns *rsa.PublicKey or *ecdsa.PublicKey of the key.
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch {
+	case k.Kty == "RSA" && k.N != "" && k.E != "":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case k.Kty == "EC" && k.X != "" && k.Y != "":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("kaspersky: unsupported JWK curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case len(k.X5c) > 0:
+		der, err := base64.StdEncoding.DecodeString(k.X5c[0])
+		if err != nil {
+			return nil, err
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return nil, fmt.Errorf("kaspersky: unsupported JWK key type %q", k.Kty)
+}
+
+// JWTClaims registered claims of JSON Web Token used for validation.
+type JWTClaims struct {
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+}
+
+// Audience aud claim, which may be either a string or an array of strings.
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*a = Audience{s}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(a))
+}
+
+// Expiry returns expiration time of the token, zero if the token has no exp claim.
+func (c *JWTClaims) Expiry() time.Time {
+	if c.ExpiresAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// JWTValidation expected values of JSON Web Token claims.
+type JWTValidation struct {
+	// Issuer expected iss claim, not checked if empty
+	Issuer string
+
+	// Audience expected value contained in aud claim, not checked if empty
+	Audience string
+
+	// Leeway allowed clock skew for exp and nbf claims
+	Leeway time.Duration
+
+	// Now returns current time, time.Now is used if nil
+	Now func() time.Time
+}
+
+// ValidateJWT verifies signature of the compact serialized JSON Web Token with the key set
+// and validates exp, nbf, iss and aud claims. RS*, PS* and ES* algorithms are supported.
+// The token algorithm must match alg of the key if the key specifies one.
+//
+// Failures are returned as errors wrapping ErrInvalidToken.
+func ValidateJWT(token string, keys *JWKS, v JWTValidation) (*JWTClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, invalidToken("malformed token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, invalidToken("malformed header: %v", err)
+	}
+
+	claims := new(JWTClaims)
+	if err := decodeJWTPart(parts[1], claims); err != nil {
+		return nil, invalidToken("malformed claims: %v", err)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, invalidToken("malformed signature: %v", err)
+	}
+
+	if keys == nil {
+		return nil, invalidToken("no keys")
+	}
+	jwk, ok := keys.key(header.Kid)
+	if !ok {
+		return nil, invalidToken("unknown key %q", header.Kid)
+	}
+	if jwk.Alg != "" && jwk.Alg != header.Alg {
+		return nil, invalidToken("algorithm %q doesn't match key algorithm %q", header.Alg, jwk.Alg)
+	}
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return nil, invalidToken("key %q is not a signing key", jwk.Kid)
+	}
+	pub, err := jwk.PublicKey()
+	if err != nil {
+		return nil, invalidToken("%v", err)
+	}
+
+	if err := verifyJWS(header.Alg, pub, []byte(parts[0]+"."+parts[1]), sig); err != nil {
+		return nil, err
+	}
+
+	now := time.Now
+	if v.Now != nil {
+		now = v.Now
+	}
+	t := now()
+
+	if claims.ExpiresAt != 0 && !t.Before(claims.Expiry().Add(v.Leeway)) {
+		return nil, invalidToken("token is expired")
+	}
+	if claims.NotBefore != 0 && t.Add(v.Leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, invalidToken("token is not valid yet")
+	}
+	if v.Issuer != "" && claims.Issuer != v.Issuer {
+		return nil, invalidToken("unexpected issuer %q", claims.Issuer)
+	}
+	if v.Audience != "" && !claims.Audience.contains(v.Audience) {
+		return nil, invalidToken("unexpected audience %q", []string(claims.Audience))
+	}
+	return claims, nil
+}
+
+func (a Audience) contains(s string) bool {
+	for _, aud := range a {
+		if aud == s {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidToken(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidToken}, args...)...)
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// verifyJWS verifies JWS signature sig of signed data with the public key.
+func verifyJWS(alg string, pub crypto.PublicKey, signed, sig []byte) error {
+	if len(alg) != 5 {
+		return invalidToken("unsupported algorithm %q", alg)
+	}
+
+	var hash crypto.Hash
+	switch alg[2:] {
+	case "256":
+		hash = crypto.SHA256
+	case "384":
+		hash = crypto.SHA384
+	case "512":
+		hash = crypto.SHA512
+	default:
+		return invalidToken("unsupported algorithm %q", alg)
+	}
+
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		var err error
+		switch alg[:2] {
+		case "RS":
+			err = rsa.VerifyPKCS1v15(key, hash, digest, sig)
+		case "PS":
+			err = rsa.VerifyPSS(key, hash, digest, sig, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		default:
+			return invalidToken("algorithm %q doesn't match RSA key", alg)
+		}
+		if err != nil {
+			return invalidToken("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		size := (key.Curve.Params().BitSize + 7) / 8
+		if alg[:2] != "ES" || len(sig) != 2*size {
+			return invalidToken("algorithm %q doesn't match EC key", alg)
+		}
+		r, s := new(big.Int).SetBytes(sig[:size]), new(big.Int).SetBytes(sig[size:])
+		if !ecdsa.Verify(key, digest, r, s) {
+			return invalidToken("invalid signature")
+		}
+	default:
+		return invalidToken("unsupported key type %T", pub)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// testKeys keys used to sign test tokens, generated once per test binary.
+var testKeys = func() struct {
+	rsa, other *rsa.PrivateKey
+	ec         *ecdsa.PrivateKey
+} {
+	var k struct {
+		rsa, other *rsa.PrivateKey
+		ec         *ecdsa.PrivateKey
+	}
+	k.rsa, _ = rsa.GenerateKey(rand.Reader, 2048)
+	k.other, _ = rsa.GenerateKey(rand.Reader, 2048)
+	k.ec, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	return k
+}()
+
+func b64url(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+// padded returns big-endian bytes of n left-padded to size.
+func padded(n *big.Int, size int) []byte {
+	b := n.Bytes()
+	return append(make([]byte, size-len(b)), b...)
+}
+
+func rsaJWK(kid string, k *rsa.PrivateKey) JWK {
+	return JWK{Kid: kid, Kty: "RSA", N: b64url(k.N.Bytes()), E: b64url(big.NewInt(int64(k.E)).Bytes())}
+}
+
+func ecJWK(kid string, k *ecdsa.PrivateKey) JWK {
+	return JWK{Kid: kid, Kty: "EC", Crv: "P-256", X: b64url(padded(k.X, 32)), Y: b64url(padded(k.Y, 32))}
+}
+
+// signJWT returns a token with the claims signed by key with RS256 or ES256.
+func signJWT(t *testing.T, alg, kid string, key crypto.Signer, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64url(header) + "." + b64url(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var sig []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = append(padded(r, 32), padded(s, 32)...)
+	}
+	return signed + "." + b64url(sig)
+}
+
+func TestParseJWKS(t *testing.T) {
+	for _, data := range []string{
+		`{"keys":[{"kid":"k1","kty":"RSA"}]}`,
+		`{"PxgRetVal":"{\"keys\":[{\"kid\":\"k1\",\"kty\":\"RSA\"}]}"}`,
+	} {
+		keys, err := ParseJWKS([]byte(data))
+		if err != nil {
+			t.Fatalf("ParseJWKS(%s): %v", data, err)
+		}
+		if _, ok := keys.key("k1"); !ok {
+			t.Errorf("ParseJWKS(%s) = %+v, want key k1", data, keys)
+		}
+	}
+}
+
+func TestValidateJWT(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	keys := &JWKS{Keys: []JWK{rsaJWK("r1", testKeys.rsa), ecJWK("e1", testKeys.ec)}}
+	rs256 := rsaJWK("rs", testKeys.rsa)
+	rs256.Alg = "RS256"
+	ps256 := rsaJWK("ps", testKeys.rsa)
+	ps256.Alg = "PS256"
+	enc := rsaJWK("enc", testKeys.rsa)
+	enc.Use = "enc"
+	keys.Keys = append(keys.Keys, rs256, ps256, enc)
+
+	v := JWTValidation{Issuer: "idp", Audience: "urn:ksc", Leeway: 30 * time.Second, Now: func() time.Time { return now }}
+	claims := func(exp time.Time, aud interface{}) map[string]interface{} {
+		return map[string]interface{}{"iss": "idp", "aud": aud, "sub": "svc", "exp": exp.Unix()}
+	}
+	valid := claims(now.Add(time.Hour), "urn:ksc")
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid RSA", signJWT(t, "RS256", "r1", testKeys.rsa, valid), false},
+		{"valid EC", signJWT(t, "ES256", "e1", testKeys.ec, valid), false},
+		{"valid key alg", signJWT(t, "RS256", "rs", testKeys.rsa, valid), false},
+		{"audience list", signJWT(t, "RS256", "r1", testKeys.rsa, claims(now.Add(time.Hour), []string{"other", "urn:ksc"})), false},
+		{"expired within leeway", signJWT(t, "RS256", "r1", testKeys.rsa, claims(now.Add(-10*time.Second), "urn:ksc")), false},
+		{"expired", signJWT(t, "RS256", "r1", testKeys.rsa, claims(now.Add(-time.Minute), "urn:ksc")), true},
+		{"wrong audience", signJWT(t, "RS256", "r1", testKeys.rsa, claims(now.Add(time.Hour), "urn:other")), true},
+		{"wrong issuer", signJWT(t, "RS256", "r1", testKeys.rsa, map[string]interface{}{"iss": "evil", "aud": "urn:ksc"}), true},
+		{"wrong key", signJWT(t, "RS256", "r1", testKeys.other, valid), true},
+		{"unknown kid", signJWT(t, "RS256", "r2", testKeys.rsa, valid), true},
+		{"alg mismatch", signJWT(t, "RS256", "ps", testKeys.rsa, valid), true},
+		{"alg of another key type", signJWT(t, "ES256", "r1", testKeys.ec, valid), true},
+		{"encryption key", signJWT(t, "RS256", "enc", testKeys.rsa, valid), true},
+		{"alg none", b64url([]byte(`{"alg":"none","kid":"r1"}`)) + "." + b64url([]byte(`{"aud":"urn:ksc"}`)) + ".", true},
+		{"malformed", "a.b", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateJWT(tt.token, keys, v)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidToken) {
+					t.Errorf("ValidateJWT() error = %v, want ErrInvalidToken", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ValidateJWT() error = %v", err)
+			}
+			if got.Subject != "svc" {
+				t.Errorf("ValidateJWT() subject = %q, want svc", got.Subject)
+			}
+		})
+	}
+}
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 //	KLEVerControl service to controls the possibility to download and automatically create installation packages.
@@ -37,58 +33,30 @@ type KLEVerControl service
 
 // CancelDownloadDistributive Cancel asynchronous operation DownloadDistributiveAsync.
 func (kvc *KLEVerControl) CancelDownloadDistributive(ctx context.Context, wstrRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", kvc.client.Server+"/api/v1.0/KLEVerControl.CancelDownloadDistributive", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := kvc.client.Request(ctx, request, nil)
+	raw, err := kvc.client.Call(ctx, "KLEVerControl.CancelDownloadDistributive", postData, nil)
 	return raw, err
 }
 
 // GetDownloadDistributiveResult Get result of asynchronous operation DownloadDistributiveAsync.
 func (kvc *KLEVerControl) GetDownloadDistributiveResult(ctx context.Context, wstrRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", kvc.client.Server+"/api/v1.0/KLEVerControl.GetDownloadDistributiveResult", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := kvc.client.Request(ctx, request, nil)
+	raw, err := kvc.client.Call(ctx, "KLEVerControl.GetDownloadDistributiveResult", postData, nil)
 	return raw, err
 }
 
 // ChangeCreatePackage Initiate or cancel distributives downloading and installation packages registration from KL public distributives storage.
 // The distributives are identified by "db_loc_id" from the appropriate SrvView Kaspersky Lab corporate product distributives available for download.
 func (kvc *KLEVerControl) ChangeCreatePackage(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", kvc.client.Server+"/api/v1.0/KLEVerControl.ChangeCreatePackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := kvc.client.Request(ctx, request, nil)
+	raw, err := kvc.client.Call(ctx, "KLEVerControl.ChangeCreatePackage", params, nil)
 	return raw, err
 }
 
 // DownloadDistributiveAsync Initiate downloading of the distributive by URL into SC-server.
 // Method is needed to download distributive by URL into SC-server. After that the distributive will be available to downloading from SC-server.
 func (kvc *KLEVerControl) DownloadDistributiveAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", kvc.client.Server+"/api/v1.0/KLEVerControl.DownloadDistributiveAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := kvc.client.Request(ctx, request, nil)
+	raw, err := kvc.client.Call(ctx, "KLEVerControl.DownloadDistributiveAsync", params, nil)
 	return raw, err
 }
@@ -25,17 +25,21 @@
 package kaspersky
 
 import (
+	"bytes"
 	"compress/gzip"
 	"context"
 	"crypto/tls"
-	"encoding/base64"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
-
 	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type Config struct {
@@ -48,133 +52,250 @@ type Config struct {
 	XKscSession        bool
 	InsecureSkipVerify bool
 	Debug              bool
+
+	// Logger structured logger for requests, if nil and Debug is set the standard logger of the log package is used.
+	// With Debug set request and response bodies are logged too. Secrets are redacted in both cases.
+	Logger Logger
+
+	// RateLimit limits rate and concurrency of all requests to the server
+	RateLimit RateLimit
+
+	// MethodLimits additional limits for particular methods ("ReportManager.ExecuteReportAsync")
+	// or whole services ("HWInvStorage"), applied together with RateLimit.
+	MethodLimits map[string]RateLimit
+
+	// Tracer starts a span around every OpenAPI call, nil disables tracing
+	Tracer Tracer
+
+	// Metrics records latency and errors of every OpenAPI call, nil disables metrics
+	Metrics Metrics
+
+	// Credentials provider of credentials for BasicAuth.
+	// If nil, UserName, Password, Domain, InternalUser and VServerName are used.
+	Credentials CredentialProvider
+
+	// RetryPolicy retry policy for transient transport failures, nil disables retries
+	RetryPolicy *RetryPolicy
+
+	// HTTPClient custom HTTP client. If set, the transport options below (except Middlewares) are ignored.
+	HTTPClient *http.Client
+
+	// Middlewares chain of http.RoundTripper wrappers, the first one is the outermost
+	Middlewares []Middleware
+
+	// TLSConfig base TLS configuration, InsecureSkipVerify, RootCAs and PinnedCertSHA256 are applied over it
+	TLSConfig *tls.Config
+
+	// RootCAs certificate authorities used to verify KSC server certificate, see LoadCertPool
+	RootCAs *x509.CertPool
+
+	// PinnedCertSHA256 hex encoded SHA-256 fingerprints of accepted KSC server certificates, see CertFingerprint.
+	// Pinning is checked in addition to the chain verification, use it with InsecureSkipVerify for self-signed certificates.
+	PinnedCertSHA256 []string
+
+	// Proxy returns a proxy for the given request, e.g. http.ProxyFromEnvironment or http.ProxyURL
+	Proxy func(*http.Request) (*url.URL, error)
+
+	// DialTimeout maximum amount of time a dial will wait for a connect to complete
+	DialTimeout time.Duration
+
+	// TLSHandshakeTimeout maximum amount of time to wait for a TLS handshake
+	TLSHandshakeTimeout time.Duration
+
+	// IdleConnTimeout maximum amount of time an idle connection will remain idle before closing itself
+	IdleConnTimeout time.Duration
+
+	// MaxResponseSize maximum size of a (decompressed) response body in bytes, 0 means unlimited.
+	// Larger responses fail with ErrResponseTooLarge.
+	MaxResponseSize int64
+
+	// KeepAlive interval of Session.Ping calls which keep the session alive between requests, 0 disables keepalive.
+	// It should be less than the session idle timeout of the server (3 minutes by default).
+	KeepAlive time.Duration
+
+	// OnSessionLost is called when the session is expired and re-authentication has failed.
+	OnSessionLost func(err error)
+
+	// OAuth2 settings of OAuth2Auth authentication
+	OAuth2 *OAuth2Config
+
+	// DiscardRawResponse skips keeping a raw []byte copy of responses which are decoded into a result,
+	// such methods return nil instead of the raw body.
+	DiscardRawResponse bool
 }
 
 // KscClient -------------Client------------------
+//
+// KscClient is safe for concurrent use by multiple goroutines.
 type KscClient struct {
-	AdfsSso                                                           *AdfsSso
-	AdHosts                                                           *AdHosts
-	AdmServerSettings                                                 *AdmServerSettings
-	AdSecManager                                                      *AdSecManager
-	AppCtrlAPI                                                        *AppCtrlApi
-	AKPatches                                                         *AKPatches
-	AsyncActionStateChecker                                           *AsyncActionStateChecker
-	CertPoolCtrl                                                      *CertPoolCtrl
-	CertPoolCtrl2                                                     *CertPoolCtrl2
-	CertUtils                                                         *CertUtils
-	CgwHelper                                                         *CgwHelper
-	ChunkAccessor                                                     *ChunkAccessor
-	CloudAccess                                                       *CloudAccess
-	ConEvents                                                         *ConEvents
-	DatabaseInfo                                                      *DatabaseInfo
-	DataProtectionAPI                                                 *DataProtectionApi
-	DpeKeyService                                                     *DpeKeyService
-	EventNotificationProperties                                       *EventNotificationProperties
-	EventNotificationsAPI                                             *EventNotificationsApi
-	EventProcessing                                                   *EventProcessing
-	EventProcessingFactory                                            *EventProcessingFactory
-	ExtAud                                                            *ExtAud
-	ExtTenant                                                         *ExtTenant
-	FileCategorizer2                                                  *FileCategorizer2
-	FilesAcceptor                                                     *FilesAcceptor
-	GatewayConnection                                                 *GatewayConnection
-	Gcm                                                               *Gcm
-	GroupSync                                                         *GroupSync
-	HostGroup                                                         *HostGroup
-	HostMoveRules                                                     *HostMoveRules
-	HostTagsAPI                                                       *HostTagsApi
-	HostTagsRulesAPI                                                  *HostTagsRulesApi
-	HostTasks                                                         *HostTasks
-	HstAccessControl                                                  *HstAccessControl
-	HWInvStorage                                                      *HWInvStorage
-	GroupSyncIterator                                                 *GroupSyncIterator
-	GroupTaskControlAPI                                               *GroupTaskControlApi
-	GuiContext                                                        *GuiContext
-	InventoryAPI                                                      *InventoryAPI
-	InvLicenseProducts                                                *InvLicenseProducts
-	IWebSrvSettings                                                   *IWebSrvSettings
-	IWebUsersSrv                                                      *IWebUsersSrv
-	IWebUsersSrv2                                                     *IWebUsersSrv2
-	KeyService                                                        *KeyService
-	KeyService2                                                       *KeyService2
-	KillChain                                                         *KillChain
-	KLEVerControl                                                     *KLEVerControl
-	KsnInternal                                                       *KsnInternal
-	LicenseInfoSync                                                   *LicenseInfoSync
-	LicenseKeys                                                       *LicenseKeys
-	LicensePolicy                                                     *LicensePolicy
-	Limits                                                            *Limits
-	ListTags                                                          *ListTags
-	MfaCache                                                          *MfaCache
-	MdmCertCtrlApi                                                    *MdmCertCtrlApi
-	MfaCacheInner                                                     *MfaCacheInner
-	MfaCacheInnerTest                                                 *MfaCacheInnerTest
-	MigrationData                                                     *MigrationData
-	ModulesIntegrityCheck                                             *ModulesIntegrityCheck
-	Multitenancy                                                      *Multitenancy
-	NagCgwHelper                                                      *NagCgwHelper
-	NagGuiCalls                                                       *NagGuiCalls
-	NagHstCtl                                                         *NagHstCtl
-	NagNetworkListAPI                                                 *NagNetworkListApi
-	NagRdu                                                            *NagRdu
-	NagRemoteScreen                                                   *NagRemoteScreen
-	NetUtils                                                          *NetUtils
-	NlaDefinedNetworks                                                *NlaDefinedNetworks
-	OAuth2                                                            *OAuth2
-	OsVersion                                                         *OsVersion
-	PackagesAPI                                                       *PackagesApi
-	PatchParameters                                                   *PatchParameters
-	PLCDevAPI                                                         *PLCDevApi
-	PluginData                                                        *PluginData
-	PluginDataStorage                                                 *PluginDataStorage
-	Policy                                                            *Policy
-	PolicyProfiles                                                    *PolicyProfiles
-	ProductBackendIntegration                                         *ProductBackendIntegration
-	ProductUserTokenIssuer                                            *ProductUserTokenIssuer
-	QueriesStorage                                                    *QueriesStorage
-	QBTNetworkListAPI                                                 *QBTNetworkListApi
-	ReportManager                                                     *ReportManager
-	RetrFiles                                                         *RetrFiles
-	ScanDiapasons                                                     *ScanDiapasons
-	SeamlessUpdatesTestAPI                                            *SeamlessUpdatesTestApi
-	SecurityPolicy                                                    *SecurityPolicy
-	SecurityPolicy3                                                   *SecurityPolicy3
-	ServerHierarchy                                                   *ServerHierarchy
-	ServerTransportSettings                                           *ServerTransportSettings
-	ServiceNwcCommandProvider                                         *ServiceNwcCommandProvider
-	ServiceNwcDeployment                                              *ServiceNwcDeployment
-	Session                                                           *Session
-	SiemExport                                                        *SiemExport
-	SmsQueue                                                          *SmsQueue
-	SmsSenders                                                        *SmsSenders
-	SpamEvents                                                        *SpamEvents
-	SrvCloud                                                          *SrvCloud
-	SrvCloudStat                                                      *SrvCloudStat
-	SrvIpmNewsAndStatistics                                           *SrvIpmNewsAndStatistics
-	SrvRi                                                             *SrvRi
-	SrvSsRevision                                                     *SrvSsRevision
-	SrvView                                                           *SrvView
-	SsContents                                                        *SsContents
-	SsRevisionGetNames                                                *SsRevisionGetNames
-	SubnetMasks                                                       *SubnetMasks
-	Tasks                                                             *Tasks
-	TotpGlobalSettings                                                *TotpGlobalSettings
-	TotpRegistration                                                  *TotpRegistration
-	TotpUserSettings                                                  *TotpUserSettings
-	TrafficManager                                                    *TrafficManager
-	UaControl                                                         *UaControl
-	Updates                                                           *Updates
-	UpdComps                                                          *UpdComps
-	UserDevicesAPI                                                    *UserDevicesApi
-	VapmControlAPI                                                    *VapmControlApi
-	UserName, Password, Server, VServerName, XKscSessionToken, Domain string
-	XKscSession, InsecureSkipVerify, InternalUser                     bool
-	VServers                                                          *VServers
-	VServers2                                                         *VServers2
-	WolSender                                                         *WolSender
-	client                                                            *http.Client
-	common                                                            service
-	Debug                                                             bool
+	AdfsSso                         *AdfsSso
+	AdHosts                         *AdHosts
+	AdmServerSettings               *AdmServerSettings
+	AdSecManager                    *AdSecManager
+	AppCtrlAPI                      *AppCtrlApi
+	AKPatches                       *AKPatches
+	AsyncActionStateChecker         *AsyncActionStateChecker
+	CertPoolCtrl                    *CertPoolCtrl
+	CertPoolCtrl2                   *CertPoolCtrl2
+	CertUtils                       *CertUtils
+	CgwHelper                       *CgwHelper
+	ChunkAccessor                   *ChunkAccessor
+	CloudAccess                     *CloudAccess
+	ConEvents                       *ConEvents
+	DatabaseInfo                    *DatabaseInfo
+	DataProtectionAPI               *DataProtectionApi
+	DpeKeyService                   *DpeKeyService
+	EventNotificationProperties     *EventNotificationProperties
+	EventNotificationsAPI           *EventNotificationsApi
+	EventProcessing                 *EventProcessing
+	EventProcessingFactory          *EventProcessingFactory
+	ExtAud                          *ExtAud
+	ExtTenant                       *ExtTenant
+	FileCategorizer2                *FileCategorizer2
+	FilesAcceptor                   *FilesAcceptor
+	GatewayConnection               *GatewayConnection
+	Gcm                             *Gcm
+	GroupSync                       *GroupSync
+	HostGroup                       *HostGroup
+	HostMoveRules                   *HostMoveRules
+	HostTagsAPI                     *HostTagsApi
+	HostTagsRulesAPI                *HostTagsRulesApi
+	HostTasks                       *HostTasks
+	HstAccessControl                *HstAccessControl
+	HWInvStorage                    *HWInvStorage
+	GroupSyncIterator               *GroupSyncIterator
+	GroupTaskControlAPI             *GroupTaskControlApi
+	GuiContext                      *GuiContext
+	InventoryAPI                    *InventoryAPI
+	InvLicenseProducts              *InvLicenseProducts
+	IWebSrvSettings                 *IWebSrvSettings
+	IWebUsersSrv                    *IWebUsersSrv
+	IWebUsersSrv2                   *IWebUsersSrv2
+	KeyService                      *KeyService
+	KeyService2                     *KeyService2
+	KillChain                       *KillChain
+	KLEVerControl                   *KLEVerControl
+	KsnInternal                     *KsnInternal
+	LicenseInfoSync                 *LicenseInfoSync
+	LicenseKeys                     *LicenseKeys
+	LicensePolicy                   *LicensePolicy
+	Limits                          *Limits
+	ListTags                        *ListTags
+	MfaCache                        *MfaCache
+	MdmCertCtrlApi                  *MdmCertCtrlApi
+	MfaCacheInner                   *MfaCacheInner
+	MfaCacheInnerTest               *MfaCacheInnerTest
+	MigrationData                   *MigrationData
+	ModulesIntegrityCheck           *ModulesIntegrityCheck
+	Multitenancy                    *Multitenancy
+	NagCgwHelper                    *NagCgwHelper
+	NagGuiCalls                     *NagGuiCalls
+	NagHstCtl                       *NagHstCtl
+	NagNetworkListAPI               *NagNetworkListApi
+	NagRdu                          *NagRdu
+	NagRemoteScreen                 *NagRemoteScreen
+	NetUtils                        *NetUtils
+	NlaDefinedNetworks              *NlaDefinedNetworks
+	OAuth2                          *OAuth2
+	OsVersion                       *OsVersion
+	PackagesAPI                     *PackagesApi
+	PatchParameters                 *PatchParameters
+	PLCDevAPI                       *PLCDevApi
+	PluginData                      *PluginData
+	PluginDataStorage               *PluginDataStorage
+	Policy                          *Policy
+	PolicyProfiles                  *PolicyProfiles
+	ProductBackendIntegration       *ProductBackendIntegration
+	ProductUserTokenIssuer          *ProductUserTokenIssuer
+	QueriesStorage                  *QueriesStorage
+	QBTNetworkListAPI               *QBTNetworkListApi
+	ReportManager                   *ReportManager
+	RetrFiles                       *RetrFiles
+	ScanDiapasons                   *ScanDiapasons
+	SeamlessUpdatesTestAPI          *SeamlessUpdatesTestApi
+	SecurityPolicy                  *SecurityPolicy
+	SecurityPolicy3                 *SecurityPolicy3
+	ServerHierarchy                 *ServerHierarchy
+	ServerTransportSettings         *ServerTransportSettings
+	ServiceNwcCommandProvider       *ServiceNwcCommandProvider
+	ServiceNwcDeployment            *ServiceNwcDeployment
+	Session                         *Session
+	SiemExport                      *SiemExport
+	SmsQueue                        *SmsQueue
+	SmsSenders                      *SmsSenders
+	SpamEvents                      *SpamEvents
+	SrvCloud                        *SrvCloud
+	SrvCloudStat                    *SrvCloudStat
+	SrvIpmNewsAndStatistics         *SrvIpmNewsAndStatistics
+	SrvRi                           *SrvRi
+	SrvSsRevision                   *SrvSsRevision
+	SrvView                         *SrvView
+	SsContents                      *SsContents
+	SsRevisionGetNames              *SsRevisionGetNames
+	SubnetMasks                     *SubnetMasks
+	Tasks                           *Tasks
+	TotpGlobalSettings              *TotpGlobalSettings
+	TotpRegistration                *TotpRegistration
+	TotpUserSettings                *TotpUserSettings
+	TrafficManager                  *TrafficManager
+	UaControl                       *UaControl
+	Updates                         *Updates
+	UpdComps                        *UpdComps
+	UserDevicesAPI                  *UserDevicesApi
+	VapmControlAPI                  *VapmControlApi
+	Server                          string
+	XKscSession, InsecureSkipVerify bool
+	VServers                        *VServers
+	VServers2                       *VServers2
+	WolSender                       *WolSender
+	client                          *http.Client
+	common                          service
+	Debug                           bool
+
+	// Deprecated: UserName, Password, Domain and InternalUser are copied from Config and aren't used for authentication,
+	// credentials are read from Config.Credentials on every login. VServerName is the virtual server of the last login.
+	UserName, Password, Domain, VServerName string
+	InternalUser                            bool
+
+	// Deprecated: XKscSessionToken is updated without synchronization and isn't safe for concurrent use, use SessionToken.
+	XKscSessionToken string
+
+	// authMu serializes Login and transparent re-authentication
+	authMu sync.Mutex
+	// authGen is incremented after every successful authentication
+	authGen   uint32
+	authType  AuthType
+	authToken string
+	loggedIn  bool
+
+	keepAlive         *keepAlive
+	oauth2            *oauth2Source
+	oauth2Refresh     *time.Timer
+	keepAliveInterval time.Duration
+	onSessionLost     func(err error)
+
+	credentials CredentialProvider
+	logger      Logger
+	tracer      Tracer
+	metrics     Metrics
+
+	// sessionMu guards sessionToken, vServerName and vServerSessions
+	sessionMu    sync.RWMutex
+	sessionToken string
+	vServerName  string
+	// vServerSessions session tokens of virtual servers used with WithVServerSession
+	vServerSessions map[string]string
+
+	retryPolicy *RetryPolicy
+
+	maxResponseSize int64
+	discardRaw      bool
+
+	limiter        *limiter
+	methodLimiters map[string]*limiter
+
+	// cfg configuration the client was created with, see Fork
+	cfg Config
 }
 
 type service struct {
@@ -182,22 +303,62 @@ type service struct {
 }
 
 func NewKscClient(cfg Config) *KscClient {
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: cfg.InsecureSkipVerify},
-		},
+	credentials := cfg.Credentials
+	if credentials == nil {
+		credentials = StaticCredentials{
+			UserName:     cfg.UserName,
+			Password:     cfg.Password,
+			Domain:       cfg.Domain,
+			InternalUser: cfg.InternalUser,
+			VServerName:  cfg.VServerName,
+		}
 	}
 
 	ksc := &KscClient{
-		client:       httpClient,
-		Server:       cfg.Server,
-		UserName:     cfg.UserName,
-		Password:     cfg.Password,
-		Domain:       cfg.Domain,
-		InternalUser: cfg.InternalUser,
-		VServerName:  cfg.VServerName,
-		XKscSession:  cfg.XKscSession,
-		Debug:        cfg.Debug,
+		client:             newHTTPClient(cfg),
+		Server:             cfg.Server,
+		XKscSession:        cfg.XKscSession,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+		Debug:              cfg.Debug,
+		UserName:           cfg.UserName,
+		Password:           cfg.Password,
+		Domain:             cfg.Domain,
+		InternalUser:       cfg.InternalUser,
+		VServerName:        cfg.VServerName,
+		credentials:        credentials,
+		retryPolicy:        cfg.RetryPolicy,
+		maxResponseSize:    cfg.MaxResponseSize,
+		discardRaw:         cfg.DiscardRawResponse,
+		keepAliveInterval:  cfg.KeepAlive,
+		onSessionLost:      cfg.OnSessionLost,
+		vServerSessions:    make(map[string]string),
+		cfg:                cfg,
+	}
+
+	ksc.oauth2 = newOAuth2Source(cfg.OAuth2, ksc.client)
+
+	logger := cfg.Logger
+	if logger == nil {
+		if cfg.Debug {
+			logger = NewStdLogger(nil, LevelDebug)
+		} else {
+			logger = nopLogger{}
+		}
+	}
+	ksc.logger = &redactingLogger{logger: logger, secrets: ksc.sessionTokens}
+
+	ksc.limiter = newLimiter(cfg.RateLimit)
+	ksc.methodLimiters = make(map[string]*limiter, len(cfg.MethodLimits))
+	for method, limit := range cfg.MethodLimits {
+		ksc.methodLimiters[method] = newLimiter(limit)
+	}
+
+	ksc.tracer, ksc.metrics = cfg.Tracer, cfg.Metrics
+	if ksc.tracer == nil {
+		ksc.tracer = nopTracer{}
+	}
+	if ksc.metrics == nil {
+		ksc.metrics = nopMetrics{}
 	}
 
 	ksc.common.client = ksc
@@ -323,16 +484,67 @@ func NewKscClient(cfg Config) *KscClient {
 	return ksc
 }
 
+// SessionToken returns current X-KSC-Session token, empty if the client doesn't use X-KSC-Session.
+func (ksc *KscClient) SessionToken() string {
+	ksc.sessionMu.RLock()
+	defer ksc.sessionMu.RUnlock()
+	return ksc.sessionToken
+}
+
+func (ksc *KscClient) setSessionToken(token string) {
+	ksc.sessionMu.Lock()
+	ksc.sessionToken, ksc.XKscSessionToken = token, token
+	ksc.sessionMu.Unlock()
+}
+
+// sessionTokens returns all X-KSC-Session tokens of the client.
+func (ksc *KscClient) sessionTokens() []string {
+	ksc.sessionMu.RLock()
+	defer ksc.sessionMu.RUnlock()
+
+	tokens := []string{ksc.sessionToken}
+	for _, token := range ksc.vServerSessions {
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// vServer returns name of the virtual server the client is logged in, empty for the main server.
+func (ksc *KscClient) vServer() string {
+	ksc.sessionMu.RLock()
+	defer ksc.sessionMu.RUnlock()
+	return ksc.vServerName
+}
+
+// basicCredentials acquires credentials from the provider and remembers the virtual server name.
+func (ksc *KscClient) basicCredentials(ctx context.Context) (BasicCredentials, error) {
+	creds, err := ksc.credentials.Credentials(ctx)
+	if err != nil {
+		return creds, err
+	}
+
+	ksc.sessionMu.Lock()
+	ksc.vServerName, ksc.VServerName = creds.VServerName, creds.VServerName
+	ksc.sessionMu.Unlock()
+	return creds, nil
+}
+
 func (ksc *KscClient) kscAuth(ctx context.Context) error {
 	request, err := http.NewRequest("POST", ksc.Server+"/api/v1.0/login", nil)
 	if err != nil {
 		return err
 	}
 
+	creds, err := ksc.basicCredentials(ctx)
+	if err != nil {
+		return err
+	}
+
+	user, pass, vServer := creds.basic()
 	authorization := fmt.Sprintf(`KSCBasic user="%s", pass="%s", domain="%s", internal=%v`,
-		ksc.UserName, ksc.Password, ksc.Domain, ksc.InternalUser)
+		user, pass, creds.Domain, creds.InternalUser)
 	request.Header.Set("Authorization", authorization)
-	request.Header.Set("X-KSC-VServer", ksc.VServerName)
+	request.Header.Set("X-KSC-VServer", vServer)
 
 	_, err = ksc.Request(ctx, request, nil)
 	return err
@@ -342,22 +554,13 @@ func (ksc *KscClient) xkscSession(ctx context.Context) error {
 	s, _, e := ksc.Session.StartSession(ctx)
 
 	if s != nil {
-		ksc.XKscSessionToken = s.Str
+		ksc.setSessionToken(s.Str)
 	}
 
 	return e
 }
 
 func (ksc *KscClient) basicAuth(ctx context.Context) error {
-	ksc.UserName = base64.StdEncoding.EncodeToString([]byte(ksc.UserName))
-	ksc.Password = base64.StdEncoding.EncodeToString([]byte(ksc.Password))
-
-	if len(ksc.VServerName) != 0 {
-		ksc.VServerName = base64.StdEncoding.EncodeToString([]byte(ksc.VServerName))
-	} else {
-		ksc.VServerName = "x"
-	}
-
 	if ksc.XKscSession {
 		return ksc.xkscSession(ctx)
 	} else {
@@ -401,17 +604,285 @@ func (ksc *KscClient) kscTAuth(ctx context.Context, token string) error {
 	return err
 }
 
+// Call calls the OpenAPI method (e.g. "HostGroup.FindHosts") and decodes the result into out.
+//
+// Parameters in are marshalled to JSON, so any string value is escaped properly. Pass nil for methods without parameters.
+// out may be nil, the raw response body is returned in any case.
+//
+// opts are applied to this call in addition to the ones carried by ctx, see WithCallOptions.
+func (ksc *KscClient) Call(ctx context.Context, method string, in, out interface{}, opts ...CallOption) ([]byte, error) {
+	if ctx == nil {
+		return nil, errors.New("context must be non-nil")
+	}
+	ctx = WithCallOptions(ctx, opts...)
+
+	var body io.Reader
+	if in != nil {
+		postData, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(postData)
+	}
+
+	request, err := http.NewRequest("POST", ksc.Server+"/api/v1.0/"+method, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return ksc.Request(ctx, request, out)
+}
+
+// Request sends the request to KSC server and decodes the response into out.
+//
+// If the server reports that the session is not authenticated anymore (see ErrSessionExpired),
+// the client re-runs the authentication flow used by the last successful Login and replays the request once.
+// 403 without PxgError counts as such only if Session.Ping fails in the same session too, otherwise it's ErrAccessDenied.
+// Requests which carry their own Authorization header (the login requests) are never replayed.
+//
+// Transient transport failures are retried according to Config.RetryPolicy.
+//
+// The body is never buffered by the client: requests whose body can't be rewound
+// (request.GetBody is nil, e.g. NetUtils.UploadFile with a plain io.Reader) are sent once and neither replayed nor retried.
+//
+// Call options carried by ctx (see WithCallOptions) are applied to the request.
 func (ksc *KscClient) Request(ctx context.Context, request *http.Request, out interface{}) (dt []byte, err error) {
 	if ctx == nil {
 		return nil, errors.New("context must be non-nil")
 	}
 
+	opts := callOptionsFrom(ctx)
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	method := methodFromPath(request.URL.Path)
+
+	vServer := ksc.vServer()
+	if opts.vServer != "" {
+		vServer = opts.vServer
+	}
+
+	ctx, span := ksc.tracer.Start(ctx, method,
+		Attribute{AttrMethod, method}, Attribute{AttrServer, ksc.Server}, Attribute{AttrVServer, vServer})
+	start := time.Now()
+	defer func() {
+		ksc.observe(span, method, time.Since(start), err)
+	}()
+
+	for attempt := 1; ; attempt++ {
+		dt, err = ksc.send(ctx, request, out)
+		if err == nil || opts.noRetry || !replayable(request) || !ksc.retryPolicy.shouldRetry(method, attempt, err, opts.idempotent) {
+			return dt, err
+		}
+
+		if sleep(ctx, ksc.retryPolicy.backoff(attempt)) != nil {
+			return dt, err
+		}
+
+		if err := rewindBody(request); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send sends the request, re-authenticating and replaying it once if the session has expired.
+func (ksc *KscClient) send(ctx context.Context, request *http.Request, out interface{}) (dt []byte, err error) {
+	opts := callOptionsFrom(ctx)
+	if opts.vServer != "" && request.Header.Get("Authorization") == "" {
+		return ksc.sendVServer(ctx, request, out, opts.vServer)
+	}
+
+	gen := atomic.LoadUint32(&ksc.authGen)
+	dt, err = ksc.do(ctx, request, out)
+	if err == nil || request.Header.Get("Authorization") != "" || !ksc.sessionExpired(ctx, err) || !replayable(request) {
+		return dt, err
+	}
+
+	if reErr := ksc.reLogin(ctx, gen); reErr != nil {
+		return dt, fmt.Errorf("%w (re-login failed: %v)", err, reErr)
+	}
+
+	if err := rewindBody(request); err != nil {
+		return nil, err
+	}
+	return ksc.do(ctx, request, out)
+}
+
+// sendVServer sends the request within the session on the virtual server,
+// starting a new session and replaying the request once if the session has expired.
+func (ksc *KscClient) sendVServer(ctx context.Context, request *http.Request, out interface{}, vServer string) (dt []byte, err error) {
+	token := ksc.vServerToken(vServer)
+	if token == "" {
+		if err := ksc.vServerSession(ctx, vServer, ""); err != nil {
+			return nil, err
+		}
+		token = ksc.vServerToken(vServer)
+	}
+
+	dt, err = ksc.do(ctx, request, out)
+	if err == nil || !ksc.sessionExpired(ctx, err) || !replayable(request) {
+		return dt, err
+	}
+
+	if reErr := ksc.vServerSession(ctx, vServer, token); reErr != nil {
+		return dt, fmt.Errorf("%w (re-login failed: %v)", err, reErr)
+	}
+
+	if err := rewindBody(request); err != nil {
+		return nil, err
+	}
+	return ksc.do(ctx, request, out)
+}
+
+// sessionExpired reports whether err of the request made in the session carried by ctx means
+// the session is not authenticated anymore.
+//
+// 401 always does. 403 without PxgError is also returned for denied requests, e.g. by a proxy,
+// so it counts only if the server refuses Session.Ping in the same session as well.
+// The error is reported as ErrSessionExpired then.
+func (ksc *KscClient) sessionExpired(ctx context.Context, err error) bool {
+	if errors.Is(err, ErrSessionExpired) {
+		return true
+	}
+
+	var kscErr *KscError
+	if !errors.As(err, &kscErr) || kscErr.StatusCode != http.StatusForbidden || kscErr.Code != 0 {
+		return false
+	}
+
+	if kscErr.Method != "Session.Ping" {
+		ping, pingErr := http.NewRequest("POST", ksc.Server+"/api/v1.0/Session.Ping", nil)
+		if pingErr != nil {
+			return false
+		}
+
+		_, pingErr = ksc.do(ctx, ping, nil)
+		var pingKscErr *KscError
+		if !errors.As(pingErr, &pingKscErr) ||
+			(pingKscErr.StatusCode != http.StatusUnauthorized && pingKscErr.StatusCode != http.StatusForbidden) {
+			return false
+		}
+	}
+
+	kscErr.sessionLost = true
+	return true
+}
+
+// replayable reports whether the request can be sent again: it has no body or the body can be rewound.
+func replayable(request *http.Request) bool {
+	return request.Body == nil || request.Body == http.NoBody || request.GetBody != nil
+}
+
+// rewindBody restores the request body before sending the request again.
+func rewindBody(request *http.Request) (err error) {
+	if request.GetBody != nil {
+		request.Body, err = request.GetBody()
+	}
+	return err
+}
+
+// reLogin repeats the last successful authentication.
+//
+// gen is the authentication generation observed by the caller before its request failed:
+// if another goroutine has already re-authenticated meanwhile, reLogin returns immediately,
+// so concurrent callers share a single re-login.
+//
+// Failed re-login is reported with Config.OnSessionLost.
+func (ksc *KscClient) reLogin(ctx context.Context, gen uint32) error {
+	ksc.authMu.Lock()
+
+	if !ksc.loggedIn {
+		ksc.authMu.Unlock()
+		return ErrSessionExpired
+	}
+
+	if atomic.LoadUint32(&ksc.authGen) != gen {
+		ksc.authMu.Unlock()
+		return nil
+	}
+
+	err := ksc.login(ctx, ksc.authType, ksc.authToken)
+	if err == nil {
+		atomic.AddUint32(&ksc.authGen, 1)
+	}
+	ksc.authMu.Unlock()
+
+	if err != nil {
+		ksc.sessionLost(err)
+	}
+	return err
+}
+
+// maxLoggedBody the largest request body which is logged in debug mode.
+const maxLoggedBody = 64 << 10
+
+// sensitiveResponses methods which responses contain session tokens and are never logged
+var sensitiveResponses = map[string]bool{
+	"Session.StartSession": true,
+	"Session.CreateToken":  true,
+}
+
+// do sends the request once and logs its outcome.
+func (ksc *KscClient) do(ctx context.Context, request *http.Request, out interface{}) ([]byte, error) {
+	method := methodFromPath(request.URL.Path)
+
+	release, err := ksc.acquireLimits(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	start := time.Now()
+	body, size, status, err := ksc.roundTrip(ctx, request, out)
+
+	kv := []interface{}{
+		"method", method,
+		"status", status,
+		"duration", time.Since(start),
+		"request_size", request.ContentLength,
+		"response_size", size,
+	}
+
+	if ksc.Debug {
+		if request.GetBody != nil && request.ContentLength <= maxLoggedBody {
+			if rc, _ := request.GetBody(); rc != nil {
+				data, _ := ioutil.ReadAll(rc)
+				kv = append(kv, "request", data)
+			}
+		}
+
+		if sensitiveResponses[method] {
+			kv = append(kv, "response", redacted)
+		} else {
+			kv = append(kv, "response", body)
+		}
+	}
+
+	if err != nil {
+		ksc.logger.Error("ksc request failed", append(kv, "error", err)...)
+	} else {
+		ksc.logger.Debug("ksc request", kv...)
+	}
+	return body, err
+}
+
+// roundTrip sends the request and decodes the response into out.
+// Returns the raw response body (nil if discarded), its size and the HTTP status.
+func (ksc *KscClient) roundTrip(ctx context.Context, request *http.Request, out interface{}) (dt []byte, size int64, status int, err error) {
 	request = withContext(ctx, request)
 
 	var response *http.Response
 
-	if ksc.XKscSession && ksc.XKscSessionToken != "" {
-		request.Header.Set("X-KSC-Session", ksc.XKscSessionToken)
+	token := ksc.SessionToken()
+	if vServer := callOptionsFrom(ctx).vServer; vServer != "" {
+		token = ksc.vServerToken(vServer)
+	}
+
+	if ksc.XKscSession && token != "" && request.Header.Get("Authorization") == "" {
+		request.Header.Set("X-KSC-Session", token)
 	}
 
 	request.Header.Set("User-Agent", "go-ksc")
@@ -423,47 +894,53 @@ func (ksc *KscClient) Request(ctx context.Context, request *http.Request, out in
 	if err != nil {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, 0, 0, ctx.Err()
 		default:
 		}
 
-		return nil, err
+		return nil, 0, 0, err
 	}
 
 	defer response.Body.Close()
 
-	var reader io.ReadCloser
+	var reader io.Reader
 
 	switch response.Header.Get("Content-Encoding") {
 	case "gzip":
-		reader, err = gzip.NewReader(response.Body)
+		gz, gzErr := gzip.NewReader(response.Body)
+		if gzErr != nil {
+			return nil, 0, response.StatusCode, gzErr
+		}
+		defer gz.Close()
+		reader = gz
 	default:
 		reader = response.Body
 	}
 
-	body, err := ioutil.ReadAll(reader)
-
-	if err != nil {
-		return body, err
+	method := methodFromPath(request.URL.Path)
+	if response.StatusCode >= http.StatusBadRequest {
+		out = nil // error responses carry no result, keep the body for the KscError
 	}
 
-	err = CheckResponse(&body)
-
-	if err != nil {
-		return body, err
+	body, size, err := decodeResponse(reader, out, !ksc.discardRaw, ksc.maxResponseSize, method)
+	if errors.Is(err, ErrResponseTooLarge) {
+		return nil, size, response.StatusCode, err
 	}
 
-	if out != nil {
-		decErr := json.Unmarshal(body, out)
-		if decErr == io.EOF {
-			decErr = nil // ignore EOF errors caused by empty response body
-		}
-		if decErr != nil {
-			err = decErr
+	var kscErr *KscError
+	if !errors.As(err, &kscErr) && response.StatusCode >= http.StatusBadRequest {
+		if size == 0 {
+			err = nil // the status is the only error report
 		}
+		kscErr = &KscError{cause: err}
+	}
+	if kscErr != nil {
+		kscErr.StatusCode = response.StatusCode
+		kscErr.Method = method
+		return body, size, response.StatusCode, kscErr
 	}
 
-	return body, err
+	return body, size, response.StatusCode, err
 }
 
 type AuthType int
@@ -473,9 +950,32 @@ const (
 	TokenAuth    AuthType = 1
 	WebTokenAuth AuthType = 2
 	GatewayAuth  AuthType = 3
+
+	// OAuth2Auth authenticates with a bearer JWT obtained with the client credentials grant, see Config.OAuth2.
+	// The token is validated against JWKS before use and renewed before it expires.
+	OAuth2Auth AuthType = 4
 )
 
+// Login authenticates the client on KSC server.
+//
+// The authentication type and token are remembered and used to re-authenticate automatically
+// when the session expires (see KscClient.Request).
+// If Config.KeepAlive is set, the session is pinged in background until KscClient.Close.
 func (ksc *KscClient) Login(ctx context.Context, authType AuthType, token string) error {
+	ksc.authMu.Lock()
+	defer ksc.authMu.Unlock()
+
+	if err := ksc.login(ctx, authType, token); err != nil {
+		return err
+	}
+
+	ksc.authType, ksc.authToken, ksc.loggedIn = authType, token, true
+	atomic.AddUint32(&ksc.authGen, 1)
+	ksc.startKeepAlive()
+	return nil
+}
+
+func (ksc *KscClient) login(ctx context.Context, authType AuthType, token string) error {
 	switch authType {
 	case BasicAuth:
 		return ksc.basicAuth(ctx)
@@ -485,12 +985,16 @@ func (ksc *KscClient) Login(ctx context.Context, authType AuthType, token string
 		return ksc.kscWTAuth(ctx, token)
 	case GatewayAuth:
 		return ksc.kscGwAuth(ctx, token)
+	case OAuth2Auth:
+		return ksc.oauth2Auth(ctx)
 	default:
 		return ksc.basicAuth(ctx)
 	}
 }
 
-// CheckResponse check KSC Response error
+// CheckResponse check KSC Response error.
+//
+// If the body contains PxgError container it's returned as *KscError.
 func CheckResponse(body *[]byte) (err error) {
 	pre := new(PxgRetError)
 
@@ -504,7 +1008,7 @@ func CheckResponse(body *[]byte) (err error) {
 	}
 
 	if pre.Error != nil {
-		err = pre.Error
+		err = newKscError(pre.Error)
 	}
 
 	return err
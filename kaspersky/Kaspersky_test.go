@@ -0,0 +1,142 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// sessionServer returns a fakeServer issuing X-KSC-Session tokens which can be expired with the returned function.
+// Every method except Session.StartSession echoes its request body when called with the current token.
+func sessionServer(t *testing.T) (srv *fakeServer, expire func()) {
+	var token int32
+	srv = newFakeServer(t)
+	srv.handle("Session.StartSession", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"PxgRetVal":"token-%d"}`, atomic.AddInt32(&token, 1))
+	})
+
+	echo := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-KSC-Session") != fmt.Sprintf("token-%d", atomic.LoadInt32(&token)) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		fmt.Fprintf(w, `{"PxgRetVal":%q}`, body)
+	}
+	srv.handle("Tasks.GetTask", echo)
+	srv.handle("FTUR", echo)
+	srv.handle("Session.Ping", echo)
+
+	return srv, func() { atomic.AddInt32(&token, 100) }
+}
+
+func TestRequestReAuth(t *testing.T) {
+	srv, expire := sessionServer(t)
+	c := srv.client(Config{UserName: "user", Password: "pass", XKscSession: true})
+
+	ctx := context.Background()
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+	expire()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			out := new(PxgValStr)
+			in := map[string]int{"n": i}
+			if _, err := c.Call(ctx, "Tasks.GetTask", in, out); err != nil {
+				t.Error(err)
+				return
+			}
+			if want := fmt.Sprintf(`{"n":%d}`, i); out.Str != want {
+				t.Errorf("replayed body = %s, want %s", out.Str, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := len(srv.received("Session.StartSession")); n != 2 {
+		t.Errorf("Session.StartSession called %d times, want 2 (login and a single shared re-login)", n)
+	}
+}
+
+func TestRequestNotLoggedIn(t *testing.T) {
+	srv, _ := sessionServer(t)
+	c := srv.client(Config{XKscSession: true})
+
+	_, err := c.Call(context.Background(), "Tasks.GetTask", nil, nil)
+	if !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Call() = %v, want ErrSessionExpired", err)
+	}
+	if n := len(srv.received("Session.StartSession")); n != 0 {
+		t.Errorf("Session.StartSession called %d times, want 0", n)
+	}
+}
+
+// onceReader is an io.Reader which can't be rewound.
+type onceReader struct{ r *strings.Reader }
+
+func (o onceReader) Read(p []byte) (int, error) { return o.r.Read(p) }
+
+func TestRequestNonRewindableBody(t *testing.T) {
+	srv, expire := sessionServer(t)
+	c := srv.client(Config{UserName: "user", Password: "pass", XKscSession: true})
+
+	ctx := context.Background()
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	out := new(PxgValStr)
+	request, _ := http.NewRequest("PUT", c.Server+"/FTUR", onceReader{strings.NewReader("file content")})
+	if _, err := c.Request(ctx, request, out); err != nil || out.Str != "file content" {
+		t.Fatalf("Request() = %q, %v", out.Str, err)
+	}
+	if request.GetBody != nil {
+		t.Error("non-rewindable body has been buffered")
+	}
+
+	expire()
+
+	request, _ = http.NewRequest("PUT", c.Server+"/FTUR", onceReader{strings.NewReader("file content")})
+	if _, err := c.Request(ctx, request, nil); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Request() = %v, want ErrSessionExpired", err)
+	}
+	if n := len(srv.received("FTUR")); n != 2 {
+		t.Errorf("upload sent %d times, want 2 (no replay)", n)
+	}
+}
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // KeyService service for working with KeyService subsystem.
@@ -41,14 +38,10 @@ type PEncryptedData struct {
 
 // EncryptData Method creates crypto container.
 func (ks *KeyService) EncryptData(ctx context.Context, pData string) (*PEncryptedData, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"pData": "%s"}`, pData))
-	request, err := http.NewRequest("POST", ks.client.Server+"/api/v1.0/KeyService.EncryptData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"pData": pData}
 
 	pEncryptedData := new(PEncryptedData)
-	raw, err := ks.client.Request(ctx, request, &pEncryptedData)
+	raw, err := ks.client.Call(ctx, "KeyService.EncryptData", postData, &pEncryptedData)
 	return pEncryptedData, raw, err
 }
 
@@ -60,29 +53,24 @@ type PDecryptedData struct {
 // DecryptData Method unprotects crypto container created by EncryptData.
 func (ks *KeyService) DecryptData(ctx context.Context, pEncryptedData, wstrProdName, wstrProdVersion string) (*PDecryptedData,
 	[]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"pEncryptedData": "%s", "wstrProdName": "%s", "wstrProdVersion": "%s"}`,
-		pEncryptedData, wstrProdName, wstrProdVersion))
-	request, err := http.NewRequest("POST", ks.client.Server+"/api/v1.0/KeyService.DecryptData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"pEncryptedData":  pEncryptedData,
+		"wstrProdName":    wstrProdName,
+		"wstrProdVersion": wstrProdVersion,
 	}
 
 	pDecryptedData := new(PDecryptedData)
-	raw, err := ks.client.Request(ctx, request, &pDecryptedData)
+	raw, err := ks.client.Call(ctx, "KeyService.DecryptData", postData, &pDecryptedData)
 	return pDecryptedData, raw, err
 }
 
 // EncryptDataForHost Method creates a crypto container for chosen host. Data may be decrypted only locally on host.
 func (ks *KeyService) EncryptDataForHost(ctx context.Context, wstrHostId, pData string) (*PEncryptedData, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"wstrHostId" : "%s", "pData": "%s"}`, wstrHostId, pData))
-	request, err := http.NewRequest("POST", ks.client.Server+"/api/v1.0/KeyService.EncryptDataForHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrHostId": wstrHostId, "pData": pData}
 
 	pEncryptedData := new(PEncryptedData)
-	raw, err := ks.client.Request(ctx, request, &pEncryptedData)
+	raw, err := ks.client.Call(ctx, "KeyService.EncryptDataForHost", postData, &pEncryptedData)
 	return pEncryptedData, raw, err
 }
 
@@ -100,13 +88,9 @@ type TransportCertificate struct {
 
 // GenerateTransportCertificate Method generates transport certificate.
 func (ks *KeyService) GenerateTransportCertificate(ctx context.Context, wstrCommonName string) (*TransportCertificate, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrCommonName": "%s"}`, wstrCommonName))
-	request, err := http.NewRequest("POST", ks.client.Server+"/api/v1.0/KeyService.GenerateTransportCertificate", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrCommonName": wstrCommonName}
 
 	transportCertificate := new(TransportCertificate)
-	raw, err := ks.client.Request(ctx, request, &transportCertificate)
+	raw, err := ks.client.Call(ctx, "KeyService.GenerateTransportCertificate", postData, &transportCertificate)
 	return transportCertificate, raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // KeyService2 additional service for working with KeyService subsystem.
@@ -36,24 +33,16 @@ type KeyService2 service
 
 // ImportDpeKeys Imports encryption keys.
 func (ks2 *KeyService2) ImportDpeKeys(ctx context.Context, pProtectedPass string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"pProtectedPass": "%s"}`, pProtectedPass))
-	request, err := http.NewRequest("POST", ks2.client.Server+"/api/v1.0/KeyService2.ImportDpeKeys", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"pProtectedPass": pProtectedPass}
 
-	raw, err := ks2.client.Request(ctx, request, nil)
+	raw, err := ks2.client.Call(ctx, "KeyService2.ImportDpeKeys", postData, nil)
 	return raw, err
 }
 
 // ExportDpeKeys Exports own and all stored encryption keys.
 func (ks2 *KeyService2) ExportDpeKeys(ctx context.Context, pProtectedPass string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"pProtectedPass": "%s"}`, pProtectedPass))
-	request, err := http.NewRequest("POST", ks2.client.Server+"/api/v1.0/KeyService2.ExportDpeKeys", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"pProtectedPass": pProtectedPass}
 
-	raw, err := ks2.client.Request(ctx, request, nil)
+	raw, err := ks2.client.Call(ctx, "KeyService2.ExportDpeKeys", postData, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // KillChain service to obtain KillChain info from host.
@@ -36,12 +33,8 @@ type KillChain service
 
 // GetByIDs Get KillChain information by hostname and element_id
 func (kc *KillChain) GetByIDs(ctx context.Context, wstrHostID, wstrElementID string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrHostID": "%s", "wstrElementID": "%s"}`, wstrHostID, wstrElementID))
-	request, err := http.NewRequest("POST", kc.client.Server+"/api/v1.0/KillChain.GetByIDs", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrHostID": wstrHostID, "wstrElementID": wstrElementID}
 
-	raw, err := kc.client.Request(ctx, request, nil)
+	raw, err := kc.client.Call(ctx, "KillChain.GetByIDs", postData, nil)
 	return raw, err
 }
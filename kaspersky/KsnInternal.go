@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // KsnInternal service for working with KsnProxy subsystem.
@@ -38,24 +35,14 @@ type KsnInternal service
 
 // CheckKsnConnection Check connection with KSN cloud (or KPSN)
 func (sd *KsnInternal) CheckKsnConnection(ctx context.Context) (*PxgValBool, []byte, error) {
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/KsnInternal.CheckKsnConnection", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValBool := new(PxgValBool)
-	raw, err := sd.client.Request(ctx, request, &pxgValBool)
+	raw, err := sd.client.Call(ctx, "KsnInternal.CheckKsnConnection", nil, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // GetNKsnEulas Get all KPSN eula.
 func (sd *KsnInternal) GetNKsnEulas(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/KsnInternal.GetNKsnEulas", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sd.client.Request(ctx, request, nil)
+	raw, err := sd.client.Call(ctx, "KsnInternal.GetNKsnEulas", nil, nil)
 	return raw, err
 }
 
@@ -75,36 +62,22 @@ type PSettings struct {
 
 // GetSettings Returns settings of KsnProxy. May be used on virtual server.
 func (sd *KsnInternal) GetSettings(ctx context.Context) (*KsnSettings, []byte, error) {
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/KsnInternal.GetSettings", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	ksnSettings := new(KsnSettings)
-	raw, err := sd.client.Request(ctx, request, &ksnSettings)
+	raw, err := sd.client.Call(ctx, "KsnInternal.GetSettings", nil, &ksnSettings)
 	return ksnSettings, raw, err
 }
 
 // NeedToSendStatistics Check possibility to send statistics.
 func (sd *KsnInternal) NeedToSendStatistics(ctx context.Context) (*PxgValBool, []byte, error) {
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/KsnInternal.NeedToSendStatistics", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValBool := new(PxgValBool)
-	raw, err := sd.client.Request(ctx, request, &pxgValBool)
+	raw, err := sd.client.Call(ctx, "KsnInternal.NeedToSendStatistics", nil, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // GetNKsnEula Get KPSN eula.
 func (sd *KsnInternal) GetNKsnEula(ctx context.Context, wstrNKsnLoc string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrNKsnLoc": "%s"}`, wstrNKsnLoc))
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/KsnInternal.GetNKsnEula", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrNKsnLoc": wstrNKsnLoc}
 
-	raw, err := sd.client.Request(ctx, request, nil)
+	raw, err := sd.client.Call(ctx, "KsnInternal.GetNKsnEula", postData, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // LicenseInfoSync service provided operating with licenses.
@@ -37,28 +33,26 @@ type LicenseInfoSync service
 
 // AcquireKeysForProductOnHost Acquire serial numbers of the active and reserved licenses on the host.
 func (lis *LicenseInfoSync) AcquireKeysForProductOnHost(ctx context.Context, szwHostName, szwProduct, szwVersion string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwHostName":"%s","szwProduct":"%s","szwVersion":"%s"}`, szwHostName, szwProduct, szwVersion))
-	request, err := http.NewRequest("POST", lis.client.Server+"/api/v1.0/LicenseInfoSync.AcquireKeysForProductOnHost",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"szwHostName": szwHostName,
+		"szwProduct":  szwProduct,
+		"szwVersion":  szwVersion,
 	}
 
-	raw, err := lis.client.Request(ctx, request, nil)
+	raw, err := lis.client.Call(ctx, "LicenseInfoSync.AcquireKeysForProductOnHost", postData, nil)
 	return raw, err
 }
 
 // GetKeyDataForHost Get host-specific key data.
 func (lis *LicenseInfoSync) GetKeyDataForHost(ctx context.Context, szwSerial, szwHostName, szwProduct, szwVersion string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwSerial":"%s", "szwHostName":"%s", "szwProduct":"%s", "szwVersion":"%s"}`,
-		szwSerial, szwHostName, szwProduct, szwVersion))
-	request, err := http.NewRequest("POST", lis.client.Server+"/api/v1.0/LicenseInfoSync.GetKeyDataForHost",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"szwSerial":   szwSerial,
+		"szwHostName": szwHostName,
+		"szwProduct":  szwProduct,
+		"szwVersion":  szwVersion,
 	}
 
-	raw, err := lis.client.Request(ctx, request, nil)
+	raw, err := lis.client.Call(ctx, "LicenseInfoSync.GetKeyDataForHost", postData, nil)
 	return raw, err
 }
 
@@ -78,65 +72,35 @@ type SaasPKeyInfo struct {
 
 // IsLicForSaasValid2 Check if license is suitable for being used by the adm. server.
 func (lis *LicenseInfoSync) IsLicForSaasValid2(ctx context.Context, params SaasKeyParam2) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", lis.client.Server+"/api/v1.0/LicenseInfoSync.IsLicForSaasValid2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lis.client.Request(ctx, request, nil)
+	raw, err := lis.client.Call(ctx, "LicenseInfoSync.IsLicForSaasValid2", params, nil)
 	return raw, err
 }
 
 // IsPCloudKey Check whether the key's product id belongs to the Public Cloud product ids list.
 func (lis *LicenseInfoSync) IsPCloudKey(ctx context.Context, nProductId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nProductId": %d}`, nProductId))
-	request, err := http.NewRequest("POST", lis.client.Server+"/api/v1.0/LicenseInfoSync.IsPCloudKey",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nProductId": nProductId}
 
-	raw, err := lis.client.Request(ctx, request, nil)
+	raw, err := lis.client.Call(ctx, "LicenseInfoSync.IsPCloudKey", postData, nil)
 	return raw, err
 }
 
 // SynchronizeLicInfo2 Force synchronization of subscription licenses' metadata.
 func (lis *LicenseInfoSync) SynchronizeLicInfo2(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", lis.client.Server+"/api/v1.0/LicenseInfoSync.SynchronizeLicInfo2",
-		nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := lis.client.Request(ctx, request, &pxgValStr)
+	raw, err := lis.client.Call(ctx, "LicenseInfoSync.SynchronizeLicInfo2", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // TryToInstallLicForSaas2 Install adm. server's license.
 func (lis *LicenseInfoSync) TryToInstallLicForSaas2(ctx context.Context, params SaasKeyParam2) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", lis.client.Server+"/api/v1.0/LicenseInfoSync.TryToInstallLicForSaas2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lis.client.Request(ctx, request, nil)
+	raw, err := lis.client.Call(ctx, "LicenseInfoSync.TryToInstallLicForSaas2", params, nil)
 	return raw, err
 }
 
 // TryToUnistallLicense Uninstall adm. server's license.
 func (lis *LicenseInfoSync) TryToUnistallLicense(ctx context.Context, bCurrent bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"bCurrent": %v}`, bCurrent))
-	request, err := http.NewRequest("POST", lis.client.Server+"/api/v1.0/LicenseInfoSync.TryToUnistallLicense",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bCurrent": bCurrent}
 
-	raw, err := lis.client.Request(ctx, request, nil)
+	raw, err := lis.client.Call(ctx, "LicenseInfoSync.TryToUnistallLicense", postData, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 //	LicenseKeys service to operating with keys
@@ -37,14 +33,7 @@ type LicenseKeys service
 
 // InstallKey Install a key on the server.
 func (lk *LicenseKeys) InstallKey(ctx context.Context, pKeyInfo interface{}) bool {
-	postData, _ := json.Marshal(pKeyInfo)
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.InstallKey",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return false
-	}
-
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, _ := lk.client.Call(ctx, "LicenseKeys.InstallKey", pKeyInfo, nil)
 	if raw != nil {
 		return true
 	}
@@ -53,17 +42,9 @@ func (lk *LicenseKeys) InstallKey(ctx context.Context, pKeyInfo interface{}) boo
 
 // DownloadKeyFiles Download license key files from activation key servers V1.
 func (lk *LicenseKeys) DownloadKeyFiles(ctx context.Context, wstrActivationCode string) bool {
-	postData := []byte(fmt.Sprintf(`
-	{
-	"wstrActivationCode": "%s"
-	}`, wstrActivationCode))
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.DownloadKeyFiles",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return false
-	}
+	postData := map[string]interface{}{"wstrActivationCode": wstrActivationCode}
 
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, _ := lk.client.Call(ctx, "LicenseKeys.DownloadKeyFiles", postData, nil)
 	if raw != nil {
 		return true
 	}
@@ -90,19 +71,8 @@ type HostsKeyIterator struct {
 // AcquireKeyHosts Get an array of hosts that are currently using the specified key.
 func (lk *LicenseKeys) AcquireKeyHosts(ctx context.Context, params AcquireKeyHostsParams) (*HostsKeyIterator,
 	[]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.AcquireKeyHosts",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	hostsKeyIterator := new(HostsKeyIterator)
-	raw, err := lk.client.Request(ctx, request, &hostsKeyIterator)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.AcquireKeyHosts", params, &hostsKeyIterator)
 	return hostsKeyIterator, raw, err
 }
 
@@ -114,18 +84,7 @@ type EnumKeysParams struct {
 
 // EnumKeys Enumerate keys.
 func (lk *LicenseKeys) EnumKeys(ctx context.Context, params EnumKeysParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.EnumKeys",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.EnumKeys", params, nil)
 	return raw, err
 }
 
@@ -155,31 +114,15 @@ type PKeyInfo struct {
 // Note that the additional "ExportLicense" access right must be set up to the user under which this call is made.
 
 func (lk *LicenseKeys) GetKeyData(ctx context.Context, params KeyDataParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.GetKeyData",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.GetKeyData", params, nil)
 	return raw, err
 }
 
 // SaasTryToUninstall Uninstall an adm. server's license.
 func (lk *LicenseKeys) SaasTryToUninstall(ctx context.Context, bCurrent bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"bCurrent": %v}`, bCurrent))
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.SaasTryToUninstall",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bCurrent": bCurrent}
 
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.SaasTryToUninstall", postData, nil)
 	return raw, err
 }
 
@@ -202,18 +145,7 @@ type PKData struct {
 
 // AdjustKey Adjust adm. server's license attributes.
 func (lk *LicenseKeys) AdjustKey(ctx context.Context, params AdjustKeyParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.AdjustKey",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.AdjustKey", params, nil)
 	return raw, err
 }
 
@@ -235,48 +167,21 @@ type SaasKeyPInData struct {
 
 // SaasTryToInstall Install an adm. server's license.
 func (lk *LicenseKeys) SaasTryToInstall(ctx context.Context, params SaasKeyParam) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.SaasTryToInstall",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.SaasTryToInstall", params, nil)
 	return raw, err
 }
 
 // CheckIfSaasLicenseIsValid Check if license can be installed to the adm. server.
 // License is treated as a valid one if it is suitable for being used by the adm. server itself (its AppId is 1017, it is not expired etc.)
 func (lk *LicenseKeys) CheckIfSaasLicenseIsValid(ctx context.Context, params SaasKeyParam) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.CheckIfSaasLicenseIsValid",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.CheckIfSaasLicenseIsValid", params, nil)
 	return raw, err
 }
 
 // UninstallKey Uninstall an adm. server's license.
 func (lk *LicenseKeys) UninstallKey(ctx context.Context, bCurrent bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"bCurrent": %v}`, bCurrent))
-	request, err := http.NewRequest("POST", lk.client.Server+"/api/v1.0/LicenseKeys.UninstallKey",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bCurrent": bCurrent}
 
-	raw, err := lk.client.Request(ctx, request, nil)
+	raw, err := lk.client.Call(ctx, "LicenseKeys.UninstallKey", postData, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-
-	"net/http"
 )
 
 // LicensePolicy service by default, all functionalities are not available (IsLimitedMode returns true - functionality is limited).
@@ -40,75 +36,51 @@ type LicensePolicy service
 
 // GetFreeLicenseCount Get number of free licenses for functionality.
 func (lp *LicensePolicy) GetFreeLicenseCount(ctx context.Context, nFunctionality int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nFunctionality": %d}`, nFunctionality))
-	request, err := http.NewRequest("POST", lp.client.Server+"/api/v1.0/LicensePolicy.GetFreeLicenseCount", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nFunctionality": nFunctionality}
 
-	raw, err := lp.client.Request(ctx, request, nil)
+	raw, err := lp.client.Call(ctx, "LicensePolicy.GetFreeLicenseCount", postData, nil)
 	return raw, err
 }
 
 // GetTotalLicenseCount Get total number of licenses for functionality.
 func (lp *LicensePolicy) GetTotalLicenseCount(ctx context.Context, nFunctionality int64) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nFunctionality": %d}`, nFunctionality))
-	request, err := http.NewRequest("POST", lp.client.Server+"/api/v1.0/LicensePolicy.GetTotalLicenseCount", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nFunctionality": nFunctionality}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := lp.client.Request(ctx, request, &pxgValInt)
+	raw, err := lp.client.Call(ctx, "LicensePolicy.GetTotalLicenseCount", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // IsLimitedMode Check a functionality in restricted mode.
 func (lp *LicensePolicy) IsLimitedMode(ctx context.Context, nFunctionality int64) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nFunctionality": %d}`, nFunctionality))
-	request, err := http.NewRequest("POST", lp.client.Server+"/api/v1.0/LicensePolicy.IsLimitedMode", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nFunctionality": nFunctionality}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := lp.client.Request(ctx, request, &pxgValBool)
+	raw, err := lp.client.Call(ctx, "LicensePolicy.IsLimitedMode", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // SetLimitedModeTest Enable or disable restricted mode for functionality.
 func (lp *LicensePolicy) SetLimitedModeTest(ctx context.Context, bLimited bool, eFunctionality int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"bLimited": %v, "eFunctionality": %d}`, bLimited, eFunctionality))
-	request, err := http.NewRequest("POST", lp.client.Server+"/api/v1.0/LicensePolicy.SetLimitedModeTest", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bLimited": bLimited, "eFunctionality": eFunctionality}
 
-	raw, err := lp.client.Request(ctx, request, nil)
+	raw, err := lp.client.Call(ctx, "LicensePolicy.SetLimitedModeTest", postData, nil)
 	return raw, err
 }
 
 // SetTotalLicenseCountTest Set total number of licenses for functionality in restricted mode.
 func (lp *LicensePolicy) SetTotalLicenseCountTest(ctx context.Context, eFunctionality, nCount int64) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"eFunctionality": %d, "nCount": %d}`, eFunctionality, nCount))
-	request, err := http.NewRequest("POST", lp.client.Server+"/api/v1.0/LicensePolicy.SetTotalLicenseCountTest", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"eFunctionality": eFunctionality, "nCount": nCount}
 
-	raw, err := lp.client.Request(ctx, request, nil)
+	raw, err := lp.client.Call(ctx, "LicensePolicy.SetTotalLicenseCountTest", postData, nil)
 	return raw, err
 }
 
 // SetUsedLicenseCountTest Set number of used licenses for functionality in restricted mode.
 func (lp *LicensePolicy) SetUsedLicenseCountTest(ctx context.Context, eFunctionality, nCount int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"eFunctionality": %d, "nCount": %d}`, eFunctionality, nCount))
-	request, err := http.NewRequest("POST", lp.client.Server+"/api/v1.0/LicensePolicy.SetUsedLicenseCountTest", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"eFunctionality": eFunctionality, "nCount": nCount}
 
-	raw, err := lp.client.Request(ctx, request, nil)
+	raw, err := lp.client.Call(ctx, "LicensePolicy.SetUsedLicenseCountTest", postData, nil)
 	return raw, err
 }
@@ -0,0 +1,161 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errEmptyToken Session.CreateToken returned no token.
+var errEmptyToken = errors.New("kaspersky: Session.CreateToken returned empty token")
+
+// keepAlive background goroutine which pings the session, see Config.KeepAlive.
+type keepAlive struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startKeepAlive starts pinging the session every ksc.keepAliveInterval if it's not running yet.
+// Must be called with authMu held.
+func (ksc *KscClient) startKeepAlive() {
+	if ksc.keepAliveInterval <= 0 || ksc.keepAlive != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ka := &keepAlive{cancel: cancel, done: make(chan struct{})}
+	ksc.keepAlive = ka
+
+	go func() {
+		defer close(ka.done)
+		ksc.keepSessionAlive(ctx)
+
+		ksc.authMu.Lock()
+		if ksc.keepAlive == ka {
+			ksc.keepAlive = nil
+		}
+		ksc.authMu.Unlock()
+	}()
+}
+
+// keepSessionAlive calls Session.Ping until ctx is canceled or the session is lost.
+func (ksc *KscClient) keepSessionAlive(ctx context.Context) {
+	ticker := time.NewTicker(ksc.keepAliveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, ksc.keepAliveInterval)
+		_, err := ksc.Session.Ping(pingCtx)
+		cancel()
+
+		switch {
+		case err == nil, ctx.Err() != nil:
+		case errors.Is(err, ErrSessionExpired):
+			// re-login has failed and the loss is reported, the next Login restarts keepalive
+			return
+		default:
+			ksc.logger.Warn("ksc keepalive failed", "error", err)
+		}
+	}
+}
+
+// sessionLost reports that the session is expired and re-authentication has failed.
+func (ksc *KscClient) sessionLost(err error) {
+	ksc.logger.Error("ksc session lost", "error", err)
+	if ksc.onSessionLost != nil {
+		ksc.onSessionLost(err)
+	}
+}
+
+// Close stops keepalive and terminates the session on the server (and sessions on virtual servers
+// started by WithVServerSession) with Session.EndSession.
+//
+// Requests made after Close are not re-authenticated until the next Login.
+// Close of a client which is not logged in does nothing.
+func (ksc *KscClient) Close(ctx context.Context) error {
+	ksc.authMu.Lock()
+	ka, loggedIn := ksc.keepAlive, ksc.loggedIn
+	ksc.keepAlive, ksc.loggedIn = nil, false
+	ksc.stopOAuth2Refresh()
+	ksc.authMu.Unlock()
+
+	if ka != nil {
+		ka.cancel()
+		<-ka.done
+	}
+
+	if !loggedIn {
+		return nil
+	}
+
+	ksc.endVServerSessions(ctx)
+
+	_, err := ksc.Session.EndSession(ctx)
+	ksc.setSessionToken("")
+
+	if errors.Is(err, ErrSessionExpired) {
+		return nil // the session is already gone
+	}
+	return err
+}
+
+// Fork returns a new independent client logged in with its own session.
+//
+// The session is started with a short-lived token minted by Session.CreateToken for the current security context
+// (the virtual server set by WithVServerSession in ctx is respected), so the child client never sees the credentials.
+// The child client has the same configuration, except credentials, and its own connections, limits and keepalive.
+// It can't re-authenticate once its session is lost and should be closed with Close when it's not needed anymore.
+func (ksc *KscClient) Fork(ctx context.Context) (*KscClient, error) {
+	token, _, err := ksc.Session.CreateToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil || token.Str == "" {
+		return nil, errEmptyToken
+	}
+
+	cfg := ksc.cfg
+	cfg.UserName, cfg.Password, cfg.Domain, cfg.InternalUser, cfg.VServerName = "", "", "", false, ""
+	cfg.Credentials, cfg.OAuth2 = StaticCredentials{}, nil
+
+	child := NewKscClient(cfg)
+	child.vServerName = ksc.vServer()
+	if vServer := callOptionsFrom(ctx).vServer; vServer != "" {
+		child.vServerName = vServer
+	}
+
+	if err := child.Login(ctx, TokenAuth, token.Str); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
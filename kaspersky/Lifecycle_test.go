@@ -0,0 +1,212 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// lifecycleServer returns a fakeServer issuing X-KSC-Session tokens, which ends sessions on Session.EndSession
+// and rejects logins while *failLogin is set. Session.Ping and Session.EndSession require the current token.
+func lifecycleServer(t *testing.T, failLogin *int32) *fakeServer {
+	var token int32
+	srv := newFakeServer(t)
+	srv.handle("Session.StartSession", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(failLogin) != 0 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"PxgRetVal":"token-%d"}`, atomic.AddInt32(&token, 1))
+	})
+
+	withSession := func(end bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-KSC-Session") != fmt.Sprintf("token-%d", atomic.LoadInt32(&token)) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			if end {
+				atomic.AddInt32(&token, 100)
+			}
+			fmt.Fprint(w, `{}`)
+		}
+	}
+	srv.handle("Session.Ping", withSession(false))
+	srv.handle("Session.EndSession", withSession(true))
+	return srv
+}
+
+func TestKeepAliveAndClose(t *testing.T) {
+	var failLogin int32
+	srv := lifecycleServer(t, &failLogin)
+	c := srv.client(Config{UserName: "user", Password: "pass", XKscSession: true, KeepAlive: 10 * time.Millisecond})
+
+	ctx := context.Background()
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if err := c.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	pings := len(srv.received("Session.Ping"))
+	if pings < 2 {
+		t.Errorf("%d keepalive pings, want at least 2", pings)
+	}
+	if n := len(srv.received("Session.EndSession")); n != 1 {
+		t.Errorf("Session.EndSession called %d times, want 1", n)
+	}
+	if c.SessionToken() != "" {
+		t.Errorf("SessionToken() = %q after Close", c.SessionToken())
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if n := len(srv.received("Session.Ping")); n != pings {
+		t.Errorf("keepalive is running after Close: %d pings, want %d", n, pings)
+	}
+
+	if _, err := c.Session.Ping(ctx); !errors.Is(err, ErrSessionExpired) {
+		t.Errorf("Ping() after Close = %v, want ErrSessionExpired", err)
+	}
+	if n := len(srv.received("Session.StartSession")); n != 1 {
+		t.Errorf("re-authenticated after Close: %d logins", n)
+	}
+
+	if err := c.Close(ctx); err != nil {
+		t.Errorf("second Close() = %v", err)
+	}
+	if n := len(srv.received("Session.EndSession")); n != 1 {
+		t.Errorf("second Close() called Session.EndSession")
+	}
+}
+
+func TestSessionLost(t *testing.T) {
+	var failLogin int32
+	srv := lifecycleServer(t, &failLogin)
+
+	lost := make(chan error, 1)
+	c := srv.client(Config{
+		UserName:      "user",
+		Password:      "pass",
+		XKscSession:   true,
+		KeepAlive:     10 * time.Millisecond,
+		OnSessionLost: func(err error) { lost <- err },
+	})
+
+	ctx := context.Background()
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	atomic.StoreInt32(&failLogin, 1)
+	if _, err := c.Session.EndSession(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-lost:
+		if err == nil {
+			t.Error("OnSessionLost called with nil error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("session loss is not reported")
+	}
+
+	// keepalive stops after the loss
+	deadline := time.Now().Add(time.Second)
+	for {
+		c.authMu.Lock()
+		stopped := c.keepAlive == nil
+		c.authMu.Unlock()
+		if stopped {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("keepalive is running after the session loss")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	atomic.StoreInt32(&failLogin, 0)
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFork(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.reply("Session.StartSession", `{"PxgRetVal":"parent-session"}`)
+	srv.reply("Session.CreateToken", `{"PxgRetVal":"minted-token"}`)
+
+	parent := srv.client(Config{UserName: "user", Password: "pass", XKscSession: true, RateLimit: RateLimit{Rate: 100}})
+	ctx := context.Background()
+	if err := parent.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	child, err := parent.Fork(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	login := srv.received("login")
+	if len(login) != 1 || login[0].Header.Get("Authorization") != "KSCT minted-token" {
+		t.Fatalf("child login requests = %+v, want a single KSCT login with the minted token", login)
+	}
+	if got := srv.received("Session.CreateToken")[0].Header.Get("X-KSC-Session"); got != "parent-session" {
+		t.Errorf("Session.CreateToken called within session %q, want the parent session", got)
+	}
+
+	creds, _ := child.credentials.Credentials(ctx)
+	if creds != (BasicCredentials{}) || child.cfg.Password != "" || child.Password != "" {
+		t.Errorf("child client has parent credentials: %+v", creds)
+	}
+	if child.Server != parent.Server || child.limiter == parent.limiter || child.client == parent.client {
+		t.Error("child client should share configuration but not connections and limits")
+	}
+}
+
+func TestForkEmptyToken(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.reply("Session.CreateToken", `{"PxgRetVal":""}`)
+
+	if _, err := srv.client(Config{}).Fork(context.Background()); err != errEmptyToken {
+		t.Errorf("Fork() = %v, want errEmptyToken", err)
+	}
+	if n := len(srv.received("login")); n != 0 {
+		t.Errorf("child logged in %d times with an empty token", n)
+	}
+}
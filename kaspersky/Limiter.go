@@ -0,0 +1,163 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit client side limits of requests sent to KSC server.
+//
+// KSC closes connections when too many async actions are open on them,
+// so limits help to keep parallel fan-outs within the server capacity.
+type RateLimit struct {
+	// Rate maximum average number of requests per second, 0 means unlimited
+	Rate float64
+
+	// Burst maximum number of requests sent at once above Rate, 1 if zero
+	Burst int
+
+	// MaxInFlight maximum number of concurrent requests, 0 means unlimited
+	MaxInFlight int
+}
+
+// limiter token bucket and in-flight semaphore built from RateLimit.
+type limiter struct {
+	sem chan struct{}
+
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter returns nil if the limit doesn't restrict anything.
+func newLimiter(limit RateLimit) *limiter {
+	if limit.Rate <= 0 && limit.MaxInFlight <= 0 {
+		return nil
+	}
+
+	l := &limiter{rate: limit.Rate, burst: float64(limit.Burst)}
+	if l.burst < 1 {
+		l.burst = 1
+	}
+	l.tokens = l.burst
+
+	if limit.MaxInFlight > 0 {
+		l.sem = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// acquire waits for a free slot and a token. The returned function releases the slot.
+func (l *limiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	if err := l.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+	return release, nil
+}
+
+// wait reserves a token and sleeps until the reservation becomes due.
+func (l *limiter) wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleep(ctx, delay); err != nil {
+		// give back the reserved token
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// acquireLimits waits for the limit of the method and then for the client wide limit.
+//
+// The method limit is acquired first, so calls throttled by their method limit
+// don't hold client wide slots and tokens needed by other methods.
+func (ksc *KscClient) acquireLimits(ctx context.Context, method string) (func(), error) {
+	methodLimiter, ok := ksc.methodLimiters[method]
+	if i := strings.Index(method, "."); !ok && i > 0 {
+		methodLimiter = ksc.methodLimiters[method[:i]]
+	}
+
+	releaseMethod, err := methodLimiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := ksc.limiter.acquire(ctx)
+	if err != nil {
+		releaseMethod()
+		return nil, err
+	}
+
+	return func() {
+		release()
+		releaseMethod()
+	}, nil
+}
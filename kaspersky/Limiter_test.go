@@ -0,0 +1,151 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewLimiterUnlimited(t *testing.T) {
+	if l := newLimiter(RateLimit{Burst: 10}); l != nil {
+		t.Errorf("newLimiter() = %+v, want nil", l)
+	}
+
+	release, err := (*limiter)(nil).acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	release()
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := newLimiter(RateLimit{Rate: 20, Burst: 2})
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		release, err := l.acquire(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+
+	// 2 requests of the burst are sent at once, the other 2 wait 50ms each
+	if d := time.Since(start); d < 90*time.Millisecond || d > time.Second {
+		t.Errorf("4 requests took %v, want about 100ms", d)
+	}
+}
+
+func TestLimiterCanceledWaitReturnsToken(t *testing.T) {
+	l := newLimiter(RateLimit{Rate: 1})
+	if _, err := l.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("acquire() = %v, want context.DeadlineExceeded", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tokens < -0.1 {
+		t.Errorf("tokens = %v, the canceled reservation is not given back", l.tokens)
+	}
+}
+
+func TestLimiterMaxInFlight(t *testing.T) {
+	var inFlight, peak int32
+	srv := newFakeServer(t)
+	srv.handle("Session.Ping", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	c := srv.client(Config{RateLimit: RateLimit{MaxInFlight: 2}})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Session.Ping(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("%d requests were in flight, want at most 2", peak)
+	}
+}
+
+func TestMethodLimitDoesNotHoldClientLimit(t *testing.T) {
+	srv := newFakeServer(t)
+	c := srv.client(Config{
+		RateLimit:    RateLimit{MaxInFlight: 1},
+		MethodLimits: map[string]RateLimit{"HostGroup": {Rate: 1}},
+	})
+	ctx := context.Background()
+
+	if _, err := c.Call(ctx, "HostGroup.FindHosts", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// waits about a second for the next HostGroup token
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := c.Call(ctx, "HostGroup.FindHosts", nil, nil); err != nil {
+			t.Error(err)
+		}
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	start := time.Now()
+	if _, err := c.Session.Ping(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Errorf("Session.Ping waited %v for a call throttled by its method limit", d)
+	}
+	<-done
+}
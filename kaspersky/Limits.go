@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-
-	"net/http"
 )
 
 //	Limits Interface for working with Limits subsystem.
@@ -39,13 +35,9 @@ type Limits service
 
 // GetLimits Returns a limit of specified parameter.
 func (ls *Limits) GetLimits(ctx context.Context, param int64) (*PxgValInt, error) {
-	postData := []byte(fmt.Sprintf(`{ "param": %d }`, param))
-	request, err := http.NewRequest("POST", ls.client.Server+"/api/v1.0/Limits.GetLimits", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"param": param}
 
 	pxgValInt := new(PxgValInt)
-	_, err = ls.client.Request(ctx, request, &pxgValInt)
+	_, err := ls.client.Call(ctx, "Limits.GetLimits", postData, &pxgValInt)
 	return pxgValInt, err
 }
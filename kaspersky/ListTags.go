@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // ListTags Service allows to acquire and manage tags to various KSC objects.
@@ -44,17 +41,7 @@ type ListTags service
 
 // GetAllTags Retrieves all known tag values that can be set for a list item
 func (lt *ListTags) GetAllTags(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lt.client.Server+"/api/v1.0/ListTags.GetAllTags", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lt.client.Request(ctx, request, nil)
+	raw, err := lt.client.Call(ctx, "ListTags.GetAllTags", params, nil)
 	return raw, err
 }
 
@@ -66,49 +53,19 @@ type NewTagParams struct {
 
 // AddTag Allows to add a new tag value that can be set for a list item
 func (lt *ListTags) AddTag(ctx context.Context, params NewTagParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lt.client.Server+"/api/v1.0/ListTags.AddTag", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lt.client.Request(ctx, request, nil)
+	raw, err := lt.client.Call(ctx, "ListTags.AddTag", params, nil)
 	return raw, err
 }
 
 // DeleteTags2 Allows to delete multiple tag values in one method call
 func (lt *ListTags) DeleteTags2(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lt.client.Server+"/api/v1.0/ListTags.DeleteTags2", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lt.client.Request(ctx, request, nil)
+	raw, err := lt.client.Call(ctx, "ListTags.DeleteTags2", params, nil)
 	return raw, err
 }
 
 // GetTags Retrieves tag values for specified list items
 func (lt *ListTags) GetTags(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lt.client.Server+"/api/v1.0/ListTags.GetTags", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lt.client.Request(ctx, request, nil)
+	raw, err := lt.client.Call(ctx, "ListTags.GetTags", params, nil)
 	return raw, err
 }
 
@@ -116,32 +73,12 @@ func (lt *ListTags) GetTags(ctx context.Context, params interface{}) ([]byte, er
 // If szwOldTagValue is assigned to some objects then szwNewTagValue will be also assigned to these objects.
 // So RenameTag is not equivalent to deleting szwOldTagValue and adding szwNewTagValue
 func (lt *ListTags) RenameTag(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lt.client.Server+"/api/v1.0/ListTags.RenameTag", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lt.client.Request(ctx, request, nil)
+	raw, err := lt.client.Call(ctx, "ListTags.RenameTag", params, nil)
 	return raw, err
 }
 
 // SetTags Assign tag values for specified list items
 func (lt *ListTags) SetTags(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", lt.client.Server+"/api/v1.0/ListTags.SetTags", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := lt.client.Request(ctx, request, nil)
+	raw, err := lt.client.Call(ctx, "ListTags.SetTags", params, nil)
 	return raw, err
 }
@@ -0,0 +1,208 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Logger structured leveled logger used by KscClient.
+//
+// keysAndValues are alternating field names and values, e.g. "method", "HostGroup.FindHosts", "status", 200.
+// Values are redacted by the client before they are passed to the logger,
+// so implementations never receive passwords, session tokens or authorization headers.
+type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+	Error(msg string, keysAndValues ...interface{})
+}
+
+// LogLevel minimal level of messages written by the logger returned from NewStdLogger.
+type LogLevel int
+
+const (
+	LevelDebug LogLevel = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	}
+	return fmt.Sprintf("LogLevel(%d)", int(l))
+}
+
+// NewStdLogger returns Logger writing messages of the given level and above to l
+// as "LEVEL msg key=value ...". If l is nil, the standard logger of the log package is used.
+func NewStdLogger(l *log.Logger, level LogLevel) Logger {
+	return &stdLogger{logger: l, level: level}
+}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  LogLevel
+}
+
+func (l *stdLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.log(LevelDebug, msg, keysAndValues)
+}
+
+func (l *stdLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(LevelInfo, msg, keysAndValues)
+}
+
+func (l *stdLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.log(LevelWarn, msg, keysAndValues)
+}
+
+func (l *stdLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.log(LevelError, msg, keysAndValues)
+}
+
+func (l *stdLogger) log(level LogLevel, msg string, keysAndValues []interface{}) {
+	if level < l.level {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(level.String() + " " + msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 < len(keysAndValues) {
+			fmt.Fprintf(&sb, " %v=%q", keysAndValues[i], fmt.Sprint(keysAndValues[i+1]))
+		} else {
+			fmt.Fprintf(&sb, " %v", keysAndValues[i])
+		}
+	}
+
+	if l.logger != nil {
+		l.logger.Print(sb.String())
+	} else {
+		log.Print(sb.String())
+	}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// redacted replacement of secret values
+const redacted = "[REDACTED]"
+
+var (
+	// sensitiveJSONField JSON string fields like "strPassword", "wstrPwd", "token", "ClientSecret"
+	sensitiveJSONField = regexp.MustCompile(`("[^"]*(?i:pass|pwd|token|secret)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	// sensitiveParam pass= values of KSCBasic authorization and URL queries
+	sensitiveParam = regexp.MustCompile(`(?i)(pass(?:word)?=)(?:"[^"]*"|[^\s,&"]*)`)
+
+	// authorizationScheme credentials of authorization header values
+	authorizationScheme = regexp.MustCompile(`((?:KSCBasic|KSCT|KSCWT|KSCGW|Bearer|Basic)\s+)\S.*`)
+)
+
+// sensitiveHeaders headers which values are never logged
+var sensitiveHeaders = []string{"Authorization", "X-KSC-Session", "Cookie", "Set-Cookie"}
+
+// redact removes passwords, tokens and authorization credentials from s.
+func redact(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, redacted, -1)
+		}
+	}
+
+	s = authorizationScheme.ReplaceAllString(s, "${1}"+redacted)
+	s = sensitiveParam.ReplaceAllString(s, "${1}"+redacted)
+	return sensitiveJSONField.ReplaceAllString(s, `${1}"`+redacted+`"`)
+}
+
+// redactingLogger redacts all values before passing them to the wrapped logger.
+type redactingLogger struct {
+	logger Logger
+
+	// secrets returns values which must never be logged, e.g. the current session token
+	secrets func() []string
+}
+
+func (l *redactingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(msg, l.redact(keysAndValues)...)
+}
+
+func (l *redactingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.logger.Info(msg, l.redact(keysAndValues)...)
+}
+
+func (l *redactingLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.logger.Warn(msg, l.redact(keysAndValues)...)
+}
+
+func (l *redactingLogger) Error(msg string, keysAndValues ...interface{}) {
+	l.logger.Error(msg, l.redact(keysAndValues)...)
+}
+
+func (l *redactingLogger) redact(keysAndValues []interface{}) []interface{} {
+	secrets := l.secrets()
+
+	result := make([]interface{}, len(keysAndValues))
+	for i, v := range keysAndValues {
+		switch value := v.(type) {
+		case string:
+			result[i] = redact(value, secrets...)
+		case []byte:
+			result[i] = redact(string(value), secrets...)
+		case http.Header:
+			header := value.Clone()
+			for _, name := range sensitiveHeaders {
+				if header.Get(name) != "" {
+					header.Set(name, redacted)
+				}
+			}
+			result[i] = header
+		case error:
+			result[i] = redact(value.Error(), secrets...)
+		case fmt.Stringer:
+			result[i] = redact(value.String(), secrets...)
+		default:
+			result[i] = v
+		}
+	}
+	return result
+}
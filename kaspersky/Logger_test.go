@@ -0,0 +1,138 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordLogger Logger which keeps all messages formatted as "LEVEL msg key=value ...".
+type recordLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *recordLogger) record(level LogLevel, msg string, keysAndValues []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.messages = append(l.messages, fmt.Sprint(level, " ", msg, " ", keysAndValues))
+}
+
+func (l *recordLogger) Debug(msg string, kv ...interface{}) { l.record(LevelDebug, msg, kv) }
+func (l *recordLogger) Info(msg string, kv ...interface{})  { l.record(LevelInfo, msg, kv) }
+func (l *recordLogger) Warn(msg string, kv ...interface{})  { l.record(LevelWarn, msg, kv) }
+func (l *recordLogger) Error(msg string, kv ...interface{}) { l.record(LevelError, msg, kv) }
+
+func (l *recordLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.messages, "\n")
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{`{"strPassword": "p@ss", "nType": 1}`, `{"strPassword": "[REDACTED]", "nType": 1}`},
+		{`{"wstrPwd":"a\"b","token":"t"}`, `{"wstrPwd":"[REDACTED]","token":"[REDACTED]"}`},
+		{`KSCBasic user="dXNlcg==", pass="cGFzcw=="`, `KSCBasic [REDACTED]`},
+		{`Bearer eyJhbGciOi`, `Bearer [REDACTED]`},
+		{`https://ksc/login?user=u&password=secret&x=1`, `https://ksc/login?user=u&password=[REDACTED]&x=1`},
+		{`session abc123 started`, `session [REDACTED] started`},
+		{`{"nCount": 10}`, `{"nCount": 10}`},
+	}
+
+	for _, tt := range tests {
+		if got := redact(tt.in, "abc123"); got != tt.want {
+			t.Errorf("redact(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRedactingLoggerHeaders(t *testing.T) {
+	rec := &recordLogger{}
+	l := &redactingLogger{logger: rec, secrets: func() []string { return nil }}
+
+	header := http.Header{}
+	header.Set("Authorization", "KSCT token")
+	header.Set("X-KSC-Session", "session")
+	header.Set("Accept", "application/json")
+	l.Info("headers", "header", header)
+
+	if got := rec.String(); strings.Contains(got, "KSCT token") || strings.Contains(got, "[session]") || !strings.Contains(got, "application/json") {
+		t.Errorf("logged %s", got)
+	}
+	if header.Get("Authorization") != "KSCT token" {
+		t.Error("the original header has been modified")
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0), LevelInfo)
+
+	l.Debug("skipped", "k", "v")
+	l.Info("ksc request", "method", "Session.Ping", "status", 200, "odd")
+	l.Error("ksc request failed", "error", "boom")
+
+	want := "INFO ksc request method=\"Session.Ping\" status=\"200\" odd\nERROR ksc request failed error=\"boom\"\n"
+	if got := buf.String(); got != want {
+		t.Errorf("logged %q, want %q", got, want)
+	}
+}
+
+func TestClientLogsRedacted(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.reply("Session.StartSession", `{"PxgRetVal":"session-token-42"}`)
+	srv.reply("Session.Ping", `{"echo":"session-token-42"}`)
+
+	rec := &recordLogger{}
+	c := srv.client(Config{UserName: "user", Password: "s3cret", XKscSession: true, Debug: true, Logger: rec})
+
+	ctx := context.Background()
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Call(ctx, "Session.Ping", map[string]string{"strPassword": "s3cret"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	got := rec.String()
+	for _, secret := range []string{"s3cret", "session-token-42"} {
+		if strings.Contains(got, secret) {
+			t.Errorf("%q is logged:\n%s", secret, got)
+		}
+	}
+	if !strings.Contains(got, "Session.Ping") {
+		t.Errorf("request is not logged:\n%s", got)
+	}
+}
@@ -25,11 +25,8 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
+	"time"
 )
 
 // MdmCertCtrlApi Mobile devices certificates and restore data management.
@@ -38,18 +35,9 @@ type MdmCertCtrlApi service
 // CancelGeneratePackage2 Cancels asynchronous operation SetCertificateAsync.GeneratePackageAsync2.
 //	wstrRequestId - identity of asynchronous operation
 func (mca *MdmCertCtrlApi) CancelGeneratePackage2(ctx context.Context, wstrRequestId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.CancelGeneratePackage2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := mca.client.Request(ctx, request, nil)
-
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.CancelGeneratePackage2", postData, nil)
 
 	return err
 }
@@ -57,18 +45,9 @@ func (mca *MdmCertCtrlApi) CancelGeneratePackage2(ctx context.Context, wstrReque
 // CancelSetCertificate2 Cancels asynchronous operation SetCertificateAsync.SetCertificateAsync2.
 //	wstrRequestId - identity of asynchronous operation
 func (mca *MdmCertCtrlApi) CancelSetCertificate2(ctx context.Context, wstrRequestId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.CancelSetCertificate2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	raw, err := mca.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.CancelSetCertificate2", postData, nil)
 
 	return err
 }
@@ -76,19 +55,10 @@ func (mca *MdmCertCtrlApi) CancelSetCertificate2(ctx context.Context, wstrReques
 // CheckMailNotificationSettings Check email settings It defines if virtual server's email settings
 //should be checked in merge (as defaults) with main server's email settings
 func (mca *MdmCertCtrlApi) CheckMailNotificationSettings(ctx context.Context, bCheckMainServerDefaults bool) (*PxgValBool, error) {
-	postData := []byte(fmt.Sprintf(`{"bCheckMainServerDefaults": %v}`, bCheckMainServerDefaults))
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.CheckMailNotificationSettings",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bCheckMainServerDefaults": bCheckMainServerDefaults}
 
 	smtpServerEmpty := new(PxgValBool)
-	raw, err := mca.client.Request(ctx, request, &smtpServerEmpty)
-
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.CheckMailNotificationSettings", postData, &smtpServerEmpty)
 
 	return smtpServerEmpty, err
 }
@@ -97,17 +67,8 @@ func (mca *MdmCertCtrlApi) CheckMailNotificationSettings(ctx context.Context, bC
 
 // CheckPkiEnabled Check if PKI enabled.
 func (mca *MdmCertCtrlApi) CheckPkiEnabled(ctx context.Context) (*PxgValStr, error) {
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.CheckPkiEnabled", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	pkiFlag := new(PxgValStr)
-	raw, err := mca.client.Request(ctx, request, &pkiFlag)
-
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.CheckPkiEnabled", nil, &pkiFlag)
 
 	return pkiFlag, err
 }
@@ -118,18 +79,9 @@ func (mca *MdmCertCtrlApi) CheckPkiEnabled(ctx context.Context) (*PxgValStr, err
 // GetCertificatePublic certificate id to get
 //	nCertId - Retrieve certificate's public part.
 func (mca *MdmCertCtrlApi) GetCertificatePublic(ctx context.Context, nCertId int64) error {
-	postData := []byte(fmt.Sprintf(`{"nCertId": %d}`, nCertId))
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.GetCertificatePublic",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	raw, err := mca.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"nCertId": nCertId}
 
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.GetCertificatePublic", postData, nil)
 
 	return err
 }
@@ -170,19 +122,15 @@ type IssuanceSettingValue struct {
 	CIWstrPKICERTTemplateName string `json:"CI_wstrPkiCertTemplateName"`
 }
 
+// RenewalPeriod returns CINRenewalPeriodSEC as time.Duration.
+func (v *IssuanceSettingValue) RenewalPeriod() time.Duration {
+	return SecondsToDuration(v.CINRenewalPeriodSEC)
+}
+
 // GetIssuanceSettings Retrieve saved issuance settings for certificate types.
 func (mca *MdmCertCtrlApi) GetIssuanceSettings(ctx context.Context) (*IssuanceSettings, error) {
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.GetIssuanceSettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	issuanceSettings := new(IssuanceSettings)
-	raw, err := mca.client.Request(ctx, request, &issuanceSettings)
-
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.GetIssuanceSettings", nil, &issuanceSettings)
 
 	return issuanceSettings, err
 }
@@ -190,19 +138,10 @@ func (mca *MdmCertCtrlApi) GetIssuanceSettings(ctx context.Context) (*IssuanceSe
 // GetIssuanceSettingsByType Retrieve saved issuance settings for specific certificate type.
 //	nCertType Certificate type (0 - Unknown, 1 - User, 2 - EMail, 3 - VPN)
 func (mca *MdmCertCtrlApi) GetIssuanceSettingsByType(ctx context.Context, nCertType int64) (*IssuanceSetting, error) {
-	postData := []byte(fmt.Sprintf(`{"nCertType": %d}`, nCertType))
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.GetIssuanceSettingsByType",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nCertType": nCertType}
 
 	issuanceSetting := new(IssuanceSetting)
-	raw, err := mca.client.Request(ctx, request, &issuanceSetting)
-
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.GetIssuanceSettingsByType", postData, &issuanceSetting)
 
 	return issuanceSetting, err
 }
@@ -212,18 +151,9 @@ func (mca *MdmCertCtrlApi) GetIssuanceSettingsByType(ctx context.Context, nCertT
 // GetPkiTemplates Get PKI templates.
 //	bForceReload (bool) if True then templates will be reloaded from AD, if False then it will be loaded from cache
 func (mca *MdmCertCtrlApi) GetPkiTemplates(ctx context.Context, bForceReload bool) error {
-	postData := []byte(fmt.Sprintf(`{"bForceReload": %v}`, bForceReload))
-	request, err := http.NewRequest("POST", mca.client.Server+"/api/v1.0/MdmCertCtrlApi.GetPkiTemplates",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	raw, err := mca.client.Request(ctx, request, nil)
-
-	if mca.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	postData := map[string]interface{}{"bForceReload": bForceReload}
+
+	_, err := mca.client.Call(ctx, "MdmCertCtrlApi.GetPkiTemplates", postData, nil)
 
 	return err
 }
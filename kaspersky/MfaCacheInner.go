@@ -26,8 +26,6 @@ package kaspersky
 
 import (
 	"context"
-	"log"
-	"net/http"
 )
 
 // MfaCacheInner MFA Cache provider (Only inside server)
@@ -35,77 +33,32 @@ type MfaCacheInner service
 
 // GetMfaRequiredForAll Get "MFA required for all users" flag
 func (mci *MfaCacheInner) GetMfaRequiredForAll(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", mci.client.Server+"/api/v1.0/MfaCacheInner.GetMfaRequiredForAll", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := mci.client.Request(ctx, request, nil)
-
-	if mci.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := mci.client.Call(ctx, "MfaCacheInner.GetMfaRequiredForAll", nil, nil)
 
 	return raw, err
 }
 
 // GetMfaKeyIssuer Get 2FA key Issuer
 func (mci *MfaCacheInner) GetMfaKeyIssuer(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", mci.client.Server+"/api/v1.0/MfaCacheInner.GetMfaKeyIssuer", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := mci.client.Request(ctx, request, nil)
-
-	if mci.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := mci.client.Call(ctx, "MfaCacheInner.GetMfaKeyIssuer", nil, nil)
 
 	return raw, err
 }
 
 func (mci *MfaCacheInner) GetTotpSecretKeySettings(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", mci.client.Server+"/api/v1.0/MfaCacheInner.GetTotpSecretKeySettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := mci.client.Request(ctx, request, nil)
-
-	if mci.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := mci.client.Call(ctx, "MfaCacheInner.GetTotpSecretKeySettings", nil, nil)
 
 	return raw, err
 }
 
 func (mci *MfaCacheInner) GetTotpVerifySettings(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", mci.client.Server+"/api/v1.0/MfaCacheInner.GetTotpVerifySettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := mci.client.Request(ctx, request, nil)
-
-	if mci.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := mci.client.Call(ctx, "MfaCacheInner.GetTotpVerifySettings", nil, nil)
 
 	return raw, err
 }
 
 func (mci *MfaCacheInner) IsCurrentUserExcludesMfa(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", mci.client.Server+"/api/v1.0/MfaCacheInner.IsCurrentUserExcludesMfa", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := mci.client.Request(ctx, request, nil)
-
-	if mci.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := mci.client.Call(ctx, "MfaCacheInner.IsCurrentUserExcludesMfa", nil, nil)
 
 	return raw, err
 }
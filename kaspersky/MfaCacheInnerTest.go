@@ -26,24 +26,13 @@ package kaspersky
 
 import (
 	"context"
-	"log"
-	"net/http"
 )
 
 // MfaCacheInnerTest Test-only interface for MfaCacheInner
 type MfaCacheInnerTest service
 
 func (mci *MfaCacheInnerTest) Test(ctx context.Context) error {
-	request, err := http.NewRequest("POST", mci.client.Server+"/api/v1.0/MfaCacheInnerTest.Test", nil)
-	if err != nil {
-		return err
-	}
-
-	raw, err := mci.client.Request(ctx, request, nil)
-
-	if mci.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mci.client.Call(ctx, "MfaCacheInnerTest.Test", nil, nil)
 
 	return err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // MigrationData service provide to:
@@ -57,28 +53,17 @@ type KnownProductVal struct {
 
 // AcquireKnownProducts Acquire list of known products for migration.
 func (md *MigrationData) AcquireKnownProducts(ctx context.Context) (*KnownProducts, []byte, error) {
-	request, err := http.NewRequest("POST", md.client.Server+"/api/v1.0/MigrationData.AcquireKnownProducts",
-		nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	knownProducts := new(KnownProducts)
-	raw, err := md.client.Request(ctx, request, &knownProducts)
+	raw, err := md.client.Call(ctx, "MigrationData.AcquireKnownProducts", nil, &knownProducts)
 	return knownProducts, raw, err
 }
 
 // CancelExport Interrupts and cancels export operation at any time by async action GUID of export operation,
 // returned by MigrationData.Export method
 func (md *MigrationData) CancelExport(ctx context.Context, wstrActionGuid string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrActionGuid": "%s"}`, wstrActionGuid))
-	request, err := http.NewRequest("POST", md.client.Server+"/api/v1.0/MigrationData.CancelExport",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := md.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"wstrActionGuid": wstrActionGuid}
+
+	raw, err := md.client.Call(ctx, "MigrationData.CancelExport", postData, nil)
 	return raw, err
 }
 
@@ -134,18 +119,8 @@ type ProductsInfoValue struct {
 //
 // As a result, user receives GUID to async action, containing URL to zip-archive with all exported data
 func (md *MigrationData) Export(ctx context.Context, params MDExportParams) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", md.client.Server+"/api/v1.0/MigrationData.Export", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := md.client.Request(ctx, request, &pxgValStr)
+	raw, err := md.client.Call(ctx, "MigrationData.Export", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -164,14 +139,8 @@ func (md *MigrationData) Export(ctx context.Context, params MDExportParams) (*Px
 //
 // After all above is done, you can call MigrationData.Import to perform import
 func (md *MigrationData) InitFileUpload(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", md.client.Server+"/api/v1.0/MigrationData.InitFileUpload",
-		nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := md.client.Request(ctx, request, &pxgValStr)
+	raw, err := md.client.Call(ctx, "MigrationData.InitFileUpload", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -200,17 +169,7 @@ type IOptions struct {
 // If the operation succeeds then AsyncActionStateChecker. CheckActionState returns bFinalized=true and lStateCode=1 in pStateData.
 // Otherwise, a call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (md *MigrationData) Import(ctx context.Context, params ImportMDParams) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", md.client.Server+"/api/v1.0/MigrationData.Import", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := md.client.Request(ctx, request, &pxgValStr)
+	raw, err := md.client.Call(ctx, "MigrationData.Import", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
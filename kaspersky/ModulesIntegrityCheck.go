@@ -26,8 +26,6 @@ package kaspersky
 
 import (
 	"context"
-	"log"
-	"net/http"
 )
 
 // ModulesIntegrityCheck Interface for modules integrity check.
@@ -43,17 +41,8 @@ type IntegrityCheckInfo struct {
 
 // GetIntegrityCheckInfo Returns integrity check info.
 func (mic *ModulesIntegrityCheck) GetIntegrityCheckInfo(ctx context.Context) (*IntegrityCheckInfo, error) {
-	request, err := http.NewRequest("POST", mic.client.Server+"/api/v1.0/ModulesIntegrityCheck.GetIntegrityCheckInfo", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	integrityCheckInfo := new(IntegrityCheckInfo)
-	raw, err := mic.client.Request(ctx, request, &integrityCheckInfo)
-
-	if mic.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := mic.client.Call(ctx, "ModulesIntegrityCheck.GetIntegrityCheckInfo", nil, &integrityCheckInfo)
 
 	return integrityCheckInfo, err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // Multitenancy product managing.
@@ -38,37 +33,23 @@ type Multitenancy service
 
 // GetTenantId Retrieves tenant identity. Identity is unique for each tenant
 func (m *Multitenancy) GetTenantId(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", m.client.Server+"/api/v1.0/Multitenancy.GetTenantId", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := m.client.Request(ctx, request, &pxgValStr)
+	raw, err := m.client.Call(ctx, "Multitenancy.GetTenantId", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // GetProducts Retrieves multitenancy products available for current tenant.
 func (m *Multitenancy) GetProducts(ctx context.Context, strProdName, strProdVersion string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strProdName": "%s", "strProdVersion": "%s"}`, strProdName, strProdVersion))
-	request, err := http.NewRequest("POST", m.client.Server+"/api/v1.0/Multitenancy.GetProducts", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strProdName": strProdName, "strProdVersion": strProdVersion}
 
-	raw, err := m.client.Request(ctx, request, nil)
+	raw, err := m.client.Call(ctx, "Multitenancy.GetProducts", postData, nil)
 	return raw, err
 }
 
 // GetAuthToken Get new binary token for current tennant
 func (m *Multitenancy) GetAuthToken(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", m.client.Server+"/api/v1.0/Multitenancy.GetAuthToken", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := m.client.Request(ctx, request, &pxgValStr)
+	raw, err := m.client.Call(ctx, "Multitenancy.GetAuthToken", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -83,17 +64,6 @@ type VerifyTokenParam struct {
 
 // CheckAuthToken Verify token
 func (m *Multitenancy) CheckAuthToken(ctx context.Context, params VerifyTokenParam) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", m.client.Server+"/api/v1.0/Multitenancy.CheckAuthToken",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := m.client.Request(ctx, request, nil)
+	raw, err := m.client.Call(ctx, "Multitenancy.CheckAuthToken", params, nil)
 	return raw, err
 }
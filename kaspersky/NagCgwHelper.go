@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-
-	"net/http"
 )
 
 // NagCgwHelper Nagent CGW (Connection Gateway) API
@@ -39,12 +35,12 @@ type NagCgwHelper service
 
 // GetProductComponentLocation Retrieves product's component location.
 func (nc *NagCgwHelper) GetProductComponentLocation(ctx context.Context, szwProduct, szwVersion, szwComponent string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProduct": "%s",	"szwVersion": "%s",	"szwComponent": "%s"}`, szwProduct, szwVersion, szwComponent))
-	request, err := http.NewRequest("POST", nc.client.Server+"/api/v1.0/NagCgwHelper.GetProductComponentLocation", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"szwProduct":   szwProduct,
+		"szwVersion":   szwVersion,
+		"szwComponent": szwComponent,
 	}
 
-	raw, err := nc.client.Request(ctx, request, nil)
+	raw, err := nc.client.Call(ctx, "NagCgwHelper.GetProductComponentLocation", postData, nil)
 	return raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // NagGuiCalls Remote host caller.
@@ -38,12 +35,6 @@ type NagGuiCalls service
 
 // CallConnectorAsync Asynchronously connects to the remote host (if hasn't connected yet), and makes call with the specified name szwCallName
 func (ngc *NagGuiCalls) CallConnectorAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ngc.client.Server+"/api/v1.0/NagGuiCalls.CallConnectorAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ngc.client.Request(ctx, request, nil)
+	raw, err := ngc.client.Call(ctx, "NagGuiCalls.CallConnectorAsync", params, nil)
 	return raw, err
 }
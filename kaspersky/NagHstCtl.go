@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // NagHstCtl Manage nagent on host.
@@ -40,13 +35,7 @@ type NagHstCtl service
 
 // GetHostRuntimeInfo Acquire runtime host information
 func (nh *NagHstCtl) GetHostRuntimeInfo(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", nh.client.Server+"/api/v1.0/NagHstCtl.GetHostRuntimeInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := nh.client.Request(ctx, request, nil)
+	raw, err := nh.client.Call(ctx, "NagHstCtl.GetHostRuntimeInfo", params, nil)
 	return raw, err
 }
 
@@ -54,26 +43,26 @@ func (nh *NagHstCtl) GetHostRuntimeInfo(ctx context.Context, params interface{})
 // The method sends to the specified product task one of such commands as 'start', 'stop', 'suspend', 'resume'.
 func (nh *NagHstCtl) SendTaskAction(ctx context.Context, szwProduct, szwVersion, szwTaskStorageId string,
 	nTaskAction int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProduct": "%s", "szwVersion": "%s", "szwTaskStorageId": "%s", "nTaskAction": %d }`,
-		szwProduct, szwVersion, szwTaskStorageId, nTaskAction))
-	request, err := http.NewRequest("POST", nh.client.Server+"/api/v1.0/NagHstCtl.SendTaskAction", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"szwProduct":       szwProduct,
+		"szwVersion":       szwVersion,
+		"szwTaskStorageId": szwTaskStorageId,
+		"nTaskAction":      nTaskAction,
 	}
 
-	raw, err := nh.client.Request(ctx, request, nil)
+	raw, err := nh.client.Call(ctx, "NagHstCtl.SendTaskAction", postData, nil)
 	return raw, err
 }
 
 // SendProductAction Initiate changing state of products at host
 // The method sends to the specified product 'start' or 'stop' command.
 func (nh *NagHstCtl) SendProductAction(ctx context.Context, szwProduct, szwVersion string, nProductAction int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(` { "szwProduct": "%s", "szwVersion": "%s", "nProductAction": %d }`, szwProduct, szwVersion, nProductAction))
-	request, err := http.NewRequest("POST", nh.client.Server+"/api/v1.0/NagHstCtl.SendProductAction", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"szwProduct":     szwProduct,
+		"szwVersion":     szwVersion,
+		"nProductAction": nProductAction,
 	}
 
-	raw, err := nh.client.Request(ctx, request, nil)
+	raw, err := nh.client.Call(ctx, "NagHstCtl.SendProductAction", postData, nil)
 	return raw, err
 }
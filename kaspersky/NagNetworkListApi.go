@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // NagNetworkListApi Nagent OpenAPI to work with network lists.
@@ -56,17 +53,7 @@ type NetworkListParams struct {
 //
 // Values for listName and itemId see in Network lists which elements are associated with files Network lists which elements are associated with files.
 func (nnl *NagNetworkListApi) GetListItemFileInfo(ctx context.Context, params NetworkListParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil
-	}
-
-	request, err := http.NewRequest("POST", nnl.client.Server+"/api/v1.0/NagNetworkListApi.GetListItemFileInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := nnl.client.Request(ctx, request, nil)
+	raw, err := nnl.client.Call(ctx, "NagNetworkListApi.GetListItemFileInfo", params, nil)
 	return raw, err
 }
 
@@ -74,16 +61,6 @@ func (nnl *NagNetworkListApi) GetListItemFileInfo(ctx context.Context, params Ne
 //
 // Values for listName and itemId see in Network lists which elements are associated with files Network lists which elements are associated with files.
 func (nnl *NagNetworkListApi) GetListItemFileChunk(ctx context.Context, params NetworkListParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil
-	}
-
-	request, err := http.NewRequest("POST", nnl.client.Server+"/api/v1.0/NagNetworkListApi.GetListItemFileChunk", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := nnl.client.Request(ctx, request, nil)
+	raw, err := nnl.client.Call(ctx, "NagNetworkListApi.GetListItemFileChunk", params, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 //	NagRdu Class service to remote diagnostics on host.
@@ -38,29 +34,25 @@ type NagRdu service
 
 // ChangeTraceParams Change trace-level for specific product, turns on/off tracing
 func (nr *NagRdu) ChangeTraceParams(ctx context.Context, szwProductID string, nTraceLevel int64) (*CurrentHostState, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProductID": "%s", "nTraceLevel": %d }`, szwProductID, nTraceLevel))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.ChangeTraceParams", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwProductID": szwProductID, "nTraceLevel": nTraceLevel}
 
 	currentHostState := new(CurrentHostState)
-	raw, err := nr.client.Request(ctx, request, &currentHostState)
+	raw, err := nr.client.Call(ctx, "NagRdu.ChangeTraceParams", postData, &currentHostState)
 	return currentHostState, raw, err
 }
 
 // ChangeTraceRotatedParams Change rotated-trace-level for specific product, turns on/off tracing
 func (nr *NagRdu) ChangeTraceRotatedParams(ctx context.Context, szwProductID string, nTraceLevel,
 	nPartsCount, nMaxPartSize int64) (*CurrentHostState, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{ "szwProductID": "%s", "nTraceLevel": %d, "nPartsCount": %d, 
-	"nMaxPartSize": %d }`, szwProductID, nTraceLevel, nPartsCount, nMaxPartSize))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.ChangeTraceRotatedParams", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"szwProductID": szwProductID,
+		"nTraceLevel":  nTraceLevel,
+		"nPartsCount":  nPartsCount,
+		"nMaxPartSize": nMaxPartSize,
 	}
 
 	currentHostState := new(CurrentHostState)
-	raw, err := nr.client.Request(ctx, request, &currentHostState)
+	raw, err := nr.client.Call(ctx, "NagRdu.ChangeTraceRotatedParams", postData, &currentHostState)
 	return currentHostState, raw, err
 }
 
@@ -68,33 +60,29 @@ func (nr *NagRdu) ChangeTraceRotatedParams(ctx context.Context, szwProductID str
 func (nr *NagRdu) ChangeXperfBaseParams(ctx context.Context, szwProductID string, nTraceLevel, nXPerfMode int64) (*CurrentHostState,
 	[]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"szwProductID": "%s", "nTraceLevel": %d, "nXPerfMode": %d }`, szwProductID,
-		nTraceLevel,
-		nXPerfMode))
-
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.ChangeXperfBaseParams", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"szwProductID": szwProductID,
+		"nTraceLevel":  nTraceLevel,
+		"nXPerfMode":   nXPerfMode,
 	}
 
 	currentHostState := new(CurrentHostState)
-	raw, err := nr.client.Request(ctx, request, &currentHostState)
+	raw, err := nr.client.Call(ctx, "NagRdu.ChangeXperfBaseParams", postData, &currentHostState)
 	return currentHostState, raw, err
 }
 
 // ChangeXperfRotatedParams Change XPerf rotated-trace-level for specific product, turns on/off XPerf tracing
 func (nr *NagRdu) ChangeXperfRotatedParams(ctx context.Context, szwProductID string, nTraceLevel, nXPerfMode,
 	nMaxPartSize int64) (*CurrentHostState, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProductID": "%s", "nTraceLevel": %d, "nXPerfMode": %d, "nMaxPartSize": %d }`, szwProductID,
-		nTraceLevel, nXPerfMode, nMaxPartSize))
-
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.ChangeXperfRotatedParams", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"szwProductID": szwProductID,
+		"nTraceLevel":  nTraceLevel,
+		"nXPerfMode":   nXPerfMode,
+		"nMaxPartSize": nMaxPartSize,
 	}
 
 	currentHostState := new(CurrentHostState)
-	raw, err := nr.client.Request(ctx, request, &currentHostState)
+	raw, err := nr.client.Call(ctx, "NagRdu.ChangeXperfRotatedParams", postData, &currentHostState)
 	return currentHostState, raw, err
 }
 
@@ -168,27 +156,19 @@ type ProductsValue struct {
 // If the operation succeeds then AsyncActionStateChecker.CheckActionState returns URL-path in pStateData.
 // Otherwise, a call to AsyncActionStateChecker::CheckActionState returns error in pStateData.
 func (nr *NagRdu) CreateAndDownloadDumpAsync(ctx context.Context, szwProcessName string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProcessName": "%s"}`, szwProcessName))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.CreateAndDownloadDumpAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwProcessName": szwProcessName}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.CreateAndDownloadDumpAsync", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // DeleteFile Permanently delete specific file on host
 func (nr *NagRdu) DeleteFile(ctx context.Context, szwRemoteFile string) (*CurrentHostState, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwRemoteFile": "%s"}`, szwRemoteFile))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.DeleteFile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwRemoteFile": szwRemoteFile}
 
 	currentHostState := new(CurrentHostState)
-	raw, err := nr.client.Request(ctx, request, &currentHostState)
+	raw, err := nr.client.Call(ctx, "NagRdu.DeleteFile", postData, &currentHostState)
 	return currentHostState, raw, err
 }
 
@@ -198,18 +178,8 @@ type RemoteFilesParams struct {
 
 // DeleteFiles Permanently delete array of specific files on host
 func (nr *NagRdu) DeleteFiles(ctx context.Context, params RemoteFilesParams) (*CurrentHostState, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, nil
-	}
-
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.DeleteFiles", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	currentHostState := new(CurrentHostState)
-	raw, err := nr.client.Request(ctx, request, &currentHostState)
+	raw, err := nr.client.Call(ctx, "NagRdu.DeleteFiles", params, &currentHostState)
 	return currentHostState, raw, err
 }
 
@@ -220,13 +190,8 @@ func (nr *NagRdu) DeleteFiles(ctx context.Context, params RemoteFilesParams) (*C
 //	If the operation succeeds then AsyncActionStateChecker.CheckActionState returns URL-path in pStateData.
 //	Otherwise, a call to AsyncActionStateChecker::CheckActionState returns error in pStateData.
 func (nr *NagRdu) DownloadCommonDataAsync(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.DownloadCommonDataAsync", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.DownloadCommonDataAsync", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -237,28 +202,24 @@ func (nr *NagRdu) DownloadCommonDataAsync(ctx context.Context) (*PxgValStr, []by
 // If the operation succeeds then AsyncActionStateChecker.CheckActionState returns URL-path in pStateData.
 // Otherwise, a call to AsyncActionStateChecker::CheckActionState returns error in pStateData.
 func (nr *NagRdu) DownloadEventlogAsync(ctx context.Context, szwEventLog string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwEventLog": "%s"}`, szwEventLog))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.DownloadEventlogAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwEventLog": szwEventLog}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.DownloadEventlogAsync", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // ExecuteFileAsync Asynchronously run executable file, previously uploaded to host using GetUrlToUploadFileToHost.
 // Uploaded file should be a zip-archive with executable-file (and, maybe, other files and folders) on 'utility'-folder
 func (nr *NagRdu) ExecuteFileAsync(ctx context.Context, szwURL, szwShortExecName, szwParams string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwURL": "%s", "szwShortExecName": "%s", "szwParams": "%s"}`, szwURL, szwShortExecName, szwParams))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.ExecuteFileAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"szwURL":           szwURL,
+		"szwShortExecName": szwShortExecName,
+		"szwParams":        szwParams,
 	}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.ExecuteFileAsync", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -269,50 +230,31 @@ func (nr *NagRdu) ExecuteFileAsync(ctx context.Context, szwURL, szwShortExecName
 // If the operation succeeds then AsyncActionStateChecker.CheckActionState returns URL-path in pStateData.
 // Otherwise, a call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (nr *NagRdu) ExecuteGsiAsync(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.ExecuteGsiAsync", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.ExecuteGsiAsync", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // GetCurrentHostState Acquire current host state
 func (nr *NagRdu) GetCurrentHostState(ctx context.Context) (*CurrentHostState, []byte, error) {
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.GetCurrentHostState", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	currentHostState := new(CurrentHostState)
-	raw, err := nr.client.Request(ctx, request, &currentHostState)
+	raw, err := nr.client.Call(ctx, "NagRdu.GetCurrentHostState", nil, &currentHostState)
 	return currentHostState, raw, err
 }
 
 // GetUrlToDownloadFileFromHost Get URL-path for later download file from host
 func (nr *NagRdu) GetUrlToDownloadFileFromHost(ctx context.Context, szwRemoteFile string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwRemoteFile": "%s"}`, jsonEscape(szwRemoteFile)))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.GetUrlToDownloadFileFromHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwRemoteFile": szwRemoteFile}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.GetUrlToDownloadFileFromHost", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // GetUrlToUploadFileToHost Get URL-path for later upload file to host
 func (nr *NagRdu) GetUrlToUploadFileToHost(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.GetUrlToUploadFileToHost", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.GetUrlToUploadFileToHost", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -323,14 +265,10 @@ func (nr *NagRdu) GetUrlToUploadFileToHost(ctx context.Context) (*PxgValStr, []b
 // If the operation succeeds then AsyncActionStateChecker.CheckActionState returns current host state in pStateData.
 // Otherwise, a call to AsyncActionStateChecker::CheckActionState returns error in pStateData.
 func (nr *NagRdu) RunKlnagchkAsync(ctx context.Context, szwProductID string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwProductID": "%s"}`, szwProductID))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.RunKlnagchkAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwProductID": szwProductID}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.RunKlnagchkAsync", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -342,13 +280,9 @@ func (nr *NagRdu) RunKlnagchkAsync(ctx context.Context, szwProductID string) (*P
 // Otherwise, a call to AsyncActionStateChecker::CheckActionState returns error in pStateData.
 func (nr *NagRdu) SetProductStateAsync(ctx context.Context, szwProductID string, nNewState int64) (*PxgValStr, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"szwProductID": "%s", "nNewState": %d }`, szwProductID, nNewState))
-	request, err := http.NewRequest("POST", nr.client.Server+"/api/v1.0/NagRdu.SetProductStateAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwProductID": szwProductID, "nNewState": nNewState}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := nr.client.Request(ctx, request, &pxgValStr)
+	raw, err := nr.client.Call(ctx, "NagRdu.SetProductStateAsync", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // NagRemoteScreen Interface for remote screen session management..
@@ -54,15 +50,10 @@ type SessionValue struct {
 
 // GetExistingSessions Returns existing remote screen sessions.
 func (nrs *NagRemoteScreen) GetExistingSessions(ctx context.Context, nType int64) (*ExistingSessions, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nType": %d}`, nType))
-	request, err := http.NewRequest("POST", nrs.client.Server+"/api/v1.0/NagRemoteScreen.GetExistingSessions",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nType": nType}
 
 	existingSessions := new(ExistingSessions)
-	raw, err := nrs.client.Request(ctx, request, &existingSessions)
+	raw, err := nrs.client.Call(ctx, "NagRemoteScreen.GetExistingSessions", postData, &existingSessions)
 	return existingSessions, raw, err
 }
 
@@ -82,15 +73,10 @@ type PSharingHandle struct {
 // OpenSession Shares the session, opens ports etc.
 func (nrs *NagRemoteScreen) OpenSession(ctx context.Context, nType int64, szwID string) (*SessionHandle, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nType": %d, "szwID": "%s"}`, nType, szwID))
-	request, err := http.NewRequest("POST", nrs.client.Server+"/api/v1.0/NagRemoteScreen.OpenSession",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nType": nType, "szwID": szwID}
 
 	sessionHandle := new(SessionHandle)
-	raw, err := nrs.client.Request(ctx, request, &sessionHandle)
+	raw, err := nrs.client.Call(ctx, "NagRemoteScreen.OpenSession", postData, &sessionHandle)
 	return sessionHandle, raw, err
 }
 
@@ -102,18 +88,7 @@ type SharingHandle struct {
 
 // CloseSession Closes session.
 func (nrs *NagRemoteScreen) CloseSession(ctx context.Context, params SharingHandle) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", nrs.client.Server+"/api/v1.0/NagRemoteScreen.CloseSession",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := nrs.client.Request(ctx, request, nil)
+	raw, err := nrs.client.Call(ctx, "NagRemoteScreen.CloseSession", params, nil)
 	return raw, err
 }
 
@@ -128,19 +103,8 @@ type TunnelData struct {
 
 // GetDataForTunnel Returns data to create an use tunnel
 func (nrs *NagRemoteScreen) GetDataForTunnel(ctx context.Context, params SharingHandle) (*TunnelData, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", nrs.client.Server+"/api/v1.0/NagRemoteScreen.GetDataForTunnel",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	tunnelData := new(TunnelData)
-	raw, err := nrs.client.Request(ctx, request, &tunnelData)
+	raw, err := nrs.client.Call(ctx, "NagRemoteScreen.GetDataForTunnel", params, &tunnelData)
 	return tunnelData, raw, err
 }
 
@@ -152,17 +116,6 @@ type WdsDataParams struct {
 
 // GetWdsData Returns data specific for Windows Desktop Sharing
 func (nrs *NagRemoteScreen) GetWdsData(ctx context.Context, params WdsDataParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", nrs.client.Server+"/api/v1.0/NagRemoteScreen.GetWdsData",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := nrs.client.Request(ctx, request, nil)
+	raw, err := nrs.client.Call(ctx, "NagRemoteScreen.GetWdsData", params, nil)
 	return raw, err
 }
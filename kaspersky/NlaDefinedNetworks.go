@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // NlaDefinedNetworks Network location awareness (NLA) defined networks.
@@ -41,28 +37,18 @@ type NlaDefinedNetworks service
 
 // AddNetwork Add NLA-defined network.
 func (ndn *NlaDefinedNetworks) AddNetwork(ctx context.Context, wstrNetworkName string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrNetworkName": "%s"}`, wstrNetworkName))
-	request, err := http.NewRequest("POST", ndn.client.Server+"/api/v1.0/NlaDefinedNetworks.AddNetwork",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrNetworkName": wstrNetworkName}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := ndn.client.Request(ctx, request, &pxgValInt)
+	raw, err := ndn.client.Call(ctx, "NlaDefinedNetworks.AddNetwork", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // DeleteNetwork Delete NLA-defined network.
 func (ndn *NlaDefinedNetworks) DeleteNetwork(ctx context.Context, nNetworkId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nNetworkId": %d}`, nNetworkId))
-	request, err := http.NewRequest("POST", ndn.client.Server+"/api/v1.0/NlaDefinedNetworks.DeleteNetwork",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ndn.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"nNetworkId": nNetworkId}
+
+	raw, err := ndn.client.Call(ctx, "NlaDefinedNetworks.DeleteNetwork", postData, nil)
 	return raw, err
 }
 
@@ -85,15 +71,10 @@ type PNetwork struct {
 
 // GetNetworkInfo Get NLA-defined network info.
 func (ndn *NlaDefinedNetworks) GetNetworkInfo(ctx context.Context, nNetworkId int64) (*PNetworkInfo, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nNetworkId": %d}`, nNetworkId))
-	request, err := http.NewRequest("POST", ndn.client.Server+"/api/v1.0/NlaDefinedNetworks.GetNetworkInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nNetworkId": nNetworkId}
 
 	pNetworkInfo := new(PNetworkInfo)
-	raw, err := ndn.client.Request(ctx, request, &pNetworkInfo)
+	raw, err := ndn.client.Call(ctx, "NlaDefinedNetworks.GetNetworkInfo", postData, &pNetworkInfo)
 	return pNetworkInfo, raw, err
 }
 
@@ -111,28 +92,13 @@ type PNetworks struct {
 
 // GetNetworksList Get list of all NLA-defined networks.
 func (ndn *NlaDefinedNetworks) GetNetworksList(ctx context.Context) (*PNetworkList, []byte, error) {
-	request, err := http.NewRequest("POST", ndn.client.Server+"/api/v1.0/NlaDefinedNetworks.GetNetworksList", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pNetworkList := new(PNetworkList)
-	raw, err := ndn.client.Request(ctx, request, &pNetworkList)
+	raw, err := ndn.client.Call(ctx, "NlaDefinedNetworks.GetNetworksList", nil, &pNetworkList)
 	return pNetworkList, raw, err
 }
 
 // SetNetworkInfo Change NLA-defined network.
 func (ndn *NlaDefinedNetworks) SetNetworkInfo(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ndn.client.Server+"/api/v1.0/NlaDefinedNetworks.SetNetworkInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ndn.client.Request(ctx, request, nil)
+	raw, err := ndn.client.Call(ctx, "NlaDefinedNetworks.SetNetworkInfo", params, nil)
 	return raw, err
 }
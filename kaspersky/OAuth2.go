@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // OAuth2 product managing.
@@ -50,67 +46,31 @@ type OAuth2 service
 //	nFilterByState	(int64) filters the result by client state (see OAuth2 items states),
 //	if set to -1 the result will not be filtered
 func (oa *OAuth2) GetClients(ctx context.Context, nFilterByState int64) (*IssuanceSetting, error) {
-	postData := []byte(fmt.Sprintf(`{"nFilterByState": %d}`, nFilterByState))
-	request, err := http.NewRequest("POST", oa.client.Server+"/api/v1.0/OAuth2.GetClients",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nFilterByState": nFilterByState}
 
 	issuanceSetting := new(IssuanceSetting)
-	raw, err := oa.client.Request(ctx, request, &issuanceSetting)
-
-	if oa.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := oa.client.Call(ctx, "OAuth2.GetClients", postData, &issuanceSetting)
 
 	return issuanceSetting, err
 }
 
 // GetClientsToRegistration Retrieves clients for registration them on the OAuth2 server.
 func (oa *OAuth2) GetClientsToRegistration(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", oa.client.Server+"/api/v1.0/OAuth2.GetClientsToRegistration", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := oa.client.Request(ctx, request, nil)
-
-	if oa.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := oa.client.Call(ctx, "OAuth2.GetClientsToRegistration", nil, nil)
 
 	return raw, err
 }
 
 // GetNewClients Retrieves new OAuth2 clients for admin approval.
 func (oa *OAuth2) GetNewClients(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", oa.client.Server+"/api/v1.0/OAuth2.GetNewClients", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := oa.client.Request(ctx, request, nil)
-
-	if oa.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := oa.client.Call(ctx, "OAuth2.GetNewClients", nil, nil)
 
 	return raw, err
 }
 
 // GetNewResServers Retrieves new OAuth2 resource servers for admin approval.
 func (oa *OAuth2) GetNewResServers(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", oa.client.Server+"/api/v1.0/OAuth2.GetNewResServers", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := oa.client.Request(ctx, request, nil)
-
-	if oa.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := oa.client.Call(ctx, "OAuth2.GetNewResServers", nil, nil)
 
 	return raw, err
 }
@@ -119,35 +79,17 @@ func (oa *OAuth2) GetNewResServers(ctx context.Context) ([]byte, error) {
 //	nFilterByState	(int64) filters the result by resource server state (see OAuth2 items states),
 //	if set to -1 the result will not be filtered
 func (oa *OAuth2) GetResServers(ctx context.Context, nFilterByState int64) (*IssuanceSetting, error) {
-	postData := []byte(fmt.Sprintf(`{"nFilterByState": %d}`, nFilterByState))
-	request, err := http.NewRequest("POST", oa.client.Server+"/api/v1.0/OAuth2.GetResServers",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nFilterByState": nFilterByState}
 
 	issuanceSetting := new(IssuanceSetting)
-	raw, err := oa.client.Request(ctx, request, &issuanceSetting)
-
-	if oa.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := oa.client.Call(ctx, "OAuth2.GetResServers", postData, &issuanceSetting)
 
 	return issuanceSetting, err
 }
 
 // GetResServersToRegistration Retrieves OAuth2 resource servers for registration them on the OAuth2 server.
 func (oa *OAuth2) GetResServersToRegistration(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", oa.client.Server+"/api/v1.0/OAuth2.GetResServersToRegistration", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := oa.client.Request(ctx, request, nil)
-
-	if oa.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := oa.client.Call(ctx, "OAuth2.GetResServersToRegistration", nil, nil)
 
 	return raw, err
 }
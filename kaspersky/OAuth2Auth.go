@@ -0,0 +1,343 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OAuth2Config settings of OAuth2Auth authentication: the client credentials grant of an SSO service principal
+// (e.g. ADFS application) and validation of issued JWT.
+type OAuth2Config struct {
+	// TokenURL token endpoint of the authorization server, e.g. https://adfs.example.com/adfs/oauth2/token
+	TokenURL string
+
+	// ClientID client identifier of the service principal
+	ClientID string
+
+	// ClientSecret client secret of the service principal
+	ClientSecret string
+
+	// Scopes requested scopes
+	Scopes []string
+
+	// Resource ADFS resource (relying party) identifier, sent if not empty
+	Resource string
+
+	// Issuer expected iss claim of the token, not checked if empty
+	Issuer string
+
+	// Audience expected aud claim of the token, Resource is used if empty
+	Audience string
+
+	// JWKSURL URL of the key set used to validate tokens, e.g. https://adfs.example.com/adfs/discovery/keys (required).
+	//
+	// KSC serves the key set with AdfsSso.GetJwks only within a session, so it can't be used to validate the token
+	// before the login.
+	JWKSURL string
+
+	// Leeway allowed clock skew when validating the token (30s by default)
+	Leeway time.Duration
+
+	// RefreshBefore how long before the token expiry the client logs in again with a new token (1m by default).
+	// Tokens living less than 2*RefreshBefore are renewed in the middle of their lifetime.
+	RefreshBefore time.Duration
+
+	// HTTPClient client used for TokenURL and JWKSURL requests.
+	// If nil, the client of KSC requests is used, so Config TLS settings, certificate pinning, proxy and middlewares apply.
+	HTTPClient *http.Client
+}
+
+var (
+	// errNoOAuth2Config OAuth2Auth is used without Config.OAuth2.
+	errNoOAuth2Config = errors.New("kaspersky: OAuth2Auth requires Config.OAuth2")
+
+	// errNoJWKSURL OAuth2Auth is used without OAuth2Config.JWKSURL.
+	errNoJWKSURL = errors.New("kaspersky: OAuth2Auth requires OAuth2Config.JWKSURL")
+)
+
+// oauth2Source obtains, validates and caches bearer tokens.
+type oauth2Source struct {
+	cfg OAuth2Config
+
+	mu    sync.Mutex
+	token string
+	// refreshAt time when the token is renewed, zero if the token doesn't expire
+	refreshAt time.Time
+	keys      *JWKS
+}
+
+// newOAuth2Source returns nil if cfg is nil. httpClient is used if cfg.HTTPClient is nil.
+func newOAuth2Source(cfg *OAuth2Config, httpClient *http.Client) *oauth2Source {
+	if cfg == nil {
+		return nil
+	}
+
+	s := &oauth2Source{cfg: *cfg}
+	if s.cfg.Leeway <= 0 {
+		s.cfg.Leeway = 30 * time.Second
+	}
+	if s.cfg.RefreshBefore <= 0 {
+		s.cfg.RefreshBefore = time.Minute
+	}
+	if s.cfg.Audience == "" {
+		s.cfg.Audience = s.cfg.Resource
+	}
+	if s.cfg.HTTPClient == nil {
+		s.cfg.HTTPClient = httpClient
+	}
+	return s
+}
+
+// Token returns a validated token which isn't due for renewal yet, with the time of its renewal.
+func (s *oauth2Source) Token(ctx context.Context) (string, time.Time, error) {
+	if s.cfg.JWKSURL == "" {
+		return "", time.Time{}, errNoJWKSURL
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.refreshAt.IsZero() || time.Now().Before(s.refreshAt)) {
+		return s.token, s.refreshAt, nil
+	}
+
+	issued := time.Now()
+	token, expiresIn, err := s.fetchToken(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	claims, err := s.validate(ctx, token)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiry := claims.Expiry()
+	if expiry.IsZero() && expiresIn > 0 {
+		expiry = issued.Add(time.Duration(expiresIn) * time.Second)
+	}
+
+	s.token, s.refreshAt = token, time.Time{}
+	if !expiry.IsZero() {
+		// renew RefreshBefore the expiry, but not earlier than in the middle of the token lifetime
+		before := s.cfg.RefreshBefore
+		if half := expiry.Sub(issued) / 2; half < before {
+			before = half
+		}
+		s.refreshAt = expiry.Add(-before)
+	}
+	return s.token, s.refreshAt, nil
+}
+
+// validate validates the token against cached key set, the key set is fetched again once on failure
+// in case the keys were rotated.
+func (s *oauth2Source) validate(ctx context.Context, token string) (*JWTClaims, error) {
+	v := JWTValidation{Issuer: s.cfg.Issuer, Audience: s.cfg.Audience, Leeway: s.cfg.Leeway}
+
+	fetched := false
+	if s.keys == nil {
+		if err := s.fetchKeys(ctx); err != nil {
+			return nil, err
+		}
+		fetched = true
+	}
+
+	claims, err := ValidateJWT(token, s.keys, v)
+	if err == nil || fetched {
+		return claims, err
+	}
+
+	if err := s.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+	return ValidateJWT(token, s.keys, v)
+}
+
+// fetchKeys fetches the key set from JWKSURL.
+func (s *oauth2Source) fetchKeys(ctx context.Context) error {
+	data, err := s.get(ctx, s.cfg.JWKSURL)
+	if err != nil {
+		return fmt.Errorf("kaspersky: fetch JWKS: %w", err)
+	}
+
+	keys, err := ParseJWKS(data)
+	if err != nil {
+		return err
+	}
+	s.keys = keys
+	return nil
+}
+
+func (s *oauth2Source) get(ctx context.Context, u string) ([]byte, error) {
+	request, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.cfg.HTTPClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return ioutil.ReadAll(response.Body)
+}
+
+// fetchToken requests a new token with the client credentials grant.
+func (s *oauth2Source) fetchToken(ctx context.Context) (string, int64, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.cfg.ClientID},
+		"client_secret": {s.cfg.ClientSecret},
+	}
+	if len(s.cfg.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.cfg.Scopes, " "))
+	}
+	if s.cfg.Resource != "" {
+		form.Set("resource", s.cfg.Resource)
+	}
+
+	request, err := http.NewRequest("POST", s.cfg.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := s.cfg.HTTPClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return "", 0, fmt.Errorf("kaspersky: oauth2 token request: %w", err)
+	}
+	defer response.Body.Close()
+
+	var result struct {
+		AccessToken      string      `json:"access_token"`
+		TokenType        string      `json:"token_type"`
+		ExpiresIn        json.Number `json:"expires_in"`
+		Error            string      `json:"error"`
+		ErrorDescription string      `json:"error_description"`
+	}
+	decErr := json.NewDecoder(response.Body).Decode(&result)
+
+	if response.StatusCode != http.StatusOK || result.Error != "" {
+		return "", 0, fmt.Errorf("kaspersky: oauth2 token request: %s: %s %s",
+			response.Status, result.Error, result.ErrorDescription)
+	}
+	if decErr != nil {
+		return "", 0, fmt.Errorf("kaspersky: oauth2 token request: %w", decErr)
+	}
+	if result.AccessToken == "" {
+		return "", 0, errors.New("kaspersky: oauth2 token request: no access_token in response")
+	}
+
+	expiresIn, _ := result.ExpiresIn.Int64()
+	return result.AccessToken, expiresIn, nil
+}
+
+// oauth2Auth logs in with a bearer token of the service principal, see OAuth2Auth.
+// Must be called with authMu held.
+func (ksc *KscClient) oauth2Auth(ctx context.Context) error {
+	if ksc.oauth2 == nil {
+		return errNoOAuth2Config
+	}
+
+	token, refreshAt, err := ksc.oauth2.Token(ctx)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", ksc.Server+"/api/v1.0/login", nil)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	if _, err = ksc.Request(ctx, request, nil); err != nil {
+		return err
+	}
+
+	ksc.scheduleOAuth2Refresh(refreshAt)
+	return nil
+}
+
+// scheduleOAuth2Refresh schedules login with a new token at refreshAt, see oauth2Source.Token.
+// Must be called with authMu held.
+func (ksc *KscClient) scheduleOAuth2Refresh(refreshAt time.Time) {
+	ksc.stopOAuth2Refresh()
+	if refreshAt.IsZero() {
+		return
+	}
+
+	d := time.Until(refreshAt)
+	if d < time.Second {
+		d = time.Second
+	}
+	ksc.oauth2Refresh = time.AfterFunc(d, ksc.refreshOAuth2)
+}
+
+// stopOAuth2Refresh cancels scheduled token refresh. Must be called with authMu held.
+func (ksc *KscClient) stopOAuth2Refresh() {
+	if ksc.oauth2Refresh != nil {
+		ksc.oauth2Refresh.Stop()
+		ksc.oauth2Refresh = nil
+	}
+}
+
+// refreshOAuth2 logs in again with a new token, failure is reported with Config.OnSessionLost.
+func (ksc *KscClient) refreshOAuth2() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	ksc.authMu.Lock()
+	if !ksc.loggedIn || ksc.authType != OAuth2Auth {
+		ksc.authMu.Unlock()
+		return
+	}
+
+	err := ksc.login(ctx, OAuth2Auth, "")
+	if err == nil {
+		atomic.AddUint32(&ksc.authGen, 1)
+	}
+	ksc.authMu.Unlock()
+
+	if err != nil {
+		ksc.sessionLost(err)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// idpServer serves token and jwks endpoints of an identity provider on srv,
+// tokens live ttl and are signed by key.
+func idpServer(t *testing.T, srv *fakeServer, ttl time.Duration, key crypto.Signer) {
+	t.Helper()
+
+	jwks, _ := json.Marshal(JWKS{Keys: []JWK{rsaJWK("r1", testKeys.rsa)}})
+	srv.reply("jwks", string(jwks))
+	srv.handle("token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_client","error_description":"bad secret"}`))
+			return
+		}
+		token := signJWT(t, "RS256", "r1", key, map[string]interface{}{
+			"iss": "idp", "aud": "urn:ksc", "sub": "svc", "exp": time.Now().Add(ttl).Unix(),
+		})
+		_, _ = fmt.Fprintf(w, `{"access_token":%q,"token_type":"bearer","expires_in":%d}`, token, int(ttl.Seconds()))
+	})
+}
+
+func oauth2Config(srv *fakeServer) *OAuth2Config {
+	return &OAuth2Config{
+		TokenURL:     srv.URL + "/token",
+		JWKSURL:      srv.URL + "/jwks",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Resource:     "urn:ksc",
+		Issuer:       "idp",
+	}
+}
+
+func TestOAuth2AuthLogin(t *testing.T) {
+	srv := newFakeServer(t)
+	idpServer(t, srv, time.Hour, testKeys.rsa)
+
+	c := srv.client(Config{
+		OAuth2: oauth2Config(srv),
+		Middlewares: []Middleware{func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				r.Header.Set("X-Test", "middleware")
+				return next.RoundTrip(r)
+			})
+		}},
+	})
+	ctx := context.Background()
+	if err := c.Login(ctx, OAuth2Auth, ""); err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close(ctx)
+
+	logins := srv.received("login")
+	if len(logins) != 1 || len(logins[0].Header.Get("Authorization")) <= len("Bearer ") {
+		t.Fatalf("login requests = %+v, want one with bearer token", logins)
+	}
+
+	tokens := srv.received("token")
+	if len(tokens) != 1 {
+		t.Fatalf("got %d token requests, want 1", len(tokens))
+	}
+	form, _ := url.ParseQuery(string(tokens[0].Body))
+	if form.Get("grant_type") != "client_credentials" || form.Get("client_id") != "client" || form.Get("resource") != "urn:ksc" {
+		t.Errorf("token request form = %v", form)
+	}
+	if h := tokens[0].Header.Get("X-Test"); h != "middleware" {
+		t.Errorf("token request X-Test = %q, want the request made with the client transport", h)
+	}
+	if len(srv.received("AdfsSso.GetJwks")) != 0 {
+		t.Error("AdfsSso.GetJwks is called before the session exists")
+	}
+}
+
+func TestOAuth2AuthRequiresJWKSURL(t *testing.T) {
+	srv := newFakeServer(t)
+	idpServer(t, srv, time.Hour, testKeys.rsa)
+
+	cfg := oauth2Config(srv)
+	cfg.JWKSURL = ""
+	c := srv.client(Config{OAuth2: cfg})
+	if err := c.Login(context.Background(), OAuth2Auth, ""); !errors.Is(err, errNoJWKSURL) {
+		t.Errorf("Login() error = %v, want errNoJWKSURL", err)
+	}
+	if n := len(srv.received("token")) + len(srv.received("login")); n != 0 {
+		t.Errorf("got %d requests, want none", n)
+	}
+}
+
+func TestOAuth2AuthInvalidToken(t *testing.T) {
+	srv := newFakeServer(t)
+	idpServer(t, srv, time.Hour, testKeys.other)
+
+	c := srv.client(Config{OAuth2: oauth2Config(srv)})
+	if err := c.Login(context.Background(), OAuth2Auth, ""); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Login() error = %v, want ErrInvalidToken", err)
+	}
+	if n := len(srv.received("login")); n != 0 {
+		t.Errorf("got %d login requests with invalid token, want none", n)
+	}
+}
+
+func TestOAuth2AuthBadCredentials(t *testing.T) {
+	srv := newFakeServer(t)
+	idpServer(t, srv, time.Hour, testKeys.rsa)
+
+	cfg := oauth2Config(srv)
+	cfg.ClientSecret = "wrong"
+	c := srv.client(Config{OAuth2: cfg})
+	if err := c.Login(context.Background(), OAuth2Auth, ""); err == nil {
+		t.Error("Login() with wrong client secret succeeded")
+	}
+}
+
+func TestOAuth2SourceRefreshWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		ttl           time.Duration
+		refreshBefore time.Duration
+		// renewal is expected between min and max after the token is issued
+		min, max time.Duration
+	}{
+		{"long-lived token", time.Hour, time.Minute, 58 * time.Minute, 59 * time.Minute},
+		{"token shorter than refresh window", 40 * time.Second, time.Minute, 19 * time.Second, 20 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeServer(t)
+			idpServer(t, srv, tt.ttl, testKeys.rsa)
+
+			cfg := oauth2Config(srv)
+			cfg.RefreshBefore = tt.refreshBefore
+			s := newOAuth2Source(cfg, http.DefaultClient)
+
+			issued := time.Now()
+			token, refreshAt, err := s.Token(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d := refreshAt.Sub(issued); d < tt.min || d > tt.max {
+				t.Errorf("token renewed in %v, want between %v and %v", d, tt.min, tt.max)
+			}
+
+			again, _, err := s.Token(context.Background())
+			if err != nil || again != token {
+				t.Errorf("Token() = %q, %v, want cached token", again, err)
+			}
+			if n := len(srv.received("token")); n != 1 {
+				t.Errorf("got %d token requests, want 1", n)
+			}
+		})
+	}
+}
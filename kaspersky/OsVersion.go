@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // OsVersion Operating systems dictionary access.
@@ -65,14 +62,8 @@ type OSAttributeValue struct {
 
 // GetAttributesByOs Acquire attributes for specified operating systems.
 func (ov *OsVersion) GetAttributesByOs(ctx context.Context, params OSIndices) (*OSAttributes, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ov.client.Server+"/api/v1.0/OsVersion.GetAttributesByOs", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	oSAttributes := new(OSAttributes)
-	raw, err := ov.client.Request(ctx, request, &oSAttributes)
+	raw, err := ov.client.Call(ctx, "OsVersion.GetAttributesByOs", params, &oSAttributes)
 	return oSAttributes, raw, err
 }
 
@@ -115,13 +106,7 @@ type OSValue struct {
 //  ]
 //}
 func (ov *OsVersion) GetOsByAttributes(ctx context.Context, params interface{}) (*OSRetValS, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ov.client.Server+"/api/v1.0/OsVersion.GetOsByAttributes", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	oSRetValS := new(OSRetValS)
-	raw, err := ov.client.Request(ctx, request, &oSRetValS)
+	raw, err := ov.client.Call(ctx, "OsVersion.GetOsByAttributes", params, &oSRetValS)
 	return oSRetValS, raw, err
 }
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // PLCDevApi Interface allows to acquire and manage PLC devices registry.
@@ -40,36 +37,18 @@ type PLCDevApi service
 
 // DeletePLCRemove PLC device.
 func (pda *PLCDevApi) DeletePLC(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pda.client.Server+"/api/v1.0/PLCDevApi.DeletePLC", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pda.client.Request(ctx, request, nil)
+	raw, err := pda.client.Call(ctx, "PLCDevApi.DeletePLC", params, nil)
 	return raw, err
 }
 
 // GetPLC Acquire attributes of specified PLC device.
 func (pda *PLCDevApi) GetPLC(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pda.client.Server+"/api/v1.0/PLCDevApi.GetPLC", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pda.client.Request(ctx, request, nil)
+	raw, err := pda.client.Call(ctx, "PLCDevApi.GetPLC", params, nil)
 	return raw, err
 }
 
 // UpdatePLC Adds/Updates PLC device.
 func (pda *PLCDevApi) UpdatePLC(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pda.client.Server+"/api/v1.0/PLCDevApi.UpdatePLC", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pda.client.Request(ctx, request, nil)
+	raw, err := pda.client.Call(ctx, "PLCDevApi.UpdatePLC", params, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 //	PackagesApi Operating with packages.
@@ -41,18 +37,7 @@ type EULAIDParams struct {
 
 // AcceptEulasAccepts given EULAs.
 func (pa *PackagesApi) AcceptEulas(ctx context.Context, params EULAIDParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.AcceptEulas",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.AcceptEulas", params, nil)
 	return raw, err
 }
 
@@ -61,98 +46,58 @@ func (pa *PackagesApi) AcceptEulas(ctx context.Context, params EULAIDParams) ([]
 // Remarks:
 // If extended sign already exists - one more sign will be added
 func (pa *PackagesApi) AddExtendedSign(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.AddExtendedSign", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.AddExtendedSign", params, nil)
 	return raw, err
 }
 
 // AddExtendedSignAsync Add extended certificate/sign with authenticated attributes to executable file (asynchronously).
 func (pa *PackagesApi) AddExtendedSignAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.AddExtendedSignAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.AddExtendedSignAsync", params, nil)
 	return raw, err
 }
 
 // AllowSharedPrerequisitesInstallation Allow installation of the shared prerequisites.
 func (pa *PackagesApi) AllowSharedPrerequisitesInstallation(ctx context.Context, nPackageId int64, bAllow bool) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d, "bAllow": %v}`, nPackageId, bAllow))
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.AllowSharedPrerequisitesInstallation", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId, "bAllow": bAllow}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.AllowSharedPrerequisitesInstallation", postData, nil)
 	return raw, err
 }
 
 // CancelCreateExecutablePkg Cancel an asynchronous call to PackagesApi.CreateExecutablePkgAsync.
 func (pa *PackagesApi) CancelCreateExecutablePkg(ctx context.Context, wstrRequestId string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.CancelCreateExecutablePkg", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.CancelCreateExecutablePkg", postData, nil)
 	return raw, err
 }
 
 // CancelGetExecutablePkgFile Cancel an asynchronous call to PackagesApi.GetExecutablePkgFileAsync.
 func (pa *PackagesApi) CancelGetExecutablePkgFile(ctx context.Context, wstrRequestId string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.CancelGetExecutablePkgFile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.CancelGetExecutablePkgFile", postData, nil)
 	return raw, err
 }
 
 // CancelRecordNewPackage Cancel an asynchronous call to PackagesApi.RecordVapmPackageAsync or PackagesApi.RecordVapmPackageAsync.
 func (pa *PackagesApi) CancelRecordNewPackage(ctx context.Context, wstrRequestId string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.CancelRecordNewPackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.CancelRecordNewPackage", postData, nil)
 	return raw, err
 }
 
 // CancelUpdateBasesInPackages Cancel an asynchronous call to PackagesApi.UpdateBasesInPackagesAsync.
 func (pa *PackagesApi) CancelUpdateBasesInPackages(ctx context.Context, wstrRequestId string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.CancelUpdateBasesInPackages", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.CancelUpdateBasesInPackages", postData, nil)
 	return raw, err
 }
 
@@ -162,30 +107,15 @@ func (pa *PackagesApi) CancelUpdateBasesInPackages(ctx context.Context, wstrRequ
 // Check the operation state by calling AsyncActionStateChecker.CheckActionState periodically until it's finalized
 // or cancel it by calling PackagesApi.CancelGetExecutablePkgFile.
 func (pa *PackagesApi) CreateExecutablePkgAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.CreateExecutablePkgAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.CreateExecutablePkgAsync", params, nil)
 	return raw, err
 }
 
 // DeleteExecutablePkg Delete standalone package.
 func (pa *PackagesApi) DeleteExecutablePkg(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.DeleteExecutablePkg", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.DeleteExecutablePkg", postData, nil)
 	return raw, err
 }
 
@@ -195,28 +125,18 @@ type EULA struct {
 
 // GetEulaText Requests EULA text.
 func (pa *PackagesApi) GetEulaText(ctx context.Context, nEulaId int64) (*EULA, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nEulaId": %d}`, nEulaId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetEulaText", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nEulaId": nEulaId}
 
 	eula := new(EULA)
-	raw, err := pa.client.Request(ctx, request, &eula)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetEulaText", postData, &eula)
 	return eula, raw, err
 }
 
 // GetExecutablePackages Get standalone packages.
 func (pa *PackagesApi) GetExecutablePackages(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetExecutablePackages", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetExecutablePackages", postData, nil)
 	return raw, err
 }
 
@@ -230,139 +150,82 @@ func (pa *PackagesApi) GetExecutablePackages(ctx context.Context, nPackageId int
 //
 // If the action failed then call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (pa *PackagesApi) GetExecutablePkgFileAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetExecutablePkgFileAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetExecutablePkgFileAsync", params, nil)
 	return raw, err
 }
 
 // GetIncompatibleAppsInfo Get incompatible apps info.
 func (pa *PackagesApi) GetIncompatibleAppsInfo(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetIncompatibleAppsInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetIncompatibleAppsInfo", postData, nil)
 	return raw, err
 }
 
 // GetIntranetFolderForNewPackage Get intranet folder for a new package.
 func (pa *PackagesApi) GetIntranetFolderForNewPackage(ctx context.Context, wstrProductName,
 	wstrProductVersion string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrProductName": "%s", "wstrProductVersion": "%s"}`, wstrProductName, wstrProductVersion))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetIntranetFolderForNewPackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"wstrProductName":    wstrProductName,
+		"wstrProductVersion": wstrProductVersion,
 	}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := pa.client.Request(ctx, request, &pxgValStr)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetIntranetFolderForNewPackage", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // GetIntranetFolderForPackage Get intranet folder for particular package.
 func (pa *PackagesApi) GetIntranetFolderForPackage(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetIntranetFolderForPackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetIntranetFolderForPackage", postData, nil)
 	return raw, err
 }
 
 // GetKpdProfileString Read kpd profile string.
 func (pa *PackagesApi) GetKpdProfileString(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetKpdProfileString", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetKpdProfileString", params, nil)
 	return raw, err
 }
 
 // GetLicenseKey Get license key.
 func (pa *PackagesApi) GetLicenseKey(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetLicenseKey", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetLicenseKey", postData, nil)
 	return raw, err
 }
 
 // GetLoginScript Get text of the login script.
 func (pa *PackagesApi) GetLoginScript(ctx context.Context, nPackageId int64, wstrTaskId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d, "wstrTaskId": "%s"}`, nPackageId, wstrTaskId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetLoginScript", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId, "wstrTaskId": wstrTaskId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetLoginScript", postData, nil)
 	return raw, err
 }
 
 // GetMoveRuleInfo Get information about the move-rule used by the standalone-package.
 func (pa *PackagesApi) GetMoveRuleInfo(ctx context.Context, nRuleId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nRuleId": %d}`, nRuleId))
+	postData := map[string]interface{}{"nRuleId": nRuleId}
 
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetMoveRuleInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetMoveRuleInfo", postData, nil)
 	return raw, err
 }
 
 // GetPackageInfo Get package info.
 func (pa *PackagesApi) GetPackageInfo(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetPackageInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetPackageInfo", postData, nil)
 	return raw, err
 }
 
 // GetPackageInfo2 Get package info.
 func (pa *PackagesApi) GetPackageInfo2(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetPackageInfo2", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetPackageInfo2", postData, nil)
 	return raw, err
 }
 
@@ -372,30 +235,15 @@ func (pa *PackagesApi) GetPackageInfo2(ctx context.Context, nPackageId int64) ([
 //
 // Second scenario: Upload archive with executable file to FileTransfer -> call PackagesApi.GetPackageInfoFromArchive -> call PackagesApi.RecordNewPackage3.
 func (pa *PackagesApi) GetPackageInfoFromArchive(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetPackageInfoFromArchive", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetPackageInfoFromArchive", params, nil)
 	return raw, err
 }
 
 // GetPackagePlugin Get package plugin
 func (pa *PackagesApi) GetPackagePlugin(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetPackagePlugin", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetPackagePlugin", postData, nil)
 	return raw, err
 }
 
@@ -458,25 +306,15 @@ type Klpkg struct {
 
 // GetPackages Get packages information.
 func (pa *PackagesApi) GetPackages(ctx context.Context) (*Packages, []byte, error) {
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetPackages", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	packages := new(Packages)
-	raw, err := pa.client.Request(ctx, request, packages)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetPackages", nil, packages)
 	return packages, raw, err
 }
 
 // GetPackages2 Get packages.
 func (pa *PackagesApi) GetPackages2(ctx context.Context) (*Packages, []byte, error) {
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetPackages2", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	packages := new(Packages)
-	raw, err := pa.client.Request(ctx, request, packages)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetPackages2", nil, packages)
 	return packages, raw, err
 }
 
@@ -493,15 +331,10 @@ type OptionsEx struct {
 
 // GetRebootOptionsEx Get reboot options.
 func (pa *PackagesApi) GetRebootOptionsEx(ctx context.Context, nPackageId int64) (*RebootOptionsEx, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetRebootOptionsEx", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
 	rebootOptionsEx := new(RebootOptionsEx)
-	raw, err := pa.client.Request(ctx, request, &rebootOptionsEx)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetRebootOptionsEx", postData, &rebootOptionsEx)
 	return rebootOptionsEx, raw, err
 }
 
@@ -531,75 +364,35 @@ type KlpkgEULA struct {
 
 // GetUserAgreements Request user agreements related to user packages, registered on current VS.
 func (pa *PackagesApi) GetUserAgreements(ctx context.Context) (*UserEULAS, []byte, error) {
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.GetUserAgreements", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	userEULAS := new(UserEULAS)
-	raw, err := pa.client.Request(ctx, request, &userEULAS)
+	raw, err := pa.client.Call(ctx, "PackagesApi.GetUserAgreements", nil, &userEULAS)
 	return userEULAS, raw, err
 }
 
 // IsPackagePublished Check whether the package is published on KSC web server.
 func (pa *PackagesApi) IsPackagePublished(ctx context.Context, nPkgExecId int64) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPkgExecId": %d}`, nPkgExecId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.IsPackagePublished", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPkgExecId": nPkgExecId}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := pa.client.Request(ctx, request, &pxgValBool)
+	raw, err := pa.client.Call(ctx, "PackagesApi.IsPackagePublished", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // PrePublishMobilePackage Prepare server-side data for mobile package publication on KSC web server.
 func (pa *PackagesApi) PrePublishMobilePackage(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.PrePublishMobilePackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.PrePublishMobilePackage", params, nil)
 	return raw, err
 }
 
 // PublishMobileManifest Publish mobile manifest on KSC web server.
 func (pa *PackagesApi) PublishMobileManifest(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.PublishMobileManifest", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.PublishMobileManifest", params, nil)
 	return raw, err
 }
 
 // PublishMobilePackage Publish mobile package on KSC web server.
 func (pa *PackagesApi) PublishMobilePackage(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.PublishMobilePackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.PublishMobilePackage", params, nil)
 	return raw, err
 }
 
@@ -608,46 +401,26 @@ func (pa *PackagesApi) PublishMobilePackage(ctx context.Context, params interfac
 // Note:
 // You can publish an already published package and vice versa
 func (pa *PackagesApi) PublishStandalonePackage(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.PublishStandalonePackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.PublishStandalonePackage", params, nil)
 	return raw, err
 }
 
 // ReadKpdFile Read kpd file.
 func (pa *PackagesApi) ReadKpdFile(ctx context.Context, nPackageId int64) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.ReadKpdFile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := pa.client.Request(ctx, request, &pxgValStr)
+	raw, err := pa.client.Call(ctx, "PackagesApi.ReadKpdFile", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // ReadPkgCfgFile Read package configuration file.
 func (pa *PackagesApi) ReadPkgCfgFile(ctx context.Context, nPackageId int64, wstrFileName string) (*PxgValStr, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d, "wstrFileName": "%s"}`, nPackageId, wstrFileName))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.ReadPkgCfgFile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId, "wstrFileName": wstrFileName}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := pa.client.Request(ctx, request, &pxgValStr)
+	raw, err := pa.client.Call(ctx, "PackagesApi.ReadPkgCfgFile", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -665,37 +438,15 @@ type NewPackage struct {
 // RecordNewPackage Creates a package with the default settings based on the product, overwritten in the folder,
 // the path to which was obtained by calling the PackagesApi.GetIntranetFolderForNewPackage.
 func (pa *PackagesApi) RecordNewPackage(ctx context.Context, params NewPackage) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RecordNewPackage",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := pa.client.Request(ctx, request, &pxgValStr)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RecordNewPackage", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // RecordNewPackage2 Creates a package with the default settings based on the product, transferred using FileTransfer.
 func (pa *PackagesApi) RecordNewPackage2(ctx context.Context, params *NewPackage) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RecordNewPackage2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := pa.client.Request(ctx, request, &pxgValStr)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RecordNewPackage2", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -709,17 +460,7 @@ func (pa *PackagesApi) RecordNewPackage2(ctx context.Context, params *NewPackage
 //
 // Third scenario: Upload executable file to FileTransfer -> call PackagesApi.RecordNewPackage3.
 func (pa *PackagesApi) RecordNewPackage3(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RecordNewPackage3", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RecordNewPackage3", params, nil)
 	return raw, err
 }
 
@@ -735,17 +476,7 @@ func (pa *PackagesApi) RecordNewPackage3(ctx context.Context, params interface{}
 //
 // If the action failed then call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (pa *PackagesApi) RecordNewPackage3Async(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RecordNewPackage3Async", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RecordNewPackage3Async", params, nil)
 	return raw, err
 }
 
@@ -761,17 +492,7 @@ func (pa *PackagesApi) RecordNewPackage3Async(ctx context.Context, params interf
 //
 // If the action failed then call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (pa *PackagesApi) RecordNewPackageAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RecordNewPackageAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RecordNewPackageAsync", params, nil)
 	return raw, err
 }
 
@@ -786,30 +507,15 @@ func (pa *PackagesApi) RecordNewPackageAsync(ctx context.Context, params interfa
 //
 // Otherwise, a call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (pa *PackagesApi) RecordVapmPackageAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RecordVapmPackageAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RecordVapmPackageAsync", params, nil)
 	return raw, err
 }
 
 // RemovePackage Remove a package.
 func (pa *PackagesApi) RemovePackage(ctx context.Context, nPackageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RemovePackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RemovePackage", postData, nil)
 	return raw, err
 }
 
@@ -821,55 +527,35 @@ type RemovePackageResult struct {
 
 // RemovePackage2 Remove a package and get the list of dependent tasks.
 func (pa *PackagesApi) RemovePackage2(ctx context.Context, nPackageId int64) (*RemovePackageResult, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RemovePackage2", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
 	removePackageResult := new(RemovePackageResult)
-	raw, err := pa.client.Request(ctx, request, &removePackageResult)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RemovePackage2", postData, &removePackageResult)
 	return removePackageResult, raw, err
 }
 
 // RenamePackage Rename package.
 func (pa *PackagesApi) RenamePackage(ctx context.Context, nPackageId int64, wstrNewPackageName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d, "wstrNewPackageName": "%s"}`, nPackageId, wstrNewPackageName))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RenamePackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId, "wstrNewPackageName": wstrNewPackageName}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RenamePackage", postData, nil)
 	return raw, err
 }
 
 // ResetDefaultServerSpecificSettings Reset server-specific settings for package.
 func (pa *PackagesApi) ResetDefaultServerSpecificSettings(ctx context.Context, nPackageId int64) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d}`, nPackageId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.ResetDefaultServerSpecificSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := pa.client.Request(ctx, request, &pxgValBool)
+	raw, err := pa.client.Call(ctx, "PackagesApi.ResetDefaultServerSpecificSettings", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // ResolvePackageLcid Resolve LCID of a package.
 func (pa *PackagesApi) ResolvePackageLcid(ctx context.Context, nPackageId, nLcid int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d, "nLcid": %d}`, nPackageId, nLcid))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.ResolvePackageLcid", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPackageId": nPackageId, "nLcid": nLcid}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.ResolvePackageLcid", postData, nil)
 	return raw, err
 }
 
@@ -881,124 +567,57 @@ func (pa *PackagesApi) ResolvePackageLcid(ctx context.Context, nPackageId, nLcid
 // If the operation succeeds then AsyncActionStateChecker.CheckActionState does not return any attributes in
 // pStateData container. If the action failed then call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (pa *PackagesApi) RetranslateToVServerAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.RetranslateToVServerAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.RetranslateToVServerAsync", params, nil)
 	return raw, err
 }
 
 // SetLicenseKey Set license key.
 func (pa *PackagesApi) SetLicenseKey(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.SetLicenseKey", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.SetLicenseKey", params, nil)
 	return raw, err
 }
 
 // SetRemoveIncompatibleApps Set incompatible apps info.
 func (pa *PackagesApi) SetRemoveIncompatibleApps(ctx context.Context, nPackageId int64, bRemoveIncompatibleApps bool) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d, "bRemoveIncompatibleApps": %v}`, nPackageId, bRemoveIncompatibleApps))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.SetRemoveIncompatibleApps", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"nPackageId":              nPackageId,
+		"bRemoveIncompatibleApps": bRemoveIncompatibleApps,
 	}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := pa.client.Request(ctx, request, &pxgValBool)
+	raw, err := pa.client.Call(ctx, "PackagesApi.SetRemoveIncompatibleApps", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // SSGetNames Get settings storage section names.
 func (pa *PackagesApi) SSGetNames(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.SS_GetNames", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.SS_GetNames", params, nil)
 	return raw, err
 }
 
 // SSRead Read settings storage data.
 func (pa *PackagesApi) SSRead(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.SS_Read", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.SS_Read", params, nil)
 	return raw, err
 }
 
 // SSSectionOperation Perform operation on a settings storage section.
 func (pa *PackagesApi) SSSectionOperation(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.SS_SectionOperation", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.SS_SectionOperation", params, nil)
 	return raw, err
 }
 
 // SSWrite Write settings storage data.
 func (pa *PackagesApi) SSWrite(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.SS_Write", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.SS_Write", params, nil)
 	return raw, err
 }
 
 // UnpublishMobilePackage Unpublish a previously published mobile package on KSC web server.
 func (pa *PackagesApi) UnpublishMobilePackage(ctx context.Context, wstrProfileId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrProfileId": "%s"}`, wstrProfileId))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.UnpublishMobilePackage", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrProfileId": wstrProfileId}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.UnpublishMobilePackage", postData, nil)
 	return raw, err
 }
 
@@ -1011,32 +630,21 @@ func (pa *PackagesApi) UnpublishMobilePackage(ctx context.Context, wstrProfileId
 // To download it, client should send an HTTP GET-request to the URL of format as follows:
 // "http://host:port" + KLPKG_EP_DOWNLOAD_PATH If the action failed then call to AsyncActionStateChecker.CheckActionState returns error in pStateData.
 func (pa *PackagesApi) UpdateBasesInPackagesAsync(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.UpdateBasesInPackagesAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.UpdateBasesInPackagesAsync", params, nil)
 	return raw, err
 }
 
 // WriteKpdProfileString Write kpd profile string.
 func (pa *PackagesApi) WriteKpdProfileString(ctx context.Context, nPackageId int64, wstrSection, wstrKey, wstrValue string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nPackageId": %d, "wstrSection": "%s", "wstrKey": "%s", "wstrValue": "%s"}`,
-		nPackageId, wstrSection, wstrKey, wstrValue))
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.WriteKpdProfileString", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"nPackageId":  nPackageId,
+		"wstrSection": wstrSection,
+		"wstrKey":     wstrKey,
+		"wstrValue":   wstrValue,
 	}
 
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.WriteKpdProfileString", postData, nil)
 	return raw, err
 }
 
@@ -1050,16 +658,6 @@ type PkgCFGFileParams struct {
 // WritePkgCfgFile Write package configuration file.
 func (pa *PackagesApi) WritePkgCfgFile(ctx context.Context, params PkgCFGFileParams) ([]byte,
 	error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pa.client.Server+"/api/v1.0/PackagesApi.WritePkgCfgFile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pa.client.Request(ctx, request, nil)
+	raw, err := pa.client.Call(ctx, "PackagesApi.WritePkgCfgFile", params, nil)
 	return raw, err
 }
@@ -0,0 +1,370 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Params KSC params container.
+//
+// Values are mapped to Go types as follows:
+//
+//	string                  string
+//	bool                    bool
+//	integer                 int
+//	other number            json.Number
+//	{"type":"long"}         int64
+//	{"type":"double"}       float64
+//	{"type":"float"}        float32
+//	{"type":"datetime"}     time.Time (UTC)
+//	{"type":"binary"}       []byte
+//	{"type":"params"}       Params
+//	{"type":"date"}         TypedValue
+//	object                  map[string]interface{} with values mapped the same way
+//	array                   []interface{}
+//	null                    nil
+//
+// An object is taken for a typed value only if it has just type and value keys, the type is one of the above
+// and the value is valid for the type, other objects are plain objects.
+// The top level object is Params whether it's plain or typed.
+//
+// Params are marshalled back to the same representation, so they can be passed to any interface{} parameter
+// of the services and decoded from any PxgRetVal or field holding params.
+type Params map[string]interface{}
+
+// TypedValue KSC typed value of a type which has no Go mapping in Params (date), kept as is.
+type TypedValue struct {
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// KSC value types of typed values.
+const (
+	ParamsTypeParams   = "params"
+	ParamsTypeLong     = "long"
+	ParamsTypeDouble   = "double"
+	ParamsTypeFloat    = "float"
+	ParamsTypeDateTime = "datetime"
+	ParamsTypeBinary   = "binary"
+	ParamsTypeDate     = "date"
+)
+
+// GetString returns the string value of the key, ok is false if the key is missing or the value isn't a string.
+func (p Params) GetString(key string) (string, bool) {
+	v, ok := p[key].(string)
+	return v, ok
+}
+
+// GetBool returns the bool value of the key, ok is false if the key is missing or the value isn't a bool.
+func (p Params) GetBool(key string) (bool, bool) {
+	v, ok := p[key].(bool)
+	return v, ok
+}
+
+// GetInt64 returns the int or long value of the key, ok is false if the key is missing or the value isn't an integer.
+func (p Params) GetInt64(key string) (int64, bool) {
+	switch v := p[key].(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+// GetFloat64 returns the double, float or plain number value of the key,
+// ok is false if the key is missing or the value isn't a float.
+func (p Params) GetFloat64(key string) (float64, bool) {
+	switch v := p[key].(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// GetTime returns the datetime value of the key, ok is false if the key is missing or the value isn't a datetime.
+func (p Params) GetTime(key string) (time.Time, bool) {
+	v, ok := p[key].(time.Time)
+	return v, ok
+}
+
+// GetBinary returns the binary value of the key, ok is false if the key is missing or the value isn't a binary.
+func (p Params) GetBinary(key string) ([]byte, bool) {
+	v, ok := p[key].([]byte)
+	return v, ok
+}
+
+// GetParams returns the nested params or plain object of the key,
+// ok is false if the key is missing or the value isn't an object.
+func (p Params) GetParams(key string) (Params, bool) {
+	return asParams(p[key])
+}
+
+// asParams returns nested params or plain object value as Params.
+func asParams(value interface{}) (Params, bool) {
+	switch v := value.(type) {
+	case Params:
+		return v, true
+	case map[string]interface{}:
+		return v, true
+	}
+	return nil, false
+}
+
+// GetArray returns the array value of the key, ok is false if the key is missing or the value isn't an array.
+func (p Params) GetArray(key string) ([]interface{}, bool) {
+	v, ok := p[key].([]interface{})
+	return v, ok
+}
+
+// MarshalJSON encodes params as a plain JSON object with typed values.
+func (p Params) MarshalJSON() ([]byte, error) {
+	if p == nil {
+		return []byte("null"), nil
+	}
+
+	obj := make(map[string]interface{}, len(p))
+	for key, value := range p {
+		v, err := encodeParamsValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("kaspersky: params %q: %w", key, err)
+		}
+		obj[key] = v
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON decodes params from a JSON object or {"type":"params","value":{...}}.
+func (p *Params) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*p = nil
+		return nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	v, err := decodeParamsValue(raw)
+	if err != nil {
+		return err
+	}
+
+	params, ok := asParams(v)
+	if !ok {
+		return fmt.Errorf("kaspersky: params expected, got %T", v)
+	}
+	*p = params
+	return nil
+}
+
+// typed returns KSC typed value.
+func typed(typ string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{"type": typ, "value": value}
+}
+
+// encodeParamsValue converts Go value of params to the value marshalled to JSON.
+func encodeParamsValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil, string, bool, int, int32, json.Number, TypedValue, json.RawMessage:
+		return v, nil
+	case int64:
+		return typed(ParamsTypeLong, v), nil
+	case float64:
+		return typed(ParamsTypeDouble, v), nil
+	case float32:
+		return typed(ParamsTypeFloat, v), nil
+	case time.Time:
+		return typed(ParamsTypeDateTime, v.UTC().Format(time.RFC3339)), nil
+	case []byte:
+		return typed(ParamsTypeBinary, base64.StdEncoding.EncodeToString(v)), nil
+	case Params:
+		if v == nil {
+			return nil, nil
+		}
+		return typed(ParamsTypeParams, v), nil
+	case map[string]interface{}:
+		if v == nil {
+			return nil, nil
+		}
+
+		obj := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			value, err := encodeParamsValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("%q: %w", key, err)
+			}
+			obj[key] = value
+		}
+		return obj, nil
+	}
+
+	// arrays of any element type
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return nil, nil
+		}
+
+		array := make([]interface{}, rv.Len())
+		for i := range array {
+			item, err := encodeParamsValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			array[i] = item
+		}
+		return array, nil
+	}
+
+	// structs and other values are marshalled as is
+	return value, nil
+}
+
+// decodeParamsValue converts value decoded from JSON (with json.Number) to Go value of params.
+func decodeParamsValue(raw interface{}) (interface{}, error) {
+	switch v := raw.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return int(n), nil
+		}
+		return v, nil
+	case []interface{}:
+		array := make([]interface{}, len(v))
+		for i, item := range v {
+			value, err := decodeParamsValue(item)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = value
+		}
+		return array, nil
+	case map[string]interface{}:
+		typ, isTyped := v["type"].(string)
+		value, hasValue := v["value"]
+		if isTyped && hasValue && len(v) == 2 {
+			if typedValue, err := decodeTypedValue(typ, value); err == nil {
+				return typedValue, nil
+			}
+		}
+
+		obj, err := decodeParamsObject(v)
+		return map[string]interface{}(obj), err
+	}
+	return raw, nil
+}
+
+// decodeParamsObject converts values of the object decoded from JSON to Go values of params.
+func decodeParamsObject(obj map[string]interface{}) (Params, error) {
+	params := make(Params, len(obj))
+	for key, item := range obj {
+		value, err := decodeParamsValue(item)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", key, err)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+// decodeTypedValue converts KSC typed value to Go value of params.
+// Returns error if the type is unknown or the value isn't valid for the type.
+func decodeTypedValue(typ string, value interface{}) (interface{}, error) {
+	switch typ {
+	case ParamsTypeParams:
+		if value == nil {
+			return Params(nil), nil
+		}
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("kaspersky: params value expected, got %v", value)
+		}
+		return decodeParamsObject(obj)
+	case ParamsTypeLong:
+		n, ok := value.(json.Number)
+		if !ok {
+			if s, isString := value.(string); isString {
+				n, ok = json.Number(s), true
+			}
+		}
+		if ok {
+			if i, err := n.Int64(); err == nil {
+				return i, nil
+			}
+		}
+		return nil, fmt.Errorf("kaspersky: long value expected, got %v", value)
+	case ParamsTypeDouble, ParamsTypeFloat:
+		n, ok := value.(json.Number)
+		if !ok {
+			return nil, fmt.Errorf("kaspersky: %s value expected, got %v", typ, value)
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return nil, err
+		}
+		if typ == ParamsTypeFloat {
+			return float32(f), nil
+		}
+		return f, nil
+	case ParamsTypeDateTime:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("kaspersky: datetime value expected, got %v", value)
+		}
+		t, err := ParseDateTime(s)
+		if err != nil {
+			return nil, err
+		}
+		return t, nil
+	case ParamsTypeBinary:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("kaspersky: binary value expected, got %v", value)
+		}
+		return base64.StdEncoding.DecodeString(s)
+	case ParamsTypeDate:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return TypedValue{Type: typ, Value: data}, nil
+	}
+	return nil, fmt.Errorf("kaspersky: unknown value type %q", typ)
+}
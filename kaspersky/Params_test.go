@@ -0,0 +1,177 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// jsonEqual reports whether a and b are the same JSON values.
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+
+	var va, vb interface{}
+	for _, x := range []struct {
+		data []byte
+		v    *interface{}
+	}{{a, &va}, {b, &vb}} {
+		dec := json.NewDecoder(bytes.NewReader(x.data))
+		dec.UseNumber()
+		if err := dec.Decode(x.v); err != nil {
+			t.Fatalf("invalid JSON %s: %v", x.data, err)
+		}
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+func TestParamsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		// want Go value of key "v"
+		want interface{}
+	}{
+		{"string", `{"v":"s"}`, "s"},
+		{"bool", `{"v":true}`, true},
+		{"int", `{"v":42}`, 42},
+		{"plain float", `{"v":1.5}`, json.Number("1.5")},
+		{"integer out of int64", `{"v":18446744073709551616}`, json.Number("18446744073709551616")},
+		{"null", `{"v":null}`, nil},
+		{"long", `{"v":{"type":"long","value":9007199254740993}}`, int64(9007199254740993)},
+		{"double", `{"v":{"type":"double","value":1.25}}`, 1.25},
+		{"float", `{"v":{"type":"float","value":0.5}}`, float32(0.5)},
+		{"datetime", `{"v":{"type":"datetime","value":"2020-05-07T23:18:00Z"}}`, time.Date(2020, 5, 7, 23, 18, 0, 0, time.UTC)},
+		{"binary", `{"v":{"type":"binary","value":"AQID"}}`, []byte{1, 2, 3}},
+		{"date", `{"v":{"type":"date","value":"2020-05-07"}}`, TypedValue{Type: "date", Value: json.RawMessage(`"2020-05-07"`)}},
+		{"params", `{"v":{"type":"params","value":{"a":1}}}`, Params{"a": 1}},
+		{"plain object", `{"v":{"a":1,"b":{"c":1.5}}}`, map[string]interface{}{"a": 1, "b": map[string]interface{}{"c": json.Number("1.5")}}},
+		{"object of unknown type", `{"v":{"type":"x","value":1}}`, map[string]interface{}{"type": "x", "value": 1}},
+		{"object with invalid long", `{"v":{"type":"long","value":"abc"}}`, map[string]interface{}{"type": "long", "value": "abc"}},
+		{"object with more keys", `{"v":{"type":"long","value":1,"x":2}}`, map[string]interface{}{"type": "long", "value": 1, "x": 2}},
+		{"array", `{"v":[1,"a",{"type":"long","value":2},{"a":true}]}`, []interface{}{1, "a", int64(2), map[string]interface{}{"a": true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Params
+			if err := json.Unmarshal([]byte(tt.json), &p); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(p["v"], tt.want) {
+				t.Errorf("decoded %#v, want %#v", p["v"], tt.want)
+			}
+
+			data, err := json.Marshal(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonEqual(t, data, []byte(tt.json)) {
+				t.Errorf("marshalled %s, want %s", data, tt.json)
+			}
+		})
+	}
+}
+
+func TestParamsTopLevel(t *testing.T) {
+	for _, data := range []string{`{"a":1}`, `{"type":"params","value":{"a":1}}`} {
+		var p Params
+		if err := json.Unmarshal([]byte(data), &p); err != nil {
+			t.Fatalf("Unmarshal(%s): %v", data, err)
+		}
+		if !reflect.DeepEqual(p, Params{"a": 1}) {
+			t.Errorf("Unmarshal(%s) = %#v", data, p)
+		}
+	}
+
+	var p Params
+	if err := json.Unmarshal([]byte(`{"type":"long","value":1}`), &p); err == nil {
+		t.Errorf("Unmarshal(typed long) = %#v, want error", p)
+	}
+}
+
+func TestParamsMarshal(t *testing.T) {
+	p := Params{
+		"long":     int64(5),
+		"double":   2.5,
+		"datetime": time.Date(2021, 1, 2, 4, 4, 5, 0, time.FixedZone("UTC+1", 3600)),
+		"binary":   []byte{1},
+		"strings":  []string{"a"},
+		"params":   Params{"i": 1},
+		"nil":      Params(nil),
+	}
+	want := `{
+		"long": {"type":"long","value":5},
+		"double": {"type":"double","value":2.5},
+		"datetime": {"type":"datetime","value":"2021-01-02T03:04:05Z"},
+		"binary": {"type":"binary","value":"AQ=="},
+		"strings": ["a"],
+		"params": {"type":"params","value":{"i":1}},
+		"nil": null
+	}`
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !jsonEqual(t, data, []byte(want)) {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
+
+func TestParamsGetters(t *testing.T) {
+	var p Params
+	data := `{"s":"a","i":1,"l":{"type":"long","value":2},"f":1.5,"d":{"type":"double","value":2.5},"o":{"a":1},"p":{"type":"params","value":{"a":1}}}`
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+
+	if v, ok := p.GetString("s"); !ok || v != "a" {
+		t.Errorf("GetString() = %q, %v", v, ok)
+	}
+	if _, ok := p.GetString("i"); ok {
+		t.Error("GetString() of int succeeded")
+	}
+	for key, want := range map[string]int64{"i": 1, "l": 2} {
+		if v, ok := p.GetInt64(key); !ok || v != want {
+			t.Errorf("GetInt64(%q) = %d, %v, want %d", key, v, ok, want)
+		}
+	}
+	for key, want := range map[string]float64{"f": 1.5, "d": 2.5} {
+		if v, ok := p.GetFloat64(key); !ok || v != want {
+			t.Errorf("GetFloat64(%q) = %v, %v, want %v", key, v, ok, want)
+		}
+	}
+	for _, key := range []string{"o", "p"} {
+		if v, ok := p.GetParams(key); !ok || v["a"] != 1 {
+			t.Errorf("GetParams(%q) = %v, %v", key, v, ok)
+		}
+	}
+	if _, ok := p.GetParams("missing"); ok {
+		t.Error("GetParams() of missing key succeeded")
+	}
+}
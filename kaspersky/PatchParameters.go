@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 //	PatchParameters Patch parameters processing.
@@ -37,60 +33,36 @@ type PatchParameters service
 
 // GetTemplate Get template for command.
 func (pp *PatchParameters) GetTemplate(ctx context.Context, patchID, locID int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"patchID": %d, "locID": %d}`, patchID, locID))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PatchParameters.GetTemplate", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"patchID": patchID, "locID": locID}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PatchParameters.GetTemplate", postData, nil)
 	return raw, err
 }
 
 // GetValues Get install command for patch.
 func (pp *PatchParameters) GetValues(ctx context.Context, patchID, locID int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"patchID": %d, "locID": %d}`, patchID, locID))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PatchParameters.GetValues", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"patchID": patchID, "locID": locID}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PatchParameters.GetValues", postData, nil)
 	return raw, err
 }
 
 // GetValuesByPkg Get install command for patch.
 func (pp *PatchParameters) GetValuesByPkg(ctx context.Context, packageId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"packageId": %d}`, packageId))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PatchParameters.GetValuesByPkg", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"packageId": packageId}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PatchParameters.GetValuesByPkg", postData, nil)
 	return raw, err
 }
 
 // SetValues Set values for parameters of command.
 func (pp *PatchParameters) SetValues(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PatchParameters.SetValues", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PatchParameters.SetValues", params, nil)
 	return raw, err
 }
 
 // SetValuesByPkg Set values for parameters of command.
 func (pp *PatchParameters) SetValuesByPkg(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PatchParameters.SetValuesByPkg", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PatchParameters.SetValuesByPkg", params, nil)
 	return raw, err
 }
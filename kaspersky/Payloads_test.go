@@ -0,0 +1,1951 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestPayloads checks that service methods send the same parameters as the request bodies
+// formerly built with fmt.Sprintf. want is the body of the former template filled with the test values,
+// the cases of methods with string, integer and bool parameters are generated from the templates.
+func TestPayloads(t *testing.T) {
+	tests := []struct {
+		method string
+		call   func(ctx context.Context, c *KscClient)
+		want   string
+	}{
+		{
+			"AdfsSso.GetSettings",
+			func(ctx context.Context, c *KscClient) { c.AdfsSso.GetSettings(ctx, true) },
+			`{"bExtenedSettings": true}`,
+		},
+		{
+			"AdfsSso.SetAdfsEnabled",
+			func(ctx context.Context, c *KscClient) { c.AdfsSso.SetAdfsEnabled(ctx, true) },
+			`{"bEnabled": true}`,
+		},
+		{
+			"AdmServerSettings.ChangeSharedFolder",
+			func(ctx context.Context, c *KscClient) {
+				c.AdmServerSettings.ChangeSharedFolder(ctx, "wstrNetworkPath-1")
+			},
+			`{"wstrNetworkPath": "wstrNetworkPath-1"}`,
+		},
+		{
+			"AsyncActionStateChecker.CheckActionState",
+			func(ctx context.Context, c *KscClient) {
+				c.AsyncActionStateChecker.CheckActionState(ctx, "wstrActionGuid-1")
+			},
+			`{"wstrActionGuid": "wstrActionGuid-1"}`,
+		},
+		{
+			"CertPoolCtrl.GetCertificateInfo",
+			func(ctx context.Context, c *KscClient) { c.CertPoolCtrl.GetCertificateInfo(ctx, 12, 13) },
+			`{"nVServerId": 12, "nFunction" : 13 }`,
+		},
+		{
+			"CertPoolCtrl2.GetCertificateInfoDetails",
+			func(ctx context.Context, c *KscClient) { c.CertPoolCtrl2.GetCertificateInfoDetails(ctx, 12, 13) },
+			`{"nVServerId": 12, "nFunction" : 13 }`,
+		},
+		{
+			"CgwHelper.GetNagentLocation",
+			func(ctx context.Context, c *KscClient) { c.CgwHelper.GetNagentLocation(ctx, "wsHostName-1") },
+			`{"wsHostName": "wsHostName-1"}`,
+		},
+		{
+			"CgwHelper.GetSlaveServerLocation",
+			func(ctx context.Context, c *KscClient) { c.CgwHelper.GetSlaveServerLocation(ctx, 12) },
+			`{"nSlaveServerId": 12}`,
+		},
+		{
+			"ChunkAccessor.GetItemsCount",
+			func(ctx context.Context, c *KscClient) { c.ChunkAccessor.GetItemsCount(ctx, "accessor-1") },
+			`{"strAccessor": "accessor-1"}`,
+		},
+		{
+			"ChunkAccessor.Release",
+			func(ctx context.Context, c *KscClient) { c.ChunkAccessor.Release(ctx, "accessor-1") },
+			`{"strAccessor": "accessor-1"}`,
+		},
+		{
+			"ConEvents.IsServiceConsoleAvailable",
+			func(ctx context.Context, c *KscClient) {
+				c.ConEvents.IsServiceConsoleAvailable(ctx, "wstrProdName-1", "wstrProdVersion-1")
+			},
+			`{"wstrProdName" : "wstrProdName-1", "wstrProdVersion" : "wstrProdVersion-1"}`,
+		},
+		{
+			"ConEvents.UnSubscribe",
+			func(ctx context.Context, c *KscClient) { c.ConEvents.UnSubscribe(ctx, 12) },
+			`{"nSubsId": 12}`,
+		},
+		{
+			"DataProtectionApi.CheckPasswordSplPpc",
+			func(ctx context.Context, c *KscClient) { c.DataProtectionAPI.CheckPasswordSplPpc(ctx, "szwPassword-1") },
+			`{"szwPassword": "szwPassword-1"}`,
+		},
+		{
+			"DataProtectionApi.ProtectDataForHost",
+			func(ctx context.Context, c *KscClient) {
+				c.DataProtectionAPI.ProtectDataForHost(ctx, "szwHostId-1", "pData-1")
+			},
+			`{"szwHostId" : "szwHostId-1", "pData" : "pData-1"}`,
+		},
+		{
+			"DataProtectionApi.ProtectDataGlobally",
+			func(ctx context.Context, c *KscClient) { c.DataProtectionAPI.ProtectDataGlobally(ctx, "pData-1") },
+			`{"pData" : "pData-1"}`,
+		},
+		{
+			"DataProtectionApi.ProtectUtf16StringForHost",
+			func(ctx context.Context, c *KscClient) {
+				c.DataProtectionAPI.ProtectUtf16StringForHost(ctx, "szwHostId-1", "szwPlainText-1")
+			},
+			`{"szwHostId" : "szwHostId-1", "szwPlainText" : "szwPlainText-1"}`,
+		},
+		{
+			"DataProtectionApi.ProtectUtf16StringGlobally",
+			func(ctx context.Context, c *KscClient) {
+				c.DataProtectionAPI.ProtectUtf16StringGlobally(ctx, "szwPlainText-1")
+			},
+			`{"szwPlainText" : "szwPlainText-1"}`,
+		},
+		{
+			"DataProtectionApi.ProtectUtf8StringForHost",
+			func(ctx context.Context, c *KscClient) {
+				c.DataProtectionAPI.ProtectUtf8StringForHost(ctx, "szwHostId-1", "szwPlainText-1")
+			},
+			`{"szwHostId" : "szwHostId-1", "szwPlainText" : "szwPlainText-1"}`,
+		},
+		{
+			"DataProtectionApi.ProtectUtf8StringGlobally",
+			func(ctx context.Context, c *KscClient) {
+				c.DataProtectionAPI.ProtectUtf8StringGlobally(ctx, "szwPlainText-1")
+			},
+			`{"szwPlainText" : "szwPlainText-1"}`,
+		},
+		{
+			"DatabaseInfo.CheckBackupPath",
+			func(ctx context.Context, c *KscClient) { c.DatabaseInfo.CheckBackupPath(ctx, "szwPath-1") },
+			`{"szwPath": "szwPath-1"}`,
+		},
+		{
+			"DatabaseInfo.CheckBackupPath2",
+			func(ctx context.Context, c *KscClient) {
+				c.DatabaseInfo.CheckBackupPath2(ctx, "szwWinPath-1", "szwLinuxPath-1")
+			},
+			`{"szwWinPath": "szwWinPath-1",	"szwLinuxPath": "szwLinuxPath-1"}`,
+		},
+		{
+			"DatabaseInfo.IsCloudSQL",
+			func(ctx context.Context, c *KscClient) { c.DatabaseInfo.IsCloudSQL(ctx, 12) },
+			`{"nCloudType": 12}`,
+		},
+		{
+			"DpeKeyService.GetDeviceKeys3",
+			func(ctx context.Context, c *KscClient) { c.DpeKeyService.GetDeviceKeys3(ctx, "wstrDeviceId-1") },
+			`{"wstrDeviceId": "wstrDeviceId-1"}`,
+		},
+		{
+			"EventProcessing.GetRecordCount",
+			func(ctx context.Context, c *KscClient) { c.EventProcessing.GetRecordCount(ctx, "strIteratorId-1") },
+			`{"strIteratorId": "strIteratorId-1"}`,
+		},
+		{
+			"EventProcessing.GetRecordRange",
+			func(ctx context.Context, c *KscClient) {
+				c.EventProcessing.GetRecordRange(ctx, "strIteratorId-1", 13, 14)
+			},
+			`{"strIteratorId": "strIteratorId-1", "nStart": 13, "nEnd": 14}`,
+		},
+		{
+			"EventProcessing.ReleaseIterator",
+			func(ctx context.Context, c *KscClient) { c.EventProcessing.ReleaseIterator(ctx, "strIteratorId-1") },
+			`{"strIteratorId": "strIteratorId-1"}`,
+		},
+		{
+			"ExtAud.UpdateRevisionDesc",
+			func(ctx context.Context, c *KscClient) {
+				c.ExtAud.UpdateRevisionDesc(ctx, 12, 13, 14, "wstrNewDescription-1")
+			},
+			`{"nObjId": 12,"nObjType": 13,"nObjRevision": 14, "wstrNewDescription": "wstrNewDescription-1"}`,
+		},
+		{
+			"ExtTenant.GetExternalTenantId",
+			func(ctx context.Context, c *KscClient) { c.ExtTenant.GetExternalTenantId(ctx, 12) },
+			`{"nVServerId": 12}`,
+		},
+		{
+			"FileCategorizer2.DeleteCategory",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.DeleteCategory(ctx, 12) },
+			`{"nCategoryId": 12}`,
+		},
+		{
+			"FileCategorizer2.DoStaticAnalysisAsync",
+			func(ctx context.Context, c *KscClient) {
+				c.FileCategorizer2.DoStaticAnalysisAsync(ctx, "wstrRequestId-1", 13)
+			},
+			`{"wstrRequestId": "wstrRequestId-1", "nPolicyId": 13}`,
+		},
+		{
+			"FileCategorizer2.DoStaticAnalysisAsync2",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.DoStaticAnalysisAsync2(ctx, 12) },
+			`{"nPolicyId": 12}`,
+		},
+		{
+			"FileCategorizer2.ForceCategoryUpdate",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.ForceCategoryUpdate(ctx, 12) },
+			`{"nCategoryId": 12}`,
+		},
+		{
+			"FileCategorizer2.GetCategory",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetCategory(ctx, 12) },
+			`{"nCategoryId": 12}`,
+		},
+		{
+			"FileCategorizer2.GetCategoryByUUID",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetCategoryByUUID(ctx, "pCategoryUUID-1") },
+			`{"pCategoryUUID": "pCategoryUUID-1"}`,
+		},
+		{
+			"FileCategorizer2.GetFileMetadata",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetFileMetadata(ctx, 12) },
+			`{"ulFlag": 12}`,
+		},
+		{
+			"FileCategorizer2.GetFilesMetadata",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetFilesMetadata(ctx, 12) },
+			`{"ulFlag": 12}`,
+		},
+		{
+			"FileCategorizer2.GetFilesMetadataFromMSI",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetFilesMetadataFromMSI(ctx, 12) },
+			`{"ulFlag": 12}`,
+		},
+		{
+			"FileCategorizer2.GetRefPolicies",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetRefPolicies(ctx, 12) },
+			`{"nCatId": 12}`,
+		},
+		{
+			"FileCategorizer2.GetSerializedCategoryBody",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetSerializedCategoryBody(ctx, 12) },
+			`{"nCategoryId": 12}`,
+		},
+		{
+			"FileCategorizer2.GetSerializedCategoryBody2",
+			func(ctx context.Context, c *KscClient) { c.FileCategorizer2.GetSerializedCategoryBody2(ctx, 12) },
+			`{"nCategoryId": 12}`,
+		},
+		{
+			"FilesAcceptor.CancelFileUpload",
+			func(ctx context.Context, c *KscClient) { c.FilesAcceptor.CancelFileUpload(ctx, "wstrFileId-1") },
+			`{"wstrFileId": "wstrFileId-1"}`,
+		},
+		{
+			"FilesAcceptor.InitiateFileUpload",
+			func(ctx context.Context, c *KscClient) { c.FilesAcceptor.InitiateFileUpload(ctx, true, 13) },
+			`{"bIsArchive": true, "qwFileSize": 13}`,
+		},
+		{
+			"GroupSync.GetSyncDeliveryTime",
+			func(ctx context.Context, c *KscClient) { c.GroupSync.GetSyncDeliveryTime(ctx, 12, "szwHostId-1") },
+			`{"nSync": 12, "szwHostId": "szwHostId-1"}`,
+		},
+		{
+			"GroupSyncIterator.ReleaseIterator",
+			func(ctx context.Context, c *KscClient) { c.GroupSyncIterator.ReleaseIterator(ctx, "szwIterator-1") },
+			`
+	{
+	"szwIterator": "szwIterator-1"
+	}`,
+		},
+		{
+			"GroupTaskControlApi.CommitImportedTask",
+			func(ctx context.Context, c *KscClient) {
+				c.GroupTaskControlAPI.CommitImportedTask(ctx, "wstrId-1", false)
+			},
+			`{"wstrId": "wstrId-1", "bCommit": false}`,
+		},
+		{
+			"GroupTaskControlApi.ExportTask",
+			func(ctx context.Context, c *KscClient) { c.GroupTaskControlAPI.ExportTask(ctx, "wstrTaskId-1") },
+			`{"wstrTaskId": "wstrTaskId-1"}`,
+		},
+		{
+			"GroupTaskControlApi.GetTaskByRevision",
+			func(ctx context.Context, c *KscClient) { c.GroupTaskControlAPI.GetTaskByRevision(ctx, 12, 13) },
+			`{"nObjId": 12, "nRevision": 13}`,
+		},
+		{
+			"GroupTaskControlApi.RestoreTaskFromRevision",
+			func(ctx context.Context, c *KscClient) { c.GroupTaskControlAPI.RestoreTaskFromRevision(ctx, 12, 13) },
+			`{"nObjId": 12, "nRevision": 13}`,
+		},
+		{
+			"GuiContext.SetLanguage",
+			func(ctx context.Context, c *KscClient) { c.GuiContext.SetLanguage(ctx, "pwchIetfLanguageTag-1") },
+			`{"pwchIetfLanguageTag": "pwchIetfLanguageTag-1"}`,
+		},
+		{
+			"HWInvStorage.AddDynColumn",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.AddDynColumn(ctx, "wstrColName-1") },
+			`{"wstrColName": "wstrColName-1"}`,
+		},
+		{
+			"HWInvStorage.DelDynColumn",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.DelDynColumn(ctx, "wstrColId-1") },
+			`{"wstrColId": "wstrColId-1"}`,
+		},
+		{
+			"HWInvStorage.DelHWInvObject",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.DelHWInvObject(ctx, 12) },
+			`{"nObjId": 12}`,
+		},
+		{
+			"HWInvStorage.ExportHWInvStorage2",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.ExportHWInvStorage2(ctx, 12) },
+			`{"eExportType": 12}`,
+		},
+		{
+			"HWInvStorage.ExportHWInvStorageCancel",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.ExportHWInvStorageCancel(ctx, "wstrAsyncId-1") },
+			`{"wstrAsyncId": "wstrAsyncId-1"}`,
+		},
+		{
+			"HWInvStorage.ExportHWInvStorageGetData",
+			func(ctx context.Context, c *KscClient) {
+				c.HWInvStorage.ExportHWInvStorageGetData(ctx, "wstrAsyncId-1", 13)
+			},
+			`{"wstrAsyncId": "wstrAsyncId-1","nGetDataSize": 13}`,
+		},
+		{
+			"HWInvStorage.GetHWInvObject",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.GetHWInvObject(ctx, 12) },
+			`{"nObjId": 12}`,
+		},
+		{
+			"HWInvStorage.ImportHWInvStorage2",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.ImportHWInvStorage2(ctx, 12) },
+			`{"eImportType": 12}`,
+		},
+		{
+			"HWInvStorage.SetWriteOffFlag",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.SetWriteOffFlag(ctx, 12, false) },
+			`{"nObjId": 12,"bFlag": false}`,
+		},
+		{
+			"HostGroup.AddDomain",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.AddDomain(ctx, "strDomain-1", 13) },
+			`{"strDomain": "strDomain-1", "nType" : 13 }`,
+		},
+		{
+			"HostGroup.AddGroupHostsForSync",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.AddGroupHostsForSync(ctx, 12, "strSSType-1") },
+			` {"nGroupId": 12 , "strSSType": "strSSType-1" }`,
+		},
+		{
+			"HostGroup.DelDomain",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.DelDomain(ctx, "strDomain-1") },
+			`{"strDomain": "strDomain-1"}`,
+		},
+		{
+			"HostGroup.DeleteIncident",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.DeleteIncident(ctx, 12) },
+			`{"nId": 12}`,
+		},
+		{
+			"HostGroup.FindHostsAsyncCancel",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.FindHostsAsyncCancel(ctx, "strRequestId-1") },
+			`{"strRequestId": "strRequestId-1"}`,
+		},
+		{
+			"HostGroup.FindHostsAsyncGetAccessor",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.FindHostsAsyncGetAccessor(ctx, "strRequestId-1") },
+			`{"strRequestId" : "strRequestId-1" }`,
+		},
+		{
+			"HostGroup.GetComponentsForProductOnHost",
+			func(ctx context.Context, c *KscClient) {
+				c.HostGroup.GetComponentsForProductOnHost(ctx, "strHostName-1", "strProductName-1", "strProductVersion-1")
+			},
+			`{"strHostName": "strHostName-1","strProductName": "strProductName-1","strProductVersion": "strProductVersion-1"}`,
+		},
+		{
+			"HostGroup.GetDomainHosts",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.GetDomainHosts(ctx, "domain-1") },
+			`{"domain": "domain-1"}`,
+		},
+		{
+			"HostGroup.GetGroupId",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.GetGroupId(ctx, 12, "strName-1") },
+			`{"nParent": 12, "strName": "strName-1"}`,
+		},
+		{
+			"HostGroup.GetGroupInfo",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.GetGroupInfo(ctx, 12) },
+			`{"nGroupId": 12}`,
+		},
+		{
+			"HostGroup.GetHostProducts",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.GetHostProducts(ctx, "strHostName-1") },
+			`{"strHostName": "strHostName-1"}`,
+		},
+		{
+			"HostGroup.GetHostTasks",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.GetHostTasks(ctx, "hostId-1") },
+			`{"strHostName": "hostId-1"}`,
+		},
+		{
+			"HostGroup.GetHostfixesForProductOnHost",
+			func(ctx context.Context, c *KscClient) {
+				c.HostGroup.GetHostfixesForProductOnHost(ctx, "strHostName-1", "strProductName-1", "strProductVersion-1")
+			},
+			`{"strHostName": "strHostName-1","strProductName": "strProductName-1","strProductVersion": "strProductVersion-1"}`,
+		},
+		{
+			"HostGroup.GetSubgroups",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.GetSubgroups(ctx, 12, 13) },
+			`{"nParent": 12, "nDepth": 13 }`,
+		},
+		{
+			"HostGroup.MoveHostsFromGroupToGroup",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.MoveHostsFromGroupToGroup(ctx, 12, 13) },
+			`{"nSrcGroupId": 12, "nDstGroupId": 13}`,
+		},
+		{
+			"HostGroup.RemoveGroup",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.RemoveGroup(ctx, 12, 13) },
+			`{ "nGroup": 12, "nFlags": 13 }`,
+		},
+		{
+			"HostGroup.RemoveHost",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.RemoveHost(ctx, "strHostName-1") },
+			`{ "strHostName": "strHostName-1" }`,
+		},
+		{
+			"HostGroup.RestartNetworkScanning",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.RestartNetworkScanning(ctx, 12) },
+			`{"nType": 12	}`,
+		},
+		{
+			"HostGroup.ZeroVirusCountForGroup",
+			func(ctx context.Context, c *KscClient) { c.HostGroup.ZeroVirusCountForGroup(ctx, 12) },
+			`{"nParent": 12}`,
+		},
+		{
+			"HostMoveRules.DeleteRule",
+			func(ctx context.Context, c *KscClient) { c.HostMoveRules.DeleteRule(ctx, 12) },
+			`{"nRule": 12}`,
+		},
+		{
+			"HostMoveRules.GetRule",
+			func(ctx context.Context, c *KscClient) { c.HostMoveRules.GetRule(ctx, 12) },
+			`{"nRule": 12}`,
+		},
+		{
+			"HostTagsRulesApi.CancelAsyncAction",
+			func(ctx context.Context, c *KscClient) { c.HostTagsRulesAPI.CancelAsyncAction(ctx, "wstrActionGuid-1") },
+			`{"wstrActionGuid": "wstrActionGuid-1"}`,
+		},
+		{
+			"HostTagsRulesApi.DeleteRule",
+			func(ctx context.Context, c *KscClient) { c.HostTagsRulesAPI.DeleteRule(ctx, "szwTagValue-1") },
+			`{"szwTagValue": "szwTagValue-1"}`,
+		},
+		{
+			"HostTagsRulesApi.ExecuteRule",
+			func(ctx context.Context, c *KscClient) { c.HostTagsRulesAPI.ExecuteRule(ctx, "szwTagValue-1") },
+			`{"szwTagValue": "szwTagValue-1"}`,
+		},
+		{
+			"HostTagsRulesApi.GetRule",
+			func(ctx context.Context, c *KscClient) { c.HostTagsRulesAPI.GetRule(ctx, "szwTagValue-1") },
+			`{"szwTagValue": "szwTagValue-1"}`,
+		},
+		{
+			"HostTasks.GetNextTask",
+			func(ctx context.Context, c *KscClient) { c.HostTasks.GetNextTask(ctx, "strSrvObjId-1") },
+			`
+	{
+	"strSrvObjId": "strSrvObjId-1"
+	}`,
+		},
+		{
+			"HostTasks.ResetTasksIterator",
+			func(ctx context.Context, c *KscClient) {
+				c.HostTasks.ResetTasksIterator(ctx, "strSrvObjId-1", "strProductName-1", "strVersion-1", "strComponentName-1", "strInstanceId-1", "strTaskName-1")
+			},
+			`{
+		"strSrvObjId": "strSrvObjId-1",
+		"strProductName": "strProductName-1",
+		"strVersion": "strVersion-1",
+		"strComponentName":	"strComponentName-1",
+		"strInstanceId": "strInstanceId-1",
+		"strTaskName": "strTaskName-1"
+	}`,
+		},
+		{
+			"HstAccessControl.AccessCheckToAdmGroup",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.AccessCheckToAdmGroup(ctx, 12, 13, "szwFuncArea-1", "szwProduct-1", "szwVersion-1")
+			},
+			`{"lGroupId":12,"dwAccessMask":13,"szwFuncArea":"szwFuncArea-1","szwProduct":"szwProduct-1", 
+	"szwVersion":"szwVersion-1"}`,
+		},
+		{
+			"HstAccessControl.DeleteRole",
+			func(ctx context.Context, c *KscClient) { c.HstAccessControl.DeleteRole(ctx, 12, false) },
+			`{"nId":12,"bProtection":false}`,
+		},
+		{
+			"HstAccessControl.DeleteScObjectAcl",
+			func(ctx context.Context, c *KscClient) { c.HstAccessControl.DeleteScObjectAcl(ctx, 12, 13) },
+			`{"nObjId":12,"nObjType":13}`,
+		},
+		{
+			"HstAccessControl.DeleteScVServerAcl",
+			func(ctx context.Context, c *KscClient) { c.HstAccessControl.DeleteScVServerAcl(ctx, 12) },
+			`{"nId":12}`,
+		},
+		{
+			"HstAccessControl.GetAccessibleFuncAreas",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.GetAccessibleFuncAreas(ctx, 12, 13, "szwProduct-1", "szwVersion-1", true)
+			},
+			`{"lGroupId" : 12, "dwAccessMask": 13, "szwProduct": "szwProduct-1", "szwVersion": "szwVersion-1", 
+	"bInvert" : true}`,
+		},
+		{
+			"HstAccessControl.GetMappingFuncAreaToPolicies",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.GetMappingFuncAreaToPolicies(ctx, "szwProduct-1", "szwVersion-1")
+			},
+			`{"szwProduct": "szwProduct-1","szwVersion": "szwVersion-1"}`,
+		},
+		{
+			"HstAccessControl.GetMappingFuncAreaToReports",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.GetMappingFuncAreaToReports(ctx, "szwProduct-1", "szwVersion-1")
+			},
+			`{"szwProduct": "szwProduct-1","szwVersion": "szwVersion-1"}`,
+		},
+		{
+			"HstAccessControl.GetMappingFuncAreaToSettings",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.GetMappingFuncAreaToSettings(ctx, "szwProduct-1", "szwVersion-1")
+			},
+			`{"szwProduct": "szwProduct-1","szwVersion": "szwVersion-1"}`,
+		},
+		{
+			"HstAccessControl.GetMappingFuncAreaToTasks",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.GetMappingFuncAreaToTasks(ctx, "szwProduct-1", "szwVersion-1")
+			},
+			`{"szwProduct": "szwProduct-1","szwVersion": "szwVersion-1"}`,
+		},
+		{
+			"HstAccessControl.GetScObjectAcl",
+			func(ctx context.Context, c *KscClient) { c.HstAccessControl.GetScObjectAcl(ctx, 12, 13) },
+			`{"nObjId":12,"nObjType":13}`,
+		},
+		{
+			"HstAccessControl.GetScVServerAcl",
+			func(ctx context.Context, c *KscClient) { c.HstAccessControl.GetScVServerAcl(ctx, 12) },
+			`{"nId":12}`,
+		},
+		{
+			"HstAccessControl.GetVisualViewForAccessRights",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.GetVisualViewForAccessRights(ctx, "wstrLangCode-1", 13, 14)
+			},
+			`{"wstrLangCode": "wstrLangCode-1","nObjId": 13,"nObjType": 14}`,
+		},
+		{
+			"HstAccessControl.IsTaskTypeReadonly",
+			func(ctx context.Context, c *KscClient) {
+				c.HstAccessControl.IsTaskTypeReadonly(ctx, 12, "szwProduct-1", "szwVersion-1", "szwTaskTypeName-1")
+			},
+			`{"lGroupId": 12,"szwProduct": "szwProduct-1","szwVersion": "szwVersion-1","szwTaskTypeName": "szwTaskTypeName-1"}`,
+		},
+		{
+			"IWebSrvSettings.SetCustomPkgHttpFqdn",
+			func(ctx context.Context, c *KscClient) { c.IWebSrvSettings.SetCustomPkgHttpFqdn(ctx, "wsFqdn-1") },
+			`{"wsFqdn": "wsFqdn-1"}`,
+		},
+		{
+			"InvLicenseProducts.DeleteLicenseKey",
+			func(ctx context.Context, c *KscClient) { c.InvLicenseProducts.DeleteLicenseKey(ctx, 12) },
+			`{"nLicKeyId": 12}`,
+		},
+		{
+			"InvLicenseProducts.DeleteLicenseProduct",
+			func(ctx context.Context, c *KscClient) { c.InvLicenseProducts.DeleteLicenseProduct(ctx, 12) },
+			`{"nLicProdId": 12}`,
+		},
+		{
+			"InventoryApi.GetHostInvPatches",
+			func(ctx context.Context, c *KscClient) { c.InventoryAPI.GetHostInvPatches(ctx, "szwHostID-1") },
+			`{"szwHostId": "szwHostID-1"}`,
+		},
+		{
+			"InventoryApi.GetHostInvProducts",
+			func(ctx context.Context, c *KscClient) { c.InventoryAPI.GetHostInvProducts(ctx, "szwHostID-1") },
+			`{"szwHostId": "szwHostID-1"}`,
+		},
+		{
+			"InventoryApi.GetSrvCompetitorIniFileInfoList",
+			func(ctx context.Context, c *KscClient) {
+				c.InventoryAPI.GetSrvCompetitorIniFileInfoList(ctx, "wstrType-1")
+			},
+			`{"wstrType": "wstrType-1"}`,
+		},
+		{
+			"KLEVerControl.CancelDownloadDistributive",
+			func(ctx context.Context, c *KscClient) {
+				c.KLEVerControl.CancelDownloadDistributive(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"KLEVerControl.GetDownloadDistributiveResult",
+			func(ctx context.Context, c *KscClient) {
+				c.KLEVerControl.GetDownloadDistributiveResult(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"KeyService.DecryptData",
+			func(ctx context.Context, c *KscClient) {
+				c.KeyService.DecryptData(ctx, "pEncryptedData-1", "wstrProdName-1", "wstrProdVersion-1")
+			},
+			`{"pEncryptedData": "pEncryptedData-1", "wstrProdName": "wstrProdName-1", "wstrProdVersion": "wstrProdVersion-1"}`,
+		},
+		{
+			"KeyService.EncryptData",
+			func(ctx context.Context, c *KscClient) { c.KeyService.EncryptData(ctx, "pData-1") },
+			`{"pData": "pData-1"}`,
+		},
+		{
+			"KeyService.EncryptDataForHost",
+			func(ctx context.Context, c *KscClient) {
+				c.KeyService.EncryptDataForHost(ctx, "wstrHostId-1", "pData-1")
+			},
+			`{"wstrHostId" : "wstrHostId-1", "pData": "pData-1"}`,
+		},
+		{
+			"KeyService.GenerateTransportCertificate",
+			func(ctx context.Context, c *KscClient) {
+				c.KeyService.GenerateTransportCertificate(ctx, "wstrCommonName-1")
+			},
+			`{"wstrCommonName": "wstrCommonName-1"}`,
+		},
+		{
+			"KeyService2.ExportDpeKeys",
+			func(ctx context.Context, c *KscClient) { c.KeyService2.ExportDpeKeys(ctx, "pProtectedPass-1") },
+			`{"pProtectedPass": "pProtectedPass-1"}`,
+		},
+		{
+			"KeyService2.ImportDpeKeys",
+			func(ctx context.Context, c *KscClient) { c.KeyService2.ImportDpeKeys(ctx, "pProtectedPass-1") },
+			`{"pProtectedPass": "pProtectedPass-1"}`,
+		},
+		{
+			"KillChain.GetByIDs",
+			func(ctx context.Context, c *KscClient) { c.KillChain.GetByIDs(ctx, "wstrHostID-1", "wstrElementID-1") },
+			`{"wstrHostID": "wstrHostID-1", "wstrElementID": "wstrElementID-1"}`,
+		},
+		{
+			"KsnInternal.GetNKsnEula",
+			func(ctx context.Context, c *KscClient) { c.KsnInternal.GetNKsnEula(ctx, "wstrNKsnLoc-1") },
+			`{"wstrNKsnLoc": "wstrNKsnLoc-1"}`,
+		},
+		{
+			"LicenseInfoSync.AcquireKeysForProductOnHost",
+			func(ctx context.Context, c *KscClient) {
+				c.LicenseInfoSync.AcquireKeysForProductOnHost(ctx, "szwHostName-1", "szwProduct-1", "szwVersion-1")
+			},
+			`{"szwHostName":"szwHostName-1","szwProduct":"szwProduct-1","szwVersion":"szwVersion-1"}`,
+		},
+		{
+			"LicenseInfoSync.GetKeyDataForHost",
+			func(ctx context.Context, c *KscClient) {
+				c.LicenseInfoSync.GetKeyDataForHost(ctx, "szwSerial-1", "szwHostName-1", "szwProduct-1", "szwVersion-1")
+			},
+			`{"szwSerial":"szwSerial-1", "szwHostName":"szwHostName-1", "szwProduct":"szwProduct-1", "szwVersion":"szwVersion-1"}`,
+		},
+		{
+			"LicenseInfoSync.IsPCloudKey",
+			func(ctx context.Context, c *KscClient) { c.LicenseInfoSync.IsPCloudKey(ctx, 12) },
+			`{"nProductId": 12}`,
+		},
+		{
+			"LicenseInfoSync.TryToUnistallLicense",
+			func(ctx context.Context, c *KscClient) { c.LicenseInfoSync.TryToUnistallLicense(ctx, true) },
+			`{"bCurrent": true}`,
+		},
+		{
+			"LicenseKeys.DownloadKeyFiles",
+			func(ctx context.Context, c *KscClient) { c.LicenseKeys.DownloadKeyFiles(ctx, "wstrActivationCode-1") },
+			`
+	{
+	"wstrActivationCode": "wstrActivationCode-1"
+	}`,
+		},
+		{
+			"LicenseKeys.SaasTryToUninstall",
+			func(ctx context.Context, c *KscClient) { c.LicenseKeys.SaasTryToUninstall(ctx, true) },
+			`{"bCurrent": true}`,
+		},
+		{
+			"LicenseKeys.UninstallKey",
+			func(ctx context.Context, c *KscClient) { c.LicenseKeys.UninstallKey(ctx, true) },
+			`{"bCurrent": true}`,
+		},
+		{
+			"LicensePolicy.GetFreeLicenseCount",
+			func(ctx context.Context, c *KscClient) { c.LicensePolicy.GetFreeLicenseCount(ctx, 12) },
+			`{"nFunctionality": 12}`,
+		},
+		{
+			"LicensePolicy.GetTotalLicenseCount",
+			func(ctx context.Context, c *KscClient) { c.LicensePolicy.GetTotalLicenseCount(ctx, 12) },
+			`{"nFunctionality": 12}`,
+		},
+		{
+			"LicensePolicy.IsLimitedMode",
+			func(ctx context.Context, c *KscClient) { c.LicensePolicy.IsLimitedMode(ctx, 12) },
+			`{"nFunctionality": 12}`,
+		},
+		{
+			"LicensePolicy.SetLimitedModeTest",
+			func(ctx context.Context, c *KscClient) { c.LicensePolicy.SetLimitedModeTest(ctx, true, 13) },
+			`{"bLimited": true, "eFunctionality": 13}`,
+		},
+		{
+			"LicensePolicy.SetTotalLicenseCountTest",
+			func(ctx context.Context, c *KscClient) { c.LicensePolicy.SetTotalLicenseCountTest(ctx, 12, 13) },
+			`{"eFunctionality": 12, "nCount": 13}`,
+		},
+		{
+			"LicensePolicy.SetUsedLicenseCountTest",
+			func(ctx context.Context, c *KscClient) { c.LicensePolicy.SetUsedLicenseCountTest(ctx, 12, 13) },
+			`{"eFunctionality": 12, "nCount": 13}`,
+		},
+		{
+			"Limits.GetLimits",
+			func(ctx context.Context, c *KscClient) { c.Limits.GetLimits(ctx, 12) },
+			`{ "param": 12 }`,
+		},
+		{
+			"MdmCertCtrlApi.CancelGeneratePackage2",
+			func(ctx context.Context, c *KscClient) {
+				c.MdmCertCtrlApi.CancelGeneratePackage2(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"MdmCertCtrlApi.CancelSetCertificate2",
+			func(ctx context.Context, c *KscClient) {
+				c.MdmCertCtrlApi.CancelSetCertificate2(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"MdmCertCtrlApi.CheckMailNotificationSettings",
+			func(ctx context.Context, c *KscClient) { c.MdmCertCtrlApi.CheckMailNotificationSettings(ctx, true) },
+			`{"bCheckMainServerDefaults": true}`,
+		},
+		{
+			"MdmCertCtrlApi.GetCertificatePublic",
+			func(ctx context.Context, c *KscClient) { c.MdmCertCtrlApi.GetCertificatePublic(ctx, 12) },
+			`{"nCertId": 12}`,
+		},
+		{
+			"MdmCertCtrlApi.GetIssuanceSettingsByType",
+			func(ctx context.Context, c *KscClient) { c.MdmCertCtrlApi.GetIssuanceSettingsByType(ctx, 12) },
+			`{"nCertType": 12}`,
+		},
+		{
+			"MdmCertCtrlApi.GetPkiTemplates",
+			func(ctx context.Context, c *KscClient) { c.MdmCertCtrlApi.GetPkiTemplates(ctx, true) },
+			`{"bForceReload": true}`,
+		},
+		{
+			"MigrationData.CancelExport",
+			func(ctx context.Context, c *KscClient) { c.MigrationData.CancelExport(ctx, "wstrActionGuid-1") },
+			`{"wstrActionGuid": "wstrActionGuid-1"}`,
+		},
+		{
+			"Multitenancy.GetProducts",
+			func(ctx context.Context, c *KscClient) {
+				c.Multitenancy.GetProducts(ctx, "strProdName-1", "strProdVersion-1")
+			},
+			`{"strProdName": "strProdName-1", "strProdVersion": "strProdVersion-1"}`,
+		},
+		{
+			"NagCgwHelper.GetProductComponentLocation",
+			func(ctx context.Context, c *KscClient) {
+				c.NagCgwHelper.GetProductComponentLocation(ctx, "szwProduct-1", "szwVersion-1", "szwComponent-1")
+			},
+			`{"szwProduct": "szwProduct-1",	"szwVersion": "szwVersion-1",	"szwComponent": "szwComponent-1"}`,
+		},
+		{
+			"NagHstCtl.SendProductAction",
+			func(ctx context.Context, c *KscClient) {
+				c.NagHstCtl.SendProductAction(ctx, "szwProduct-1", "szwVersion-1", 14)
+			},
+			` { "szwProduct": "szwProduct-1", "szwVersion": "szwVersion-1", "nProductAction": 14 }`,
+		},
+		{
+			"NagHstCtl.SendTaskAction",
+			func(ctx context.Context, c *KscClient) {
+				c.NagHstCtl.SendTaskAction(ctx, "szwProduct-1", "szwVersion-1", "szwTaskStorageId-1", 15)
+			},
+			`{"szwProduct": "szwProduct-1", "szwVersion": "szwVersion-1", "szwTaskStorageId": "szwTaskStorageId-1", "nTaskAction": 15 }`,
+		},
+		{
+			"NagRdu.ChangeTraceParams",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.ChangeTraceParams(ctx, "szwProductID-1", 13) },
+			`{"szwProductID": "szwProductID-1", "nTraceLevel": 13 }`,
+		},
+		{
+			"NagRdu.ChangeTraceRotatedParams",
+			func(ctx context.Context, c *KscClient) {
+				c.NagRdu.ChangeTraceRotatedParams(ctx, "szwProductID-1", 13, 14, 15)
+			},
+			`{ "szwProductID": "szwProductID-1", "nTraceLevel": 13, "nPartsCount": 14, 
+	"nMaxPartSize": 15 }`,
+		},
+		{
+			"NagRdu.ChangeXperfBaseParams",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.ChangeXperfBaseParams(ctx, "szwProductID-1", 13, 14) },
+			`{"szwProductID": "szwProductID-1", "nTraceLevel": 13, "nXPerfMode": 14 }`,
+		},
+		{
+			"NagRdu.ChangeXperfRotatedParams",
+			func(ctx context.Context, c *KscClient) {
+				c.NagRdu.ChangeXperfRotatedParams(ctx, "szwProductID-1", 13, 14, 15)
+			},
+			`{"szwProductID": "szwProductID-1", "nTraceLevel": 13, "nXPerfMode": 14, "nMaxPartSize": 15 }`,
+		},
+		{
+			"NagRdu.CreateAndDownloadDumpAsync",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.CreateAndDownloadDumpAsync(ctx, "szwProcessName-1") },
+			`{"szwProcessName": "szwProcessName-1"}`,
+		},
+		{
+			"NagRdu.DeleteFile",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.DeleteFile(ctx, "szwRemoteFile-1") },
+			`{"szwRemoteFile": "szwRemoteFile-1"}`,
+		},
+		{
+			"NagRdu.DownloadEventlogAsync",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.DownloadEventlogAsync(ctx, "szwEventLog-1") },
+			`{"szwEventLog": "szwEventLog-1"}`,
+		},
+		{
+			"NagRdu.ExecuteFileAsync",
+			func(ctx context.Context, c *KscClient) {
+				c.NagRdu.ExecuteFileAsync(ctx, "szwURL-1", "szwShortExecName-1", "szwParams-1")
+			},
+			`{"szwURL": "szwURL-1", "szwShortExecName": "szwShortExecName-1", "szwParams": "szwParams-1"}`,
+		},
+		{
+			"NagRdu.RunKlnagchkAsync",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.RunKlnagchkAsync(ctx, "szwProductID-1") },
+			`{"szwProductID": "szwProductID-1"}`,
+		},
+		{
+			"NagRdu.SetProductStateAsync",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.SetProductStateAsync(ctx, "szwProductID-1", 13) },
+			`{"szwProductID": "szwProductID-1", "nNewState": 13 }`,
+		},
+		{
+			"NagRemoteScreen.GetExistingSessions",
+			func(ctx context.Context, c *KscClient) { c.NagRemoteScreen.GetExistingSessions(ctx, 12) },
+			`{"nType": 12}`,
+		},
+		{
+			"NagRemoteScreen.OpenSession",
+			func(ctx context.Context, c *KscClient) { c.NagRemoteScreen.OpenSession(ctx, 12, "szwID-1") },
+			`{"nType": 12, "szwID": "szwID-1"}`,
+		},
+		{
+			"NlaDefinedNetworks.AddNetwork",
+			func(ctx context.Context, c *KscClient) { c.NlaDefinedNetworks.AddNetwork(ctx, "wstrNetworkName-1") },
+			`{"wstrNetworkName": "wstrNetworkName-1"}`,
+		},
+		{
+			"NlaDefinedNetworks.DeleteNetwork",
+			func(ctx context.Context, c *KscClient) { c.NlaDefinedNetworks.DeleteNetwork(ctx, 12) },
+			`{"nNetworkId": 12}`,
+		},
+		{
+			"NlaDefinedNetworks.GetNetworkInfo",
+			func(ctx context.Context, c *KscClient) { c.NlaDefinedNetworks.GetNetworkInfo(ctx, 12) },
+			`{"nNetworkId": 12}`,
+		},
+		{
+			"OAuth2.GetClients",
+			func(ctx context.Context, c *KscClient) { c.OAuth2.GetClients(ctx, 12) },
+			`{"nFilterByState": 12}`,
+		},
+		{
+			"OAuth2.GetResServers",
+			func(ctx context.Context, c *KscClient) { c.OAuth2.GetResServers(ctx, 12) },
+			`{"nFilterByState": 12}`,
+		},
+		{
+			"PackagesApi.AllowSharedPrerequisitesInstallation",
+			func(ctx context.Context, c *KscClient) {
+				c.PackagesAPI.AllowSharedPrerequisitesInstallation(ctx, 12, false)
+			},
+			`{"nPackageId": 12, "bAllow": false}`,
+		},
+		{
+			"PackagesApi.CancelCreateExecutablePkg",
+			func(ctx context.Context, c *KscClient) {
+				c.PackagesAPI.CancelCreateExecutablePkg(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"PackagesApi.CancelGetExecutablePkgFile",
+			func(ctx context.Context, c *KscClient) {
+				c.PackagesAPI.CancelGetExecutablePkgFile(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"PackagesApi.CancelRecordNewPackage",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.CancelRecordNewPackage(ctx, "wstrRequestId-1") },
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"PackagesApi.CancelUpdateBasesInPackages",
+			func(ctx context.Context, c *KscClient) {
+				c.PackagesAPI.CancelUpdateBasesInPackages(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"PackagesApi.DeleteExecutablePkg",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.DeleteExecutablePkg(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetEulaText",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetEulaText(ctx, 12) },
+			`{"nEulaId": 12}`,
+		},
+		{
+			"PackagesApi.GetExecutablePackages",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetExecutablePackages(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetIncompatibleAppsInfo",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetIncompatibleAppsInfo(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetIntranetFolderForNewPackage",
+			func(ctx context.Context, c *KscClient) {
+				c.PackagesAPI.GetIntranetFolderForNewPackage(ctx, "wstrProductName-1", "wstrProductVersion-1")
+			},
+			`{"wstrProductName": "wstrProductName-1", "wstrProductVersion": "wstrProductVersion-1"}`,
+		},
+		{
+			"PackagesApi.GetIntranetFolderForPackage",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetIntranetFolderForPackage(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetLicenseKey",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetLicenseKey(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetLoginScript",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetLoginScript(ctx, 12, "wstrTaskId-1") },
+			`{"nPackageId": 12, "wstrTaskId": "wstrTaskId-1"}`,
+		},
+		{
+			"PackagesApi.GetMoveRuleInfo",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetMoveRuleInfo(ctx, 12) },
+			`{"nRuleId": 12}`,
+		},
+		{
+			"PackagesApi.GetPackageInfo",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetPackageInfo(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetPackageInfo2",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetPackageInfo2(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetPackagePlugin",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetPackagePlugin(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.GetRebootOptionsEx",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.GetRebootOptionsEx(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.IsPackagePublished",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.IsPackagePublished(ctx, 12) },
+			`{"nPkgExecId": 12}`,
+		},
+		{
+			"PackagesApi.ReadKpdFile",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.ReadKpdFile(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.ReadPkgCfgFile",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.ReadPkgCfgFile(ctx, 12, "wstrFileName-1") },
+			`{"nPackageId": 12, "wstrFileName": "wstrFileName-1"}`,
+		},
+		{
+			"PackagesApi.RemovePackage",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.RemovePackage(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.RemovePackage2",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.RemovePackage2(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.RenamePackage",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.RenamePackage(ctx, 12, "wstrNewPackageName-1") },
+			`{"nPackageId": 12, "wstrNewPackageName": "wstrNewPackageName-1"}`,
+		},
+		{
+			"PackagesApi.ResetDefaultServerSpecificSettings",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.ResetDefaultServerSpecificSettings(ctx, 12) },
+			`{"nPackageId": 12}`,
+		},
+		{
+			"PackagesApi.ResolvePackageLcid",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.ResolvePackageLcid(ctx, 12, 13) },
+			`{"nPackageId": 12, "nLcid": 13}`,
+		},
+		{
+			"PackagesApi.SetRemoveIncompatibleApps",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.SetRemoveIncompatibleApps(ctx, 12, false) },
+			`{"nPackageId": 12, "bRemoveIncompatibleApps": false}`,
+		},
+		{
+			"PackagesApi.UnpublishMobilePackage",
+			func(ctx context.Context, c *KscClient) { c.PackagesAPI.UnpublishMobilePackage(ctx, "wstrProfileId-1") },
+			`{"wstrProfileId": "wstrProfileId-1"}`,
+		},
+		{
+			"PackagesApi.WriteKpdProfileString",
+			func(ctx context.Context, c *KscClient) {
+				c.PackagesAPI.WriteKpdProfileString(ctx, 12, "wstrSection-1", "wstrKey-1", "wstrValue-1")
+			},
+			`{"nPackageId": 12, "wstrSection": "wstrSection-1", "wstrKey": "wstrKey-1", "wstrValue": "wstrValue-1"}`,
+		},
+		{
+			"PatchParameters.GetTemplate",
+			func(ctx context.Context, c *KscClient) { c.PatchParameters.GetTemplate(ctx, 12, 13) },
+			`{"patchID": 12, "locID": 13}`,
+		},
+		{
+			"PatchParameters.GetValues",
+			func(ctx context.Context, c *KscClient) { c.PatchParameters.GetValues(ctx, 12, 13) },
+			`{"patchID": 12, "locID": 13}`,
+		},
+		{
+			"PatchParameters.GetValuesByPkg",
+			func(ctx context.Context, c *KscClient) { c.PatchParameters.GetValuesByPkg(ctx, 12) },
+			`{"packageId": 12}`,
+		},
+		{
+			"Policy.DeletePolicy",
+			func(ctx context.Context, c *KscClient) { c.Policy.DeletePolicy(ctx, 12) },
+			`{"nPolicy": 12}`,
+		},
+		{
+			"Policy.ExportPolicy",
+			func(ctx context.Context, c *KscClient) { c.Policy.ExportPolicy(ctx, 12) },
+			`{"lPolicy": 12}`,
+		},
+		{
+			"Policy.GetEffectivePoliciesForGroup",
+			func(ctx context.Context, c *KscClient) { c.Policy.GetEffectivePoliciesForGroup(ctx, 12) },
+			`{"nGroupId": 12}`,
+		},
+		{
+			"Policy.GetPoliciesForGroup",
+			func(ctx context.Context, c *KscClient) { c.Policy.GetPoliciesForGroup(ctx, 12) },
+			`{"nGroupId": 12}`,
+		},
+		{
+			"Policy.GetPolicyContents",
+			func(ctx context.Context, c *KscClient) { c.Policy.GetPolicyContents(ctx, 12, 13, 14) },
+			`{ "nPolicy": 12 , "nRevisionId": 13 , "nLifeTime": 14 }`,
+		},
+		{
+			"Policy.GetPolicyData",
+			func(ctx context.Context, c *KscClient) { c.Policy.GetPolicyData(ctx, 12) },
+			`{"nPolicy": 12}`,
+		},
+		{
+			"Policy.MakePolicyActive",
+			func(ctx context.Context, c *KscClient) { c.Policy.MakePolicyActive(ctx, 12, false) },
+			`{"nPolicy": 12, "bActive": false}`,
+		},
+		{
+			"Policy.MakePolicyRoaming",
+			func(ctx context.Context, c *KscClient) { c.Policy.MakePolicyRoaming(ctx, 12) },
+			`{"nPolicy": 12}`,
+		},
+		{
+			"Policy.RevertPolicyToRevision",
+			func(ctx context.Context, c *KscClient) { c.Policy.RevertPolicyToRevision(ctx, 12, 13) },
+			`{"nPolicy": 12, "nRevisionId": 13}`,
+		},
+		{
+			"PolicyProfiles.DeleteProfile",
+			func(ctx context.Context, c *KscClient) { c.PolicyProfiles.DeleteProfile(ctx, 12, "szwName-1") },
+			`{"nPolicy": 12, "szwName": "szwName-1"}`,
+		},
+		{
+			"PolicyProfiles.EnumProfiles",
+			func(ctx context.Context, c *KscClient) { c.PolicyProfiles.EnumProfiles(ctx, 12, 13) },
+			`{"nPolicy": 12, "nRevision": 13}`,
+		},
+		{
+			"PolicyProfiles.ExportProfile",
+			func(ctx context.Context, c *KscClient) { c.PolicyProfiles.ExportProfile(ctx, 12, "szwName-1") },
+			`{"lPolicy": 12, "szwName": "szwName-1"}`,
+		},
+		{
+			"PolicyProfiles.GetEffectivePolicyContents",
+			func(ctx context.Context, c *KscClient) {
+				c.PolicyProfiles.GetEffectivePolicyContents(ctx, 12, 13, "szwHostId-1")
+			},
+			`{"nPolicy": 12, "szwHostId": "szwHostId-1", "nLifeTime": 13}`,
+		},
+		{
+			"PolicyProfiles.GetPriorities",
+			func(ctx context.Context, c *KscClient) { c.PolicyProfiles.GetPriorities(ctx, 12, 13) },
+			`{"nPolicy": 12, "nRevision": 13}`,
+		},
+		{
+			"PolicyProfiles.GetProfile",
+			func(ctx context.Context, c *KscClient) { c.PolicyProfiles.GetProfile(ctx, 12, 13, "szwName-1") },
+			`{"nPolicy": 12, "nRevision": 13, "szwName": "szwName-1"}`,
+		},
+		{
+			"PolicyProfiles.GetProfileSettings",
+			func(ctx context.Context, c *KscClient) {
+				c.PolicyProfiles.GetProfileSettings(ctx, 12, 13, 14, "szwName-1")
+			},
+			`{"nPolicy": 12, "nRevision": 14, "szwName": "szwName-1", "nLifeTime": 13}`,
+		},
+		{
+			"PolicyProfiles.ImportProfile",
+			func(ctx context.Context, c *KscClient) { c.PolicyProfiles.ImportProfile(ctx, 12, "pData-1") },
+			`{"lPolicy": 12, "pData": "pData-1"}`,
+		},
+		{
+			"PolicyProfiles.RenameProfile",
+			func(ctx context.Context, c *KscClient) {
+				c.PolicyProfiles.RenameProfile(ctx, 12, "szwExistingName-1", "szwNewName-1")
+			},
+			`{"nPolicy": 12, "szwExistingName": "szwExistingName-1", "szwExistingName": "szwNewName-1"}`,
+		},
+		{
+			"ProductUserTokenIssuer.IssueUserToken",
+			func(ctx context.Context, c *KscClient) {
+				c.ProductUserTokenIssuer.IssueUserToken(ctx, "wstrProductName-1", "wstrProductVersion-1")
+			},
+			`{"wstrProductName": "wstrProductName-1", "wstrProductVersion": "wstrProductVersion-1"}`,
+		},
+		{
+			"ProductUserTokenIssuer.RevokeUserToken",
+			func(ctx context.Context, c *KscClient) {
+				c.ProductUserTokenIssuer.RevokeUserToken(ctx, "wstrProductName-1", "wstrProductVersion-1")
+			},
+			`{"wstrProductName": "wstrProductName-1", "wstrProductVersion": "wstrProductVersion-1"}`,
+		},
+		{
+			"QBTNetworkListApi.GetListItemInfo",
+			func(ctx context.Context, c *KscClient) { c.QBTNetworkListAPI.GetListItemInfo(ctx, 12) },
+			`{"itemId": 12}`,
+		},
+		{
+			"QueriesStorage.DeleteQuery",
+			func(ctx context.Context, c *KscClient) { c.QueriesStorage.DeleteQuery(ctx, 12) },
+			`{"nId": 12}`,
+		},
+		{
+			"QueriesStorage.GetQueries",
+			func(ctx context.Context, c *KscClient) { c.QueriesStorage.GetQueries(ctx, 12) },
+			`{"eType": 12}`,
+		},
+		{
+			"QueriesStorage.GetQuery",
+			func(ctx context.Context, c *KscClient) { c.QueriesStorage.GetQuery(ctx, 12) },
+			`{"nId": 12}`,
+		},
+		{
+			"QueriesStorage.GetQueryIds",
+			func(ctx context.Context, c *KscClient) { c.QueriesStorage.GetQueryIds(ctx, 12) },
+			`{"eType": 12}`,
+		},
+		{
+			"ReportManager.CancelStatisticsRequest",
+			func(ctx context.Context, c *KscClient) {
+				c.ReportManager.CancelStatisticsRequest(ctx, "strRequestId-1")
+			},
+			`{"strRequestId": "strRequestId-1"}`,
+		},
+		{
+			"ReportManager.ExecuteReportAsyncCancel",
+			func(ctx context.Context, c *KscClient) {
+				c.ReportManager.ExecuteReportAsyncCancel(ctx, "strRequestId-1")
+			},
+			`{"strRequestId": "strRequestId-1"}`,
+		},
+		{
+			"ReportManager.ExecuteReportAsyncCancelWaitingForSlaves",
+			func(ctx context.Context, c *KscClient) {
+				c.ReportManager.ExecuteReportAsyncCancelWaitingForSlaves(ctx, "strRequestId-1")
+			},
+			`{"strRequestId": "strRequestId-1"}`,
+		},
+		{
+			"ReportManager.ExecuteReportAsyncGetData",
+			func(ctx context.Context, c *KscClient) {
+				c.ReportManager.ExecuteReportAsyncGetData(ctx, "strRequestId-1", 13)
+			},
+			`{"strRequestId" : "strRequestId-1", "nChunkSize": 13}`,
+		},
+		{
+			"ReportManager.GetConstantOutputForReportType",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.GetConstantOutputForReportType(ctx, 12, 13) },
+			`{"lReportType": 12, "lXmlTargetType": 13}`,
+		},
+		{
+			"ReportManager.GetDefaultReportInfo",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.GetDefaultReportInfo(ctx, 12) },
+			`{"lReportType": 12}`,
+		},
+		{
+			"ReportManager.GetFilterSettings",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.GetFilterSettings(ctx, 12) },
+			`{"lReportType": 12}`,
+		},
+		{
+			"ReportManager.GetReportCommonData",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.GetReportCommonData(ctx, 12) },
+			`{"lReportId": 12}`,
+		},
+		{
+			"ReportManager.GetReportInfo",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.GetReportInfo(ctx, 12) },
+			`{"lReportId": 12}`,
+		},
+		{
+			"ReportManager.GetReportTypeDetailedInfo",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.GetReportTypeDetailedInfo(ctx, 12) },
+			`{"lReportType": 12}`,
+		},
+		{
+			"ReportManager.GetStatisticsData",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.GetStatisticsData(ctx, "strRequestId-1") },
+			`{"strRequestId": "strRequestId-1"}`,
+		},
+		{
+			"ReportManager.RemoveReport",
+			func(ctx context.Context, c *KscClient) { c.ReportManager.RemoveReport(ctx, 12) },
+			`{"lReportId": 12}`,
+		},
+		{
+			"ScanDiapasons.RemoveDiapason",
+			func(ctx context.Context, c *KscClient) { c.ScanDiapasons.RemoveDiapason(ctx, 12) },
+			`{"idDiapason": 12}`,
+		},
+		{
+			"SecurityPolicy.GetUsers",
+			func(ctx context.Context, c *KscClient) { c.SecurityPolicy.GetUsers(ctx, 12, 13) },
+			`{"lUserId" : 12, "lVsId" : 13}`,
+		},
+		{
+			"SecurityPolicy3.AddUserIntoSecurityGroup",
+			func(ctx context.Context, c *KscClient) { c.SecurityPolicy3.AddUserIntoSecurityGroup(ctx, 12, 13) },
+			`{"lUserId": 12, "lGrpId": 13}`,
+		},
+		{
+			"SecurityPolicy3.CloseUserConnections",
+			func(ctx context.Context, c *KscClient) { c.SecurityPolicy3.CloseUserConnections(ctx, 12) },
+			`{"lUserId": 12 }`,
+		},
+		{
+			"SecurityPolicy3.DeleteSecurityGroup",
+			func(ctx context.Context, c *KscClient) { c.SecurityPolicy3.DeleteSecurityGroup(ctx, 12) },
+			`{"lGrpId": 12}`,
+		},
+		{
+			"SecurityPolicy3.DeleteUserFromSecurityGroup",
+			func(ctx context.Context, c *KscClient) { c.SecurityPolicy3.DeleteUserFromSecurityGroup(ctx, 12, 13) },
+			`{"lUserId": 12, "lGrpId": 13}`,
+		},
+		{
+			"SecurityPolicy3.MoveUserIntoOtherSecurityGroup",
+			func(ctx context.Context, c *KscClient) {
+				c.SecurityPolicy3.MoveUserIntoOtherSecurityGroup(ctx, 12, 13, 14)
+			},
+			`{"lUserId": 12, "lGrpIdFrom": 13, "lGrpIdTo": 14}`,
+		},
+		{
+			"ServerHierarchy.DelServer",
+			func(ctx context.Context, c *KscClient) { c.ServerHierarchy.DelServer(ctx, 12) },
+			`{"lServer": 12}`,
+		},
+		{
+			"ServerHierarchy.GetChildServers",
+			func(ctx context.Context, c *KscClient) { c.ServerHierarchy.GetChildServers(ctx, 12) },
+			`{"nGroupId": 12}`,
+		},
+		{
+			"ServerTransportSettings.CheckDefaultCertificateExists",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.CheckDefaultCertificateExists(ctx, "szwCertType-1")
+			},
+			`{"szwCertType": "szwCertType-1"}`,
+		},
+		{
+			"ServerTransportSettings.GetCurrentConnectionSettings",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.GetCurrentConnectionSettings(ctx, "szwCertType-1")
+			},
+			`{"szwCertType": "szwCertType-1"}`,
+		},
+		{
+			"ServerTransportSettings.GetCustomSrvCertificateInfo",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.GetCustomSrvCertificateInfo(ctx, "szwCertType-1")
+			},
+			`{"szwCertType": "szwCertType-1"}`,
+		},
+		{
+			"ServerTransportSettings.GetDefaultConnectionSettings",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.GetDefaultConnectionSettings(ctx, "szwCertType-1")
+			},
+			`{"szwCertType": "szwCertType-1"}`,
+		},
+		{
+			"ServerTransportSettings.IsFeatureActive",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.IsFeatureActive(ctx, "szwCertType-1")
+			},
+			`{"szwCertType": "szwCertType-1"}`,
+		},
+		{
+			"ServerTransportSettings.ResetCstmReserveCertificate",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.ResetCstmReserveCertificate(ctx, "szwCertType-1")
+			},
+			`{"szwCertType": "szwCertType-1"}`,
+		},
+		{
+			"ServerTransportSettings.ResetDefaultReserveCertificate",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.ResetDefaultReserveCertificate(ctx, "szwCertType-1")
+			},
+			`{"szwCertType": "szwCertType-1"}`,
+		},
+		{
+			"ServerTransportSettings.SetFeatureActive",
+			func(ctx context.Context, c *KscClient) {
+				c.ServerTransportSettings.SetFeatureActive(ctx, "szwCertType-1", false)
+			},
+			`{"szwCertType": "szwCertType-1", "bFeatureActive" : false}`,
+		},
+		{
+			"ServiceNwcCommandProvider.GetCommandPayload",
+			func(ctx context.Context, c *KscClient) {
+				c.ServiceNwcCommandProvider.GetCommandPayload(ctx, "wstrCommandId-1")
+			},
+			`{"wstrCommandId": "wstrCommandId-1"}`,
+		},
+		{
+			"SrvCloudStat.CloudWizardCompleted",
+			func(ctx context.Context, c *KscClient) { c.SrvCloudStat.CloudWizardCompleted(ctx, true) },
+			`{"bErrorHappen": true}`,
+		},
+		{
+			"SrvRi.SetRebootConfirmedHosts",
+			func(ctx context.Context, c *KscClient) {
+				c.SrvRi.SetRebootConfirmedHosts(ctx, "wstrHostID-1", "wstrTaskID-1")
+			},
+			`{"strDomain": "wstrHostID-1", "nType" : "wstrTaskID-1" }`,
+		},
+		{
+			"SrvRi.ShouldForceReboot",
+			func(ctx context.Context, c *KscClient) {
+				c.SrvRi.ShouldForceReboot(ctx, "wstrHostID-1", "wstrTaskID-1")
+			},
+			`{"wstrHostID" : "wstrHostID-1", "wstrTaskID" : "wstrTaskID-1"}`,
+		},
+		{
+			"SrvSsRevision.SsRevisionGetNames",
+			func(ctx context.Context, c *KscClient) {
+				c.SrvSsRevision.SsRevisionGetNames(ctx, "szwId-1", "product-1", "version-1")
+			},
+			// the former template had a stray quote before product and sent invalid JSON
+			`{"szwId": "szwId-1", "product": "product-1", "version": "version-1" }`,
+		},
+		{
+			"SrvSsRevision.SsRevision_Close",
+			func(ctx context.Context, c *KscClient) { c.SrvSsRevision.SsRevisionClose(ctx, "szwType-1") },
+			`{
+		"szwType": "szwType-1"
+	}`,
+		},
+		{
+			"SrvSsRevision.SsRevision_Open",
+			func(ctx context.Context, c *KscClient) { c.SrvSsRevision.SsRevisionOpen(ctx, 12, 13, "szwType-1") },
+			`{"nVServer": 12, "nRevision": 13, "szwType": "szwType-1" }`,
+		},
+		{
+			"SrvView.GetRecordCount",
+			func(ctx context.Context, c *KscClient) { c.SrvView.GetRecordCount(ctx, "wstrIteratorId-1") },
+			`{"wstrIteratorId":"wstrIteratorId-1"}`,
+		},
+		{
+			"SrvView.ReleaseIterator",
+			func(ctx context.Context, c *KscClient) { c.SrvView.ReleaseIterator(ctx, "wstrIteratorId-1") },
+			`{"wstrIteratorId":"wstrIteratorId-1"}`,
+		},
+		{
+			"SsContents.Ss_Apply",
+			func(ctx context.Context, c *KscClient) { c.SsContents.SsApply(ctx, "wstrID-1") },
+			`	{ "wstrID": "wstrID-1"}`,
+		},
+		{
+			"SsContents.Ss_Release",
+			func(ctx context.Context, c *KscClient) { c.SsContents.SsRelease(ctx, "wstrID-1") },
+			`{"wstrID": "wstrID-1"}`,
+		},
+		{
+			"SsRevisionGetNames.DeleteIntegrationToken",
+			func(ctx context.Context, c *KscClient) {
+				c.SsRevisionGetNames.DeleteIntegrationToken(ctx, "wstrProdName-1", "wstrProdVersion-1")
+			},
+			`{"wstrProdName": "wstrProdName-1", "wstrProdVersion": "wstrProdVersion-1"}`,
+		},
+		{
+			"SubnetMasks.DeleteSubnet",
+			func(ctx context.Context, c *KscClient) { c.SubnetMasks.DeleteSubnet(ctx, 12, 13) },
+			`{"nIpAddress" : 12, "nMask" : 13}`,
+		},
+		{
+			"Tasks.CancelTask",
+			func(ctx context.Context, c *KscClient) { c.Tasks.CancelTask(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"Tasks.DeleteTask",
+			func(ctx context.Context, c *KscClient) { c.Tasks.DeleteTask(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"Tasks.GetAllTasksOfHost",
+			func(ctx context.Context, c *KscClient) {
+				c.Tasks.GetAllTasksOfHost(ctx, "strDomainName-1", "strHostName-1")
+			},
+			`{"strDomainName": "strDomainName-1","strHostName": "strHostName-1"}`,
+		},
+		{
+			"Tasks.GetHostStatusRecordRange",
+			func(ctx context.Context, c *KscClient) {
+				c.Tasks.GetHostStatusRecordRange(ctx, "strHostIteratorId-1", 13, 14)
+			},
+			`{"strHostIteratorId": "strHostIteratorId-1", "nStart": 13, "nEnd" : 14}`,
+		},
+		{
+			"Tasks.GetHostStatusRecordsCount",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetHostStatusRecordsCount(ctx, "strHostIteratorId-1") },
+			`{"strHostIteratorId": "strHostIteratorId-1"}`,
+		},
+		{
+			"Tasks.GetNextHostStatus",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetNextHostStatus(ctx, "strTaskIteratorId-1") },
+			`{"strTaskIteratorId": "strTaskIteratorId-1"}`,
+		},
+		{
+			"Tasks.GetNextTask",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetNextTask(ctx, "strTaskIteratorId-1") },
+			`{"strTaskIteratorId": "strTaskIteratorId-1"}`,
+		},
+		{
+			"Tasks.GetTask",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetTask(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"Tasks.GetTaskGroup",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetTaskGroup(ctx, "strTaskId-1") },
+			`{"strTaskId": "strTaskId-1"}`,
+		},
+		{
+			"Tasks.GetTaskStartEvent",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetTaskStartEvent(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"Tasks.GetTaskStatistics",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetTaskStatistics(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"Tasks.ProtectPassword",
+			func(ctx context.Context, c *KscClient) { c.Tasks.ProtectPassword(ctx, "strPassword-1") },
+			`{"strPassword": "strPassword-1"}`,
+		},
+		{
+			"Tasks.ReleaseHostStatusIterator",
+			func(ctx context.Context, c *KscClient) { c.Tasks.ReleaseHostStatusIterator(ctx, "strHostIteratorId-1") },
+			`{"strHostIteratorId": "strHostIteratorId-1"}`,
+		},
+		{
+			"Tasks.ReleaseTasksIterator",
+			func(ctx context.Context, c *KscClient) { c.Tasks.ReleaseTasksIterator(ctx, "strTaskIteratorId-1") },
+			`{"strTaskIteratorId": "strTaskIteratorId-1"}`,
+		},
+		{
+			"Tasks.ResolveTaskId",
+			func(ctx context.Context, c *KscClient) { c.Tasks.ResolveTaskId(ctx, "strPrtsTaskId-1") },
+			`{"strPrtsTaskId": "strPrtsTaskId-1"}`,
+		},
+		{
+			"Tasks.ResumeTask",
+			func(ctx context.Context, c *KscClient) { c.Tasks.ResumeTask(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"Tasks.RunTask",
+			func(ctx context.Context, c *KscClient) { c.Tasks.RunTask(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"Tasks.SuspendTask",
+			func(ctx context.Context, c *KscClient) { c.Tasks.SuspendTask(ctx, "strTask-1") },
+			`{"strTask": "strTask-1"}`,
+		},
+		{
+			"TotpGlobalSettings.Set2FaRequiredForAll",
+			func(ctx context.Context, c *KscClient) { c.TotpGlobalSettings.Set2FaRequiredForAll(ctx, true) },
+			`{"bRequiredForAll" : true}`,
+		},
+		{
+			"TotpRegistration.DeleteSecret",
+			func(ctx context.Context, c *KscClient) { c.TotpRegistration.DeleteSecret(ctx, "wstrSecretId-1") },
+			`{"wstrSecretId" : "wstrSecretId-1"}`,
+		},
+		{
+			"TotpRegistration.SaveSecretForCurrentUser",
+			func(ctx context.Context, c *KscClient) {
+				c.TotpRegistration.SaveSecretForCurrentUser(ctx, "wstrSecretId-1", "wstrValidationCode-1")
+			},
+			`{"wstrSecretId" : "wstrSecretId-1", "wstrValidationCode" : "wstrValidationCode-1"}`,
+		},
+		{
+			"TotpUserSettings.AddUserToTotpRequrementExceptions",
+			func(ctx context.Context, c *KscClient) {
+				c.TotpUserSettings.AddUserToTotpRequrementExceptions(ctx, 12, false)
+			},
+			`{"llTrusteeId" : 12, "bInExceptions" : false}`,
+		},
+		{
+			"TotpUserSettings.ClearUserSecret",
+			func(ctx context.Context, c *KscClient) { c.TotpUserSettings.ClearUserSecret(ctx, 12) },
+			`{"llTrusteeId" : 12}`,
+		},
+		{
+			"TotpUserSettings.IfCanClearUser2FaSecret",
+			func(ctx context.Context, c *KscClient) { c.TotpUserSettings.IfCanClearUser2FaSecret(ctx, 12) },
+			`{"llTrusteeId" : 12}`,
+		},
+		{
+			"TrafficManager.DeleteRestriction",
+			func(ctx context.Context, c *KscClient) { c.TrafficManager.DeleteRestriction(ctx, 12) },
+			`{"nRestrictionId": 12}`,
+		},
+		{
+			"UaControl.GetUpdateAgentInfo",
+			func(ctx context.Context, c *KscClient) { c.UaControl.GetUpdateAgentInfo(ctx, "wstrUaHostId-1") },
+			`{"wstrUaHostId": "wstrUaHostId-1"}`,
+		},
+		{
+			"UaControl.GetUpdateAgentsDisplayInfoForHost",
+			func(ctx context.Context, c *KscClient) {
+				c.UaControl.GetUpdateAgentsDisplayInfoForHost(ctx, "wstrHostId-1")
+			},
+			`{"wstrHostId": "wstrHostId-1"}`,
+		},
+		{
+			// the former request was sent to UaControl.UnregisterUpdateAgent by mistake
+			"UaControl.SetAssignUasAutomatically",
+			func(ctx context.Context, c *KscClient) { c.UaControl.SetAssignUasAutomatically(ctx, true) },
+			`{"bEnabled": true}`,
+		},
+		{
+			"UaControl.UnregisterUpdateAgent",
+			func(ctx context.Context, c *KscClient) { c.UaControl.UnregisterUpdateAgent(ctx, "wstrUaHostId-1") },
+			`{"wstrUaHostId": "wstrUaHostId-1"}`,
+		},
+		{
+			"UpdComps.Stop",
+			func(ctx context.Context, c *KscClient) { c.UpdComps.Stop(ctx, "wsRequestId-1", false) },
+			`{"wsRequestId": "wsRequestId-1", "bWait": false}`,
+		},
+		{
+			"Updates.GetAvailableUpdatesInfo",
+			func(ctx context.Context, c *KscClient) { c.Updates.GetAvailableUpdatesInfo(ctx, "strLocalization-1") },
+			`{"strLocalization": "strLocalization-1"}`,
+		},
+		{
+			"Updates.RemoveUpdatesCancel",
+			func(ctx context.Context, c *KscClient) { c.Updates.RemoveUpdatesCancel(ctx, "strRequestId-1") },
+			`{"strRequestId": "strRequestId-1"}`,
+		},
+		{
+			"UserDevicesApi.DeleteCommand",
+			func(ctx context.Context, c *KscClient) {
+				c.UserDevicesAPI.DeleteCommand(ctx, "c_wstrCommandGuid-1", false)
+			},
+			`{"c_wstrCommandGuid" : "c_wstrCommandGuid-1", "bForced" : false }`,
+		},
+		{
+			"UserDevicesApi.DeleteDevice",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.DeleteDevice(ctx, 12) },
+			`{"lDeviceId": 12 }`,
+		},
+		{
+			"UserDevicesApi.DeleteEnrollmentPackage",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.DeleteEnrollmentPackage(ctx, 12) },
+			`{"lEnrPkgId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GenerateQRCode",
+			func(ctx context.Context, c *KscClient) {
+				c.UserDevicesAPI.GenerateQRCode(ctx, "strInputData-1", 13, 14)
+			},
+			`{"strInputData": "strInputData-1", "lQRCodeSize": 13, "lImageFormat": 14 }`,
+		},
+		{
+			"UserDevicesApi.GetCommands",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetCommands(ctx, 12) },
+			`{"lDeviceId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GetDevice",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetDevice(ctx, 12) },
+			`{"lDeviceId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GetEnrollmentPackage",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetEnrollmentPackage(ctx, 12) },
+			`{"llEnrPkgId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GetEnrollmentPackageFileData",
+			func(ctx context.Context, c *KscClient) {
+				c.UserDevicesAPI.GetEnrollmentPackageFileData(ctx, "c_wstrPackageId-1", "c_wstrPackageFileType-1", 14, 15)
+			},
+			`
+	{
+		"c_wstrPackageId": "c_wstrPackageId-1",
+		"c_wstrPackageFileType": "c_wstrPackageFileType-1",
+		"lQRCodeSize": 14, 
+		"lImageFormat":15
+	}`,
+		},
+		{
+			"UserDevicesApi.GetEnrollmentPackageFileInfo",
+			func(ctx context.Context, c *KscClient) {
+				c.UserDevicesAPI.GetEnrollmentPackageFileInfo(ctx, "c_wstrPackageId-1", "c_wstrUserAgent-1", "c_wstrPackageFileType-1")
+			},
+			`{"c_wstrPackageId": "c_wstrPackageId-1", "c_wstrUserAgent": "c_wstrUserAgent-1", "c_wstrPackageFileType": "c_wstrPackageFileType-1"	}`,
+		},
+		{
+			"UserDevicesApi.GetJournalCommandResult",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetJournalCommandResult(ctx, 12) },
+			`{"llJrnlId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GetJournalRecords",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetJournalRecords(ctx, 12) },
+			`{"lDeviceId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GetJournalRecords2",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetJournalRecords2(ctx, 12) },
+			`{"lDeviceId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GetLatestDeviceActivityDate",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetLatestDeviceActivityDate(ctx, 12) },
+			`{"lDeviceId": 12 }`,
+		},
+		{
+			"UserDevicesApi.GetMobileAgentSettingStorageData",
+			func(ctx context.Context, c *KscClient) {
+				c.UserDevicesAPI.GetMobileAgentSettingStorageData(ctx, 12, "c_wstrSectionName-1")
+			},
+			`{"lDeviceId": 12, "c_wstrSectionName" : "c_wstrSectionName-1" }`,
+		},
+		{
+			"UserDevicesApi.GetMultitenancyServerSettings",
+			func(ctx context.Context, c *KscClient) {
+				c.UserDevicesAPI.GetMultitenancyServerSettings(ctx, "c_wstrMtncServerId-1")
+			},
+			`{"c_wstrMtncServerId": "c_wstrMtncServerId-1" }`,
+		},
+		{
+			"UserDevicesApi.GetMultitenancyServersInfo",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GetMultitenancyServersInfo(ctx, 12) },
+			`{"nProtocolIds" : 12 }`,
+		},
+		{
+			"UserDevicesApi.GlueDevices",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.GlueDevices(ctx, 12, 13) },
+			`{"lDevice1Id": 12, "lDevice2Id" : 13 }`,
+		},
+		{
+			"UserDevicesApi.RecallCommand",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.RecallCommand(ctx, "c_wstrCommandGuid-1") },
+			`{"c_wstrCommandGuid": "c_wstrCommandGuid-1"}`,
+		},
+		{
+			"UserDevicesApi.SetSafeBrowserAutoinstallFlag",
+			func(ctx context.Context, c *KscClient) { c.UserDevicesAPI.SetSafeBrowserAutoinstallFlag(ctx, true) },
+			`{"bInstall": true}`,
+		},
+		{
+			"VServers.AddVServerInfo",
+			func(ctx context.Context, c *KscClient) { c.VServers.AddVServerInfo(ctx, "strDisplayName-1", 13) },
+			`{"lParentGroup": 13, "strDisplayName" : "strDisplayName-1"}`,
+		},
+		{
+			"VServers.DelVServer",
+			func(ctx context.Context, c *KscClient) { c.VServers.DelVServer(ctx, 12) },
+			`{"lVServer": 12}`,
+		},
+		{
+			"VServers.GetPermissions",
+			func(ctx context.Context, c *KscClient) { c.VServers.GetPermissions(ctx, 12) },
+			`{"lVServer": 12}`,
+		},
+		{
+			"VServers.GetVServers",
+			func(ctx context.Context, c *KscClient) { c.VServers.GetVServers(ctx, 12) },
+			`{"lParentGroup": 12}`,
+		},
+		{
+			"VServers.MoveVServer",
+			func(ctx context.Context, c *KscClient) { c.VServers.MoveVServer(ctx, 12, 13) },
+			`{"lVServer": 12, "lNewParentGroup" : 13}`,
+		},
+		{
+			"VServers.RecallCertAndCloseConnections",
+			func(ctx context.Context, c *KscClient) { c.VServers.RecallCertAndCloseConnections(ctx, 12) },
+			`{"lVServer": 12}`,
+		},
+		{
+			"VServers2.GetVServerStatistic",
+			func(ctx context.Context, c *KscClient) { c.VServers2.GetVServerStatistic(ctx, 12) },
+			`{"lVsId": 12}`,
+		},
+		{
+			"VapmControlApi.CancelDeleteFilesForUpdates",
+			func(ctx context.Context, c *KscClient) {
+				c.VapmControlAPI.CancelDeleteFilesForUpdates(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"VapmControlApi.CancelDownloadPatch",
+			func(ctx context.Context, c *KscClient) { c.VapmControlAPI.CancelDownloadPatch(ctx, "wstrRequestId-1") },
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"VapmControlApi.ChangeVulnerabilityIgnorance",
+			func(ctx context.Context, c *KscClient) {
+				c.VapmControlAPI.ChangeVulnerabilityIgnorance(ctx, "wstrVulnerabilityUid-1", "wstrHostId-1", true)
+			},
+			`{"wstrVulnerabilityUid": "wstrVulnerabilityUid-1", "wstrHostId": "wstrHostId-1", "bIgnore": true}`,
+		},
+		{
+			"VapmControlApi.DownloadPatchAsync",
+			func(ctx context.Context, c *KscClient) {
+				c.VapmControlAPI.DownloadPatchAsync(ctx, 12, 13, "wstrRequestId-1")
+			},
+			`{"llPatchGlbId": 12, "nLcid": 13, "wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"VapmControlApi.GetDownloadPatchDataChunk",
+			func(ctx context.Context, c *KscClient) {
+				c.VapmControlAPI.GetDownloadPatchDataChunk(ctx, "wstrRequestId-1", 13, 14)
+			},
+			`{"wstrRequestId": "wstrRequestId-1", "nStartPos": 13, "nSizeMax": 14}`,
+		},
+		{
+			"VapmControlApi.GetDownloadPatchResult",
+			func(ctx context.Context, c *KscClient) {
+				c.VapmControlAPI.GetDownloadPatchResult(ctx, "wstrRequestId-1")
+			},
+			`{"wstrRequestId": "wstrRequestId-1"}`,
+		},
+		{
+			"VapmControlApi.GetEulaParams",
+			func(ctx context.Context, c *KscClient) { c.VapmControlAPI.GetEulaParams(ctx, 12) },
+			`{"nEulaId": 12}`,
+		},
+		{
+			"VapmControlApi.GetSupportedLcidsForPatchPrerequisites",
+			func(ctx context.Context, c *KscClient) {
+				c.VapmControlAPI.GetSupportedLcidsForPatchPrerequisites(ctx, 12, 13)
+			},
+			`{"llPatchGlobalId": 12, "nOriginalLcid": 13}`,
+		},
+		{
+			"VapmControlApi.GetUpdateSupportedLanguagesFilter",
+			func(ctx context.Context, c *KscClient) { c.VapmControlAPI.GetUpdateSupportedLanguagesFilter(ctx, 12) },
+			`{"nUpdateSource": 12}`,
+		},
+		{
+			"WolSender.SendWolSignal",
+			func(ctx context.Context, c *KscClient) { c.WolSender.SendWolSignal(ctx, "szwHostId-1") },
+			`{"szwHostId":"szwHostId-1"}`,
+		},
+		{
+			"EventNotificationProperties.TestNotification",
+			func(ctx context.Context, c *KscClient) {
+				c.EventNotificationProperties.TestNotification(ctx, 11, json.RawMessage(`{"KLEVP_ND_EVETN_TYPE":"GNRL_EV_VIRUS_FOUND"}`))
+			},
+			`{"eType": 11,"pSettings": {"KLEVP_ND_EVETN_TYPE":"GNRL_EV_VIRUS_FOUND"}	}`,
+		},
+		{
+			"ExtAud.GetRevision",
+			func(ctx context.Context, c *KscClient) { c.ExtAud.GetRevision(ctx, 11, 12, 13, nil) },
+			`{"nObjId": 11,"nObjType": 12,"nObjRevision": 13}`,
+		},
+		{
+			"GroupSyncIterator.GetNextItems",
+			func(ctx context.Context, c *KscClient) { c.GroupSyncIterator.GetNextItems(ctx, "it-1", 50, nil) },
+			`{"szwIterator": "it-1","nCount": 50}`,
+		},
+		{
+			"HWInvStorage.DelHWInvObject2",
+			func(ctx context.Context, c *KscClient) { c.HWInvStorage.DelHWInvObject2(ctx, []int64{11, 12}) },
+			`{"arrObjId": [11,12]}`,
+		},
+		{
+			"NagRdu.GetUrlToDownloadFileFromHost",
+			func(ctx context.Context, c *KscClient) { c.NagRdu.GetUrlToDownloadFileFromHost(ctx, "szwRemoteFile-1") },
+			`{"szwRemoteFile": "szwRemoteFile-1"}`,
+		},
+		{
+			"PushServerOApi.SendSyncPushMessage",
+			func(ctx context.Context, c *KscClient) {
+				(*PushServerOApi)(&service{client: c}).SendSyncPushMessage(ctx, "wstrHostId-1")
+			},
+			`{"wstrHostId": "wstrHostId-1"}`,
+		},
+		{
+			"SecurityPolicy.UpdateUser",
+			func(ctx context.Context, c *KscClient) {
+				c.SecurityPolicy.UpdateUser(ctx, 11, PUserData{PUser: PUser{KlsplUserName: "user", KlsplUserEnabled: true}})
+			},
+			`{"pUser" : {"KLSPL_USER_NAME":"user","KLSPL_USER_FULL_NAME":"","KLSPL_USER_DESCRIPTION":"","KLSPL_USER_MAIL":"","KLSPL_USER_PHONE":"","KLSPL_USER_ENABLED":true},"lUserId" : 11}`,
+		},
+		{
+			"Tasks.GetTaskData",
+			func(ctx context.Context, c *KscClient) { c.Tasks.GetTaskData(ctx, "12", nil) },
+			"\n\t{\n\t\"strTask\": \"12\"\n\t}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			srv := newFakeServer(t)
+			tt.call(context.Background(), srv.client(Config{}))
+
+			requests := srv.received(tt.method)
+			if len(requests) != 1 {
+				t.Fatalf("%s sent %d times, want 1", tt.method, len(requests))
+			}
+
+			var got, want interface{}
+			if err := json.Unmarshal(requests[0].Body, &got); err != nil {
+				t.Fatalf("invalid body %s: %v", requests[0].Body, err)
+			}
+			if err := json.Unmarshal([]byte(tt.want), &want); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("body = %s, want %s", requests[0].Body, tt.want)
+			}
+		})
+	}
+}
+
+// TestPayloadEscaping checks that string parameters which broke the former fmt.Sprintf templates are sent as is.
+func TestPayloadEscaping(t *testing.T) {
+	srv := newFakeServer(t)
+	c := srv.client(Config{})
+
+	name := `Servers "EU"\Berlin` + "\n"
+	c.HostGroup.GetGroupId(context.Background(), 0, name)
+
+	var got struct {
+		NParent int64  `json:"nParent"`
+		StrName string `json:"strName"`
+	}
+	body := srv.received("HostGroup.GetGroupId")[0].Body
+	if err := json.Unmarshal(body, &got); err != nil || got.StrName != name {
+		t.Errorf("body = %s, %v, want strName %q", body, err, name)
+	}
+}
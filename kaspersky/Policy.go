@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // Policy Policies processing. Allows to manage policies, change policies states and acquire policies data
@@ -79,18 +75,8 @@ type PPolicyData struct {
 // AddPolicy Create new policy.
 // Creates a new policy with the specified attributes
 func (pl *Policy) AddPolicy(ctx context.Context, params NewPolicy) (*PxgValInt, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.AddPolicy", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	_, err = pl.client.Request(ctx, request, &pxgValInt)
+	_, err := pl.client.Call(ctx, "Policy.AddPolicy", params, &pxgValInt)
 	return pxgValInt, err
 }
 
@@ -115,31 +101,17 @@ type PExtraDataValue struct {
 // CopyOrMovePolicy Copy or move policy.
 // Copies the specified policy and optionally deletes it. Returns id of the new policy
 func (pl *Policy) CopyOrMovePolicy(ctx context.Context, params MovePolicyParams) (*PxgValInt, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.CopyOrMovePolicy", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	_, err = pl.client.Request(ctx, request, &pxgValInt)
+	_, err := pl.client.Call(ctx, "Policy.CopyOrMovePolicy", params, &pxgValInt)
 	return pxgValInt, err
 }
 
 // DeletePolicy Delete policy.
 // Makes the the specified policy inactive, and then deletes it.
 func (pl *Policy) DeletePolicy(ctx context.Context, nPolicy int64) error {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d}`, nPolicy))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.DeletePolicy", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy}
 
-	_, err = pl.client.Request(ctx, request, nil)
+	_, err := pl.client.Call(ctx, "Policy.DeletePolicy", postData, nil)
 	return err
 }
 
@@ -177,14 +149,10 @@ type PListValue struct {
 // Obtain policies that affect the specified group.
 // Returns active and roaming policies that affect specified group
 func (pl *Policy) GetEffectivePoliciesForGroup(ctx context.Context, nGroupId int64) (*PolicyList, error) {
-	postData := []byte(fmt.Sprintf(`{"nGroupId": %d}`, nGroupId))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.GetEffectivePoliciesForGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nGroupId": nGroupId}
 
 	policyList := new(PolicyList)
-	_, err = pl.client.Request(ctx, request, &policyList)
+	_, err := pl.client.Call(ctx, "Policy.GetEffectivePoliciesForGroup", postData, &policyList)
 	return policyList, err
 }
 
@@ -195,27 +163,18 @@ type OutbreakPolicies struct {
 // GetOutbreakPolicies Acquire array of outbreak policies.
 // Returns the array of outbreak policies
 func (pl *Policy) GetOutbreakPolicies(ctx context.Context) (*OutbreakPolicies, error) {
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.GetOutbreakPolicies", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	outbreakPolicies := new(OutbreakPolicies)
-	_, err = pl.client.Request(ctx, request, &outbreakPolicies)
+	_, err := pl.client.Call(ctx, "Policy.GetOutbreakPolicies", nil, &outbreakPolicies)
 	return outbreakPolicies, err
 }
 
 // GetPoliciesForGroup Obtain policies for specified group.
 // Returns policies located in specified group.
 func (pl *Policy) GetPoliciesForGroup(ctx context.Context, nGroupId int64) (*PolicyList, error) {
-	postData := []byte(fmt.Sprintf(`{"nGroupId": %d}`, nGroupId))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.GetPoliciesForGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nGroupId": nGroupId}
 
 	policyList := new(PolicyList)
-	_, err = pl.client.Request(ctx, request, &policyList)
+	_, err := pl.client.Call(ctx, "Policy.GetPoliciesForGroup", postData, &policyList)
 	return policyList, err
 }
 
@@ -223,68 +182,51 @@ func (pl *Policy) GetPoliciesForGroup(ctx context.Context, nGroupId int64) (*Pol
 // Opens settings storage SsContents of the specified policy.
 // The settings storage contains both predefined and product-specific sections.
 func (pl *Policy) GetPolicyContents(ctx context.Context, nPolicy, nRevisionId, nLifeTime int64) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{ "nPolicy": %d , "nRevisionId": %d , "nLifeTime": %d }`, nPolicy, nRevisionId,
-		nLifeTime))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.GetPolicyContents", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"nPolicy":     nPolicy,
+		"nRevisionId": nRevisionId,
+		"nLifeTime":   nLifeTime,
 	}
 
 	pxgValStr := new(PxgValStr)
-	_, err = pl.client.Request(ctx, request, &pxgValStr)
+	_, err := pl.client.Call(ctx, "Policy.GetPolicyContents", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
 // GetPolicyData Obtain policy data.
 // Returns data for specified policy
 func (pl *Policy) GetPolicyData(ctx context.Context, nPolicy int64) (*PxgValPolicy, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d}`, nPolicy))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.GetPolicyData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy}
 
 	pxgValPolicy := new(PxgValPolicy)
-	_, err = pl.client.Request(ctx, request, &pxgValPolicy)
+	_, err := pl.client.Call(ctx, "Policy.GetPolicyData", postData, &pxgValPolicy)
 	return pxgValPolicy, err
 }
 
 // MakePolicyActive Make policy active or inactive.
 func (pl *Policy) MakePolicyActive(ctx context.Context, nPolicy int64, bActive bool) (*PxgValBool, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "bActive": %v}`, nPolicy, bActive))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.MakePolicyActive", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "bActive": bActive}
 
 	pxgValBool := new(PxgValBool)
-	_, err = pl.client.Request(ctx, request, &pxgValBool)
+	_, err := pl.client.Call(ctx, "Policy.MakePolicyActive", postData, &pxgValBool)
 	return pxgValBool, err
 }
 
 // MakePolicyRoaming Make policy roaming.
 func (pl *Policy) MakePolicyRoaming(ctx context.Context, nPolicy int64) (*PxgValBool, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d}`, nPolicy))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.MakePolicyRoaming", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy}
 
 	pxgValBool := new(PxgValBool)
-	_, err = pl.client.Request(ctx, request, &pxgValBool)
+	_, err := pl.client.Call(ctx, "Policy.MakePolicyRoaming", postData, &pxgValBool)
 	return pxgValBool, err
 }
 
 // RevertPolicyToRevision Revert policy to its older version.
 // Replaces the specified policy nPolicy by its revision (older version) nRevisionId
 func (pl *Policy) RevertPolicyToRevision(ctx context.Context, nPolicy, nRevisionId int64) error {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "nRevisionId": %d}`, nPolicy, nRevisionId))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.RevertPolicyToRevision", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "nRevisionId": nRevisionId}
 
-	_, err = pl.client.Request(ctx, request, nil)
+	_, err := pl.client.Call(ctx, "Policy.RevertPolicyToRevision", postData, nil)
 	return err
 }
 
@@ -310,17 +252,7 @@ type PolOutbreak struct {
 // SetOutbreakPolicies Specify array of outbreak policies.
 // Sets the array of outbreak policies
 func (pl *Policy) SetOutbreakPolicies(ctx context.Context, params OutbreakPoliciesParams) error {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.SetOutbreakPolicies", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = pl.client.Request(ctx, request, nil)
+	_, err := pl.client.Call(ctx, "Policy.SetOutbreakPolicies", params, nil)
 	return err
 }
 
@@ -335,30 +267,16 @@ type PolicyDataUpdateParams struct {
 
 // UpdatePolicyData Update policy attributes.
 func (pl *Policy) UpdatePolicyData(ctx context.Context, params PolicyDataUpdateParams) ([]byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.UpdatePolicyData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pl.client.Request(ctx, request, nil)
+	raw, err := pl.client.Call(ctx, "Policy.UpdatePolicyData", params, nil)
 	return raw, err
 }
 
 // ExportPolicy Export policy to a blob.
 func (pl *Policy) ExportPolicy(ctx context.Context, lPolicy int64) (*PxgValStr, error) {
-	postData := []byte(fmt.Sprintf(`{"lPolicy": %d}`, lPolicy))
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.ExportPolicy", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lPolicy": lPolicy}
 
 	pxgValStr := new(PxgValStr)
-	_, err = pl.client.Request(ctx, request, &pxgValStr)
+	_, err := pl.client.Call(ctx, "Policy.ExportPolicy", postData, &pxgValStr)
 	return pxgValStr, err
 }
 
@@ -371,17 +289,7 @@ type PolicyBlob struct {
 
 // ImportPolicy Import policy from blob.
 func (pl *Policy) ImportPolicy(ctx context.Context, params PolicyBlob) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", pl.client.Server+"/api/v1.0/Policy.ImportPolicy", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := pl.client.Request(ctx, request, &pxgValStr)
+	raw, err := pl.client.Call(ctx, "Policy.ImportPolicy", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
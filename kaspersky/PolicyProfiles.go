@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // PolicyProfiles service Policy profiles processing.
@@ -52,25 +48,15 @@ type PolicyProfiles service
 // Note:
 // Don't forget to fill returned SsContents, and call SsContents::Ss_Apply and SsContents::SS_Release methods
 func (pp *PolicyProfiles) AddProfile(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.AddProfile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.AddProfile", params, nil)
 	return raw, err
 }
 
 // DeleteProfile Deletes the specified profile and all data associated.
 func (pp *PolicyProfiles) DeleteProfile(ctx context.Context, nPolicy int64, szwName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "szwName": "%s"}`, nPolicy, szwName))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.DeleteProfile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "szwName": szwName}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.DeleteProfile", postData, nil)
 	return raw, err
 }
 
@@ -78,25 +64,17 @@ func (pp *PolicyProfiles) DeleteProfile(ctx context.Context, nPolicy int64, szwN
 //
 // Returns array of all profiles for the specified policy
 func (pp *PolicyProfiles) EnumProfiles(ctx context.Context, nPolicy, nRevision int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "nRevision": %d}`, nPolicy, nRevision))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.EnumProfiles", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "nRevision": nRevision}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.EnumProfiles", postData, nil)
 	return raw, err
 }
 
 // ExportProfile Export policy profile to a blob.
 func (pp *PolicyProfiles) ExportProfile(ctx context.Context, lPolicy int64, szwName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lPolicy": %d, "szwName": "%s"}`, lPolicy, szwName))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.ExportProfile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lPolicy": lPolicy, "szwName": szwName}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.ExportProfile", postData, nil)
 	return raw, err
 }
 
@@ -106,15 +84,10 @@ func (pp *PolicyProfiles) ExportProfile(ctx context.Context, lPolicy int64, szwN
 // and applies to it those policy profiles which are active at the specified host.
 func (pp *PolicyProfiles) GetEffectivePolicyContents(ctx context.Context, nPolicy, nLifeTime int64,
 	szwHostId string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "szwHostId": "%s", "nLifeTime": %d}`, nPolicy, szwHostId,
-		nLifeTime))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.GetEffectivePolicyContents", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "szwHostId": szwHostId, "nLifeTime": nLifeTime}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := pp.client.Request(ctx, request, &pxgValStr)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.GetEffectivePolicyContents", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -122,13 +95,9 @@ func (pp *PolicyProfiles) GetEffectivePolicyContents(ctx context.Context, nPolic
 //
 // Returns array of profile names, the profile with lesser index has greater priority.
 func (pp *PolicyProfiles) GetPriorities(ctx context.Context, nPolicy, nRevision int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "nRevision": %d}`, nPolicy, nRevision))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.GetPriorities", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "nRevision": nRevision}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.GetPriorities", postData, nil)
 	return raw, err
 }
 
@@ -136,13 +105,9 @@ func (pp *PolicyProfiles) GetPriorities(ctx context.Context, nPolicy, nRevision
 //
 // Returns profile data for the specified policy profile.
 func (pp *PolicyProfiles) GetProfile(ctx context.Context, nPolicy, nRevision int64, szwName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "nRevision": %d, "szwName": "%s"}`, nPolicy, nRevision, szwName))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.GetProfile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "nRevision": nRevision, "szwName": szwName}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.GetProfile", postData, nil)
 	return raw, err
 }
 
@@ -151,29 +116,23 @@ func (pp *PolicyProfiles) GetProfile(ctx context.Context, nPolicy, nRevision int
 // Returns SsContents interface for the profile contents
 func (pp *PolicyProfiles) GetProfileSettings(ctx context.Context, nPolicy, nLifeTime, nRevision int64,
 	szwName string) (*PxgValStr, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "nRevision": %d, "szwName": "%s", "nLifeTime": %d}`, nPolicy,
-		nRevision,
-		szwName,
-		nLifeTime))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.GetProfileSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
+	postData := map[string]interface{}{
+		"nPolicy":   nPolicy,
+		"nRevision": nRevision,
+		"szwName":   szwName,
+		"nLifeTime": nLifeTime,
 	}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := pp.client.Request(ctx, request, &pxgValStr)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.GetProfileSettings", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // ImportProfile Import policy profile from blob.
 func (pp *PolicyProfiles) ImportProfile(ctx context.Context, lPolicy int64, pData string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lPolicy": %d, "pData": "%s"}`, lPolicy, pData))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.ImportProfile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lPolicy": lPolicy, "pData": pData}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.ImportProfile", postData, nil)
 	return raw, err
 }
 
@@ -187,37 +146,20 @@ type ProfilesPrioritiesParams struct {
 
 // PutPriorities Update profile priority array.
 func (pp *PolicyProfiles) PutPriorities(ctx context.Context, params ProfilesPrioritiesParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.PutPriorities", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.PutPriorities", params, nil)
 	return raw, err
 }
 
 // RenameProfile Changes the name of the existing profile.
 func (pp *PolicyProfiles) RenameProfile(ctx context.Context, nPolicy int64, szwExistingName, szwNewName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nPolicy": %d, "szwExistingName": "%s", "szwExistingName": "%s"}`, nPolicy,
-		szwExistingName, szwNewName))
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.RenameProfile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nPolicy": nPolicy, "szwExistingName": szwNewName}
 
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.RenameProfile", postData, nil)
 	return raw, err
 }
 
 // UpdateProfile Update attributes of an existing profile.
 func (pp *PolicyProfiles) UpdateProfile(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", pp.client.Server+"/api/v1.0/PolicyProfiles.UpdateProfile", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := pp.client.Request(ctx, request, nil)
+	raw, err := pp.client.Call(ctx, "PolicyProfiles.UpdateProfile", params, nil)
 	return raw, err
 }
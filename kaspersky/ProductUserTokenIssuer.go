@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // ProductUserTokenIssuer Product backend user token issuer.
@@ -45,20 +41,13 @@ type ProductUserTokenIssuer service
 //Command result may be obtained using AsyncActionStateChecker.
 //Command result format is defined by product backend, errors returned as Asynchronous action errors
 func (srn *ProductUserTokenIssuer) IssueUserToken(ctx context.Context, wstrProductName, wstrProductVersion string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrProductName": "%s", "wstrProductVersion": "%s"}`, wstrProductName, wstrProductVersion))
-	request, err := http.NewRequest("POST", srn.client.Server+"/api/v1.0/ProductUserTokenIssuer.IssueUserToken",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
+	postData := map[string]interface{}{
+		"wstrProductName":    wstrProductName,
+		"wstrProductVersion": wstrProductVersion,
 	}
 
 	//TODO check response
-	raw, err := srn.client.Request(ctx, request, nil)
-
-	if srn.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := srn.client.Call(ctx, "ProductUserTokenIssuer.IssueUserToken", postData, nil)
 
 	return err
 }
@@ -69,20 +58,13 @@ func (srn *ProductUserTokenIssuer) IssueUserToken(ctx context.Context, wstrProdu
 //Command result may be obtained using AsyncActionStateChecker.
 //Command result format is defined by product backend, errors returned as Asynchronous action errors
 func (srn *ProductUserTokenIssuer) RevokeUserToken(ctx context.Context, wstrProductName, wstrProductVersion string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrProductName": "%s", "wstrProductVersion": "%s"}`, wstrProductName, wstrProductVersion))
-	request, err := http.NewRequest("POST", srn.client.Server+"/api/v1.0/ProductUserTokenIssuer.RevokeUserToken",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
+	postData := map[string]interface{}{
+		"wstrProductName":    wstrProductName,
+		"wstrProductVersion": wstrProductVersion,
 	}
 
 	//TODO check response
-	raw, err := srn.client.Request(ctx, request, nil)
-
-	if srn.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := srn.client.Call(ctx, "ProductUserTokenIssuer.RevokeUserToken", postData, nil)
 
 	return err
 }
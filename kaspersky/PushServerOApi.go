@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // PushServerOApi Interface for working with PushServer subsystem.
@@ -38,19 +34,10 @@ type PushServerOApi service
 // SendSyncPushMessage Send synchronization push message to device.
 //	return (bool) true if message sent, otherwise it returns false
 func (pso *PushServerOApi) SendSyncPushMessage(ctx context.Context, wstrHostId string) (*PxgValBool, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrHostId": "%s"}`, wstrHostId))
-	request, err := http.NewRequest("POST", pso.client.Server+"/api/v1.0/PushServerOApi.SendSyncPushMessage",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrHostId": wstrHostId}
 
 	result := new(PxgValBool)
-	raw, err := pso.client.Request(ctx, request, &result)
-
-	if pso.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := pso.client.Call(ctx, "PushServerOApi.SendSyncPushMessage", postData, &result)
 
 	return result, err
 }
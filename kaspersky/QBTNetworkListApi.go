@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // QBTNetworkListApi Interface to working with Quarantine, Backup and TIF network lists.
@@ -57,14 +53,10 @@ type PPInfo struct {
 
 // GetListItemInfo Acquire info about specified file from specified network list.
 func (nc *QBTNetworkListApi) GetListItemInfo(ctx context.Context, itemId int64) (*NetworkListFileInfo, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"itemId": %d}`, itemId))
-	request, err := http.NewRequest("POST", nc.client.Server+"/api/v1.0/QBTNetworkListApi.GetListItemInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"itemId": itemId}
 
 	networkListFileInfo := new(NetworkListFileInfo)
-	raw, err := nc.client.Request(ctx, request, &networkListFileInfo)
+	raw, err := nc.client.Call(ctx, "QBTNetworkListApi.GetListItemInfo", postData, &networkListFileInfo)
 	return networkListFileInfo, raw, err
 }
 
@@ -95,17 +87,7 @@ type PTaskParams struct{}
 
 // AddListItemTask Initiate action under the specified file from specified network list.
 func (nc *QBTNetworkListApi) AddListItemTask(ctx context.Context, params QBTParams) ([]byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", nc.client.Server+"/api/v1.0/QBTNetworkListApi.AddListItemTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := nc.client.Request(ctx, request, nil)
+	raw, err := nc.client.Call(ctx, "QBTNetworkListApi.AddListItemTask", params, nil)
 	return raw, err
 }
 
@@ -134,16 +116,6 @@ type QBTsParam struct {
 
 // AddListItemsTask Initiate action under the specified files from specified network list.
 func (nc *QBTNetworkListApi) AddListItemsTask(ctx context.Context, params QBTsParam) ([]byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", nc.client.Server+"/api/v1.0/QBTNetworkListApi.AddListItemsTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := nc.client.Request(ctx, request, nil)
+	raw, err := nc.client.Call(ctx, "QBTNetworkListApi.AddListItemsTask", params, nil)
 	return raw, err
 }
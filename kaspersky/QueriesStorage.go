@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // QueriesStorage QueriesStorage.
@@ -39,18 +35,7 @@ type QueriesStorage service
 //
 // Creates a new query and stores it for the current user (associated with the connection to the Administration Server).
 func (qs *QueriesStorage) AddQuery(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", qs.client.Server+"/api/v1.0/QueriesStorage.AddQuery",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := qs.client.Request(ctx, request, nil)
+	raw, err := qs.client.Call(ctx, "QueriesStorage.AddQuery", params, nil)
 	return raw, err
 }
 
@@ -58,13 +43,9 @@ func (qs *QueriesStorage) AddQuery(ctx context.Context, params interface{}) ([]b
 //
 // Deletes the query with the specified ID.
 func (qs *QueriesStorage) DeleteQuery(ctx context.Context, nId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nId": %d}`, nId))
-	request, err := http.NewRequest("POST", qs.client.Server+"/api/v1.0/QueriesStorage.DeleteQuery", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nId": nId}
 
-	raw, err := qs.client.Request(ctx, request, nil)
+	raw, err := qs.client.Call(ctx, "QueriesStorage.DeleteQuery", postData, nil)
 	return raw, err
 }
 
@@ -73,13 +54,9 @@ func (qs *QueriesStorage) DeleteQuery(ctx context.Context, nId int64) ([]byte, e
 // Returns array of IDs and data of all queries of given type defined for the current user
 // (associated with the connection to the Administration Server).
 func (qs *QueriesStorage) GetQueries(ctx context.Context, eType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"eType": %d}`, eType))
-	request, err := http.NewRequest("POST", qs.client.Server+"/api/v1.0/QueriesStorage.GetQueries", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"eType": eType}
 
-	raw, err := qs.client.Request(ctx, request, nil)
+	raw, err := qs.client.Call(ctx, "QueriesStorage.GetQueries", postData, nil)
 	return raw, err
 }
 
@@ -123,14 +100,10 @@ type PageSettingsValue struct {
 //
 // Returns data of the query with the specified ID.
 func (qs *QueriesStorage) GetQuery(ctx context.Context, nId int64) (*QueryParams, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nId": %d}`, nId))
-	request, err := http.NewRequest("POST", qs.client.Server+"/api/v1.0/QueriesStorage.GetQuery", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"nId": nId}
 
 	queryParams := new(QueryParams)
-	raw, err := qs.client.Request(ctx, request, &queryParams)
+	raw, err := qs.client.Call(ctx, "QueriesStorage.GetQuery", postData, &queryParams)
 	return queryParams, raw, err
 }
 
@@ -138,13 +111,9 @@ func (qs *QueriesStorage) GetQuery(ctx context.Context, nId int64) (*QueryParams
 //
 // Returns array of IDs of all queries of given type defined for the current user (associated with the connection to the Administration Server).
 func (qs *QueriesStorage) GetQueryIds(ctx context.Context, eType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"eType": %d}`, eType))
-	request, err := http.NewRequest("POST", qs.client.Server+"/api/v1.0/QueriesStorage.GetQueryIds", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"eType": eType}
 
-	raw, err := qs.client.Request(ctx, request, nil)
+	raw, err := qs.client.Call(ctx, "QueriesStorage.GetQueryIds", postData, nil)
 	return raw, err
 }
 
@@ -152,17 +121,6 @@ func (qs *QueriesStorage) GetQueryIds(ctx context.Context, eType int64) ([]byte,
 //
 // Clears current contents of the query data and stores new contents specified in pParData
 func (qs *QueriesStorage) UpdateQuery(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", qs.client.Server+"/api/v1.0/QueriesStorage.UpdateQuery",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := qs.client.Request(ctx, request, nil)
+	raw, err := qs.client.Call(ctx, "QueriesStorage.UpdateQuery", params, nil)
 	return raw, err
 }
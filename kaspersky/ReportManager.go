@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // ReportManager Reports managing.
@@ -41,12 +37,7 @@ type ReportManager service
 //
 // Enumerates all existing types.
 func (rm *ReportManager) EnumReportTypes(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.EnumReportTypes", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.EnumReportTypes", nil, nil)
 	return raw, err
 }
 
@@ -54,36 +45,21 @@ func (rm *ReportManager) EnumReportTypes(ctx context.Context) ([]byte, error) {
 //
 // Enumerates all existing reports.
 func (rm *ReportManager) EnumReports(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.EnumReports", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.EnumReports", nil, nil)
 	return raw, err
 }
 
 // GetAvailableDashboards Enumerate available dashboards.
 func (rm *ReportManager) GetAvailableDashboards(ctx context.Context) (*PxgValArrayOfInt, []byte, error) {
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetAvailableDashboards", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	reportsArray := new(PxgValArrayOfInt)
-	raw, err := rm.client.Request(ctx, request, &reportsArray)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetAvailableDashboards", nil, &reportsArray)
 	return reportsArray, raw, err
 }
 
 // CollectStatisticsAsync TODO params ???
 func (rm *ReportManager) CollectStatisticsAsync(ctx context.Context) (*PxgValArrayOfInt, []byte, error) {
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.CollectStatisticsAsync", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	reportsArray := new(PxgValArrayOfInt)
-	raw, err := rm.client.Request(ctx, request, &reportsArray)
+	raw, err := rm.client.Call(ctx, "ReportManager.CollectStatisticsAsync", nil, &reportsArray)
 	return reportsArray, raw, err
 }
 
@@ -92,14 +68,10 @@ func (rm *ReportManager) CollectStatisticsAsync(ctx context.Context) (*PxgValArr
 // Returns XSLT transform as a string for specified report type.
 func (rm *ReportManager) GetConstantOutputForReportType(ctx context.Context, lReportType, lXmlTargetType int64) (*PxgValStr,
 	[]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lReportType": %d, "lXmlTargetType": %d}`, lReportType, lXmlTargetType))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetConstantOutputForReportType", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"lReportType": lReportType, "lXmlTargetType": lXmlTargetType}
 
 	pxgValStr := new(PxgValStr)
-	raw, err := rm.client.Request(ctx, request, &pxgValStr)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetConstantOutputForReportType", postData, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -107,25 +79,17 @@ func (rm *ReportManager) GetConstantOutputForReportType(ctx context.Context, lRe
 //
 // Returns default report info for the specified report type.
 func (rm *ReportManager) GetDefaultReportInfo(ctx context.Context, lReportType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lReportType": %d}`, lReportType))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetDefaultReportInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lReportType": lReportType}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetDefaultReportInfo", postData, nil)
 	return raw, err
 }
 
 // GetFilterSettings Get filter settings.
 func (rm *ReportManager) GetFilterSettings(ctx context.Context, lReportType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lReportType": %d}`, lReportType))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetFilterSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lReportType": lReportType}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetFilterSettings", postData, nil)
 	return raw, err
 }
 
@@ -133,13 +97,9 @@ func (rm *ReportManager) GetFilterSettings(ctx context.Context, lReportType int6
 //
 // Returns common data for specified report.
 func (rm *ReportManager) GetReportCommonData(ctx context.Context, lReportId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lReportId": %d}`, lReportId))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetReportCommonData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lReportId": lReportId}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetReportCommonData", postData, nil)
 	return raw, err
 }
 
@@ -147,13 +107,8 @@ func (rm *ReportManager) GetReportCommonData(ctx context.Context, lReportId int6
 //
 // Returns array of existing report ids.
 func (rm *ReportManager) GetReportIds(ctx context.Context) (*PxgValArrayOfInt, []byte, error) {
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetReportIds", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	reportsIdsArray := new(PxgValArrayOfInt)
-	raw, err := rm.client.Request(ctx, request, &reportsIdsArray)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetReportIds", nil, &reportsIdsArray)
 	return reportsIdsArray, raw, err
 }
 
@@ -161,13 +116,9 @@ func (rm *ReportManager) GetReportIds(ctx context.Context) (*PxgValArrayOfInt, [
 //
 // Returns report info for specified report.
 func (rm *ReportManager) GetReportInfo(ctx context.Context, lReportId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lReportId": %d}`, lReportId))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetReportInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lReportId": lReportId}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetReportInfo", postData, nil)
 	return raw, err
 }
 
@@ -175,13 +126,9 @@ func (rm *ReportManager) GetReportInfo(ctx context.Context, lReportId int64) ([]
 //
 // Returns report info for specified report type.
 func (rm *ReportManager) GetReportTypeDetailedInfo(ctx context.Context, lReportType int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lReportType": %d}`, lReportType))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetReportTypeDetailedInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lReportType": lReportType}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetReportTypeDetailedInfo", postData, nil)
 	return raw, err
 }
 
@@ -189,25 +136,17 @@ func (rm *ReportManager) GetReportTypeDetailedInfo(ctx context.Context, lReportT
 // such as statistics, general statuses and dashboards data.
 //	Deprecated: Since SC12 patch B
 func (rm *ReportManager) GetStatisticsData(ctx context.Context, strRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strRequestId": "%s"}`, strRequestId))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.GetStatisticsData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.GetStatisticsData", postData, nil)
 	return raw, err
 }
 
 // RemoveReport Removes specified report
 func (rm *ReportManager) RemoveReport(ctx context.Context, lReportId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lReportId": %d}`, lReportId))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.RemoveReport", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lReportId": lReportId}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.RemoveReport", postData, nil)
 	return raw, err
 }
 
@@ -215,18 +154,8 @@ func (rm *ReportManager) RemoveReport(ctx context.Context, lReportId int64) ([]b
 // Asynchronously requests statistics, general statuses and dashboards data.
 //	Deprecated: Since SC12 patch B, see ReportManager.CollectStatisticsAsync
 func (rm *ReportManager) RequestStatisticsData(ctx context.Context, params interface{}) (*RequestID, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.RequestStatisticsData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	requestID := new(RequestID)
-	raw, err := rm.client.Request(ctx, request, &requestID)
+	raw, err := rm.client.Call(ctx, "ReportManager.RequestStatisticsData", params, &requestID)
 	return requestID, raw, err
 }
 
@@ -330,43 +259,25 @@ type KlrptOutputFormatValue struct {
 // The progress and result of the report generation is reported by the event KLPPT_EventRptExecDone.
 func (rm *ReportManager) ExecuteReportAsync(ctx context.Context, params ExecuteReportParams) (*RequestID, []byte,
 	error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.ExecuteReportAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	requestID := new(RequestID)
-	raw, err := rm.client.Request(ctx, request, &requestID)
+	raw, err := rm.client.Call(ctx, "ReportManager.ExecuteReportAsync", params, &requestID)
 	return requestID, raw, err
 }
 
 // CancelStatisticsRequest Cancels asynchronous operation ReportManager.RequestStatisticsData.
 //	Deprecated: Since SC12 patch B
 func (rm *ReportManager) CancelStatisticsRequest(ctx context.Context, strRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strRequestId": "%s"}`, strRequestId))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.CancelStatisticsRequest", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.CancelStatisticsRequest", postData, nil)
 	return raw, err
 }
 
 // ExecuteReportAsyncCancel Cancels asynchronous operation ReportManager.ExecuteReportAsync.
 func (rm *ReportManager) ExecuteReportAsyncCancel(ctx context.Context, strRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strRequestId": "%s"}`, strRequestId))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.ExecuteReportAsyncCancel", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.ExecuteReportAsyncCancel", postData, nil)
 	return raw, err
 }
 
@@ -400,14 +311,10 @@ type Value struct {
 func (rm *ReportManager) ExecuteReportAsyncGetData(ctx context.Context, strRequestId string,
 	nChunkSize int64) (*ReportData, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"strRequestId" : "%s", "nChunkSize": %d}`, strRequestId, nChunkSize))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.ExecuteReportAsyncGetData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId, "nChunkSize": nChunkSize}
 
 	reportData := new(ReportData)
-	raw, err := rm.client.Request(ctx, request, &reportData)
+	raw, err := rm.client.Call(ctx, "ReportManager.ExecuteReportAsyncGetData", postData, &reportData)
 	return reportData, raw, err
 }
 
@@ -418,13 +325,9 @@ func (rm *ReportManager) ExecuteReportAsyncGetData(ctx context.Context, strReque
 // event KLPPT_EventRptExecDone received), but the data from slave servers is not,
 // to get the report without data from some slave servers.
 func (rm *ReportManager) ExecuteReportAsyncCancelWaitingForSlaves(ctx context.Context, strRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strRequestId": "%s"}`, strRequestId))
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.ExecuteReportAsyncCancelWaitingForSlaves", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId}
 
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.ExecuteReportAsyncCancelWaitingForSlaves", postData, nil)
 	return raw, err
 }
 
@@ -442,18 +345,8 @@ type CDPOptions struct {
 // CreateChartPNG Creates image in PNG format with chart data
 func (rm *ReportManager) CreateChartPNG(ctx context.Context, params ChartDataParams) (*PPngData, []byte,
 	error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.CreateChartPNG", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pPngData := new(PPngData)
-	raw, err := rm.client.Request(ctx, request, &pPngData)
+	raw, err := rm.client.Call(ctx, "ReportManager.CreateChartPNG", params, &pPngData)
 	return pPngData, raw, err
 }
 
@@ -462,50 +355,20 @@ func (rm *ReportManager) CreateChartPNG(ctx context.Context, params ChartDataPar
 // Force resets statistics data, for example, resets the status of "Virus attack" or "Failed to perform the administration server task"
 // after acquaintance with the detailed information.
 func (rm *ReportManager) ResetStatisticsData(ctx context.Context, params interface{}) (*RequestID, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.ResetStatisticsData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	requestID := new(RequestID)
-	raw, err := rm.client.Request(ctx, request, &requestID)
+	raw, err := rm.client.Call(ctx, "ReportManager.ResetStatisticsData", params, &requestID)
 	return requestID, raw, err
 }
 
 // AddReport Create new report.
 func (rm *ReportManager) AddReport(ctx context.Context, params interface{}) (*PxgValInt, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.AddReport", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := rm.client.Request(ctx, request, &pxgValInt)
+	raw, err := rm.client.Call(ctx, "ReportManager.AddReport", params, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // UpdateReport Updates info for existing report
 func (rm *ReportManager) UpdateReport(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", rm.client.Server+"/api/v1.0/ReportManager.UpdateReport", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := rm.client.Request(ctx, request, nil)
+	raw, err := rm.client.Call(ctx, "ReportManager.UpdateReport", params, nil)
 	return raw, err
 }
@@ -0,0 +1,182 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sync"
+	"unicode"
+)
+
+// sizeLimitedReader fails with ErrResponseTooLarge once more than limit bytes were read.
+type sizeLimitedReader struct {
+	r      io.Reader
+	limit  int64
+	n      int64
+	method string
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	if l.limit > 0 && l.n > l.limit {
+		return 0, l.tooLarge()
+	}
+
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+
+	if l.limit > 0 && l.n > l.limit {
+		return n, l.tooLarge()
+	}
+	return n, err
+}
+
+func (l *sizeLimitedReader) tooLarge() error {
+	return fmt.Errorf("%w: %s response exceeds %d bytes", ErrResponseTooLarge, l.method, l.limit)
+}
+
+// errorTypes caches wrapper types built by errorWrapperType
+var errorTypes sync.Map
+
+// errorWrapperType returns struct type with PxgError container field and embedded *t,
+// so the result and PxgError container are decoded in a single pass.
+// Returns nil if t can't be embedded: unnamed, unexported or having methods (e.g. a custom json.Unmarshaler).
+func errorWrapperType(t reflect.Type) reflect.Type {
+	if cached, ok := errorTypes.Load(t); ok {
+		wt, _ := cached.(reflect.Type)
+		return wt
+	}
+
+	var wt reflect.Type
+	if t.Kind() == reflect.Struct && t.Name() != "" && t.Name() != "PxgError" && t.PkgPath() != "" &&
+		unicode.IsUpper([]rune(t.Name())[0]) && reflect.PtrTo(t).NumMethod() == 0 {
+		wt = reflect.StructOf([]reflect.StructField{
+			{Name: "PxgError", Type: reflect.TypeOf((*Error)(nil)), Tag: `json:"PxgError"`},
+			// embedded by pointer: reflect.StructOf can't embed pointer-shaped structs (e.g. PxgRetError) by value
+			{Name: t.Name(), Type: reflect.PtrTo(t), Anonymous: true},
+		})
+	}
+
+	errorTypes.Store(t, wt)
+	return wt
+}
+
+// decodeResult decodes JSON from dec into out and returns PxgError container if the response carries one.
+//
+// Structs are decoded in a single pass together with PxgError container (see errorWrapperType),
+// other values are decoded after the container is checked.
+func decodeResult(dec *json.Decoder, out interface{}) error {
+	// unwrap *interface{} and **T holding a pointer to the result
+	v := reflect.ValueOf(out)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		e := v.Elem()
+		if (e.Kind() != reflect.Interface && e.Kind() != reflect.Ptr) || e.IsNil() {
+			break
+		}
+		if v = e; e.Kind() == reflect.Interface {
+			v = e.Elem()
+		}
+	}
+
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		if wt := errorWrapperType(v.Type().Elem()); wt != nil {
+			w := reflect.New(wt).Elem()
+			w.Field(1).Set(reflect.New(v.Type().Elem()))
+			w.Field(1).Elem().Set(v.Elem())
+			if err := dec.Decode(w.Addr().Interface()); err != nil {
+				return err
+			}
+
+			if e := w.Field(0).Interface().(*Error); e != nil {
+				return newKscError(e)
+			}
+			v.Elem().Set(w.Field(1).Elem())
+			return nil
+		}
+	}
+
+	var body json.RawMessage
+	if err := dec.Decode(&body); err != nil {
+		return err
+	}
+	if err := CheckResponse((*[]byte)(&body)); err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// decodeResponse reads the response body in a single pass.
+//
+// PxgError container is returned as *KscError, otherwise the body is decoded into out.
+// The container is detected while decoding the body, wherever it's placed in the response.
+// The raw body is kept only if keepRaw is set or there is nothing to decode it into.
+// Returns the raw body (if kept) and the number of bytes read.
+func decodeResponse(r io.Reader, out interface{}, keepRaw bool, limit int64, method string) ([]byte, int64, error) {
+	lr := &sizeLimitedReader{r: r, limit: limit, method: method}
+	br := bufio.NewReader(lr)
+
+	if out == nil {
+		body, err := ioutil.ReadAll(br)
+		if err != nil {
+			return body, lr.n, err
+		}
+		return body, lr.n, CheckResponse(&body)
+	}
+
+	var raw *bytes.Buffer
+	var src io.Reader = br
+	if keepRaw {
+		raw = new(bytes.Buffer)
+		src = io.TeeReader(br, raw)
+	}
+
+	err := decodeResult(json.NewDecoder(src), out)
+	if err == io.EOF {
+		err = nil // ignore EOF errors caused by empty response body
+	}
+
+	// drain the rest of the body, so the raw copy is complete
+	var kscErr *KscError
+	if err == nil || errors.As(err, &kscErr) {
+		var dst io.Writer = ioutil.Discard
+		if raw != nil {
+			dst = raw
+		}
+		if _, copyErr := io.Copy(dst, br); copyErr != nil {
+			err = copyErr
+		}
+	}
+
+	if raw == nil {
+		return nil, lr.n, err
+	}
+	return raw.Bytes(), lr.n, err
+}
@@ -0,0 +1,197 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// ResponseTestResult exported named struct decoded together with PxgError container.
+type ResponseTestResult struct {
+	Str      string `json:"PxgRetVal"`
+	Accessor string `json:"strAccessor"`
+}
+
+// responseTestUnmarshaler result with a custom decoder, decoded after PxgError container is checked.
+type responseTestUnmarshaler struct{ data string }
+
+func (u *responseTestUnmarshaler) UnmarshalJSON(data []byte) error {
+	u.data = string(data)
+	return nil
+}
+
+func TestDecodeResponse(t *testing.T) {
+	pxgError := `{"PxgError":{"code":1183,"module":"KLSTD","message":"Object not found"}}`
+	tests := []struct {
+		name    string
+		body    string
+		out     func() interface{}
+		want    string
+		wantErr error
+	}{
+		{"struct", `{"PxgRetVal":"ok","strAccessor":"acc"}`, func() interface{} { return new(ResponseTestResult) }, "ok acc", nil},
+		{"struct error", pxgError, func() interface{} { return new(ResponseTestResult) }, "", ErrObjectNotFound},
+		{
+			"struct error after other fields",
+			`{"strAccessor":"` + strings.Repeat("a", 100) + `",` + strings.Repeat(" ", 100) + pxgError[1:],
+			func() interface{} { return new(ResponseTestResult) },
+			"",
+			ErrObjectNotFound,
+		},
+		{"anonymous struct", `{"PxgRetVal":"ok"}`, func() interface{} { return new(struct{ PxgRetVal string }) }, "ok", nil},
+		{"anonymous struct error", pxgError, func() interface{} { return new(struct{ PxgRetVal string }) }, "", ErrObjectNotFound},
+		{"interface", `{"PxgRetVal":"ok"}`, func() interface{} { var v interface{}; return &v }, "map[PxgRetVal:ok]", nil},
+		{"interface error", pxgError, func() interface{} { var v interface{}; return &v }, "", ErrObjectNotFound},
+		{
+			"interface holding struct",
+			`{"PxgRetVal":"ok"}`,
+			func() interface{} { var v interface{} = new(ResponseTestResult); return &v },
+			"ok ",
+			nil,
+		},
+		{
+			"pointer to struct pointer",
+			`{"PxgRetVal":"ok"}`,
+			func() interface{} { v := new(ResponseTestResult); return &v },
+			"ok ",
+			nil,
+		},
+		{
+			"pointer to struct pointer error",
+			pxgError,
+			func() interface{} { v := new(ResponseTestResult); return &v },
+			"",
+			ErrObjectNotFound,
+		},
+		{"pointer-shaped struct", `{"PxgRetVal":"ok"}`, func() interface{} { return new(PxgRetError) }, "", nil},
+		{"pointer-shaped struct error", pxgError, func() interface{} { return new(PxgRetError) }, "", ErrObjectNotFound},
+		{"map error", pxgError, func() interface{} { return new(map[string]interface{}) }, "", ErrObjectNotFound},
+		{"unmarshaler", `{"a":1}`, func() interface{} { return new(responseTestUnmarshaler) }, `{"a":1}`, nil},
+		{"unmarshaler error", pxgError, func() interface{} { return new(responseTestUnmarshaler) }, "", ErrObjectNotFound},
+		{"empty body", ``, func() interface{} { return new(ResponseTestResult) }, " ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := tt.out()
+			raw, size, err := decodeResponse(strings.NewReader(tt.body), out, true, 0, "Test.Method")
+			if size != int64(len(tt.body)) || string(raw) != tt.body {
+				t.Errorf("raw body = %q (%d bytes), want the complete body", raw, size)
+			}
+
+			if tt.wantErr != nil {
+				var kscErr *KscError
+				if !errors.Is(err, tt.wantErr) || !errors.As(err, &kscErr) {
+					t.Errorf("decodeResponse() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeResponse() error = %v", err)
+			}
+
+			var got string
+			switch v := out.(type) {
+			case *ResponseTestResult:
+				got = v.Str + " " + v.Accessor
+			case *struct{ PxgRetVal string }:
+				got = v.PxgRetVal
+			case *interface{}:
+				if r, ok := (*v).(*ResponseTestResult); ok {
+					got = r.Str + " " + r.Accessor
+				} else {
+					got = fmt.Sprint(*v)
+				}
+			case **ResponseTestResult:
+				got = (*v).Str + " " + (*v).Accessor
+			case *responseTestUnmarshaler:
+				got = v.data
+			}
+			if got != tt.want {
+				t.Errorf("decoded %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeResponseKeepsPrefilledFields(t *testing.T) {
+	out := &ResponseTestResult{Accessor: "prefilled"}
+	if _, _, err := decodeResponse(strings.NewReader(`{"PxgRetVal":"ok"}`), out, false, 0, "Test.Method"); err != nil {
+		t.Fatal(err)
+	}
+	if out.Str != "ok" || out.Accessor != "prefilled" {
+		t.Errorf("decoded %+v", out)
+	}
+}
+
+func TestDecodeResponseLimit(t *testing.T) {
+	body := fmt.Sprintf(`{"PxgRetVal":"%s"}`, strings.Repeat("a", 5000))
+	for _, out := range []interface{}{nil, new(ResponseTestResult)} {
+		raw, _, err := decodeResponse(strings.NewReader(body), out, true, 1000, "Test.Method")
+		if !errors.Is(err, ErrResponseTooLarge) {
+			t.Errorf("decodeResponse(%T) = %d bytes, %v, want ErrResponseTooLarge", out, len(raw), err)
+		}
+	}
+}
+
+func TestResponseEncodingAndRaw(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.handle("Test.Gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		fmt.Fprint(gz, `{"PxgRetVal":"zip"}`)
+		gz.Close()
+	})
+	srv.handle("Test.Status", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, "oops")
+	})
+
+	ctx := context.Background()
+	for _, discard := range []bool{false, true} {
+		c := srv.client(Config{DiscardRawResponse: discard})
+
+		out := new(ResponseTestResult)
+		raw, err := c.Call(ctx, "Test.Gzip", nil, out)
+		if err != nil || out.Str != "zip" {
+			t.Errorf("discard %v: gzip response = %+v, %v", discard, out, err)
+		}
+		if discard != (raw == nil) {
+			t.Errorf("discard %v: raw body = %q", discard, raw)
+		}
+
+		raw, err = c.Call(ctx, "Test.Status", nil, out)
+		var kscErr *KscError
+		if !errors.As(err, &kscErr) || kscErr.StatusCode != http.StatusInternalServerError || string(raw) != "oops" {
+			t.Errorf("discard %v: error response = %q, %v", discard, raw, err)
+		}
+	}
+}
@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // RetrFiles service provides means to get retranslated files info
@@ -74,17 +71,7 @@ type RetranslateValue struct {
 
 // GetInfo Synchronously requests information about some retranslated files.
 func (rf *RetrFiles) GetInfo(ctx context.Context, params FilesRequest) (*Retranslates, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", rf.client.Server+"/api/v1.0/RetrFiles.GetInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	retranslates := new(Retranslates)
-	_, err = rf.client.Request(ctx, request, &retranslates)
+	_, err := rf.client.Call(ctx, "RetrFiles.GetInfo", params, &retranslates)
 	return retranslates, err
 }
@@ -0,0 +1,335 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how KscClient retries requests failed because of transient transport errors.
+//
+// Read-only methods (see IsReadOnlyMethod) and calls marked with Idempotent are retried by default,
+// other mutating methods are retried only if RetryMutating is set.
+type RetryPolicy struct {
+	// MaxAttempts total number of attempts including the first one. Values less than 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff delay before the first retry (200ms by default)
+	InitialBackoff time.Duration
+
+	// MaxBackoff upper bound of the delay between attempts, jitter included (10s by default)
+	MaxBackoff time.Duration
+
+	// Multiplier backoff growth factor between attempts (2 by default)
+	Multiplier float64
+
+	// Jitter fraction of the delay which is randomized, in range [0, 1]
+	Jitter float64
+
+	// RetryMutating allows to retry methods which are not read-only
+	RetryMutating bool
+
+	// Retryable classifies errors which may be retried. IsRetryable is used if nil.
+	Retryable func(err error) bool
+
+	// ReadOnly reports whether the OpenAPI method doesn't change server state. IsReadOnlyMethod is used if nil.
+	ReadOnly func(method string) bool
+}
+
+// DefaultRetryPolicy returns retry policy with 4 attempts and exponential backoff from 200ms up to 10s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// readOnlyMethods OpenAPI methods which don't change server state.
+//
+// Methods which advance iterators (e.g. "GroupSyncIterator.GetNextItems"), start asynchronous actions,
+// finalize them ("AsyncActionStateChecker.CheckActionState") or issue tokens are deliberately left out.
+var readOnlyMethods = map[string]bool{
+	"AdHosts.FindAdGroups": true, "AdHosts.GetChildComputer": true, "AdHosts.GetChildComputers": true,
+	"AdHosts.GetChildOUs": true, "AdHosts.GetOU": true,
+	"AdfsSso.GetAdfsEnabled": true, "AdfsSso.GetJwks": true, "AdfsSso.GetSettings": true,
+	"AdmServerSettings.GetSharedFolder":       true,
+	"AppCtrlApi.GetExeFileInfo":               true,
+	"CertPoolCtrl.GetCertificateInfo":         true,
+	"CertPoolCtrl2.GetCertificateInfoDetails": true,
+	"CgwHelper.GetNagentLocation":             true, "CgwHelper.GetSlaveServerLocation": true,
+	"ChunkAccessor.GetItemsChunk": true, "ChunkAccessor.GetItemsCount": true,
+	"CloudAccess.VerifyCredentials":          true,
+	"ConEvents.IsAnyServiceConsoleAvailable": true, "ConEvents.IsServiceConsoleAvailable": true,
+	"DataProtectionApi.CheckPasswordSplPpc": true,
+	"DatabaseInfo.CheckBackupPath":          true, "DatabaseInfo.CheckBackupPath2": true,
+	"DatabaseInfo.GetDBDataSize": true, "DatabaseInfo.GetDBEventsCount": true, "DatabaseInfo.GetDBSize": true,
+	"DatabaseInfo.IsCloudSQL": true, "DatabaseInfo.IsLinuxSQL": true,
+	"DpeKeyService.GetDeviceKeys3":                      true,
+	"EventNotificationProperties.GetDefaultSettings":    true,
+	"EventNotificationProperties.GetNotificationLimits": true,
+	"EventProcessing.GetRecordCount":                    true, "EventProcessing.GetRecordRange": true,
+	"ExtAud.GetRevision":                                true,
+	"ExtTenant.GetExternalTenantId":                     true,
+	"FileCategorizer2.GetCategoriesModificationCounter": true, "FileCategorizer2.GetCategory": true,
+	"FileCategorizer2.GetCategoryByUUID": true, "FileCategorizer2.GetRefPolicies": true,
+	"FileCategorizer2.GetSerializedCategoryBody": true, "FileCategorizer2.GetSerializedCategoryBody2": true,
+	"FileCategorizer2.GetSyncId":          true,
+	"Gcm.CheckIfGcmServerSettingsPresent": true, "Gcm.CheckIfGcmServerSettingsShouldBeSet": true,
+	"Gcm.GetGcmPropagation2VS": true, "Gcm.GetGcmServerSettings": true,
+	"GroupSync.GetSyncDeliveryTime": true, "GroupSync.GetSyncHostsInfo": true, "GroupSync.GetSyncInfo": true,
+	"GroupTaskControlApi.GetTaskByRevision": true,
+	"HWInvStorage.EnumDynColumns":           true, "HWInvStorage.GetHWInvObject": true,
+	"HWInvStorage.GetProcessingRules": true,
+	"HostGroup.FindGroups":            true, "HostGroup.FindHosts": true, "HostGroup.FindIncidents": true,
+	"HostGroup.FindUsers": true, "HostGroup.GetAllHostfixes": true,
+	"HostGroup.GetComponentsForProductOnHost": true, "HostGroup.GetDomainHosts": true,
+	"HostGroup.GetDomains": true, "HostGroup.GetGroupId": true, "HostGroup.GetGroupInfo": true,
+	"HostGroup.GetGroupInfoEx": true, "HostGroup.GetHostInfo": true, "HostGroup.GetHostProducts": true,
+	"HostGroup.GetHostTasks": true, "HostGroup.GetHostfixesForProductOnHost": true,
+	"HostGroup.GetInstanceStatistics": true, "HostGroup.GetRunTimeInfo": true, "HostGroup.GetStaticInfo": true,
+	"HostGroup.GetSubgroups": true,
+	"HostMoveRules.GetRule":  true, "HostMoveRules.GetRules": true,
+	"HostTagsApi.GetHostTags":  true,
+	"HostTagsRulesApi.GetRule": true, "HostTagsRulesApi.GetRules": true,
+	"HstAccessControl.FindRoles": true, "HstAccessControl.FindTrustees": true,
+	"HstAccessControl.GetAccessibleFuncAreas": true, "HstAccessControl.GetMappingFuncAreaToPolicies": true,
+	"HstAccessControl.GetMappingFuncAreaToReports": true, "HstAccessControl.GetMappingFuncAreaToSettings": true,
+	"HstAccessControl.GetMappingFuncAreaToTasks": true, "HstAccessControl.GetPolicyReadonlyNodes": true,
+	"HstAccessControl.GetRole": true, "HstAccessControl.GetScObjectAcl": true,
+	"HstAccessControl.GetScVServerAcl": true, "HstAccessControl.GetSettingsReadonlyNodes": true,
+	"HstAccessControl.GetTrustee": true, "HstAccessControl.GetVisualViewForAccessRights": true,
+	"HstAccessControl.IsTaskTypeReadonly": true,
+	"IWebSrvSettings.GetCertificateInfo":  true, "IWebSrvSettings.GetCustomPkgHttpFqdn": true,
+	"InvLicenseProducts.GetLicenseProducts": true,
+	"InventoryApi.GetHostInvPatches":        true, "InventoryApi.GetHostInvProducts": true,
+	"InventoryApi.GetInvPatchesList": true, "InventoryApi.GetInvProductsList": true,
+	"InventoryApi.GetObservedApps": true, "InventoryApi.GetSrvCompetitorIniFileInfoList": true,
+	"KillChain.GetByIDs":             true,
+	"KsnInternal.CheckKsnConnection": true, "KsnInternal.GetNKsnEula": true, "KsnInternal.GetNKsnEulas": true,
+	"KsnInternal.GetSettings":           true,
+	"LicenseInfoSync.GetKeyDataForHost": true, "LicenseInfoSync.IsLicForSaasValid2": true,
+	"LicenseInfoSync.IsPCloudKey":           true,
+	"LicenseKeys.CheckIfSaasLicenseIsValid": true, "LicenseKeys.EnumKeys": true, "LicenseKeys.GetKeyData": true,
+	"LicensePolicy.GetFreeLicenseCount": true, "LicensePolicy.GetTotalLicenseCount": true,
+	"LicensePolicy.IsLimitedMode": true,
+	"Limits.GetLimits":            true,
+	"ListTags.GetAllTags":         true, "ListTags.GetTags": true,
+	"MdmCertCtrlApi.CheckMailNotificationSettings": true, "MdmCertCtrlApi.CheckPkiEnabled": true,
+	"MdmCertCtrlApi.GetCertificatePublic": true, "MdmCertCtrlApi.GetIssuanceSettings": true,
+	"MdmCertCtrlApi.GetIssuanceSettingsByType": true, "MdmCertCtrlApi.GetPkiTemplates": true,
+	"MfaCacheInner.GetMfaKeyIssuer": true, "MfaCacheInner.GetMfaRequiredForAll": true,
+	"MfaCacheInner.GetTotpSecretKeySettings": true, "MfaCacheInner.GetTotpVerifySettings": true,
+	"MfaCacheInner.IsCurrentUserExcludesMfa":      true,
+	"ModulesIntegrityCheck.GetIntegrityCheckInfo": true,
+	"Multitenancy.CheckAuthToken":                 true, "Multitenancy.GetProducts": true, "Multitenancy.GetTenantId": true,
+	"NagCgwHelper.GetProductComponentLocation": true,
+	"NagHstCtl.GetHostRuntimeInfo":             true,
+	"NagNetworkListApi.GetListItemFileChunk":   true, "NagNetworkListApi.GetListItemFileInfo": true,
+	"NagRdu.GetCurrentHostState":          true,
+	"NagRemoteScreen.GetExistingSessions": true, "NagRemoteScreen.GetWdsData": true,
+	"NlaDefinedNetworks.GetNetworkInfo": true, "NlaDefinedNetworks.GetNetworksList": true,
+	"OAuth2.GetClients": true, "OAuth2.GetClientsToRegistration": true, "OAuth2.GetNewClients": true,
+	"OAuth2.GetNewResServers": true, "OAuth2.GetResServers": true, "OAuth2.GetResServersToRegistration": true,
+	"OsVersion.GetAttributesByOs": true, "OsVersion.GetOsByAttributes": true,
+	"PLCDevApi.GetPLC":        true,
+	"PackagesApi.GetEulaText": true, "PackagesApi.GetExecutablePackages": true,
+	"PackagesApi.GetIncompatibleAppsInfo": true, "PackagesApi.GetIntranetFolderForPackage": true,
+	"PackagesApi.GetKpdProfileString": true, "PackagesApi.GetLicenseKey": true,
+	"PackagesApi.GetLoginScript": true, "PackagesApi.GetMoveRuleInfo": true, "PackagesApi.GetPackageInfo": true,
+	"PackagesApi.GetPackageInfo2": true, "PackagesApi.GetPackagePlugin": true, "PackagesApi.GetPackages": true,
+	"PackagesApi.GetPackages2": true, "PackagesApi.GetRebootOptionsEx": true,
+	"PackagesApi.GetUserAgreements": true, "PackagesApi.IsPackagePublished": true,
+	"PatchParameters.GetTemplate": true, "PatchParameters.GetValues": true,
+	"PatchParameters.GetValuesByPkg":      true,
+	"Policy.GetEffectivePoliciesForGroup": true, "Policy.GetOutbreakPolicies": true,
+	"Policy.GetPoliciesForGroup": true, "Policy.GetPolicyContents": true, "Policy.GetPolicyData": true,
+	"PolicyProfiles.EnumProfiles": true, "PolicyProfiles.GetEffectivePolicyContents": true,
+	"PolicyProfiles.GetPriorities": true, "PolicyProfiles.GetProfile": true,
+	"PolicyProfiles.GetProfileSettings": true,
+	"QBTNetworkListApi.GetListItemInfo": true,
+	"QueriesStorage.GetQueries":         true, "QueriesStorage.GetQuery": true, "QueriesStorage.GetQueryIds": true,
+	"ReportManager.EnumReportTypes": true, "ReportManager.EnumReports": true,
+	"ReportManager.GetAvailableDashboards": true, "ReportManager.GetConstantOutputForReportType": true,
+	"ReportManager.GetDefaultReportInfo": true, "ReportManager.GetFilterSettings": true,
+	"ReportManager.GetReportCommonData": true, "ReportManager.GetReportIds": true,
+	"ReportManager.GetReportInfo": true, "ReportManager.GetReportTypeDetailedInfo": true,
+	"ReportManager.GetStatisticsData": true,
+	"RetrFiles.GetInfo":               true,
+	"ScanDiapasons.GetDiapason":       true, "ScanDiapasons.GetDiapasons": true,
+	"SeamlessUpdatesTestApi.GetRequiredPlugins": true, "SeamlessUpdatesTestApi.GetVapmKlUpdatesToApprove": true,
+	"SecurityPolicy.GetCurrentUserId": true, "SecurityPolicy.GetCurrentUserId2": true,
+	"SecurityPolicy.GetUsers":          true,
+	"ServerHierarchy.FindSlaveServers": true, "ServerHierarchy.GetChildServers": true,
+	"ServerHierarchy.GetServerInfo":                              true,
+	"ServerTransportSettings.CheckDefaultCertificateExists":      true,
+	"ServerTransportSettings.GetCurrentConnectionSettings":       true,
+	"ServerTransportSettings.GetCustomSrvCertificateInfo":        true,
+	"ServerTransportSettings.GetDefaultConnectionSettings":       true,
+	"ServerTransportSettings.GetNumberOfManagedDevicesAgentless": true,
+	"ServerTransportSettings.GetNumberOfManagedDevicesKSM":       true,
+	"ServerTransportSettings.IsFeatureActive":                    true,
+	"ServiceNwcCommandProvider.GetCommandPayload":                true,
+	"Session.Ping":                 true,
+	"SiemExport.GetSiemSettings":   true,
+	"SmsSenders.HasAllowedSenders": true,
+	"SpamEvents.GetSpamList":       true,
+	"SrvCloud.GetCloudHostInfo":    true, "SrvCloud.GetCloudsInfo": true,
+	"SrvIpmNewsAndStatistics.GetTrackingData": true,
+	"SrvView.GetRecordCount":                  true, "SrvView.GetRecordRange": true,
+	"Tasks.GetAllTasksOfHost": true, "Tasks.GetHostStatusRecordRange": true,
+	"Tasks.GetHostStatusRecordsCount": true, "Tasks.GetTask": true, "Tasks.GetTaskData": true,
+	"Tasks.GetTaskGroup": true, "Tasks.GetTaskHistory": true, "Tasks.GetTaskStartEvent": true,
+	"Tasks.GetTaskStatistics":                 true,
+	"TotpGlobalSettings.Get2FaRequiredForAll": true, "TotpGlobalSettings.GetTotpGlobalSettings": true,
+	"TrafficManager.GetRestrictions":      true,
+	"UaControl.GetAssignUasAutomatically": true, "UaControl.GetDefaultUpdateAgentRegistrationInfo": true,
+	"UaControl.GetUpdateAgentInfo": true, "UaControl.GetUpdateAgentsDisplayInfoForHost": true,
+	"UaControl.GetUpdateAgentsList":   true,
+	"Updates.GetAvailableUpdatesInfo": true, "Updates.GetUpdatesInfo": true,
+	"UserDevicesApi.GetCommands": true, "UserDevicesApi.GetCommandsLibrary": true,
+	"UserDevicesApi.GetDecipheredCommandList": true, "UserDevicesApi.GetDevice": true,
+	"UserDevicesApi.GetDevices": true, "UserDevicesApi.GetDevicesExtraData": true,
+	"UserDevicesApi.GetEnrollmentPackage": true, "UserDevicesApi.GetEnrollmentPackageFileData": true,
+	"UserDevicesApi.GetEnrollmentPackageFileInfo": true, "UserDevicesApi.GetEnrollmentPackages": true,
+	"UserDevicesApi.GetJournalCommandResult": true, "UserDevicesApi.GetJournalRecords": true,
+	"UserDevicesApi.GetJournalRecords2": true, "UserDevicesApi.GetLatestDeviceActivityDate": true,
+	"UserDevicesApi.GetMobileAgentSettingStorageData": true,
+	"UserDevicesApi.GetMultitenancyServerSettings":    true, "UserDevicesApi.GetMultitenancyServersInfo": true,
+	"UserDevicesApi.GetSafeBrowserAutoinstallFlag": true, "UserDevicesApi.GetSyncInfo": true,
+	"VServers.GetPermissions": true, "VServers.GetVServerInfo": true, "VServers.GetVServers": true,
+	"VServers2.GetVServerStatistic":             true,
+	"VapmControlApi.GetAttributesSetVersionNum": true, "VapmControlApi.GetDownloadPatchDataChunk": true,
+	"VapmControlApi.GetEulaParams": true, "VapmControlApi.GetEulasIdsForPatchPrerequisites": true,
+	"VapmControlApi.GetEulasIdsForUpdates": true, "VapmControlApi.GetEulasIdsForVulnerabilitiesPatches": true,
+	"VapmControlApi.GetEulasInfo": true, "VapmControlApi.GetPendingRulesTasks": true,
+	"VapmControlApi.GetSupportedLcidsForPatchPrerequisites": true,
+	"VapmControlApi.GetUpdateSupportedLanguagesFilter":      true,
+}
+
+// IsReadOnlyMethod reports whether the OpenAPI method (e.g. "HostGroup.FindHosts") is known not to change server state.
+// Unknown methods are considered mutating, mark such calls with Idempotent to retry them.
+func IsReadOnlyMethod(method string) bool {
+	return readOnlyMethods[method]
+}
+
+// IsRetryable reports whether err is a transient failure: connection reset or refused, unexpected EOF,
+// network timeout or HTTP 429, 502, 503 and 504 statuses.
+//
+// A bare io.EOF is not retryable: the request may have been processed by the server before the connection was closed.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var kscErr *KscError
+	if errors.As(err, &kscErr) {
+		switch kscErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// shouldRetry reports whether the failed attempt number attempt of method may be repeated.
+// idempotent marks the call as safe to retry regardless of the method, see Idempotent.
+func (p *RetryPolicy) shouldRetry(method string, attempt int, err error, idempotent bool) bool {
+	if p == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+
+	readOnly := IsReadOnlyMethod
+	if p.ReadOnly != nil {
+		readOnly = p.ReadOnly
+	}
+	if !idempotent && !p.RetryMutating && !readOnly(method) {
+		return false
+	}
+
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// backoff returns delay before the next attempt after the failed attempt number attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max, multiplier := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = 200 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 10 * time.Second
+	}
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if d > float64(max) {
+		d = float64(max)
+	}
+
+	if jitter := math.Min(math.Max(p.Jitter, 0), 1); jitter > 0 {
+		d = d * (1 - jitter + 2*jitter*rand.Float64())
+	}
+	if d > float64(max) {
+		d = float64(max)
+	}
+	return time.Duration(d)
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestIsReadOnlyMethod(t *testing.T) {
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"HostGroup.FindHosts", true},
+		{"HostGroup.GetHostInfo", true},
+		{"Session.Ping", true},
+		{"ChunkAccessor.GetItemsChunk", true},
+		{"ProductUserTokenIssuer.IssueUserToken", false},
+		{"GroupSyncIterator.GetNextItems", false},
+		{"HostTasks.GetNextTask", false},
+		{"Tasks.GetNextTask", false},
+		{"AsyncActionStateChecker.CheckActionState", false},
+		{"HostGroup.FindHostsAsync", false},
+		{"HostGroup.AddHost", false},
+		{"Unknown.GetSomething", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsReadOnlyMethod(tt.method); got != tt.want {
+			t.Errorf("IsReadOnlyMethod(%q) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var _ net.Error = timeoutError{}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"canceled", context.Canceled, false},
+		{"deadline", fmt.Errorf("call: %w", context.DeadlineExceeded), false},
+		{"bare EOF", io.EOF, false},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true},
+		{"connection reset", &net.OpError{Op: "read", Err: syscall.ECONNRESET}, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"timeout", timeoutError{}, true},
+		{"503", &KscError{StatusCode: http.StatusServiceUnavailable}, true},
+		{"429", &KscError{StatusCode: http.StatusTooManyRequests}, true},
+		{"500", &KscError{StatusCode: http.StatusInternalServerError}, false},
+		{"PxgError", &KscError{Module: "KLSTD", Code: KlstdErrObjectNotFound}, false},
+		{"other", errors.New("boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}
+	for i, w := range want {
+		if got := p.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("backoff(1) with jitter = %v, want within [50ms, 150ms]", got)
+		}
+	}
+
+	p.Jitter = 1
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(10); got > time.Second {
+			t.Fatalf("backoff(10) with jitter = %v, want at most MaxBackoff", got)
+		}
+	}
+}
+
+func TestRequestRetry(t *testing.T) {
+	var calls int32
+	srv := newFakeServer(t)
+	unavailable := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1)%3 != 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"PxgRetVal":1}`))
+	}
+	srv.handle("HostGroup.GetHostInfo", unavailable)
+	srv.handle("HostGroup.AddHost", unavailable)
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff, policy.MaxBackoff = time.Millisecond, time.Millisecond
+	c := srv.client(Config{RetryPolicy: policy})
+	ctx := context.Background()
+
+	if _, err := c.Call(ctx, "HostGroup.GetHostInfo", nil, nil); err != nil {
+		t.Errorf("read-only call: %v", err)
+	}
+	if n := len(srv.received("HostGroup.GetHostInfo")); n != 3 {
+		t.Errorf("read-only call sent %d times, want 3", n)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	if _, err := c.Call(ctx, "HostGroup.AddHost", nil, nil); err == nil {
+		t.Error("mutating call succeeded, want the first failure")
+	}
+	if n := len(srv.received("HostGroup.AddHost")); n != 1 {
+		t.Errorf("mutating call sent %d times, want 1", n)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	if _, err := c.Call(ctx, "HostGroup.AddHost", nil, nil, Idempotent()); err != nil {
+		t.Errorf("idempotent call: %v", err)
+	}
+	if n := len(srv.received("HostGroup.AddHost")); n != 4 {
+		t.Errorf("mutating calls sent %d times in total, want 4", n)
+	}
+}
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // ScanDiapasons Network subnets processing.
@@ -37,12 +33,7 @@ type ScanDiapasons service
 
 // NotifyDpnsTask Restart the task scanning IP diapasons.
 func (sd *ScanDiapasons) NotifyDpnsTask(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/ScanDiapasons.NotifyDpnsTask", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sd.client.Request(ctx, request, nil)
+	raw, err := sd.client.Call(ctx, "ScanDiapasons.NotifyDpnsTask", nil, nil)
 	return raw, err
 }
 
@@ -57,18 +48,8 @@ type DiapasonsParams struct {
 
 // GetDiapasons Enumerate existing diapasons.
 func (sd *ScanDiapasons) GetDiapasons(ctx context.Context, params DiapasonsParams) (*PxgValStr, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/ScanDiapasons.GetDiapasons", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := sd.client.Request(ctx, request, &pxgValStr)
+	raw, err := sd.client.Call(ctx, "ScanDiapasons.GetDiapasons", params, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
@@ -127,30 +108,16 @@ type KldpnsILValue struct {
 //
 // Returns specified attributes of given diapason.
 func (sd *ScanDiapasons) GetDiapason(ctx context.Context, params DiapasonParams) (*DiapasonAttributes, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/ScanDiapasons.GetDiapason", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	diapasonAttributes := new(DiapasonAttributes)
-	raw, err := sd.client.Request(ctx, request, &diapasonAttributes)
+	raw, err := sd.client.Call(ctx, "ScanDiapasons.GetDiapason", params, &diapasonAttributes)
 	return diapasonAttributes, raw, err
 }
 
 // RemoveDiapason Removes specified diapason.
 func (sd *ScanDiapasons) RemoveDiapason(ctx context.Context, idDiapason int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"idDiapason": %d}`, idDiapason))
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/ScanDiapasons.RemoveDiapason", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"idDiapason": idDiapason}
 
-	raw, err := sd.client.Request(ctx, request, nil)
+	raw, err := sd.client.Call(ctx, "ScanDiapasons.RemoveDiapason", postData, nil)
 	return raw, err
 }
 
@@ -190,34 +157,14 @@ type PInvalidIntervals struct {
 // If at least one of diapasons intersects with any of existing diapasons or is invalid then
 // false is returned and such interval is added to "KLDPNS_ILS" array in ppInvalidIntervals.
 func (sd *ScanDiapasons) UpdateDiapason(ctx context.Context, params UpdateDiapasonParams) (*UpdateDiapasonRespond, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/ScanDiapasons.UpdateDiapason", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	updateDiapasonRespond := new(UpdateDiapasonRespond)
-	raw, err := sd.client.Request(ctx, request, &updateDiapasonRespond)
+	raw, err := sd.client.Call(ctx, "ScanDiapasons.UpdateDiapason", params, &updateDiapasonRespond)
 	return updateDiapasonRespond, raw, err
 }
 
 // AddDiapason Adds new diapason with the specified attributes (see List of network diapason attributes).
 // Error occurs if at least one of intervals/subnet intersects with any of existing intervals/subnets.
 func (sd *ScanDiapasons) AddDiapason(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sd.client.Server+"/api/v1.0/ScanDiapasons.AddDiapason", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sd.client.Request(ctx, request, nil)
+	raw, err := sd.client.Call(ctx, "ScanDiapasons.AddDiapason", params, nil)
 	return raw, err
 }
@@ -26,8 +26,6 @@ package kaspersky
 
 import (
 	"context"
-	"log"
-	"net/http"
 )
 
 // Deprecated: Obsolete, removed ???
@@ -49,17 +47,8 @@ type RequiredPlugins struct {
 }
 
 func (suta *SeamlessUpdatesTestApi) GetRequiredPlugins(ctx context.Context) (*RequiredPlugins, error) {
-	request, err := http.NewRequest("POST", suta.client.Server+"/api/v1.0/SeamlessUpdatesTestApi.GetRequiredPlugins", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(RequiredPlugins)
-	raw, err := suta.client.Request(ctx, request, &result)
-
-	if suta.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := suta.client.Call(ctx, "SeamlessUpdatesTestApi.GetRequiredPlugins", nil, &result)
 
 	return result, err
 }
@@ -78,33 +67,15 @@ type VapmKlUpdatesToApprove struct {
 }
 
 func (suta *SeamlessUpdatesTestApi) GetVapmKlUpdatesToApprove(ctx context.Context) (*VapmKlUpdatesToApprove, error) {
-	request, err := http.NewRequest("POST", suta.client.Server+"/api/v1.0/SeamlessUpdatesTestApi.GetVapmKlUpdatesToApprove", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(VapmKlUpdatesToApprove)
-	raw, err := suta.client.Request(ctx, request, &result)
-
-	if suta.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := suta.client.Call(ctx, "SeamlessUpdatesTestApi.GetVapmKlUpdatesToApprove", nil, &result)
 
 	return result, err
 }
 
 func (suta *SeamlessUpdatesTestApi) CleanupSeamlessUpdates(ctx context.Context) (*LoggedInUsing2FA, error) {
-	request, err := http.NewRequest("POST", suta.client.Server+"/api/v1.0/SeamlessUpdatesTestApi.CleanupSeamlessUpdates", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(LoggedInUsing2FA)
-	raw, err := suta.client.Request(ctx, request, &result)
-
-	if suta.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := suta.client.Call(ctx, "SeamlessUpdatesTestApi.CleanupSeamlessUpdates", nil, &result)
 
 	return result, err
 }
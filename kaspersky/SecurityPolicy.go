@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // SecurityPolicy Allows to manage users and permissions.
@@ -67,18 +62,8 @@ type PUser struct {
 
 // AddUser Add new user.
 func (sp *SecurityPolicy) AddUser(ctx context.Context, params PUserData) (*PxgValInt, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.AddUser", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := sp.client.Request(ctx, request, &pxgValInt)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.AddUser", params, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -86,16 +71,10 @@ func (sp *SecurityPolicy) AddUser(ctx context.Context, params PUserData) (*PxgVa
 //
 // Modifies properties of the specified user
 func (sp *SecurityPolicy) UpdateUser(ctx context.Context, lUserId int, params PUserData) (*PxgValInt, []byte, error) {
-	marshalledData, _ := json.Marshal(params.PUser)
-	pUser := []byte(fmt.Sprintf(`{"pUser" : %v,"lUserId" : %d}`, string(marshalledData), lUserId))
-
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.UpdateUser", bytes.NewBuffer(pUser))
-	if err != nil {
-		log.Fatal(err.Error())
-	}
+	postData := map[string]interface{}{"pUser": params.PUser, "lUserId": lUserId}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := sp.client.Request(ctx, request, &pxgValInt)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.UpdateUser", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -115,14 +94,8 @@ type UserInfoEx struct {
 
 // GetCurrentUserId Acquire current internal user id.
 func (sp *SecurityPolicy) GetCurrentUserId(ctx context.Context) (*UserInfo, []byte, error) {
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.GetCurrentUserId",
-		nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	user := new(UserInfo)
-	raw, err := sp.client.Request(ctx, request, &user)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.GetCurrentUserId", nil, &user)
 	return user, raw, err
 }
 
@@ -132,14 +105,8 @@ func (sp *SecurityPolicy) GetCurrentUserId(ctx context.Context) (*UserInfo, []by
 //
 // For non internal user: lUserId = -1; binSystemId - binary representation of user SID;
 func (sp *SecurityPolicy) GetCurrentUserId2(ctx context.Context) (*UserInfoEx, []byte, error) {
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.GetCurrentUserId2",
-		nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	userEx := new(UserInfoEx)
-	raw, err := sp.client.Request(ctx, request, &userEx)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.GetCurrentUserId2", nil, &userEx)
 	return userEx, raw, err
 }
 
@@ -185,40 +152,22 @@ type UserProperties struct {
 //
 // Acquires properties of the specified user, or all users if lUserId==(-1);
 func (sp *SecurityPolicy) GetUsers(ctx context.Context, lUserId, lVsId int64) (*UsersInfo, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lUserId" : %d, "lVsId" : %d}`, lUserId, lVsId))
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.GetUsers",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"lUserId": lUserId, "lVsId": lVsId}
 
 	userInf := new(UsersInfo)
-	raw, err := sp.client.Request(ctx, request, &userInf)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.GetUsers", postData, &userInf)
 	return userInf, raw, err
 }
 
 // LoadPerUserData Get current user personal data.
 func (sp *SecurityPolicy) LoadPerUserData(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.LoadPerUserData",
-		nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sp.client.Request(ctx, request, nil)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.LoadPerUserData", nil, nil)
 	return raw, err
 }
 
 // SavePerUserData Save or replace current user personal data.
 func (sp *SecurityPolicy) SavePerUserData(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.SavePerUserData",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sp.client.Request(ctx, request, nil)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.SavePerUserData", params, nil)
 	return raw, err
 }
 
@@ -264,13 +213,6 @@ type PTrusteeData struct {
 // UpdateTrustee Modifies properties of the specified user (either internal user or user and group from Active Directory);
 // for internal groups use SecurityPolicy3.UpdateSecurityGroup.
 func (sp *SecurityPolicy) UpdateTrustee(ctx context.Context, params TrusteeParam) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy.UpdateTrustee",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sp.client.Request(ctx, request, nil)
+	raw, err := sp.client.Call(ctx, "SecurityPolicy.UpdateTrustee", params, nil)
 	return raw, err
 }
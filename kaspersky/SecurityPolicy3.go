@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // SecurityPolicy3 Allows to manage security groups of internal users.
@@ -67,18 +62,8 @@ type PGrpValue struct {
 // It is ignored in case of connection to virtual server.
 //
 func (sp *SecurityPolicy3) AddSecurityGroup(ctx context.Context, params SecurityGroupParams) (*PxgValInt, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy3.AddSecurityGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	_, err = sp.client.Request(ctx, request, &pxgValInt)
+	_, err := sp.client.Call(ctx, "SecurityPolicy3.AddSecurityGroup", params, &pxgValInt)
 	return pxgValInt, err
 }
 
@@ -92,25 +77,17 @@ func (sp *SecurityPolicy3) AddSecurityGroup(ctx context.Context, params Security
 //
 // A user located on a main server can be added only into a group located on this main server, otherwise a error occurs.
 func (sp *SecurityPolicy3) AddUserIntoSecurityGroup(ctx context.Context, lUserId, lGrpId int64) error {
-	postData := []byte(fmt.Sprintf(`{"lUserId": %d, "lGrpId": %d}`, lUserId, lGrpId))
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy3.AddUserIntoSecurityGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"lUserId": lUserId, "lGrpId": lGrpId}
 
-	_, err = sp.client.Request(ctx, request, nil)
+	_, err := sp.client.Call(ctx, "SecurityPolicy3.AddUserIntoSecurityGroup", postData, nil)
 	return err
 }
 
 // CloseUserConnections Closes user connections.
 func (sp *SecurityPolicy3) CloseUserConnections(ctx context.Context, lUserId int64) error {
-	postData := []byte(fmt.Sprintf(`{"lUserId": %d }`, lUserId))
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy3.CloseUserConnections", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"lUserId": lUserId}
 
-	_, err = sp.client.Request(ctx, request, nil)
+	_, err := sp.client.Call(ctx, "SecurityPolicy3.CloseUserConnections", postData, nil)
 	return err
 }
 
@@ -118,13 +95,9 @@ func (sp *SecurityPolicy3) CloseUserConnections(ctx context.Context, lUserId int
 //
 // Connection to a virtual server has access only to groups which located on this virtual server.
 func (sp *SecurityPolicy3) DeleteSecurityGroup(ctx context.Context, lGrpId int64) error {
-	postData := []byte(fmt.Sprintf(`{"lGrpId": %d}`, lGrpId))
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy3.DeleteSecurityGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"lGrpId": lGrpId}
 
-	_, err = sp.client.Request(ctx, request, nil)
+	_, err := sp.client.Call(ctx, "SecurityPolicy3.DeleteSecurityGroup", postData, nil)
 	return err
 }
 
@@ -132,25 +105,17 @@ func (sp *SecurityPolicy3) DeleteSecurityGroup(ctx context.Context, lGrpId int64
 //
 // Connection to a virtual server has access only to groups and users which located on this virtual server.
 func (sp *SecurityPolicy3) DeleteUserFromSecurityGroup(ctx context.Context, lUserId, lGrpId int64) error {
-	postData := []byte(fmt.Sprintf(`{"lUserId": %d, "lGrpId": %d}`, lUserId, lGrpId))
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy3.DeleteUserFromSecurityGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"lUserId": lUserId, "lGrpId": lGrpId}
 
-	_, err = sp.client.Request(ctx, request, nil)
+	_, err := sp.client.Call(ctx, "SecurityPolicy3.DeleteUserFromSecurityGroup", postData, nil)
 	return err
 }
 
 // MoveUserIntoOtherSecurityGroup Moves user from one security group into other security group.
 func (sp *SecurityPolicy3) MoveUserIntoOtherSecurityGroup(ctx context.Context, lUserId, lGrpIdFrom, lGrpIdTo int64) error {
-	postData := []byte(fmt.Sprintf(`{"lUserId": %d, "lGrpIdFrom": %d, "lGrpIdTo": %d}`, lUserId, lGrpIdFrom, lGrpIdTo))
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy3.MoveUserIntoOtherSecurityGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"lUserId": lUserId, "lGrpIdFrom": lGrpIdFrom, "lGrpIdTo": lGrpIdTo}
 
-	_, err = sp.client.Request(ctx, request, nil)
+	_, err := sp.client.Call(ctx, "SecurityPolicy3.MoveUserIntoOtherSecurityGroup", postData, nil)
 	return err
 }
 
@@ -166,16 +131,6 @@ type UpdateSecurityGroupParams struct {
 // If a group does not exist a error occurs.
 // Connection to a virtual server has access only to groups which located on this virtual server.
 func (sp *SecurityPolicy3) UpdateSecurityGroup(ctx context.Context, params UpdateSecurityGroupParams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", sp.client.Server+"/api/v1.0/SecurityPolicy3.UpdateSecurityGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = sp.client.Request(ctx, request, nil)
+	_, err := sp.client.Call(ctx, "SecurityPolicy3.UpdateSecurityGroup", params, nil)
 	return err
 }
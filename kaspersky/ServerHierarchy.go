@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // ServerHierarchy Server hierarchy management service.
@@ -41,13 +36,9 @@ type ServerHierarchy service
 // This action only removes slave server registration info from master server. To remove master server settings from slave server use HostGroup.SSWrite
 // to overwrite master server connection settings section and set "KLSRV_MASTER_SRV_USE" to false.
 func (sh *ServerHierarchy) DelServer(ctx context.Context, lServer int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lServer": %d}`, lServer))
-	request, err := http.NewRequest("POST", sh.client.Server+"/api/v1.0/ServerHierarchy.DelServer", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lServer": lServer}
 
-	raw, _ := sh.client.Request(ctx, request, nil)
+	raw, err := sh.client.Call(ctx, "ServerHierarchy.DelServer", postData, nil)
 	return raw, err
 }
 
@@ -87,29 +78,15 @@ type ServerHierarchyParams struct {
 
 // GetServerInfo Acquire specified slave server attributes.
 func (sh *ServerHierarchy) GetServerInfo(ctx context.Context, params ServerHierarchyParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sh.client.Server+"/api/v1.0/ServerHierarchy.GetServerInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sh.client.Request(ctx, request, nil)
-	return raw, nil
+	raw, err := sh.client.Call(ctx, "ServerHierarchy.GetServerInfo", params, nil)
+	return raw, err
 }
 
 // GetChildServers Enumerate slave servers for specified group.
 func (sh *ServerHierarchy) GetChildServers(ctx context.Context, nGroupId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nGroupId": %d}`, nGroupId))
-	request, err := http.NewRequest("POST", sh.client.Server+"/api/v1.0/ServerHierarchy.GetChildServers", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nGroupId": nGroupId}
 
-	raw, err := sh.client.Request(ctx, request, nil)
+	raw, err := sh.client.Call(ctx, "ServerHierarchy.GetChildServers", postData, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -118,17 +95,7 @@ func (sh *ServerHierarchy) GetChildServers(ctx context.Context, nGroupId int64)
 
 // FindSlaveServers Searches for slave servers meeting specified criteria.
 func (sh *ServerHierarchy) FindSlaveServers(ctx context.Context, params PFindParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sh.client.Server+"/api/v1.0/ServerHierarchy.FindSlaveServers", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sh.client.Request(ctx, request, nil)
+	raw, err := sh.client.Call(ctx, "ServerHierarchy.FindSlaveServers", params, nil)
 	if err != nil {
 		return nil, err
 	}
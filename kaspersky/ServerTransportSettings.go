@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // ServerTransportSettings Server transport settings proxy class.
@@ -39,13 +35,8 @@ type ServerTransportSettings service
 //
 // Note: It can be called from main server only !
 func (sts *ServerTransportSettings) GetNumberOfManagedDevicesAgentless(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.GetNumberOfManagedDevicesAgentless", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := sts.client.Request(ctx, request, &pxgValInt)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.GetNumberOfManagedDevicesAgentless", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -53,52 +44,35 @@ func (sts *ServerTransportSettings) GetNumberOfManagedDevicesAgentless(ctx conte
 //
 // Note: It can be called from main server only !
 func (sts *ServerTransportSettings) GetNumberOfManagedDevicesKSM(ctx context.Context) (*PxgValInt, []byte, error) {
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.GetNumberOfManagedDevicesKSM", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := sts.client.Request(ctx, request, &pxgValInt)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.GetNumberOfManagedDevicesKSM", nil, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // IsFeatureActive Checks if feature is activated and certificate can be changed to some custom value.
 func (sts *ServerTransportSettings) IsFeatureActive(ctx context.Context, szwCertType string) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s"}`, szwCertType))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.IsFeatureActive", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := sts.client.Request(ctx, request, &pxgValBool)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.IsFeatureActive", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // SetFeatureActive Sets feature active.
 func (sts *ServerTransportSettings) SetFeatureActive(ctx context.Context, szwCertType string, bFeatureActive bool) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s", "bFeatureActive" : %v}`, szwCertType, bFeatureActive))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.SetFeatureActive", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType, "bFeatureActive": bFeatureActive}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := sts.client.Request(ctx, request, &pxgValBool)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.SetFeatureActive", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // CheckDefaultCertificateExists It checks if default certificate exists.
 func (sts *ServerTransportSettings) CheckDefaultCertificateExists(ctx context.Context, szwCertType string) (*PxgValBool, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s"}`, szwCertType))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.CheckDefaultCertificateExists", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType}
 
 	pxgValBool := new(PxgValBool)
-	raw, err := sts.client.Request(ctx, request, &pxgValBool)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.CheckDefaultCertificateExists", postData, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
@@ -133,96 +107,54 @@ type CERTPub struct {
 
 // GetCurrentConnectionSettings Returns current connection settings.
 func (sts *ServerTransportSettings) GetCurrentConnectionSettings(ctx context.Context, szwCertType string) (*CurrentConnectionSettings, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s"}`, szwCertType))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.GetCurrentConnectionSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType}
 
 	cCSettings := new(CurrentConnectionSettings)
-	raw, err := sts.client.Request(ctx, request, &cCSettings)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.GetCurrentConnectionSettings", postData, &cCSettings)
 	return cCSettings, raw, err
 }
 
 // GetCustomSrvCertificateInfo Returns information about custom certificate.
 func (sts *ServerTransportSettings) GetCustomSrvCertificateInfo(ctx context.Context, szwCertType string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s"}`, szwCertType))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.GetCustomSrvCertificateInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType}
 
-	raw, err := sts.client.Request(ctx, request, nil)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.GetCustomSrvCertificateInfo", postData, nil)
 	return raw, err
 }
 
 // GetDefaultConnectionSettings Returns default connection settings.
 func (sts *ServerTransportSettings) GetDefaultConnectionSettings(ctx context.Context, szwCertType string) (*CurrentConnectionSettings, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s"}`, szwCertType))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.GetDefaultConnectionSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType}
 
 	cCSettings := new(CurrentConnectionSettings)
-	raw, err := sts.client.Request(ctx, request, &cCSettings)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.GetDefaultConnectionSettings", postData, &cCSettings)
 	return cCSettings, raw, err
 }
 
 // ResetCstmReserveCertificate Resets custom reserve certificate.
 func (sts *ServerTransportSettings) ResetCstmReserveCertificate(ctx context.Context, szwCertType string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s"}`, szwCertType))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.ResetCstmReserveCertificate", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType}
 
-	raw, err := sts.client.Request(ctx, request, nil)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.ResetCstmReserveCertificate", postData, nil)
 	return raw, err
 }
 
 // ResetDefaultReserveCertificate Resets default reserve certificate.
 func (sts *ServerTransportSettings) ResetDefaultReserveCertificate(ctx context.Context, szwCertType string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwCertType": "%s"}`, szwCertType))
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.ResetDefaultReserveCertificate", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwCertType": szwCertType}
 
-	raw, err := sts.client.Request(ctx, request, nil)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.ResetDefaultReserveCertificate", postData, nil)
 	return raw, err
 }
 
 // SetOrCreateDefaultCertificate. It sets or recreates default certificate.
 func (sts *ServerTransportSettings) SetOrCreateDefaultCertificate(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.SetOrCreateDefaultCertificate",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sts.client.Request(ctx, request, nil)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.SetOrCreateDefaultCertificate", params, nil)
 	return raw, err
 }
 
 // SetCustomSrvCertificate Sets custom certificate for one of SC Server's SSL listener.
 func (sts *ServerTransportSettings) SetCustomSrvCertificate(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sts.client.Server+"/api/v1.0/ServerTransportSettings.SetCustomSrvCertificate",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sts.client.Request(ctx, request, nil)
+	raw, err := sts.client.Call(ctx, "ServerTransportSettings.SetCustomSrvCertificate", params, nil)
 	return raw, err
 }
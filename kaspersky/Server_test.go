@@ -0,0 +1,186 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeServer is a KSC server stub which routes OpenAPI methods to handlers and records the requests.
+type fakeServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]http.HandlerFunc
+	requests []fakeRequest
+}
+
+// fakeRequest is a request received by fakeServer.
+type fakeRequest struct {
+	Method string
+	Header http.Header
+	Body   []byte
+}
+
+// newFakeServer starts a fakeServer which is closed when the test finishes.
+// Methods without a handler reply with an empty object.
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+
+	s := &fakeServer{handlers: map[string]http.HandlerFunc{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	method := methodFromPath(r.URL.Path)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, fakeRequest{Method: method, Header: r.Header.Clone(), Body: body})
+	h := s.handlers[method]
+	s.mu.Unlock()
+
+	if h == nil {
+		_, _ = w.Write([]byte(`{}`))
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	h(w, r)
+}
+
+// handle registers the handler of the OpenAPI method, e.g. "Session.Ping".
+func (s *fakeServer) handle(method string, h http.HandlerFunc) {
+	s.mu.Lock()
+	s.handlers[method] = h
+	s.mu.Unlock()
+}
+
+// reply registers a handler which always responds with the body.
+func (s *fakeServer) reply(method, body string) {
+	s.handle(method, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+// received returns the requests of the OpenAPI method received so far.
+func (s *fakeServer) received(method string) []fakeRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var requests []fakeRequest
+	for _, r := range s.requests {
+		if r.Method == method {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+// client returns a client of the server, cfg.Server is overridden.
+func (s *fakeServer) client(cfg Config) *KscClient {
+	cfg.Server = s.URL
+	return NewKscClient(cfg)
+}
+
+// fakeResultSet is a server-side result-set served by fakeServer.resultSet.
+type fakeResultSet struct {
+	// CountMethod replies with the number of rows, e.g. "SrvView.GetRecordCount"
+	CountMethod string
+
+	// RangeMethod replies with the rows from nStart to nEnd (exclusive) or nStart+nCount, e.g. "SrvView.GetRecordRange"
+	RangeMethod string
+
+	// Array path of the rows array in the RangeMethod response, e.g. "pRecords.KLCSP_ITERATOR_ARRAY"
+	Array string
+
+	// Rows of the result-set
+	Rows []Params
+
+	// Count number of rows reported by CountMethod, len(Rows) if zero
+	Count int
+}
+
+// resultSet serves the result-set, each row is wrapped into params.
+func (s *fakeServer) resultSet(t *testing.T, rs fakeResultSet) {
+	t.Helper()
+
+	count := rs.Count
+	if count == 0 {
+		count = len(rs.Rows)
+	}
+	s.reply(rs.CountMethod, `{"PxgRetVal":`+strconv.Itoa(count)+`}`)
+
+	s.handle(rs.RangeMethod, func(w http.ResponseWriter, r *http.Request) {
+		var in struct {
+			NStart int  `json:"nStart"`
+			NEnd   *int `json:"nEnd"`
+			NCount *int `json:"nCount"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("%s: %v", rs.RangeMethod, err)
+		}
+
+		end := len(rs.Rows)
+		switch {
+		case in.NEnd != nil && *in.NEnd < end:
+			end = *in.NEnd
+		case in.NCount != nil && in.NStart+*in.NCount < end:
+			end = in.NStart + *in.NCount
+		}
+		rows := []interface{}{}
+		for i := in.NStart; i < end; i++ {
+			rows = append(rows, map[string]interface{}{"type": "params", "value": rs.Rows[i]})
+		}
+
+		var out interface{} = rows
+		path := strings.Split(rs.Array, ".")
+		for i := len(path) - 1; i >= 0; i-- {
+			out = map[string]interface{}{path[i]: out}
+		}
+		out.(map[string]interface{})["PxgRetVal"] = len(rows)
+		if err := json.NewEncoder(w).Encode(out); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+// fakeRows returns n rows made by row.
+func fakeRows(n int, row func(i int) Params) []Params {
+	rows := make([]Params, n)
+	for i := range rows {
+		rows[i] = row(i)
+	}
+	return rows
+}
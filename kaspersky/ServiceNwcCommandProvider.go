@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // ServiceNwcCommandProvider Service console command provider.
@@ -37,20 +33,10 @@ type ServiceNwcCommandProvider service
 
 // GetCommandPayload Get command payload.
 func (srn *ServiceNwcCommandProvider) GetCommandPayload(ctx context.Context, wstrCommandId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrCommandId": "%s"}`, wstrCommandId))
-	request, err := http.NewRequest("POST", srn.client.Server+"/api/v1.0/ServiceNwcCommandProvider.GetCommandPayload",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"wstrCommandId": wstrCommandId}
 
 	//TODO check response
-	raw, err := srn.client.Request(ctx, request, nil)
-
-	if srn.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := srn.client.Call(ctx, "ServiceNwcCommandProvider.GetCommandPayload", postData, nil)
 
 	return err
 }
@@ -26,8 +26,6 @@ package kaspersky
 
 import (
 	"context"
-	"log"
-	"net/http"
 )
 
 // ServiceNwcDeployment Service NWC Deployment.
@@ -44,17 +42,8 @@ type PAccount struct {
 
 // CreateServiceAccount Create NWC Service Account.
 func (snd *ServiceNwcDeployment) CreateServiceAccount(ctx context.Context) (*ServiceAccount, error) {
-	request, err := http.NewRequest("POST", snd.client.Server+"/api/v1.0/ServiceNwcDeployment.CreateServiceAccount", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(ServiceAccount)
-	raw, err := snd.client.Request(ctx, request, &result)
-
-	if snd.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := snd.client.Call(ctx, "ServiceNwcDeployment.CreateServiceAccount", nil, &result)
 
 	return result, err
 }
@@ -25,9 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"net/http"
 )
 
@@ -40,25 +38,15 @@ type Session service
 //
 // Those token can be used for logon purposes to Administaration Server for a short time (3 minutes by default).
 func (s *Session) CreateToken(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", s.client.Server+"/api/v1.0/Session.CreateToken", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	raw, err := s.client.Request(ctx, request, &pxgValStr)
+	raw, err := s.client.Call(ctx, "Session.CreateToken", nil, &pxgValStr)
 	return pxgValStr, raw, err
 }
 
 // Ping Does nothing. May be used to effectively verify session validity.
 // Session id to verify is passed in "X-KSC-Session" header.
 func (s *Session) Ping(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", s.client.Server+"/api/v1.0/Session.Ping", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := s.client.Request(ctx, request, nil)
+	raw, err := s.client.Call(ctx, "Session.Ping", nil, nil)
 	return raw, err
 }
 
@@ -68,26 +56,31 @@ func (s *Session) Ping(ctx context.Context) ([]byte, error) {
 //
 // Session id to terminate is passed in "X-KSC-Session" header.
 func (s *Session) EndSession(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", s.client.Server+"/api/v1.0/Session.EndSession", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := s.client.Request(ctx, request, nil)
+	raw, err := s.client.Call(ctx, "Session.EndSession", nil, nil)
 	return raw, err
 }
 
 // StartSession Method to create authenticated session.
 // Authentication details should be provided in Authorization HTTP header.
 func (s *Session) StartSession(ctx context.Context) (*PxgValStr, []byte, error) {
-	request, err := http.NewRequest("POST", s.client.Server+"/api/v1.0/Session.StartSession", nil)
+	creds, err := s.client.basicCredentials(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	return s.startSession(ctx, creds)
+}
+
+// startSession creates authenticated session with the given credentials.
+func (s *Session) startSession(ctx context.Context, creds BasicCredentials) (*PxgValStr, []byte, error) {
+	request, err := http.NewRequest("POST", s.client.Server+"/api/v1.0/Session.StartSession", nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	request.Header.Set("Authorization", "KSCBasic user=\""+s.client.UserName+"\", pass=\""+s.client.Password+"\"")
-	request.Header.Set("X-KSC-VServer", s.client.VServerName)
+	user, pass, vServer := creds.basic()
+	request.Header.Set("Authorization", "KSCBasic user=\""+user+"\", pass=\""+pass+"\"")
+	request.Header.Set("X-KSC-VServer", vServer)
 
 	pxgValStr := new(PxgValStr)
 	raw, err := s.client.Request(ctx, request, &pxgValStr)
@@ -97,17 +90,6 @@ func (s *Session) StartSession(ctx context.Context) (*PxgValStr, []byte, error)
 // CreateBlob Create blob with connection parameters for the klsctunnel utility,
 // including target address and one-time authentication token. See Tunnels creation
 func (s *Session) CreateBlob(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", s.client.Server+"/api/v1.0/Session.CreateBlob",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := s.client.Request(ctx, request, nil)
+	raw, err := s.client.Call(ctx, "Session.CreateBlob", params, nil)
 	return raw, err
 }
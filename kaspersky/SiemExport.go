@@ -26,7 +26,6 @@ package kaspersky
 
 import (
 	"context"
-	"net/http"
 )
 
 // SiemExport is SIEM control interface
@@ -34,11 +33,6 @@ type SiemExport service
 
 // GetAdfsEnabled SIEM export settings.
 func (se *SiemExport) GetAdfsEnabled(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", se.client.Server+"/api/v1.0/SiemExport.GetSiemSettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := se.client.Request(ctx, request, nil)
+	raw, err := se.client.Call(ctx, "SiemExport.GetSiemSettings", nil, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-
-	"net/http"
 )
 
 // SmsQueue service to manage SMS message queue.
@@ -42,28 +38,13 @@ type SQParams struct {
 
 // Enqueue message into SMS queue for one or more recipients.
 func (sq *SmsQueue) Enqueue(ctx context.Context, params SQParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sq.client.Server+"/api/v1.0/SmsQueue.Enqueue", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sq.client.Request(ctx, request, nil)
+	raw, err := sq.client.Call(ctx, "SmsQueue.Enqueue", params, nil)
 	return raw, err
 }
 
 // Clear sms queue. All requests which have not been sent to any device, or marked as failed will be removed.
 func (sq *SmsQueue) Clear(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", sq.client.Server+"/api/v1.0/SmsQueue.Clear", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sq.client.Request(ctx, request, nil)
+	raw, err := sq.client.Call(ctx, "SmsQueue.Clear", nil, nil)
 	return raw, err
 }
 
@@ -75,16 +56,6 @@ type SQCParams struct {
 
 // Cancel request nRequestId to recipients pRecipients.
 func (sq *SmsQueue) Cancel(ctx context.Context, params SQCParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sq.client.Server+"/api/v1.0/SmsQueue.Cancel", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sq.client.Request(ctx, request, nil)
+	raw, err := sq.client.Call(ctx, "SmsQueue.Cancel", params, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-
-	"net/http"
 )
 
 // SmsSenders service provide to configure mobile devices as SMS senders.
@@ -37,13 +33,8 @@ type SmsSenders service
 
 // HasAllowedSenders checks if there is a device allowed to send SMS
 func (ss *SmsSenders) HasAllowedSenders(ctx context.Context) (*PxgValBool, []byte, error) {
-	request, err := http.NewRequest("POST", ss.client.Server+"/api/v1.0/SmsSenders.HasAllowedSenders", nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValBool := new(PxgValBool)
-	raw, err := ss.client.Request(ctx, request, &pxgValBool)
+	raw, err := ss.client.Call(ctx, "SmsSenders.HasAllowedSenders", nil, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
@@ -66,17 +57,6 @@ type PNewStatusValue struct {
 
 // AllowSenders change bMayUseSmsSending parameter for mobile devices
 func (ss *SmsSenders) AllowSenders(ctx context.Context, params PNewStatuses) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ss.client.Server+"/api/v1.0/SmsSenders.AllowSenders",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ss.client.Request(ctx, request, nil)
+	raw, err := ss.client.Call(ctx, "SmsSenders.AllowSenders", params, nil)
 	return raw, err
 }
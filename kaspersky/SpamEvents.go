@@ -26,8 +26,6 @@ package kaspersky
 
 import (
 	"context"
-	"log"
-	"net/http"
 )
 
 // SpamEvents Service for reading and editing of SPAM-events list. Works in the context of a virtual server.
@@ -35,21 +33,12 @@ type SpamEvents service
 
 // GetSpamList Return array of SPAM events
 func (se *SpamEvents) GetSpamList(ctx context.Context) error {
-	request, err := http.NewRequest("POST", se.client.Server+"/api/v1.0/SpamEvents.GetSpamList", nil)
-	if err != nil {
-		return err
-	}
-
-	raw, err := se.client.Request(ctx, request, nil)
+	_, err := se.client.Call(ctx, "SpamEvents.GetSpamList", nil, nil)
 
 	if err != nil {
 		return err
 	}
 
-	if se.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
-
 	return err
 }
 
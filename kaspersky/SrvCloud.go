@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 )
 
 // SrvCloud service to acquire info about public clouds.
@@ -38,14 +35,7 @@ type SrvCloud service
 //
 // For main server also returns only clouds of main server.
 func (sc *SrvCloud) GetCloudsInfo(ctx context.Context, params Null) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SrvCloud.GetCloudsInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SrvCloud.GetCloudsInfo", params, nil)
 	return raw, err
 }
 
@@ -63,16 +53,6 @@ type CloudHostInfoParams struct {
 
 // GetCloudHostInfo Returns properties of the cloud host.
 func (sc *SrvCloud) GetCloudHostInfo(ctx context.Context, params CloudHostInfoParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SrvCloud.GetCloudHostInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SrvCloud.GetCloudHostInfo", params, nil)
 	return raw, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // SrvCloudStat Service for working with statistics of using clouds.
@@ -37,16 +33,7 @@ type SrvCloudStat service
 
 // CloudWizardStarted Notify that the Cloud Wizard has been started.
 func (scs *SrvCloudStat) CloudWizardStarted(ctx context.Context) error {
-	request, err := http.NewRequest("POST", scs.client.Server+"/api/v1.0/SrvCloudStat.CloudWizardStarted", nil)
-	if err != nil {
-		return err
-	}
-
-	raw, err := scs.client.Request(ctx, request, nil)
-
-	if scs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := scs.client.Call(ctx, "SrvCloudStat.CloudWizardStarted", nil, nil)
 
 	return err
 }
@@ -54,18 +41,9 @@ func (scs *SrvCloudStat) CloudWizardStarted(ctx context.Context) error {
 // CloudWizardCompleted Notify that the Cloud Wizard has been completed.
 // Method shouldn't be called if user cancelled the wizard.
 func (scs *SrvCloudStat) CloudWizardCompleted(ctx context.Context, bErrorHappen bool) error {
-	postData := []byte(fmt.Sprintf(`{"bErrorHappen": %v}`, bErrorHappen))
-	request, err := http.NewRequest("POST", scs.client.Server+"/api/v1.0/SrvCloudStat.CloudWizardCompleted",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	raw, err := scs.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"bErrorHappen": bErrorHappen}
 
-	if scs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := scs.client.Call(ctx, "SrvCloudStat.CloudWizardCompleted", postData, nil)
 
 	return err
 }
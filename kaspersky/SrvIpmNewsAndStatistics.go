@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"log"
-	"net/http"
 )
 
 // SrvIpmNewsAndStatistics In-product marketing support interface
@@ -55,39 +51,15 @@ type TrackingData struct {
 
 // GetTrackingData Gets TrackingData for specified content.
 func (sins *SrvIpmNewsAndStatistics) GetTrackingData(ctx context.Context, params Parameters) (*TrackingData, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", sins.client.Server+"/api/v1.0/SrvIpmNewsAndStatistics.GetTrackingData",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
-
 	trackingData := new(TrackingData)
-	raw, err := sins.client.Request(ctx, request, &trackingData)
-
-	if sins.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := sins.client.Call(ctx, "SrvIpmNewsAndStatistics.GetTrackingData", params, &trackingData)
 
 	return trackingData, err
 }
 
 // SendStatistics Send IPM statistics.
 func (sins *SrvIpmNewsAndStatistics) SendStatistics(ctx context.Context, params Parameters) error {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", sins.client.Server+"/api/v1.0/SrvIpmNewsAndStatistics.SendStatistics",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
-
-	raw, err := sins.client.Request(ctx, request, nil)
-
-	if sins.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := sins.client.Call(ctx, "SrvIpmNewsAndStatistics.SendStatistics", params, nil)
 
 	return err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // SrvRi "Remote installation" task results
@@ -39,21 +34,10 @@ type SrvRi service
 // ShouldForceReboot Check if the administrator has started a forced reboot of the host.
 // This method is only for Network Agents.
 func (sr *SrvRi) ShouldForceReboot(ctx context.Context, wstrHostID, wstrTaskID string) (*PxgValBool, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrHostID" : "%s", "wstrTaskID" : "%s"}`, wstrHostID, wstrTaskID))
-
-	request, err := http.NewRequest("POST", sr.client.Server+"/api/v1.0/SrvRi.ShouldForceReboot",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrHostID": wstrHostID, "wstrTaskID": wstrTaskID}
 
 	result := new(PxgValBool)
-	raw, err := sr.client.Request(ctx, request, &result)
-
-	if sr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := sr.client.Call(ctx, "SrvRi.ShouldForceReboot", postData, &result)
 
 	return result, err
 }
@@ -75,41 +59,16 @@ type RiTask struct {
 
 // SetRiTaskResults Send to server "Remote installation" task results. This method is only for Network Agents.
 func (sr *SrvRi) SetRiTaskResults(ctx context.Context, params RiTask) (*PropagationState, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sr.client.Server+"/api/v1.0/SrvRi.SetRiTaskResults",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sr.client.Request(ctx, request, nil)
-
-	if sr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := sr.client.Call(ctx, "SrvRi.SetRiTaskResults", params, nil)
 
 	return nil, err
 }
 
 func (sr *SrvRi) SetRebootConfirmedHosts(ctx context.Context, wstrHostID, wstrTaskID string) (*PxgValBool, error) {
-	postData := []byte(fmt.Sprintf(`{"strDomain": "%s", "nType" : "%s" }`, wstrHostID, wstrTaskID))
-
-	request, err := http.NewRequest("POST", sr.client.Server+"/api/v1.0/SrvRi.SetRebootConfirmedHosts",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strDomain": wstrHostID, "nType": wstrTaskID}
 
 	result := new(PxgValBool)
-	raw, err := sr.client.Request(ctx, request, &result)
-
-	if sr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := sr.client.Call(ctx, "SrvRi.SetRebootConfirmedHosts", postData, &result)
 
 	return result, err
 }
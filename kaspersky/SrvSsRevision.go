@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // SrvSsRevision service provide access to virtual server settings storage revisions.
@@ -37,46 +33,28 @@ type SrvSsRevision service
 
 // SsRevisionOpen Open specified version of virtual server settings storage.
 func (ssr *SrvSsRevision) SsRevisionOpen(ctx context.Context, nVServer, nRevision int64, szwType string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nVServer": %d, "nRevision": %d, "szwType": "%s" }`, nVServer, nRevision, szwType))
-	request, err := http.NewRequest("POST", ssr.client.Server+"/api/v1.0/SrvSsRevision.SsRevision_Open", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nVServer": nVServer, "nRevision": nRevision, "szwType": szwType}
 
-	raw, err := ssr.client.Request(ctx, request, nil)
+	raw, err := ssr.client.Call(ctx, "SrvSsRevision.SsRevision_Open", postData, nil)
 	return raw, err
 }
 
 // SsRevisionClose Close settings storage opened by SrvSsRevision.SsRevisionOpen
 func (ssr *SrvSsRevision) SsRevisionClose(ctx context.Context, szwType string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{
-		"szwType": "%s"
-	}`, szwType))
-	request, err := http.NewRequest("POST", ssr.client.Server+"/api/v1.0/SrvSsRevision.SsRevision_Close", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwType": szwType}
 
-	raw, err := ssr.client.Request(ctx, request, nil)
+	raw, err := ssr.client.Call(ctx, "SrvSsRevision.SsRevision_Close", postData, nil)
 	return raw, err
 }
 
 func (ssr *SrvSsRevision) SsRevisionGetNames(ctx context.Context, szwId, product, version string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"szwId": "%s", "product": ""%s", "version": "%s" }`, szwId, product, version))
-	request, err := http.NewRequest("POST", ssr.client.Server+"/api/v1.0/SrvSsRevision.SsRevisionGetNames", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"szwId": szwId, "product": product, "version": version}
 
-	raw, err := ssr.client.Request(ctx, request, nil)
+	raw, err := ssr.client.Call(ctx, "SrvSsRevision.SsRevisionGetNames", postData, nil)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if ssr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
-
 	return raw, err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // SrvView service to get plain-queries from SC-server.
@@ -88,18 +83,8 @@ type ESrvViewParams struct {
 // Session to the Administration Server has been closed.
 // ReleaseIterator has been called.
 func (sv *SrvView) ResetIterator(ctx context.Context, params *SrvViewParams) (*WstrIteratorID, []byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", sv.client.Server+"/api/v1.0/SrvView.ResetIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	srvViewIter := new(WstrIteratorID)
-	raw, err := sv.client.Request(ctx, request, &srvViewIter)
+	raw, err := sv.client.Call(ctx, "SrvView.ResetIterator", params, &srvViewIter)
 	return srvViewIter, raw, err
 }
 
@@ -107,26 +92,18 @@ func (sv *SrvView) ResetIterator(ctx context.Context, params *SrvViewParams) (*W
 //
 // Returns number of elements contained in the specified result-set.
 func (sv *SrvView) GetRecordCount(ctx context.Context, wstrIteratorId string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrIteratorId":"%s"}`, wstrIteratorId))
-	request, err := http.NewRequest("POST", sv.client.Server+"/api/v1.0/SrvView.GetRecordCount", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"wstrIteratorId": wstrIteratorId}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := sv.client.Request(ctx, request, &pxgValInt)
+	raw, err := sv.client.Call(ctx, "SrvView.GetRecordCount", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // ReleaseIterator Releases the specified result-set and frees associated memory
 func (sv *SrvView) ReleaseIterator(ctx context.Context, wstrIteratorId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrIteratorId":"%s"}`, wstrIteratorId))
-	request, err := http.NewRequest("POST", sv.client.Server+"/api/v1.0/SrvView.ReleaseIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrIteratorId": wstrIteratorId}
 
-	raw, err := sv.client.Request(ctx, request, nil)
+	raw, err := sv.client.Call(ctx, "SrvView.ReleaseIterator", postData, nil)
 	return raw, err
 }
 
@@ -141,16 +118,6 @@ type RecordRangeParams struct {
 //
 // Returns elements contained in the specified result-set in the diapason from position nStart to position nEnd.
 func (sv *SrvView) GetRecordRange(ctx context.Context, params *RecordRangeParams, out interface{}) ([]byte, error) {
-	postData, err := json.Marshal(&params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sv.client.Server+"/api/v1.0/SrvView.GetRecordRange", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sv.client.Request(ctx, request, &out)
+	raw, err := sv.client.Call(ctx, "SrvView.GetRecordRange", params, &out)
 	return raw, err
 }
@@ -0,0 +1,308 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// DefaultViewLifetime lifetime of srvview result-sets created by SrvView.Query.
+const DefaultViewLifetime = 10 * time.Minute
+
+// DefaultViewMaxIdle how long SrvViewIterator keeps the result-set alive while nobody reads it.
+const DefaultViewMaxIdle = 30 * time.Minute
+
+// ErrViewIdle the result-set was released because the iterator was not read for DefaultViewMaxIdle.
+var ErrViewIdle = errors.New("kaspersky: srvview result-set released after idle timeout")
+
+// viewMaxIdle is DefaultViewMaxIdle, tests shorten it.
+var viewMaxIdle = DefaultViewMaxIdle
+
+// SrvViewIterator streams records of a srvview result-set created by SrvView.Query.
+//
+// The next range of records is acquired in background while the current one is processed.
+// While the iterator waits for the caller, the result-set lifetime is extended, so long scans
+// do not lose the result-set. The result-set is released when the records are exhausted,
+// on error, on context cancellation, by Close or when the caller does not read the iterator
+// for DefaultViewMaxIdle, whatever happens first. In the last case Next fails with ErrViewIdle.
+type SrvViewIterator struct {
+	sv       *SrvView
+	id       string
+	count    int64
+	pageSize int64
+	lifetime time.Duration
+	maxIdle  time.Duration
+
+	// ctx is the context of Query with cancellation detached, to release the result-set.
+	ctx    context.Context
+	cancel context.CancelFunc
+	pages  chan srvViewPage
+
+	rows   []json.RawMessage
+	row    json.RawMessage
+	closed bool
+	err    error
+
+	// released is set by prefetch before closing pages, when it gave up waiting for the caller.
+	released bool
+}
+
+type srvViewPage struct {
+	rows []json.RawMessage
+	err  error
+}
+
+// recordRange result of SrvView.GetRecordRange with records left undecoded.
+type recordRange struct {
+	PRecords struct {
+		Rows []json.RawMessage `json:"KLCSP_ITERATOR_ARRAY"`
+	} `json:"pRecords"`
+}
+
+// OrderBy returns sort order by attribute name for vecFieldsToOrder.
+func OrderBy(name string, asc bool) FieldsToOrder {
+	return FieldsToOrder{Type: "params", OrderValue: OrderValue{Name: name, Asc: asc}}
+}
+
+// Query finds records of srvview view that satisfy filter and returns iterator over them,
+// see SrvView.ResetIterator. Records contain fields attributes ordered by order.
+//
+//	it, err := client.SrvView.Query(ctx, "HWInvStorageSrvViewName", `(Type = 4)`,
+//		[]string{"Id", "Name", "SerialNumber"}, []kaspersky.FieldsToOrder{kaspersky.OrderBy("Id", true)})
+//	if err != nil {
+//		...
+//	}
+//	defer it.Close()
+//	for it.Next(ctx) {
+//		var device struct {
+//			ID   int64  `json:"Id"`
+//			Name string `json:"Name"`
+//		}
+//		if err := it.Scan(&device); err != nil {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (sv *SrvView) Query(ctx context.Context, view, filter string, fields []string, order []FieldsToOrder) (*SrvViewIterator, error) {
+	return sv.QueryParams(ctx, &SrvViewParams{
+		WstrViewName:      view,
+		WstrFilter:        filter,
+		VecFieldsToReturn: fields,
+		VecFieldsToOrder:  order,
+	}, DefaultPageSize)
+}
+
+// QueryParams same as Query, with all of SrvView.ResetIterator parameters and pageSize records
+// acquired at once, DefaultPageSize if not positive. Zero LifetimeSEC means DefaultViewLifetime.
+func (sv *SrvView) QueryParams(ctx context.Context, params *SrvViewParams, pageSize int64) (*SrvViewIterator, error) {
+	p := *params
+	if p.LifetimeSEC <= 0 {
+		p.LifetimeSEC = DurationToSeconds(DefaultViewLifetime)
+	}
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	id, _, err := sv.ResetIterator(ctx, &p)
+	if err != nil {
+		return nil, err
+	}
+	if id.WstrIteratorID == "" {
+		return nil, errors.New("kaspersky: SrvView.ResetIterator returned empty iterator id")
+	}
+
+	it := &SrvViewIterator{
+		sv:       sv,
+		id:       id.WstrIteratorID,
+		pageSize: pageSize,
+		lifetime: SecondsToDuration(p.LifetimeSEC),
+		maxIdle:  viewMaxIdle,
+		ctx:      detach(ctx),
+		pages:    make(chan srvViewPage),
+	}
+
+	count, _, err := sv.GetRecordCount(ctx, it.id)
+	if err != nil {
+		it.closed = true
+		it.release()
+		return nil, err
+	}
+	it.count = count.Int
+
+	var prefetchCtx context.Context
+	prefetchCtx, it.cancel = context.WithCancel(it.ctx)
+	go it.prefetch(prefetchCtx)
+	return it, nil
+}
+
+// prefetch acquires record ranges one ahead of the caller, touching the result-set
+// while the caller is busy so it does not expire. If the caller does not take a range
+// for maxIdle, prefetch releases the result-set and exits.
+func (it *SrvViewIterator) prefetch(ctx context.Context) {
+	defer close(it.pages)
+
+	interval := it.lifetime / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+	touch := time.NewTicker(interval)
+	defer touch.Stop()
+
+	for start := int64(0); start < it.count; start += it.pageSize {
+		end := start + it.pageSize
+		if end > it.count {
+			end = it.count
+		}
+
+		records := new(recordRange)
+		_, err := it.sv.client.Call(ctx, "SrvView.GetRecordRange", &RecordRangeParams{
+			WstrIteratorID: it.id,
+			NStart:         start,
+			NEnd:           end,
+		}, records)
+		page := srvViewPage{rows: records.PRecords.Rows, err: err}
+
+		idle := time.NewTimer(it.maxIdle)
+		for sent := false; !sent; {
+			select {
+			case it.pages <- page:
+				sent = true
+			case <-touch.C:
+				// access to the result-set extends its lifetime, errors show up on the next range
+				_, _, _ = it.sv.GetRecordCount(ctx, it.id)
+			case <-idle.C:
+				_ = it.release()
+				it.released = true
+				return
+			case <-ctx.Done():
+				idle.Stop()
+				return
+			}
+		}
+		idle.Stop()
+		if err != nil || len(page.rows) == 0 {
+			return
+		}
+	}
+}
+
+// Next advances to the next record, it returns false when records are exhausted or on error,
+// see Err. The result-set is released at this point.
+func (it *SrvViewIterator) Next(ctx context.Context) bool {
+	if it.closed {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		return it.fail(err)
+	}
+
+	if len(it.rows) == 0 {
+		select {
+		case page, ok := <-it.pages:
+			if !ok && it.released {
+				return it.fail(ErrViewIdle)
+			}
+			if !ok || (page.err == nil && len(page.rows) == 0) {
+				it.Close()
+				return false
+			}
+			if page.err != nil {
+				return it.fail(page.err)
+			}
+			it.rows = page.rows
+		case <-ctx.Done():
+			return it.fail(ctx.Err())
+		}
+	}
+
+	it.row, it.rows = it.rows[0], it.rows[1:]
+	return true
+}
+
+// Scan decodes the current record into v, matching attributes with json tags of struct fields.
+func (it *SrvViewIterator) Scan(v interface{}) error {
+	if it.row == nil {
+		return errors.New("kaspersky: Scan called without Next")
+	}
+	return decodeRow(it.row, v)
+}
+
+// Row returns the current record as is.
+func (it *SrvViewIterator) Row() json.RawMessage {
+	return it.row
+}
+
+// Count returns number of records in the result-set.
+func (it *SrvViewIterator) Count() int64 {
+	return it.count
+}
+
+// Err returns the error stopped the iteration, if any.
+func (it *SrvViewIterator) Err() error {
+	return it.err
+}
+
+// All reads the rest of records into out, a pointer to slice, and releases the result-set.
+func (it *SrvViewIterator) All(ctx context.Context, out interface{}) error {
+	defer it.Close()
+	return collectRows(ctx, it, out)
+}
+
+// Close stops prefetching and releases the result-set. It is safe to call Close several times.
+func (it *SrvViewIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	it.rows, it.row = nil, nil
+
+	it.cancel()
+	for range it.pages {
+		// wait for prefetch to stop
+	}
+	if it.released {
+		return nil
+	}
+	return it.release()
+}
+
+func (it *SrvViewIterator) release() error {
+	ctx, cancel := context.WithTimeout(it.ctx, releaseTimeout)
+	defer cancel()
+
+	_, err := it.sv.ReleaseIterator(ctx, it.id)
+	return err
+}
+
+func (it *SrvViewIterator) fail(err error) bool {
+	it.err = err
+	it.Close()
+	return false
+}
@@ -0,0 +1,160 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// viewServer serves srvview result-set "it1" of n records with Id and Created.
+func viewServer(t *testing.T, n int) *fakeServer {
+	t.Helper()
+
+	srv := newFakeServer(t)
+	srv.reply("SrvView.ResetIterator", `{"wstrIteratorId":"it1"}`)
+	srv.resultSet(t, fakeResultSet{
+		CountMethod: "SrvView.GetRecordCount",
+		RangeMethod: "SrvView.GetRecordRange",
+		Array:       "pRecords.KLCSP_ITERATOR_ARRAY",
+		Rows: fakeRows(n, func(i int) Params {
+			return Params{"Id": i, "Created": time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)}
+		}),
+	})
+	return srv
+}
+
+func TestSrvViewIteratorAll(t *testing.T) {
+	srv := viewServer(t, 7)
+	c := srv.client(Config{})
+	ctx := context.Background()
+
+	it, err := c.SrvView.QueryParams(ctx, &SrvViewParams{WstrViewName: "v"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var devices []struct {
+		ID      int64     `json:"Id"`
+		Created time.Time `json:"Created"`
+	}
+	if err := it.All(ctx, &devices); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(devices) != 7 || devices[6].ID != 6 || devices[6].Created.Year() != 2021 {
+		t.Errorf("devices = %+v", devices)
+	}
+	var ranges []string
+	for _, r := range srv.received("SrvView.GetRecordRange") {
+		var in RecordRangeParams
+		_ = json.Unmarshal(r.Body, &in)
+		ranges = append(ranges, fmt.Sprintf("%d-%d", in.NStart, in.NEnd))
+	}
+	if got := strings.Join(ranges, ","); got != "0-3,3-6,6-7" {
+		t.Errorf("ranges = %s, want 0-3,3-6,6-7", got)
+	}
+	var reset SrvViewParams
+	_ = json.Unmarshal(srv.received("SrvView.ResetIterator")[0].Body, &reset)
+	if reset.LifetimeSEC != DurationToSeconds(DefaultViewLifetime) {
+		t.Errorf("lifetimeSec = %d, want default", reset.LifetimeSEC)
+	}
+	if n := len(srv.received("SrvView.ReleaseIterator")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestSrvViewIteratorCanceled(t *testing.T) {
+	srv := viewServer(t, 7)
+	c := srv.client(Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it, err := c.SrvView.Query(ctx, "v", "", []string{"Id"}, []FieldsToOrder{OrderBy("Id", true)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next(ctx) {
+		t.Fatal(it.Err())
+	}
+	cancel()
+
+	if it.Next(ctx) || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Next() after cancel, Err() = %v, want context.Canceled", it.Err())
+	}
+	if err := it.Close(); err != nil {
+		t.Error(err)
+	}
+	if n := len(srv.received("SrvView.ReleaseIterator")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestSrvViewIteratorIdle(t *testing.T) {
+	viewMaxIdle = 50 * time.Millisecond
+	defer func() { viewMaxIdle = DefaultViewMaxIdle }()
+
+	srv := viewServer(t, 7)
+	c := srv.client(Config{})
+	ctx := context.Background()
+
+	it, err := c.SrvView.QueryParams(ctx, &SrvViewParams{WstrViewName: "v"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the caller abandons the iterator, prefetch gives up and releases the result-set
+	for deadline := time.Now().Add(2 * time.Second); len(srv.received("SrvView.ReleaseIterator")) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("result-set is not released after idle timeout")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if it.Next(ctx) || !errors.Is(it.Err(), ErrViewIdle) {
+		t.Errorf("Next() after idle timeout, Err() = %v, want ErrViewIdle", it.Err())
+	}
+	if err := it.Close(); err != nil {
+		t.Error(err)
+	}
+	if n := len(srv.received("SrvView.ReleaseIterator")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
+
+func TestSrvViewIteratorCountError(t *testing.T) {
+	srv := viewServer(t, 7)
+	srv.reply("SrvView.GetRecordCount", `{"PxgError":{"code":1183,"module":"KLSTD","message":"Object not found"}}`)
+	c := srv.client(Config{})
+
+	if _, err := c.SrvView.Query(context.Background(), "v", "", nil, nil); !errors.Is(err, ErrObjectNotFound) {
+		t.Errorf("Query() error = %v, want ErrObjectNotFound", err)
+	}
+	if n := len(srv.received("SrvView.ReleaseIterator")); n != 1 {
+		t.Errorf("result-set released %d times, want 1", n)
+	}
+}
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // SsContents service provide access to settings storage.
@@ -60,29 +55,15 @@ type SsContent struct {
 // SsAdd Add new data to settings storage. Adds new variables to the specified section.
 //	Changes are not saved until method SsApply is called. Unsaved data is not available by methods SsRead and SSGetNames.
 func (sc *SsContents) SsAdd(ctx context.Context, params SsContent) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Add", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Add", params, nil)
 	return raw, err
 }
 
 // SsApply	Saves changes made by methods: SsUpdate, SsAdd, SsReplace, SsClear, SsDelete, SsCreateSection, SsDeleteSection
 func (sc *SsContents) SsApply(ctx context.Context, wstrID string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`	{ "wstrID": "%s"}`, wstrID))
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Apply", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrID": wstrID}
 
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Apply", postData, nil)
 	return raw, err
 }
 
@@ -91,17 +72,7 @@ func (sc *SsContents) SsApply(ctx context.Context, wstrID string) ([]byte, error
 //	from pData will be written to the section.
 //	Changes are not saved until method SsApply is called. Unsaved data is not available by methods SsRead and SSGetNames.
 func (sc *SsContents) SsClear(ctx context.Context, params SsContent) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Clear", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Clear", params, nil)
 	return raw, err
 }
 
@@ -109,17 +80,7 @@ func (sc *SsContents) SsClear(ctx context.Context, params SsContent) ([]byte, er
 //	Creates empty section in settings storage
 //	Changes are not saved until method SsApply is called. Unsaved data is not available by methods SsRead and SSGetNames.
 func (sc *SsContents) SsCreateSection(ctx context.Context, params SsContentD) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_CreateSection", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_CreateSection", params, nil)
 	return raw, err
 }
 
@@ -148,17 +109,7 @@ type SsContentD struct {
 //	Deletes variables specified in pData from the specified section.
 //	Changes are not saved until method SsApply is called. Unsaved data is not available by methods SsRead and SSGetNames.
 func (sc *SsContents) SsDelete(ctx context.Context, params SsContentD) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Delete", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Delete", params, nil)
 	return raw, err
 }
 
@@ -167,63 +118,30 @@ func (sc *SsContents) SsDelete(ctx context.Context, params SsContentD) ([]byte,
 //	Changes are not saved until method SsApply is called.
 //	Unsaved data is not available by methods SsRead and SSGetNames.
 func (sc *SsContents) SsDeleteSection(ctx context.Context, params SsContentD) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_DeleteSection", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_DeleteSection", params, nil)
 	return raw, err
 }
 
 // SSGetNames Enumerate contents of settings storage
 //	Retrieves list of sections.
 func (sc *SsContents) SSGetNames(ctx context.Context, params SsContentD) (*PxgValArrayOfString, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.SS_GetNames", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValArrayOfString := new(PxgValArrayOfString)
-	raw, err := sc.client.Request(ctx, request, &pxgValArrayOfString)
+	raw, err := sc.client.Call(ctx, "SsContents.SS_GetNames", params, &pxgValArrayOfString)
 	return pxgValArrayOfString, raw, err
 }
 
 // SsRead Reads saved data from the specified section of settings storage.
 func (sc *SsContents) SsRead(ctx context.Context, params SsContentD, v interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Read", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, &v)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Read", params, &v)
 	return raw, err
 }
 
 // SsRelease Closes opened SsContents and releases associated server resources.
 //	After calling this method wstrID is not longer valid.
 func (sc *SsContents) SsRelease(ctx context.Context, wstrID string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrID": "%s"}`, wstrID))
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Release", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrID": wstrID}
 
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Release", postData, nil)
 	return raw, err
 }
 
@@ -231,33 +149,13 @@ func (sc *SsContents) SsRelease(ctx context.Context, wstrID string) ([]byte, err
 //	If a variable already exists it will be updated, if a variable does not exist it will be added.
 //	Changes are not saved until method Ss_Apply is called. Unsaved data is not available by methods SsRead and SSGetNames.
 func (sc *SsContents) SsReplace(ctx context.Context, params SsContent) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Replace", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Replace", params, nil)
 	return raw, err
 }
 
 // SsUpdate Updates existing variables in the specified section.
 //	Changes are not saved until method SsApply is called. Unsaved data is not available by methods SsRead and SSGetNames.
 func (sc *SsContents) SsUpdate(ctx context.Context, params SsContent) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sc.client.Server+"/api/v1.0/SsContents.Ss_Update", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sc.client.Request(ctx, request, nil)
+	raw, err := sc.client.Call(ctx, "SsContents.Ss_Update", params, nil)
 	return raw, err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // SsRevisionGetNames Product backend integration.
@@ -54,21 +49,7 @@ type IntegrationToken struct {
 
 // SetIntegrationToken Set up integration with product backend.
 func (srn *SsRevisionGetNames) SetIntegrationToken(ctx context.Context, params IntegrationToken) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", srn.client.Server+"/api/v1.0/SsRevisionGetNames.SetIntegrationToken", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := srn.client.Request(ctx, request, nil)
-
-	if srn.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	raw, err := srn.client.Call(ctx, "SsRevisionGetNames.SetIntegrationToken", params, nil)
 
 	return raw, err
 }
@@ -77,19 +58,9 @@ func (srn *SsRevisionGetNames) SetIntegrationToken(ctx context.Context, params I
 //
 // Before removing integration KSC server calls backend command to delete all user tokens issued within this integration.
 func (srn *SsRevisionGetNames) DeleteIntegrationToken(ctx context.Context, wstrProdName, wstrProdVersion string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrProdName": "%s", "wstrProdVersion": "%s"}`, wstrProdName, wstrProdVersion))
-	request, err := http.NewRequest("POST", srn.client.Server+"/api/v1.0/SsRevisionGetNames.DeleteIntegrationToken",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
-
-	raw, err := srn.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"wstrProdName": wstrProdName, "wstrProdVersion": wstrProdVersion}
 
-	if srn.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := srn.client.Call(ctx, "SsRevisionGetNames.DeleteIntegrationToken", postData, nil)
 
 	return err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // SubnetMasks Subnets provider.
@@ -79,45 +74,20 @@ type PSubnetUpdateSettingsClass struct {
 
 // CreateSubnet for current server with specific parameters
 func (sm *SubnetMasks) CreateSubnet(ctx context.Context, params PSubnetSettings) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sm.client.Server+"/api/v1.0/SubnetMasks.CreateSubnet", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sm.client.Request(ctx, request, nil)
+	raw, err := sm.client.Call(ctx, "SubnetMasks.CreateSubnet", params, nil)
 	return raw, err
 }
 
 // DeleteSubnet Remove existing subnet
 func (sm *SubnetMasks) DeleteSubnet(ctx context.Context, nIpAddress, nMask int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nIpAddress" : %d, "nMask" : %d}`, nIpAddress, nMask))
-
-	request, err := http.NewRequest("POST", sm.client.Server+"/api/v1.0/SubnetMasks.DeleteSubnet", bytes.NewBuffer(postData))
-	if err != nil {
-		log.Fatal(err.Error())
-	}
+	postData := map[string]interface{}{"nIpAddress": nIpAddress, "nMask": nMask}
 
-	raw, err := sm.client.Request(ctx, request, nil)
+	raw, err := sm.client.Call(ctx, "SubnetMasks.DeleteSubnet", postData, nil)
 	return raw, err
 }
 
 // ModifySubnet Modify existing subnet parameters
 func (sm *SubnetMasks) ModifySubnet(ctx context.Context, params PSubnetUpdateSettings) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", sm.client.Server+"/api/v1.0/SubnetMasks.ModifySubnet", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := sm.client.Request(ctx, request, nil)
+	raw, err := sm.client.Call(ctx, "SubnetMasks.ModifySubnet", params, nil)
 	return raw, err
 }
@@ -0,0 +1,180 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TaskState state of task on host, bit of nHostStateMask of Tasks.ResetHostIteratorForTaskStatusEx.
+// The same bits key the host counters of TaskStatistic.
+type TaskState int64
+
+const (
+	TaskStatePending              TaskState = 0x01
+	TaskStateRunning              TaskState = 0x02
+	TaskStateCompleted            TaskState = 0x04
+	TaskStateCompletedWithWarning TaskState = 0x08
+	TaskStateFailed               TaskState = 0x10
+	TaskStateScheduled            TaskState = 0x20
+	TaskStatePaused               TaskState = 0x40
+
+	// TaskStateAll mask of all task states
+	TaskStateAll = TaskStatePending | TaskStateRunning | TaskStateCompleted | TaskStateCompletedWithWarning |
+		TaskStateFailed | TaskStateScheduled | TaskStatePaused
+)
+
+var taskStateNames = []struct {
+	state TaskState
+	name  string
+}{
+	{TaskStatePending, "Pending"},
+	{TaskStateRunning, "Running"},
+	{TaskStateCompleted, "Completed"},
+	{TaskStateCompletedWithWarning, "CompletedWithWarning"},
+	{TaskStateFailed, "Failed"},
+	{TaskStateScheduled, "Scheduled"},
+	{TaskStatePaused, "Paused"},
+}
+
+// Has reports whether any of the states is set.
+func (s TaskState) Has(states TaskState) bool {
+	return s&states != 0
+}
+
+// IsFinal reports whether the task is completed or failed.
+func (s TaskState) IsFinal() bool {
+	return s.Has(TaskStateCompleted | TaskStateCompletedWithWarning | TaskStateFailed)
+}
+
+// String returns state name or set states joined by "|" for masks.
+func (s TaskState) String() string {
+	var names []string
+	rest := s
+	for _, n := range taskStateNames {
+		if s&n.state != 0 {
+			names = append(names, n.name)
+			rest &^= n.state
+		}
+	}
+	if rest != 0 {
+		names = append(names, fmt.Sprintf("0x%x", int64(rest)))
+	}
+	if len(names) == 0 {
+		return "0"
+	}
+	return strings.Join(names, "|")
+}
+
+// TaskHostStatus state of task on host, record of Tasks.GetHostStatusRecordRange.
+//
+// The record has only the documented host task state attributes. The state itself isn't one of them,
+// query hosts in a single state with the mask of Tasks.HostStatuses to know it.
+type TaskHostStatus struct {
+	// Hostname host name, unique host identifier
+	Hostname string `json:"hostname,omitempty"`
+
+	// HostDN host display name
+	HostDN string `json:"hostdn,omitempty"`
+
+	// Description state description, error text for failed task
+	Description string `json:"state_descr,omitempty"`
+}
+
+// TaskHostStatusIterator iterates over task states on hosts page by page. The host status iterator
+// is released when records are exhausted, on error, on context cancellation or by Close, whatever happens first.
+type TaskHostStatusIterator struct {
+	pager
+}
+
+// hostStatusRange result of Tasks.GetHostStatusRecordRange with records left undecoded.
+type hostStatusRange struct {
+	PParHostStatus struct {
+		Rows []json.RawMessage `json:"statuses"`
+	} `json:"pParHostStatus"`
+}
+
+// HostStatuses returns iterator over states of the task strTask on hosts which state is in mask,
+// e.g. to find hosts the task failed on:
+//
+//	it, err := client.Tasks.HostStatuses(ctx, "195", kaspersky.TaskStateFailed)
+//	if err != nil {
+//		...
+//	}
+//	var failed []kaspersky.TaskHostStatus
+//	err = it.All(ctx, &failed)
+func (ts *Tasks) HostStatuses(ctx context.Context, strTask string, mask TaskState) (*TaskHostStatusIterator, error) {
+	if mask == 0 {
+		mask = TaskStateAll
+	}
+
+	id, _, err := ts.ResetHostIteratorForTaskStatusEx(ctx, HostIteratorForTaskParamsEx{
+		StrTask:        strTask,
+		NHostStateMask: int64(mask),
+		PFields2Return: FieldsOf(TaskHostStatus{}),
+		PFields2Order:  []FieldsToOrder{OrderBy("hostname", true)},
+		NLifetime:      DurationToSeconds(DefaultViewLifetime),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if id.StrHostIteratorId == "" {
+		return nil, errors.New("kaspersky: Tasks.ResetHostIteratorForTaskStatusEx returned empty iterator id")
+	}
+
+	iteratorID := id.StrHostIteratorId
+	return &TaskHostStatusIterator{pager{
+		ctx: detach(ctx),
+		count: func(ctx context.Context) (int64, error) {
+			count, _, err := ts.GetHostStatusRecordsCount(ctx, iteratorID)
+			if err != nil {
+				return 0, err
+			}
+			return count.Int, nil
+		},
+		fetch: func(ctx context.Context, start, n int64) ([]json.RawMessage, error) {
+			// nEnd is exclusive, nStart 0 and nEnd count return the whole result-set
+			statuses := new(hostStatusRange)
+			postData := map[string]interface{}{"strHostIteratorId": iteratorID, "nStart": start, "nEnd": start + n}
+			_, err := ts.client.Call(ctx, "Tasks.GetHostStatusRecordRange", postData, statuses)
+			return statuses.PParHostStatus.Rows, err
+		},
+		release: func(ctx context.Context) error {
+			_, err := ts.ReleaseHostStatusIterator(ctx, iteratorID)
+			return err
+		},
+	}}, nil
+}
+
+// Status decodes the current record.
+func (it *TaskHostStatusIterator) Status() (TaskHostStatus, error) {
+	var s TaskHostStatus
+	err := it.Scan(&s)
+	return s, err
+}
@@ -0,0 +1,130 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestTaskState(t *testing.T) {
+	tests := []struct {
+		state TaskState
+		name  string
+		final bool
+	}{
+		{0, "0", false},
+		{TaskStateRunning, "Running", false},
+		{TaskStateFailed, "Failed", true},
+		{TaskStateCompleted | TaskStatePaused, "Completed|Paused", true},
+		{TaskStateScheduled | 0x100, "Scheduled|0x100", false},
+	}
+	for _, tt := range tests {
+		if s := tt.state.String(); s != tt.name {
+			t.Errorf("TaskState(%#x).String() = %q, want %q", int64(tt.state), s, tt.name)
+		}
+		if f := tt.state.IsFinal(); f != tt.final {
+			t.Errorf("TaskState(%#x).IsFinal() = %v, want %v", int64(tt.state), f, tt.final)
+		}
+	}
+	if TaskStateAll != 0x7f {
+		t.Errorf("TaskStateAll = %#x, want 0x7f", int64(TaskStateAll))
+	}
+}
+
+func TestTaskHostStatuses(t *testing.T) {
+	const total = 5
+	srv := newFakeServer(t)
+	srv.reply("Tasks.ResetHostIteratorForTaskStatusEx", `{"strHostIteratorId":"hs1"}`)
+	srv.resultSet(t, fakeResultSet{
+		CountMethod: "Tasks.GetHostStatusRecordsCount",
+		RangeMethod: "Tasks.GetHostStatusRecordRange",
+		Array:       "pParHostStatus.statuses",
+		Rows: fakeRows(total, func(i int) Params {
+			// the record of Tasks.GetHostStatusRecordRange example with state_descr requested
+			return Params{"hostname": fmt.Sprintf("53bf5bda-d728-4888-b002-67e63b6e4c6%d", i),
+				"hostdn": fmt.Sprintf("Host %d", i), "state_descr": "no space"}
+		}),
+	})
+	c := srv.client(Config{})
+	ctx := context.Background()
+
+	it, err := c.Tasks.HostStatuses(ctx, "195", TaskStateFailed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next(ctx) {
+		t.Fatal(it.Err())
+	}
+	s, err := it.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := TaskHostStatus{Hostname: "53bf5bda-d728-4888-b002-67e63b6e4c60", HostDN: "Host 0", Description: "no space"}
+	if s != want {
+		t.Errorf("Status() = %+v", s)
+	}
+	var rest []TaskHostStatus
+	if err := it.All(ctx, &rest); err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != total-1 || rest[total-2].HostDN != "Host 4" {
+		t.Errorf("All() = %+v, want the rest %d records", rest, total-1)
+	}
+
+	var rng struct {
+		ID string `json:"strHostIteratorId"`
+	}
+	_ = json.Unmarshal(srv.received("Tasks.GetHostStatusRecordRange")[0].Body, &rng)
+	if rng.ID != "hs1" {
+		t.Errorf("GetHostStatusRecordRange(%q), want hs1", rng.ID)
+	}
+
+	var in HostIteratorForTaskParamsEx
+	_ = json.Unmarshal(srv.received("Tasks.ResetHostIteratorForTaskStatusEx")[0].Body, &in)
+	if in.StrTask != "195" || in.NHostStateMask != int64(TaskStateFailed) || len(in.PFields2Return) != 3 ||
+		in.NLifetime != DurationToSeconds(DefaultViewLifetime) {
+		t.Errorf("ResetHostIteratorForTaskStatusEx(%+v)", in)
+	}
+	if n := len(srv.received("Tasks.ReleaseHostStatusIterator")); n != 1 {
+		t.Errorf("iterator released %d times, want 1", n)
+	}
+
+	// zero mask means all states
+	if _, err := c.Tasks.HostStatuses(ctx, "195", 0); err != nil {
+		t.Fatal(err)
+	}
+	_ = json.Unmarshal(srv.received("Tasks.ResetHostIteratorForTaskStatusEx")[1].Body, &in)
+	if in.NHostStateMask != int64(TaskStateAll) {
+		t.Errorf("nHostStateMask = %#x, want TaskStateAll", in.NHostStateMask)
+	}
+
+	srv.reply("Tasks.ResetHostIteratorForTaskStatusEx", `{}`)
+	if _, err := c.Tasks.HostStatuses(ctx, "195", 0); err == nil {
+		t.Error("HostStatuses() with empty iterator id succeeded")
+	}
+}
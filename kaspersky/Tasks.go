@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // Tasks Group tasks service allows to acquire task attributes, enumerate, control and delete tasks.
@@ -39,14 +34,10 @@ type Tasks service
 // GetAllTasksOfHost Get all group and global tasks of specified host.
 func (ts *Tasks) GetAllTasksOfHost(ctx context.Context, strDomainName, strHostName string) (*PxgValArrayOfString,
 	[]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strDomainName": "%s","strHostName": "%s"}`, strDomainName, strHostName))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetAllTasksOfHost", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strDomainName": strDomainName, "strHostName": strHostName}
 
 	pxgValArrayOfString := new(PxgValArrayOfString)
-	raw, err := ts.client.Request(ctx, request, &pxgValArrayOfString)
+	raw, err := ts.client.Call(ctx, "Tasks.GetAllTasksOfHost", postData, &pxgValArrayOfString)
 	return pxgValArrayOfString, raw, err
 }
 
@@ -73,42 +64,27 @@ type PrtsTaskCreationDate struct {
 
 // GetTask Acquire attributes of specified task.
 func (ts *Tasks) GetTask(ctx context.Context, strTask string) (*TaskData, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
 	taskData := new(TaskData)
-	raw, err := ts.client.Request(ctx, request, &taskData)
+	raw, err := ts.client.Call(ctx, "Tasks.GetTask", postData, &taskData)
 	return taskData, raw, err
 }
 
 // GetTaskData Acquire task settings.
 func (ts *Tasks) GetTaskData(ctx context.Context, strTask string, tsk interface{}) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`
-	{
-	"strTask": "%s"
-	}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetTaskData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
-	raw, err := ts.client.Request(ctx, request, &tsk)
+	raw, err := ts.client.Call(ctx, "Tasks.GetTaskData", postData, &tsk)
 	return raw, err
 }
 
 // GetTaskGroup Return the group id for the group task.
 func (ts *Tasks) GetTaskGroup(ctx context.Context, strTaskId string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTaskId": "%s"}`, strTaskId))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetTaskGroup", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strTaskId": strTaskId}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := ts.client.Request(ctx, request, &pxgValInt)
+	raw, err := ts.client.Call(ctx, "Tasks.GetTaskGroup", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -132,74 +108,50 @@ type TaskStatistic struct {
 
 // GetTaskStatistics Acquire statistics of the specified task.
 func (ts *Tasks) GetTaskStatistics(ctx context.Context, strTask string) (*TaskStatistics, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetTaskStatistics", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
 	taskStatistics := new(TaskStatistics)
-	raw, err := ts.client.Request(ctx, request, &taskStatistics)
+	raw, err := ts.client.Call(ctx, "Tasks.GetTaskStatistics", postData, &taskStatistics)
 	return taskStatistics, raw, err
 }
 
 // SuspendTask Suspend execution of the specified task.
 func (ts *Tasks) SuspendTask(ctx context.Context, strTask string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.SuspendTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.SuspendTask", postData, nil)
 	return raw, err
 }
 
 // ResumeTask Resumes specified task
 func (ts *Tasks) ResumeTask(ctx context.Context, strTask string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ResumeTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.ResumeTask", postData, nil)
 	return raw, err
 }
 
 // RunTask Start specified task.
 func (ts *Tasks) RunTask(ctx context.Context, strTask string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.RunTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.RunTask", postData, nil)
 	return raw, err
 }
 
 // DeleteTask Deletes the specified task.
 func (ts *Tasks) DeleteTask(ctx context.Context, strTask string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.DeleteTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.DeleteTask", postData, nil)
 	return raw, err
 }
 
 // CancelTask Cancels execution of the specified task.
 func (ts *Tasks) CancelTask(ctx context.Context, strTask string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.CancelTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.CancelTask", postData, nil)
 	return raw, err
 }
 
@@ -237,42 +189,24 @@ type TaskHistoryParams struct {
 //		PFilter:        nil,
 //	})
 func (ts *Tasks) GetTaskHistory(ctx context.Context, params interface{}) (*StrIteratorId, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetTaskHistory", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	strIteratorID := new(StrIteratorId)
-	raw, err := ts.client.Request(ctx, request, &strIteratorID)
+	raw, err := ts.client.Call(ctx, "Tasks.GetTaskHistory", params, &strIteratorID)
 	return strIteratorID, raw, err
 }
 
 // GetTaskStartEvent Returns event which should run the task.
 func (ts *Tasks) GetTaskStartEvent(ctx context.Context, strTask string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTask": "%s"}`, strTask))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetTaskStartEvent", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTask": strTask}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.GetTaskStartEvent", postData, nil)
 	return raw, err
 }
 
 // ProtectPassword Encrypt an account password.
 func (ts *Tasks) ProtectPassword(ctx context.Context, strPassword string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strPassword": "%s"}`, strPassword))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ProtectPassword", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strPassword": strPassword}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.ProtectPassword", postData, nil)
 	return raw, err
 }
 
@@ -295,37 +229,23 @@ type TasksIteratorParams struct {
 // The group Super is parent of the group Groups and is intended for assignment of group tasks and policies received
 // from the master server.
 func (ts *Tasks) ResetTasksIterator(ctx context.Context, params TasksIteratorParams) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ResetTasksIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.ResetTasksIterator", params, nil)
 	return raw, err
 }
 
 // ReleaseTasksIterator Release task iterator.
 func (ts *Tasks) ReleaseTasksIterator(ctx context.Context, strTaskIteratorId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTaskIteratorId": "%s"}`, strTaskIteratorId))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ReleaseTasksIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTaskIteratorId": strTaskIteratorId}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.ReleaseTasksIterator", postData, nil)
 	return raw, err
 }
 
 // ReleaseHostStatusIterator Releases iterator of specified data and frees associated memory
 func (ts *Tasks) ReleaseHostStatusIterator(ctx context.Context, strHostIteratorId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strHostIteratorId": "%s"}`, strHostIteratorId))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ReleaseHostStatusIterator", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strHostIteratorId": strHostIteratorId}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.ReleaseHostStatusIterator", postData, nil)
 	return raw, err
 }
 
@@ -334,23 +254,14 @@ type HostIteratorForTaskParams struct {
 	StrTask        string   `json:"strTask"`
 	NHostStateMask string   `json:"nHostStateMask"`
 	PFields2Return []string `json:"pFields2Return"`
-	NLifetime      int64    `json:"nLifetime"`
+	// NLifetime result-set lifetime in seconds, see DurationToSeconds
+	NLifetime int64 `json:"nLifetime"`
 }
 
 // ResetHostIteratorForTaskStatus Make host task states request.
 // Makes request of the status of group task that runs on many machines
 func (ts *Tasks) ResetHostIteratorForTaskStatus(ctx context.Context, params HostIteratorForTaskParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ResetHostIteratorForTaskStatus", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.ResetHostIteratorForTaskStatus", params, nil)
 	return raw, err
 }
 
@@ -360,7 +271,8 @@ type HostIteratorForTaskParamsEx struct {
 	NHostStateMask int64           `json:"nHostStateMask"`
 	PFields2Return []string        `json:"pFields2Return"`
 	PFields2Order  []FieldsToOrder `json:"pFields2Order"`
-	NLifetime      int64           `json:"nLifetime"`
+	// NLifetime result-set lifetime in seconds, see DurationToSeconds
+	NLifetime int64 `json:"nLifetime"`
 }
 
 // ResetHostIteratorForTaskStatusEx Make host task states request.
@@ -381,27 +293,17 @@ type HostIteratorForTaskParamsEx struct {
 //		NLifetime:      100,
 //	})
 func (ts *Tasks) ResetHostIteratorForTaskStatusEx(ctx context.Context, params HostIteratorForTaskParamsEx) (*StrHostIteratorId, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ResetHostIteratorForTaskStatusEx", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	strHostIteratorId := new(StrHostIteratorId)
-	raw, err := ts.client.Request(ctx, request, &strHostIteratorId)
+	raw, err := ts.client.Call(ctx, "Tasks.ResetHostIteratorForTaskStatusEx", params, &strHostIteratorId)
 	return strHostIteratorId, raw, err
 }
 
 // GetHostStatusRecordsCount Get records count of result of operation: ResetHostIteratorForTaskStatus or ResetHostIteratorForTaskStatusEx.
 func (ts *Tasks) GetHostStatusRecordsCount(ctx context.Context, strHostIteratorId string) (*PxgValInt, []byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strHostIteratorId": "%s"}`, strHostIteratorId))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetHostStatusRecordsCount", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"strHostIteratorId": strHostIteratorId}
 
 	pxgValInt := new(PxgValInt)
-	raw, err := ts.client.Request(ctx, request, &pxgValInt)
+	raw, err := ts.client.Call(ctx, "Tasks.GetHostStatusRecordsCount", postData, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
@@ -411,7 +313,7 @@ func (ts *Tasks) GetHostStatusRecordsCount(ctx context.Context, strHostIteratorI
 //	- strHostIteratorId	(string) iterator id which got from
 //	Tasks.ResetHostIteratorForTaskStatus or Tasks.ResetHostIteratorForTaskStatusEx
 //	- nStart	(int) zero-based start position.
-//	- nEnd	(int) zero-based finish position.
+//	- nEnd	(int) zero-based finish position, exclusive.
 //
 //	Returns:
 //	- (int64) actual number of elements contained in the record set
@@ -434,62 +336,43 @@ func (ts *Tasks) GetHostStatusRecordsCount(ctx context.Context, strHostIteratorI
 //  "PxgRetVal" : 1
 //}
 func (ts *Tasks) GetHostStatusRecordRange(ctx context.Context, strHostIteratorId string, nStart, nEnd int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strHostIteratorId": "%s", "nStart": %d, "nEnd" : %d}`, strHostIteratorId,
-		nStart, nEnd))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetHostStatusRecordRange", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"strHostIteratorId": strHostIteratorId,
+		"nStart":            nStart,
+		"nEnd":              nEnd,
 	}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.GetHostStatusRecordRange", postData, nil)
 	return raw, err
 }
 
 // ResolveTaskId Get task id by PRTS task id.
 func (ts *Tasks) ResolveTaskId(ctx context.Context, strPrtsTaskId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strPrtsTaskId": "%s"}`, strPrtsTaskId))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.ResolveTaskId", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strPrtsTaskId": strPrtsTaskId}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.ResolveTaskId", postData, nil)
 	return raw, err
 }
 
 // GetNextTask Sequentially get task data. Gets result of operation Tasks.ResetTasksIterator
 func (ts *Tasks) GetNextTask(ctx context.Context, strTaskIteratorId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTaskIteratorId": "%s"}`, strTaskIteratorId))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetNextTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTaskIteratorId": strTaskIteratorId}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.GetNextTask", postData, nil)
 	return raw, err
 }
 
 // GetNextHostStatus Sequentially gets result of operation Tasks.ResetHostIteratorForTaskStatus or Tasks.ResetHostIteratorForTaskStatusEx
 func (ts *Tasks) GetNextHostStatus(ctx context.Context, strTaskIteratorId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strTaskIteratorId": "%s"}`, strTaskIteratorId))
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.GetNextHostStatus", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strTaskIteratorId": strTaskIteratorId}
 
-	raw, err := ts.client.Request(ctx, request, nil)
+	raw, err := ts.client.Call(ctx, "Tasks.GetNextHostStatus", postData, nil)
 	return raw, err
 }
 
 // AddTask Creates new task.
 func (ts *Tasks) AddTask(ctx context.Context, params interface{}) (*PxgValInt, []byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", ts.client.Server+"/api/v1.0/Tasks.AddTask", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := ts.client.Request(ctx, request, &pxgValInt)
+	raw, err := ts.client.Call(ctx, "Tasks.AddTask", params, &pxgValInt)
 	return pxgValInt, raw, err
 }
@@ -0,0 +1,148 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Tracer starts a span around every OpenAPI call made by KscClient.Request.
+//
+// The package doesn't depend on any telemetry SDK, adapters (e.g. for OpenTelemetry) implement this interface.
+type Tracer interface {
+	// Start starts a span named after the OpenAPI method, e.g. "HostGroup.FindHosts".
+	// The returned context is used for the HTTP request, so it may carry the span to RoundTripper middlewares.
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span single traced OpenAPI call.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute key/value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span attribute keys set by KscClient.
+const (
+	AttrMethod      = "ksc.method"
+	AttrServer      = "ksc.server"
+	AttrVServer     = "ksc.vserver"
+	AttrError       = "error"
+	AttrErrorKind   = "ksc.error.kind"
+	AttrErrorCode   = "ksc.error.code"
+	AttrErrorModule = "ksc.error.module"
+	AttrHTTPStatus  = "http.status_code"
+)
+
+// Metrics records per method metrics of OpenAPI calls. Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveLatency records the duration of the call including retries and re-authentication.
+	ObserveLatency(method string, d time.Duration)
+
+	// IncErrors increments errors counter of the method. kind is one of the ErrorKind* values.
+	IncErrors(method string, kind string)
+}
+
+// Error kinds passed to Metrics.IncErrors and set as AttrErrorKind span attribute.
+const (
+	ErrorKindAccessDenied    = "access_denied"
+	ErrorKindObjectNotFound  = "object_not_found"
+	ErrorKindSessionExpired  = "session_expired"
+	ErrorKindInvalidArgument = "invalid_argument"
+	ErrorKindServer          = "server"
+	ErrorKindCanceled        = "canceled"
+	ErrorKindTransport       = "transport"
+)
+
+// errorKind classifies err for metrics and traces.
+func errorKind(err error) string {
+	switch {
+	case errors.Is(err, ErrAccessDenied):
+		return ErrorKindAccessDenied
+	case errors.Is(err, ErrObjectNotFound):
+		return ErrorKindObjectNotFound
+	case errors.Is(err, ErrSessionExpired):
+		return ErrorKindSessionExpired
+	case errors.Is(err, ErrInvalidArgument):
+		return ErrorKindInvalidArgument
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return ErrorKindCanceled
+	}
+
+	var kscErr *KscError
+	if errors.As(err, &kscErr) {
+		return ErrorKindServer
+	}
+	return ErrorKindTransport
+}
+
+// observe finishes the span and records metrics of the call.
+func (ksc *KscClient) observe(span Span, method string, d time.Duration, err error) {
+	ksc.metrics.ObserveLatency(method, d)
+
+	if err != nil {
+		kind := errorKind(err)
+		ksc.metrics.IncErrors(method, kind)
+
+		span.SetAttributes(Attribute{AttrError, true}, Attribute{AttrErrorKind, kind})
+
+		var kscErr *KscError
+		if errors.As(err, &kscErr) {
+			span.SetAttributes(
+				Attribute{AttrErrorCode, kscErr.Code},
+				Attribute{AttrErrorModule, kscErr.Module},
+				Attribute{AttrHTTPStatus, kscErr.StatusCode},
+			)
+		}
+		span.RecordError(err)
+	}
+
+	span.End()
+}
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...Attribute) {}
+func (nopSpan) RecordError(error)          {}
+func (nopSpan) End()                       {}
+
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveLatency(string, time.Duration) {}
+func (nopMetrics) IncErrors(string, string)             {}
@@ -0,0 +1,153 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+type spanKey struct{}
+
+type recordSpan struct {
+	name  string
+	attrs map[string]interface{}
+	err   error
+	ended int
+}
+
+func (s *recordSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *recordSpan) RecordError(err error) { s.err = err }
+func (s *recordSpan) End()                  { s.ended++ }
+
+type recordTracer struct {
+	spans []*recordSpan
+}
+
+func (t *recordTracer) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span) {
+	span := &recordSpan{name: name, attrs: map[string]interface{}{}}
+	span.SetAttributes(attrs...)
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+type recordMetrics struct {
+	mu        sync.Mutex
+	latencies map[string]int
+	errors    map[string]int
+}
+
+func (m *recordMetrics) ObserveLatency(method string, d time.Duration) {
+	m.mu.Lock()
+	m.latencies[method]++
+	m.mu.Unlock()
+}
+
+func (m *recordMetrics) IncErrors(method string, kind string) {
+	m.mu.Lock()
+	m.errors[method+" "+kind]++
+	m.mu.Unlock()
+}
+
+func TestErrorKind(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&KscError{Module: "KLSTD", Code: KlstdErrAccessDenied}, ErrorKindAccessDenied},
+		{&KscError{Module: "KLSTD", Code: KlstdErrObjectNotFound}, ErrorKindObjectNotFound},
+		{&KscError{StatusCode: http.StatusUnauthorized}, ErrorKindSessionExpired},
+		{&KscError{Module: "KLSTD", Code: KlstdErrInvalidArgument}, ErrorKindInvalidArgument},
+		{&KscError{StatusCode: http.StatusInternalServerError}, ErrorKindServer},
+		{fmt.Errorf("call: %w", context.DeadlineExceeded), ErrorKindCanceled},
+		{errors.New("connection reset"), ErrorKindTransport},
+	}
+
+	for _, tt := range tests {
+		if got := errorKind(tt.err); got != tt.want {
+			t.Errorf("errorKind(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestTracerAndMetrics(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.reply("Tasks.GetTask", `{"PxgError":{"code":1183,"module":"KLSTD","message":"Object not found"}}`)
+
+	var spanInTransport bool
+	tracer := &recordTracer{}
+	metrics := &recordMetrics{latencies: map[string]int{}, errors: map[string]int{}}
+	c := srv.client(Config{
+		Tracer:  tracer,
+		Metrics: metrics,
+		Middlewares: []Middleware{func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				spanInTransport = r.Context().Value(spanKey{}) != nil
+				return next.RoundTrip(r)
+			})
+		}},
+	})
+
+	ctx := context.Background()
+	if _, err := c.Session.Ping(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Call(ctx, "Tasks.GetTask", nil, nil); !errors.Is(err, ErrObjectNotFound) {
+		t.Fatalf("Call() = %v", err)
+	}
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("%d spans started, want 2", len(tracer.spans))
+	}
+
+	ping, get := tracer.spans[0], tracer.spans[1]
+	if ping.name != "Session.Ping" || ping.ended != 1 || ping.err != nil || ping.attrs[AttrServer] != srv.URL {
+		t.Errorf("Session.Ping span = %+v", ping)
+	}
+	if get.name != "Tasks.GetTask" || get.ended != 1 || get.err == nil ||
+		get.attrs[AttrErrorKind] != ErrorKindObjectNotFound || get.attrs[AttrErrorCode] != KlstdErrObjectNotFound {
+		t.Errorf("Tasks.GetTask span = %+v", get)
+	}
+	if !spanInTransport {
+		t.Error("span context is not passed to the transport")
+	}
+
+	if metrics.latencies["Session.Ping"] != 1 || metrics.latencies["Tasks.GetTask"] != 1 {
+		t.Errorf("latencies = %v", metrics.latencies)
+	}
+	if len(metrics.errors) != 1 || metrics.errors["Tasks.GetTask "+ErrorKindObjectNotFound] != 1 {
+		t.Errorf("errors = %v", metrics.errors)
+	}
+}
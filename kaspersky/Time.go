@@ -0,0 +1,141 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// KSC datetime layouts. Server sends datetime values as RFC 3339 in UTC, older servers omit the zone.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// ParseDateTime parses KSC datetime value and returns it in UTC. Empty value gives zero time.
+func ParseDateTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("kaspersky: invalid datetime %q", value)
+}
+
+// ParseTimeIn parses value with the layout in the time zone loc (UTC if nil) and returns it in UTC,
+// e.g. for datetimes typed by a user to build search filters.
+func ParseTimeIn(layout, value string, loc *time.Location) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	t, err := time.ParseInLocation(layout, value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.UTC(), nil
+}
+
+// FormatTime formats t with the layout in the time zone loc (UTC if nil). Zero time gives empty string.
+func FormatTime(t time.Time, layout string, loc *time.Location) string {
+	if t.IsZero() {
+		return ""
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(layout)
+}
+
+// DateTime KSC datetime value, {"type":"datetime","value":"2021-01-02T03:04:05Z"}, in UTC.
+type DateTime struct {
+	time.Time
+}
+
+// MarshalJSON encodes t as KSC datetime value.
+func (t DateTime) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(TypedValue{
+		Type:  ParamsTypeDateTime,
+		Value: json.RawMessage(`"` + t.UTC().Format(time.RFC3339) + `"`),
+	})
+}
+
+// UnmarshalJSON decodes KSC datetime value, either typed or plain string.
+func (t *DateTime) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	var s string
+	if len(data) > 0 && data[0] == '{' {
+		var tv TypedValue
+		if err := json.Unmarshal(data, &tv); err != nil {
+			return err
+		}
+		if tv.Type != ParamsTypeDateTime {
+			return fmt.Errorf("kaspersky: datetime value expected, got %q", tv.Type)
+		}
+		if len(tv.Value) != 0 && !bytes.Equal(tv.Value, []byte("null")) {
+			if err := json.Unmarshal(tv.Value, &s); err != nil {
+				return fmt.Errorf("kaspersky: datetime value: %w", err)
+			}
+		}
+	} else if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("kaspersky: datetime value: %w", err)
+	}
+
+	parsed, err := ParseDateTime(s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// DurationToSeconds converts d to whole seconds, as KSC takes lifetimes and timeouts,
+// e.g. LMaxLifeTime of HGParams.
+func DurationToSeconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
+
+// SecondsToDuration converts seconds returned by KSC to time.Duration.
+func SecondsToDuration(sec int64) time.Duration {
+	return time.Duration(sec) * time.Second
+}
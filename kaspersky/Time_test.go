@@ -0,0 +1,151 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseDateTime(t *testing.T) {
+	want := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, value := range []string{
+		"2021-01-02T03:04:05Z",
+		"2021-01-02T06:04:05+03:00",
+		"2021-01-02T03:04:05",
+		" 2021-01-02 03:04:05 ",
+	} {
+		got, err := ParseDateTime(value)
+		if err != nil || !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("ParseDateTime(%q) = %v, %v, want %v", value, got, err, want)
+		}
+	}
+
+	if got, err := ParseDateTime(""); err != nil || !got.IsZero() {
+		t.Errorf("ParseDateTime(\"\") = %v, %v, want zero time", got, err)
+	}
+	if _, err := ParseDateTime("2 Jan 2021 03:04"); err == nil {
+		t.Error("ParseDateTime() of invalid value succeeded")
+	}
+}
+
+func TestParseTimeInAndFormatTime(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*3600)
+	got, err := ParseTimeIn("02.01.2006 15:04", "02.01.2021 06:04", msk)
+	if want := time.Date(2021, 1, 2, 3, 4, 0, 0, time.UTC); err != nil || !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("ParseTimeIn() = %v, %v, want %v", got, err, want)
+	}
+	if _, err := ParseTimeIn("02.01.2006", "2021-01-02", nil); err == nil {
+		t.Error("ParseTimeIn() of invalid value succeeded")
+	}
+
+	if s := FormatTime(got, "02.01.2006 15:04", msk); s != "02.01.2021 06:04" {
+		t.Errorf("FormatTime() = %q", s)
+	}
+	if s := FormatTime(got, time.RFC3339, nil); s != "2021-01-02T03:04:00Z" {
+		t.Errorf("FormatTime() in UTC = %q", s)
+	}
+	if s := FormatTime(time.Time{}, time.RFC3339, nil); s != "" {
+		t.Errorf("FormatTime() of zero time = %q", s)
+	}
+}
+
+func TestParseTimeDeprecated(t *testing.T) {
+	if s := ParseTime("2021-01-02T03:04:05Z"); s != "2 Jan 2021 03:04" {
+		t.Errorf("ParseTime() = %q", s)
+	}
+}
+
+func TestDateTimeJSON(t *testing.T) {
+	want := time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, data := range []string{
+		`{"type":"datetime","value":"2021-01-02T03:04:05Z"}`,
+		`"2021-01-02T06:04:05+03:00"`,
+	} {
+		var dt DateTime
+		if err := json.Unmarshal([]byte(data), &dt); err != nil || !dt.Equal(want) || dt.Location() != time.UTC {
+			t.Errorf("Unmarshal(%s) = %v, %v, want %v", data, dt, err, want)
+		}
+	}
+
+	for _, data := range []string{`null`, `{"type":"datetime","value":null}`} {
+		dt := DateTime{want}
+		if err := json.Unmarshal([]byte(data), &dt); err != nil || !dt.IsZero() {
+			t.Errorf("Unmarshal(%s) = %v, %v, want zero time", data, dt, err)
+		}
+	}
+
+	for _, data := range []string{`{"type":"long","value":1}`, `"yesterday"`, `1`} {
+		var dt DateTime
+		if err := json.Unmarshal([]byte(data), &dt); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded", data)
+		}
+	}
+
+	data, err := json.Marshal(struct {
+		Set  DateTime
+		Zero DateTime
+	}{Set: DateTime{want.In(time.FixedZone("MSK", 3*3600))}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(data); s != `{"Set":{"type":"datetime","value":"2021-01-02T03:04:05Z"},"Zero":null}` {
+		t.Errorf("Marshal() = %s", s)
+	}
+}
+
+func TestTaskDateTimeFields(t *testing.T) {
+	var v TaskValue
+	data := `{"TASKSCH_FIRST_EXECUTION_TIME":{"type":"datetime","value":"2021-01-02T03:04:05Z"},"TASKSCH_EW_SECS":30}`
+	if err := json.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.TaskschFirstExecutionTime == nil || v.TaskschFirstExecutionTime.Year() != 2021 || v.TaskschEwSecs != 30 {
+		t.Errorf("TaskValue = %+v", v)
+	}
+}
+
+func TestDurations(t *testing.T) {
+	if s := DurationToSeconds(90*time.Second + 900*time.Millisecond); s != 90 {
+		t.Errorf("DurationToSeconds() = %d, want 90", s)
+	}
+	if d := SecondsToDuration(90); d != 90*time.Second {
+		t.Errorf("SecondsToDuration() = %v", d)
+	}
+
+	var res ActionStateResult
+	if err := json.Unmarshal([]byte(`{"lNextCheckDelay":1500}`), &res); err != nil {
+		t.Fatal(err)
+	}
+	if d := res.NextCheckDelay(); d != 1500*time.Millisecond {
+		t.Errorf("NextCheckDelay() = %v", d)
+	}
+
+	v := IssuanceSettingValue{CINRenewalPeriodSEC: 3600}
+	if d := v.RenewalPeriod(); d != time.Hour {
+		t.Errorf("RenewalPeriod() = %v", d)
+	}
+}
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // TotpGlobalSettings 2FA global settings
@@ -37,17 +33,8 @@ type TotpGlobalSettings service
 
 // Get2FaRequiredForAll Read global flag "2FA is required for all users".
 func (tgs *TotpGlobalSettings) Get2FaRequiredForAll(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", tgs.client.Server+"/api/v1.0/TotpGlobalSettings.Get2FaRequiredForAll", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
-	raw, err := tgs.client.Request(ctx, request, &result)
-
-	if tgs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tgs.client.Call(ctx, "TotpGlobalSettings.Get2FaRequiredForAll", nil, &result)
 
 	return result, err
 }
@@ -60,17 +47,8 @@ type TOTPSettings struct {
 
 // GetTotpGlobalSettings Read global TOTP settings.
 func (tgs *TotpGlobalSettings) GetTotpGlobalSettings(ctx context.Context) (*TOTPSettings, error) {
-	request, err := http.NewRequest("POST", tgs.client.Server+"/api/v1.0/TotpGlobalSettings.GetTotpGlobalSettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(TOTPSettings)
-	raw, err := tgs.client.Request(ctx, request, &result)
-
-	if tgs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tgs.client.Call(ctx, "TotpGlobalSettings.GetTotpGlobalSettings", nil, &result)
 
 	return result, err
 }
@@ -84,17 +62,8 @@ type LoggedInUsing2FA struct {
 // Used by console to decide whether to display configuration options and whether to start setting up 2FA for current user.
 // 2FA settings modification is allowed only if this method returns true AND bLoggedInUsing2FA is true.
 func (tgs *TotpGlobalSettings) IfCanConfigure2FaSettings(ctx context.Context) (*LoggedInUsing2FA, error) {
-	request, err := http.NewRequest("POST", tgs.client.Server+"/api/v1.0/TotpGlobalSettings.IfCanConfigure2FaSettings", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(LoggedInUsing2FA)
-	raw, err := tgs.client.Request(ctx, request, &result)
-
-	if tgs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tgs.client.Call(ctx, "TotpGlobalSettings.IfCanConfigure2FaSettings", nil, &result)
 
 	return result, err
 }
@@ -102,20 +71,9 @@ func (tgs *TotpGlobalSettings) IfCanConfigure2FaSettings(ctx context.Context) (*
 // Set2FaRequiredForAll Set global flag "2FA is required for all users"
 // Second factor must be configured for current user to be able to call this method.
 func (tgs *TotpGlobalSettings) Set2FaRequiredForAll(ctx context.Context, bRequiredForAll bool) error {
-	postData := []byte(fmt.Sprintf(`{"bRequiredForAll" : %v}`, bRequiredForAll))
-
-	request, err := http.NewRequest("POST", tgs.client.Server+"/api/v1.0/TotpGlobalSettings.Set2FaRequiredForAll",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
-
-	raw, err := tgs.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"bRequiredForAll": bRequiredForAll}
 
-	if tgs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tgs.client.Call(ctx, "TotpGlobalSettings.Set2FaRequiredForAll", postData, nil)
 
 	return err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // TotpRegistration TOTP registration.
@@ -49,34 +45,16 @@ type TotpSecretData struct {
 
 // GenerateSecret Generates TotpSecret with random key.
 func (tr *TotpRegistration) GenerateSecret(ctx context.Context) (*TotpSecretData, error) {
-	request, err := http.NewRequest("POST", tr.client.Server+"/api/v1.0/TotpRegistration.GenerateSecret", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	totpSecretData := new(TotpSecretData)
-	raw, err := tr.client.Request(ctx, request, &totpSecretData)
-
-	if tr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tr.client.Call(ctx, "TotpRegistration.GenerateSecret", nil, &totpSecretData)
 
 	return totpSecretData, err
 }
 
 // IfCurrentUserMayClearSecret Checks whether current user is allowed to reset his TOTP secret.
 func (tr *TotpRegistration) IfCurrentUserMayClearSecret(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", tr.client.Server+"/api/v1.0/TotpRegistration.IfCurrentUserMayClearSecret", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
-	raw, err := tr.client.Request(ctx, request, &result)
-
-	if tr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tr.client.Call(ctx, "TotpRegistration.IfCurrentUserMayClearSecret", nil, &result)
 
 	return result, err
 }
@@ -86,40 +64,21 @@ func (tr *TotpRegistration) IfCurrentUserMayClearSecret(ctx context.Context) (*P
 //If validation code passes verification, secret will be used as second factor when users logs in.
 //If user already has registered TOTP secret, it will be replaced with new one.
 func (tr *TotpRegistration) SaveSecretForCurrentUser(ctx context.Context, wstrSecretId, wstrValidationCode string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrSecretId" : "%s", "wstrValidationCode" : "%s"}`, wstrSecretId, wstrValidationCode))
-
-	request, err := http.NewRequest("POST", tr.client.Server+"/api/v1.0/TotpRegistration.SaveSecretForCurrentUser",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
+	postData := map[string]interface{}{
+		"wstrSecretId":       wstrSecretId,
+		"wstrValidationCode": wstrValidationCode,
 	}
 
-	raw, err := tr.client.Request(ctx, request, nil)
-
-	if tr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tr.client.Call(ctx, "TotpRegistration.SaveSecretForCurrentUser", postData, nil)
 
 	return err
 }
 
 // DeleteSecret Clears secret, identified by wstrSecretId.
 func (tr *TotpRegistration) DeleteSecret(ctx context.Context, wstrSecretId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrSecretId" : "%s"}`, wstrSecretId))
-
-	request, err := http.NewRequest("POST", tr.client.Server+"/api/v1.0/TotpRegistration.DeleteSecret",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
-
-	raw, err := tr.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"wstrSecretId": wstrSecretId}
 
-	if tr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tr.client.Call(ctx, "TotpRegistration.DeleteSecret", postData, nil)
 
 	return err
 }
@@ -127,18 +86,8 @@ func (tr *TotpRegistration) DeleteSecret(ctx context.Context, wstrSecretId strin
 // ClearSecretForCurrentUser Clears TOTP secret for current user. Function succeeds only
 // if '2FA required for all' flag is not set, or if user is in exceptions list.
 func (tr *TotpRegistration) ClearSecretForCurrentUser(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", tr.client.Server+"/api/v1.0/TotpRegistration.ClearSecretForCurrentUser", nil)
-
-	if err != nil {
-		return nil, err
-	}
-
 	result := new(PxgValBool)
-	raw, err := tr.client.Request(ctx, request, &result)
-
-	if tr.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tr.client.Call(ctx, "TotpRegistration.ClearSecretForCurrentUser", nil, &result)
 
 	return result, err
 }
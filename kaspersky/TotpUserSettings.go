@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"log"
-	"net/http"
 )
 
 // TotpUserSettings 2FA user settings
@@ -37,19 +33,9 @@ type TotpUserSettings service
 
 // ClearUserSecret Clear TOTP secret for user (spl_users, ak_users only)
 func (tgs *TotpUserSettings) ClearUserSecret(ctx context.Context, llTrusteeID int) error {
-	postData := []byte(fmt.Sprintf(`{"llTrusteeId" : %d}`, llTrusteeID))
-	request, err := http.NewRequest("POST", tgs.client.Server+"/api/v1.0/TotpUserSettings.ClearUserSecret",
-		bytes.NewBuffer(postData))
+	postData := map[string]interface{}{"llTrusteeId": llTrusteeID}
 
-	if err != nil {
-		return err
-	}
-
-	raw, err := tgs.client.Request(ctx, request, nil)
-
-	if tgs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tgs.client.Call(ctx, "TotpUserSettings.ClearUserSecret", postData, nil)
 
 	return err
 }
@@ -57,40 +43,19 @@ func (tgs *TotpUserSettings) ClearUserSecret(ctx context.Context, llTrusteeID in
 // AddUserToTotpRequrementExceptions Add/remove user to/from exceptions
 // list of "2FA is required for all users" requirement.
 func (tgs *TotpUserSettings) AddUserToTotpRequrementExceptions(ctx context.Context, llTrusteeID int, bInExceptions bool) error {
-	postData := []byte(fmt.Sprintf(`{"llTrusteeId" : %d, "bInExceptions" : %v}`, llTrusteeID, bInExceptions))
-
-	request, err := http.NewRequest("POST", tgs.client.Server+"/api/v1.0/TotpUserSettings.AddUserToTotpRequrementExceptions",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"llTrusteeId": llTrusteeID, "bInExceptions": bInExceptions}
 
-	raw, err := tgs.client.Request(ctx, request, nil)
-
-	if tgs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tgs.client.Call(ctx, "TotpUserSettings.AddUserToTotpRequrementExceptions", postData, nil)
 
 	return err
 }
 
 // IfCanClearUser2FaSecret Checks whether calling user has enough access rights to clear 2FA secret.
 func (tgs *TotpUserSettings) IfCanClearUser2FaSecret(ctx context.Context, llTrusteeId int) (*LoggedInUsing2FA, error) {
-	postData := []byte(fmt.Sprintf(`{"llTrusteeId" : %d}`, llTrusteeId))
-	request, err := http.NewRequest("POST", tgs.client.Server+"/api/v1.0/TotpUserSettings.IfCanClearUser2FaSecret",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"llTrusteeId": llTrusteeId}
 
 	result := new(LoggedInUsing2FA)
-	raw, err := tgs.client.Request(ctx, request, &result)
-
-	if tgs.client.Debug {
-		log.Printf("raw response: %s", string(raw))
-	}
+	_, err := tgs.client.Call(ctx, "TotpUserSettings.IfCanClearUser2FaSecret", postData, &result)
 
 	return result, err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // TrafficManager service allows to limit network traffic speed between KSC server and Network agents or between servers within server hierarchy.
@@ -76,52 +71,27 @@ type TrafficPRestrictions struct {
 
 // AddRestriction Add traffic restriction.
 func (tm *TrafficManager) AddRestriction(ctx context.Context, params TrafficRestrictions) (*PxgValInt, []byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	request, err := http.NewRequest("POST", tm.client.Server+"/api/v1.0/TrafficManager.AddRestriction", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	raw, err := tm.client.Request(ctx, request, &pxgValInt)
+	raw, err := tm.client.Call(ctx, "TrafficManager.AddRestriction", params, &pxgValInt)
 	return pxgValInt, raw, err
 }
 
 // DeleteRestriction Remove traffic restriction.
 func (tm *TrafficManager) DeleteRestriction(ctx context.Context, nRestrictionId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"nRestrictionId": %d}`, nRestrictionId))
-	request, err := http.NewRequest("POST", tm.client.Server+"/api/v1.0/TrafficManager.DeleteRestriction", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nRestrictionId": nRestrictionId}
 
-	raw, err := tm.client.Request(ctx, request, nil)
+	raw, err := tm.client.Call(ctx, "TrafficManager.DeleteRestriction", postData, nil)
 	return raw, err
 }
 
 // GetRestrictions Returns all currently active restrictions list.
 func (tm *TrafficManager) GetRestrictions(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", tm.client.Server+"/api/v1.0/TrafficManager.GetRestrictions", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := tm.client.Request(ctx, request, nil)
+	raw, err := tm.client.Call(ctx, "TrafficManager.GetRestrictions", nil, nil)
 	return raw, err
 }
 
 // UpdateRestriction Modify existing traffic restriction settings.
 func (tm *TrafficManager) UpdateRestriction(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, _ := json.Marshal(params)
-	request, err := http.NewRequest("POST", tm.client.Server+"/api/v1.0/TrafficManager.UpdateRestriction", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := tm.client.Request(ctx, request, nil)
+	raw, err := tm.client.Call(ctx, "TrafficManager.UpdateRestriction", params, nil)
 	return raw, err
 }
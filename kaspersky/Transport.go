@@ -0,0 +1,139 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Middleware wraps http.RoundTripper, e.g. to add authentication headers, logging or tracing.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(r).
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// LoadCertPool reads PEM encoded certificates from the file and returns them as a pool for Config.RootCAs.
+func LoadCertPool(pemFile string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(pemFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("kaspersky: no certificates found in %s", pemFile)
+	}
+	return pool, nil
+}
+
+// CertFingerprint returns hex encoded SHA-256 fingerprint of the certificate, as expected by Config.PinnedCertSHA256.
+func CertFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// newHTTPClient builds http.Client from transport related Config options.
+func newHTTPClient(cfg Config) *http.Client {
+	var httpClient http.Client
+	if cfg.HTTPClient != nil {
+		httpClient = *cfg.HTTPClient
+	} else {
+		httpClient.Transport = newTransport(cfg)
+	}
+
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for i := len(cfg.Middlewares) - 1; i >= 0; i-- {
+		transport = cfg.Middlewares[i](transport)
+	}
+
+	httpClient.Transport = transport
+	return &httpClient
+}
+
+func newTransport(cfg Config) *http.Transport {
+	tlsConfig := &tls.Config{}
+	if cfg.TLSConfig != nil {
+		tlsConfig = cfg.TLSConfig.Clone()
+	}
+
+	if cfg.InsecureSkipVerify {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	if cfg.RootCAs != nil {
+		tlsConfig.RootCAs = cfg.RootCAs
+	}
+
+	if len(cfg.PinnedCertSHA256) != 0 {
+		tlsConfig.VerifyPeerCertificate = verifyPinnedCert(cfg.PinnedCertSHA256)
+	}
+
+	return &http.Transport{
+		Proxy:               cfg.Proxy,
+		DialContext:         (&net.Dialer{Timeout: cfg.DialTimeout}).DialContext,
+		TLSClientConfig:     tlsConfig,
+		TLSHandshakeTimeout: cfg.TLSHandshakeTimeout,
+		IdleConnTimeout:     cfg.IdleConnTimeout,
+	}
+}
+
+// verifyPinnedCert returns tls.Config.VerifyPeerCertificate callback
+// that accepts only server leaf certificate with one of the given SHA-256 fingerprints.
+func verifyPinnedCert(fingerprints []string) func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	pins := make(map[string]bool, len(fingerprints))
+	for _, fp := range fingerprints {
+		pins[strings.ToLower(strings.Replace(fp, ":", "", -1))] = true
+	}
+
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("kaspersky: server didn't present a certificate")
+		}
+
+		sum := sha256.Sum256(rawCerts[0])
+		if !pins[hex.EncodeToString(sum[:])] {
+			return errors.New("kaspersky: server certificate doesn't match pinned fingerprint")
+		}
+		return nil
+	}
+}
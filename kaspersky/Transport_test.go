@@ -0,0 +1,140 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMiddlewaresOrder(t *testing.T) {
+	srv := newFakeServer(t)
+
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				r.Header.Add("X-Test", name)
+				return next.RoundTrip(r)
+			})
+		}
+	}
+
+	c := srv.client(Config{Middlewares: []Middleware{mw("outer"), mw("inner")}})
+	if _, err := c.Session.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(order, ","); got != "outer,inner" {
+		t.Errorf("middlewares called in order %s, want outer,inner", got)
+	}
+	if got := srv.received("Session.Ping")[0].Header["X-Test"]; len(got) != 2 {
+		t.Errorf("X-Test header = %v, want both middlewares", got)
+	}
+}
+
+func TestCustomHTTPClient(t *testing.T) {
+	srv := newFakeServer(t)
+
+	var used bool
+	httpClient := &http.Client{Transport: RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		used = true
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+
+	c := srv.client(Config{HTTPClient: httpClient})
+	if _, err := c.Session.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !used {
+		t.Error("Config.HTTPClient is not used")
+	}
+}
+
+func TestTLSVerification(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+
+	dir, err := ioutil.TempDir("", "ksc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	pemFile := filepath.Join(dir, "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := ioutil.WriteFile(pemFile, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadCertPool(pemFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fingerprint := CertFingerprint(srv.Certificate())
+	colons := strings.ToUpper(fingerprint[:2] + ":" + fingerprint[2:])
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{"untrusted", Config{}, true},
+		{"insecure", Config{InsecureSkipVerify: true}, false},
+		{"root CAs", Config{RootCAs: pool}, false},
+		{"loaded root CAs", Config{RootCAs: loaded}, false},
+		{"pinned", Config{InsecureSkipVerify: true, PinnedCertSHA256: []string{fingerprint}}, false},
+		{"pinned with colons", Config{InsecureSkipVerify: true, PinnedCertSHA256: []string{colons}}, false},
+		{"pin mismatch", Config{InsecureSkipVerify: true, PinnedCertSHA256: []string{strings.Repeat("0", 64)}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Server = srv.URL
+			_, err := NewKscClient(tt.cfg).Session.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := LoadCertPool(filepath.Join(dir, "missing.pem")); !os.IsNotExist(err) {
+		t.Errorf("LoadCertPool() of a missing file = %v", err)
+	}
+}
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // UaControl service Update agents and Connection gateways management.
@@ -38,13 +33,8 @@ type UaControl service
 
 // GetAssignUasAutomatically Check if Update agents automatic assignment is enabled, see uactl_ua_assignment.
 func (uc *UaControl) GetAssignUasAutomatically(ctx context.Context) (*PxgValBool, error) {
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.GetAssignUasAutomatically", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValBool := new(PxgValBool)
-	_, err = uc.client.Request(ctx, request, &pxgValBool)
+	_, err := uc.client.Call(ctx, "UaControl.GetAssignUasAutomatically", nil, &pxgValBool)
 	return pxgValBool, err
 }
 
@@ -82,13 +72,8 @@ type PUaInfo struct {
 
 // GetDefaultUpdateAgentRegistrationInfo Fill in default Update agent settings.
 func (uc *UaControl) GetDefaultUpdateAgentRegistrationInfo(ctx context.Context) (*AgentRegistrationInfo, error) {
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.GetDefaultUpdateAgentRegistrationInfo", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	agentRegistrationInfo := new(AgentRegistrationInfo)
-	_, err = uc.client.Request(ctx, request, &agentRegistrationInfo)
+	_, err := uc.client.Call(ctx, "UaControl.GetDefaultUpdateAgentRegistrationInfo", nil, &agentRegistrationInfo)
 	return agentRegistrationInfo, err
 }
 
@@ -124,15 +109,10 @@ type ScopeID struct {
 
 // GetUpdateAgentInfo Get Update agent info.
 func (uc *UaControl) GetUpdateAgentInfo(ctx context.Context, wstrUaHostId string) (*UpdateAgentInfo, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrUaHostId": "%s"}`, wstrUaHostId))
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.GetUpdateAgentInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrUaHostId": wstrUaHostId}
 
 	updateAgentInfo := new(UpdateAgentInfo)
-	_, err = uc.client.Request(ctx, request, &updateAgentInfo)
+	_, err := uc.client.Call(ctx, "UaControl.GetUpdateAgentInfo", postData, &updateAgentInfo)
 	return updateAgentInfo, err
 }
 
@@ -147,14 +127,9 @@ type UADInfo struct {
 
 // GetUpdateAgentsDisplayInfoForHost Returns list of Update agents assigned to specified host.
 func (uc *UaControl) GetUpdateAgentsDisplayInfoForHost(ctx context.Context, wstrHostId string) (*UpdateAgentsDisplayInfoForHost, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrHostId": "%s"}`, wstrHostId))
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.GetUpdateAgentsDisplayInfoForHost",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrHostId": wstrHostId}
 	updateAgentsDisplayInfoForHost := new(UpdateAgentsDisplayInfoForHost)
-	_, err = uc.client.Request(ctx, request, &updateAgentsDisplayInfoForHost)
+	_, err := uc.client.Call(ctx, "UaControl.GetUpdateAgentsDisplayInfoForHost", postData, &updateAgentsDisplayInfoForHost)
 	return updateAgentsDisplayInfoForHost, err
 }
 
@@ -176,30 +151,14 @@ type UpdateAgentValue struct {
 
 // GetUpdateAgentsList List all registered Update agents.
 func (uc *UaControl) GetUpdateAgentsList(ctx context.Context) (*UpdateAgentsList, error) {
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.GetUpdateAgentsList", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	updateAgentsList := new(UpdateAgentsList)
-	_, err = uc.client.Request(ctx, request, &updateAgentsList)
+	_, err := uc.client.Call(ctx, "UaControl.GetUpdateAgentsList", nil, &updateAgentsList)
 	return updateAgentsList, err
 }
 
 // ModifyUpdateAgent Modify update agent info of an existing Update agent.
 func (uc *UaControl) ModifyUpdateAgent(ctx context.Context, params AgentRegistrationInfo) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.ModifyUpdateAgent",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = uc.client.Request(ctx, request, nil)
+	_, err := uc.client.Call(ctx, "UaControl.ModifyUpdateAgent", params, nil)
 	return err
 }
 
@@ -215,18 +174,7 @@ func (uc *UaControl) ModifyUpdateAgent(ctx context.Context, params AgentRegistra
 // If Network agents which will be using this CG cannot access KSC server without using this CG (which is likely)
 // they must be installed with 'Use CG' installer option.
 func (uc *UaControl) RegisterDmzGateway(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.RegisterDmzGateway",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uc.client.Request(ctx, request, nil)
+	raw, err := uc.client.Call(ctx, "UaControl.RegisterDmzGateway", params, nil)
 	return raw, err
 }
 
@@ -237,44 +185,22 @@ func (uc *UaControl) RegisterDmzGateway(ctx context.Context, params interface{})
 // define Update agent scope via UaScope attribute (mandatory) modify other options if required (optional),
 // see Update agent settings perform this call
 func (uc *UaControl) RegisterUpdateAgent(ctx context.Context, params AgentRegistrationInfo) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.RegisterUpdateAgent",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = uc.client.Request(ctx, request, nil)
+	_, err := uc.client.Call(ctx, "UaControl.RegisterUpdateAgent", params, nil)
 	return err
 }
 
 // SetAssignUasAutomatically Enable or disable automatic Update agents assignment, see uactl_ua_assignment.
 func (uc *UaControl) SetAssignUasAutomatically(ctx context.Context, bEnabled bool) error {
-	postData := []byte(fmt.Sprintf(`{"bEnabled": %v}`, bEnabled))
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.UnregisterUpdateAgent",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"bEnabled": bEnabled}
 
-	_, err = uc.client.Request(ctx, request, nil)
+	_, err := uc.client.Call(ctx, "UaControl.SetAssignUasAutomatically", postData, nil)
 	return err
 }
 
 // UnregisterUpdateAgent Unregister host as Update agent.
 func (uc *UaControl) UnregisterUpdateAgent(ctx context.Context, wstrUaHostId string) error {
-	postData := []byte(fmt.Sprintf(`{"wstrUaHostId": "%s"}`, wstrUaHostId))
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UaControl.UnregisterUpdateAgent",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"wstrUaHostId": wstrUaHostId}
 
-	_, err = uc.client.Request(ctx, request, nil)
+	_, err := uc.client.Call(ctx, "UaControl.UnregisterUpdateAgent", postData, nil)
 	return err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // UpdComps service provides means to manage updatable components (bases).
@@ -52,45 +48,21 @@ type UPParams struct {
 
 // AsyncUpdate Requests asynchronous bases update or retranslation.
 func (uc *UpdComps) AsyncUpdate(ctx context.Context, params UpdateParams) (*PxgValStr, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UpdComps.AsyncUpdate", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	_, err = uc.client.Request(ctx, request, &pxgValStr)
+	_, err := uc.client.Call(ctx, "UpdComps.AsyncUpdate", params, &pxgValStr)
 	return pxgValStr, err
 }
 
 // Stop Stops operation by request.
 func (uc *UpdComps) Stop(ctx context.Context, wsRequestId string, bWait bool) error {
-	postData := []byte(fmt.Sprintf(`{"wsRequestId": "%s", "bWait": %v}`, wsRequestId, bWait))
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UpdComps.Stop", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"wsRequestId": wsRequestId, "bWait": bWait}
 
-	_, err = uc.client.Request(ctx, request, nil)
+	_, err := uc.client.Call(ctx, "UpdComps.Stop", postData, nil)
 	return err
 }
 
 // UpdateAsync Requests asynchronous bases update or retranslation.
 func (uc *UpdComps) UpdateAsync(ctx context.Context, params UpdateParams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", uc.client.Server+"/api/v1.0/UpdComps.UpdateAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = uc.client.Request(ctx, request, nil)
+	_, err := uc.client.Call(ctx, "UpdComps.UpdateAsync", params, nil)
 	return err
 }
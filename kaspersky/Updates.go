@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // Updates processing.
@@ -80,15 +75,10 @@ type KLUpdateValue struct {
 // GetAvailableUpdatesInfo Get available updates info.
 // strLocalization can be one of "ru", "en", "fr", "de"; for other values "en" localization will be used.
 func (upd *Updates) GetAvailableUpdatesInfo(ctx context.Context, strLocalization string) (*AvailableUpdates, error) {
-	postData := []byte(fmt.Sprintf(`{"strLocalization": "%s"}`, strLocalization))
-	request, err := http.NewRequest("POST", upd.client.Server+"/api/v1.0/Updates.GetAvailableUpdatesInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"strLocalization": strLocalization}
 
 	availableUpdates := new(AvailableUpdates)
-	_, err = upd.client.Request(ctx, request, &availableUpdates)
+	_, err := upd.client.Call(ctx, "Updates.GetAvailableUpdatesInfo", postData, &availableUpdates)
 	return availableUpdates, err
 }
 
@@ -127,42 +117,22 @@ type UpdatesInfoValue struct {
 
 // GetUpdatesInfo lists of retransmissions.
 func (upd *Updates) GetUpdatesInfo(ctx context.Context, params UpdatesInfoParams) (*UpdatesInfos, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", upd.client.Server+"/api/v1.0/Updates.GetUpdatesInfo", bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
-
 	updatesInfos := new(UpdatesInfos)
-	_, err = upd.client.Request(ctx, request, &updatesInfos)
+	_, err := upd.client.Call(ctx, "Updates.GetUpdatesInfo", params, &updatesInfos)
 	return updatesInfos, err
 }
 
 // RemoveUpdates Asynchronously remove updates.
 func (upd *Updates) RemoveUpdates(ctx context.Context) (*PxgValStr, error) {
-	request, err := http.NewRequest("POST", upd.client.Server+"/api/v1.0/Updates.RemoveUpdates", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValStr := new(PxgValStr)
-	_, err = upd.client.Request(ctx, request, &pxgValStr)
+	_, err := upd.client.Call(ctx, "Updates.RemoveUpdates", nil, &pxgValStr)
 	return pxgValStr, err
 }
 
 // RemoveUpdatesCancel Cancel asynchronous operation RemoveUpdates.
 func (upd *Updates) RemoveUpdatesCancel(ctx context.Context, strRequestId string) error {
-	postData := []byte(fmt.Sprintf(`{"strRequestId": "%s"}`, strRequestId))
-	request, err := http.NewRequest("POST", upd.client.Server+"/api/v1.0/Updates.RemoveUpdatesCancel", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"strRequestId": strRequestId}
 
-	_, err = upd.client.Request(ctx, request, nil)
+	_, err := upd.client.Call(ctx, "Updates.RemoveUpdatesCancel", postData, nil)
 	return err
 }
@@ -25,12 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-
-	"net/http"
 )
 
 // UserDevicesApi Interface to unified mobile device management.
@@ -39,70 +34,49 @@ type UserDevicesApi service
 // DeleteCommand
 // Delete a command previously posted to the specified device.
 func (uda *UserDevicesApi) DeleteCommand(ctx context.Context, c_wstrCommandGuid string, bForced bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"c_wstrCommandGuid" : "%s", "bForced" : %v }`, c_wstrCommandGuid, bForced))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.DeleteCommand",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"c_wstrCommandGuid": c_wstrCommandGuid, "bForced": bForced}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.DeleteCommand", postData, nil)
 	return raw, err
 }
 
 // DeleteDevice
 // Delete device.
 func (uda *UserDevicesApi) DeleteDevice(ctx context.Context, lDeviceId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lDeviceId": %d }`, lDeviceId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.DeleteDevice",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lDeviceId": lDeviceId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.DeleteDevice", postData, nil)
 	return raw, err
 }
 
 // DeleteEnrollmentPackage
 // Delete enrollment package.
 func (uda *UserDevicesApi) DeleteEnrollmentPackage(ctx context.Context, lEnrPkgId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lEnrPkgId": %d }`, lEnrPkgId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.DeleteEnrollmentPackage",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lEnrPkgId": lEnrPkgId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.DeleteEnrollmentPackage", postData, nil)
 	return raw, err
 }
 
 // GenerateQRCode
 // Generates QR code from any string
 func (uda *UserDevicesApi) GenerateQRCode(ctx context.Context, strInputData string, lQRCodeSize, lImageFormat int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"strInputData": "%s", "lQRCodeSize": %d, "lImageFormat": %d }`, strInputData, lQRCodeSize, lImageFormat))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GenerateQRCode",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"strInputData": strInputData,
+		"lQRCodeSize":  lQRCodeSize,
+		"lImageFormat": lImageFormat,
 	}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GenerateQRCode", postData, nil)
 	return raw, err
 }
 
 // GetCommands
 // Acquire states of all commands posted to the specified device.
 func (uda *UserDevicesApi) GetCommands(ctx context.Context, lDeviceId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lDeviceId": %d }`, lDeviceId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetCommands",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lDeviceId": lDeviceId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetCommands", postData, nil)
 	return raw, err
 }
 
@@ -130,13 +104,8 @@ type KlmdmCmdFlag struct {
 // GetCommandsLibrary
 // Acquires list contains commands info reqired to display and launch commands
 func (uda *UserDevicesApi) GetCommandsLibrary(ctx context.Context) (*CommandsLibrary, error) {
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetCommandsLibrary", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	commandsLibrary := new(CommandsLibrary)
-	_, err = uda.client.Request(ctx, request, &commandsLibrary)
+	_, err := uda.client.Call(ctx, "UserDevicesApi.GetCommandsLibrary", nil, &commandsLibrary)
 	return commandsLibrary, err
 }
 
@@ -144,31 +113,16 @@ func (uda *UserDevicesApi) GetCommandsLibrary(ctx context.Context) (*CommandsLib
 // Calculate commands array according to bit mask of supported commands.
 // Makes commands array according to bit mask of commands supported by device
 func (uda *UserDevicesApi) GetDecipheredCommandList(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetDecipheredCommandList", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetDecipheredCommandList", params, nil)
 	return raw, err
 }
 
 // GetDevice Acquire properties of the specified user device.
 //	Deprecated: Previously used for the SSP. Now, use the SrvView UmdmDevices instead.
 func (uda *UserDevicesApi) GetDevice(ctx context.Context, lDeviceId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lDeviceId": %d }`, lDeviceId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetDevice",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lDeviceId": lDeviceId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetDevice", postData, nil)
 	return raw, err
 }
 
@@ -180,47 +134,22 @@ type UserID struct {
 // GetDevices Acquire properties of all registered devices owned by specified user.
 //	Deprecated: Previously used for the SSP. Now, use the SrvView UmdmDevices instead.
 func (uda *UserDevicesApi) GetDevices(ctx context.Context, params UserID) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetDevices", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetDevices", params, nil)
 	return raw, err
 }
 
 // GetDevicesExtraData
 // Gets additional data from devices such as installed applications, profiles, certificates and etc. !
 func (uda *UserDevicesApi) GetDevicesExtraData(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetDevicesExtraData", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetDevicesExtraData", params, nil)
 	return raw, err
 }
 
 // GetEnrollmentPackage Get the info of enrollment package created for the device.
 func (uda *UserDevicesApi) GetEnrollmentPackage(ctx context.Context, llEnrPkgId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"llEnrPkgId": %d }`, llEnrPkgId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetEnrollmentPackage",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"llEnrPkgId": llEnrPkgId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetEnrollmentPackage", postData, nil)
 	return raw, err
 }
 
@@ -228,79 +157,51 @@ func (uda *UserDevicesApi) GetEnrollmentPackage(ctx context.Context, llEnrPkgId
 // Get enrollment package file data.
 func (uda *UserDevicesApi) GetEnrollmentPackageFileData(ctx context.Context, c_wstrPackageId,
 	c_wstrPackageFileType string, lBuffOffset, lBuffSize int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`
-	{
-		"c_wstrPackageId": "%s",
-		"c_wstrPackageFileType": "%s",
-		"lQRCodeSize": %d, 
-		"lImageFormat":%d
-	}`, c_wstrPackageId, c_wstrPackageFileType, lBuffOffset, lBuffSize))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetEnrollmentPackageFileData",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"c_wstrPackageId":       c_wstrPackageId,
+		"c_wstrPackageFileType": c_wstrPackageFileType,
+		"lQRCodeSize":           lBuffOffset,
+		"lImageFormat":          lBuffSize,
 	}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetEnrollmentPackageFileData", postData, nil)
 	return raw, err
 }
 
 // GetEnrollmentPackageFileInfo
 // Get enrollment package file data.
 func (uda *UserDevicesApi) GetEnrollmentPackageFileInfo(ctx context.Context, c_wstrPackageId, c_wstrUserAgent, c_wstrPackageFileType string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"c_wstrPackageId": "%s", "c_wstrUserAgent": "%s", "c_wstrPackageFileType": "%s"	}`, c_wstrPackageId, c_wstrUserAgent, c_wstrPackageFileType))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetEnrollmentPackageFileInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"c_wstrPackageId":       c_wstrPackageId,
+		"c_wstrUserAgent":       c_wstrUserAgent,
+		"c_wstrPackageFileType": c_wstrPackageFileType,
 	}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetEnrollmentPackageFileInfo", postData, nil)
 	return raw, err
 }
 
 // GetEnrollmentPackages
 // Get the list of enrollment packages created for a device.
 func (uda *UserDevicesApi) GetEnrollmentPackages(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetEnrollmentPackages", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetEnrollmentPackages", params, nil)
 	return raw, err
 }
 
 // GetJournalCommandResult
 // Returns command result for specific journal record.
 func (uda *UserDevicesApi) GetJournalCommandResult(ctx context.Context, llJrnlId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"llJrnlId": %d }`, llJrnlId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetJournalCommandResult",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"llJrnlId": llJrnlId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetJournalCommandResult", postData, nil)
 	return raw, err
 }
 
 // GetJournalRecords
 // Acquire records from journal about completed or failed commands posted to device
 func (uda *UserDevicesApi) GetJournalRecords(ctx context.Context, lDeviceId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lDeviceId": %d }`, lDeviceId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetJournalRecords",
-		bytes.NewBuffer(postData))
-
-	if err != nil {
-		return nil, err
-	}
-	raw, err := uda.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"lDeviceId": lDeviceId}
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetJournalRecords", postData, nil)
 	return raw, err
 }
 
@@ -308,41 +209,26 @@ func (uda *UserDevicesApi) GetJournalRecords(ctx context.Context, lDeviceId int6
 // Acquires records from journal about completed or failed commands posted to device without command result.
 // To get command result for specific record you can use UserDevicesApi.GetJournalCommandResult
 func (uda *UserDevicesApi) GetJournalRecords2(ctx context.Context, lDeviceId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lDeviceId": %d }`, lDeviceId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetJournalRecords2",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lDeviceId": lDeviceId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetJournalRecords2", postData, nil)
 	return raw, err
 }
 
 // GetLatestDeviceActivityDate Acquire latest device activity date.
 //	Deprecated: Previously used for the SSP. Now, use the SrvView UmdmDevices instead.
 func (uda *UserDevicesApi) GetLatestDeviceActivityDate(ctx context.Context, lDeviceId int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lDeviceId": %d }`, lDeviceId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetLatestDeviceActivityDate",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lDeviceId": lDeviceId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetLatestDeviceActivityDate", postData, nil)
 	return raw, err
 }
 
 // GetMobileAgentSettingStorageData Get mobile agent setting storage data.
 func (uda *UserDevicesApi) GetMobileAgentSettingStorageData(ctx context.Context, lDeviceId int64,
 	c_wstrSectionName string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"lDeviceId": %d, "c_wstrSectionName" : "%s" }`, lDeviceId, c_wstrSectionName))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetMobileAgentSettingStorageData",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-	raw, err := uda.client.Request(ctx, request, nil)
+	postData := map[string]interface{}{"lDeviceId": lDeviceId, "c_wstrSectionName": c_wstrSectionName}
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetMobileAgentSettingStorageData", postData, nil)
 	return raw, err
 }
 
@@ -350,14 +236,9 @@ func (uda *UserDevicesApi) GetMobileAgentSettingStorageData(ctx context.Context,
 // Retrieves multitenancy server settings.
 func (uda *UserDevicesApi) GetMultitenancyServerSettings(ctx context.Context, c_wstrMtncServerId string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"c_wstrMtncServerId": "%s" }`, c_wstrMtncServerId))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetMultitenancyServerSettings",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"c_wstrMtncServerId": c_wstrMtncServerId}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetMultitenancyServerSettings", postData, nil)
 	return raw, err
 }
 
@@ -365,14 +246,9 @@ func (uda *UserDevicesApi) GetMultitenancyServerSettings(ctx context.Context, c_
 // Retrieves multitenancy servers list.
 func (uda *UserDevicesApi) GetMultitenancyServersInfo(ctx context.Context, nProtocolIds int64) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"nProtocolIds" : %d }`, nProtocolIds))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetMultitenancyServersInfo",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nProtocolIds": nProtocolIds}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetMultitenancyServersInfo", postData, nil)
 	return raw, err
 }
 
@@ -380,31 +256,15 @@ func (uda *UserDevicesApi) GetMultitenancyServersInfo(ctx context.Context, nProt
 // Returns flag which means install or don't install SafeBrowser automatically when device connects first time.
 func (uda *UserDevicesApi) GetSafeBrowserAutoinstallFlag(ctx context.Context) (*PxgValBool, []byte,
 	error) {
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetSafeBrowserAutoinstallFlag",
-		nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	pxgValBool := new(PxgValBool)
-	raw, err := uda.client.Request(ctx, request, &pxgValBool)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetSafeBrowserAutoinstallFlag", nil, &pxgValBool)
 	return pxgValBool, raw, err
 }
 
 // GetSyncInfo
 // Retrieves group synchronization info for UMDM policy.
 func (uda *UserDevicesApi) GetSyncInfo(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GetSyncInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GetSyncInfo", params, nil)
 	return raw, err
 }
 
@@ -412,14 +272,9 @@ func (uda *UserDevicesApi) GetSyncInfo(ctx context.Context, params interface{})
 //	Deprecated: Not used.
 func (uda *UserDevicesApi) GlueDevices(ctx context.Context, lDevice1Id, lDevice2Id int64) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"lDevice1Id": %d, "lDevice2Id" : %d }`, lDevice1Id, lDevice2Id))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.GlueDevices",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lDevice1Id": lDevice1Id, "lDevice2Id": lDevice2Id}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.GlueDevices", postData, nil)
 	return raw, err
 }
 
@@ -427,48 +282,23 @@ func (uda *UserDevicesApi) GlueDevices(ctx context.Context, lDevice1Id, lDevice2
 // Note, you can't sent more then one command with same type to the specified device
 // while command with same type is not completed, instead you may call method RecallCommand
 func (uda *UserDevicesApi) PostCommand(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.PostCommand", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.PostCommand", params, nil)
 	return raw, err
 }
 
 // RecallCommand previously posted to the specified device.
 func (uda *UserDevicesApi) RecallCommand(ctx context.Context, c_wstrCommandGuid string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"c_wstrCommandGuid": "%s"}`, c_wstrCommandGuid))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.RecallCommand",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"c_wstrCommandGuid": c_wstrCommandGuid}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.RecallCommand", postData, nil)
 	return raw, err
 }
 
 // SetMultitenancyServerSettings
 // Set multitenancy server settings.
 func (uda *UserDevicesApi) SetMultitenancyServerSettings(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.SetMultitenancyServerSettings", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.SetMultitenancyServerSettings", params, nil)
 	return raw, err
 }
 
@@ -476,14 +306,9 @@ func (uda *UserDevicesApi) SetMultitenancyServerSettings(ctx context.Context, pa
 //	Deprecated: The SafeBrowser is not supported now
 func (uda *UserDevicesApi) SetSafeBrowserAutoinstallFlag(ctx context.Context, bInstall bool) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"bInstall": %v}`, bInstall))
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.SetSafeBrowserAutoinstallFlag",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"bInstall": bInstall}
 
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.SetSafeBrowserAutoinstallFlag", postData, nil)
 	return raw, err
 }
 
@@ -491,29 +316,13 @@ func (uda *UserDevicesApi) SetSafeBrowserAutoinstallFlag(ctx context.Context, bI
 //	Deprecated: The SSP is not supported now
 func (uda *UserDevicesApi) SspLoginAllowed(ctx context.Context) ([]byte,
 	error) {
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.SspLoginAllowed",
-		nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.SspLoginAllowed", nil, nil)
 	return raw, err
 }
 
 // UpdateDevice Modify properties of the specified user device.
 //	Deprecated: Previously used for the SSP
 func (uda *UserDevicesApi) UpdateDevice(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", uda.client.Server+"/api/v1.0/UserDevicesApi.UpdateDevice", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := uda.client.Request(ctx, request, nil)
+	raw, err := uda.client.Call(ctx, "UserDevicesApi.UpdateDevice", params, nil)
 	return raw, err
 }
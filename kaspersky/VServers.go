@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // VServers service allows to create and destroy virtual servers, acquire and modify their attributes.
@@ -47,14 +43,10 @@ type VServersInfo struct {
 
 // GetVServers Acquire virtual servers for the specified group.
 func (vs *VServers) GetVServers(ctx context.Context, lParentGroup int64) (*VServersInfos, error) {
-	postData := []byte(fmt.Sprintf(`{"lParentGroup": %d}`, lParentGroup))
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.GetVServers", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lParentGroup": lParentGroup}
 
 	vServersInfos := new(VServersInfos)
-	_, err = vs.client.Request(ctx, request, &vServersInfos)
+	_, err := vs.client.Call(ctx, "VServers.GetVServers", postData, &vServersInfos)
 	return vServersInfos, err
 }
 
@@ -108,27 +100,19 @@ type VServer struct {
 // AddVServerInfo Register new virtual server.
 func (vs *VServers) AddVServerInfo(ctx context.Context, strDisplayName string, lParentGroup int64) (*VServerInfo, []byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"lParentGroup": %d, "strDisplayName" : "%s"}`, lParentGroup, strDisplayName))
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.AddVServerInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, nil, err
-	}
+	postData := map[string]interface{}{"lParentGroup": lParentGroup, "strDisplayName": strDisplayName}
 
 	vServerInfo := new(VServerInfo)
-	raw, err := vs.client.Request(ctx, request, &vServerInfo)
+	raw, err := vs.client.Call(ctx, "VServers.AddVServerInfo", postData, &vServerInfo)
 	return vServerInfo, raw, err
 }
 
 // DelVServer Unregister specified Virtual Server.
 func (vs *VServers) DelVServer(ctx context.Context, lVServer int64) (*WActionGUID, error) {
-	postData := []byte(fmt.Sprintf(`{"lVServer": %d}`, lVServer))
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.DelVServer", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lVServer": lVServer}
 
 	wActionGUID := new(WActionGUID)
-	_, err = vs.client.Request(ctx, request, &wActionGUID)
+	_, err := vs.client.Call(ctx, "VServers.DelVServer", postData, &wActionGUID)
 	return wActionGUID, err
 }
 
@@ -139,14 +123,10 @@ type VServerPermissions struct {
 
 // GetPermissions Return ACL for the specified virtual server.
 func (vs *VServers) GetPermissions(ctx context.Context, lVServer int64) (*VServerPermissions, error) {
-	postData := []byte(fmt.Sprintf(`{"lVServer": %d}`, lVServer))
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.GetPermissions", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lVServer": lVServer}
 
 	vServerPermissions := new(VServerPermissions)
-	_, err = vs.client.Request(ctx, request, &vServerPermissions)
+	_, err := vs.client.Call(ctx, "VServers.GetPermissions", postData, &vServerPermissions)
 	return vServerPermissions, err
 }
 
@@ -161,44 +141,26 @@ type VServerInfoParams struct {
 
 // GetVServerInfo Returns info about the specified virtual server.
 func (vs *VServers) GetVServerInfo(ctx context.Context, params VServerInfoParams) (*VServerInfo, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.GetVServerInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	vServerInfo := new(VServerInfo)
-	_, err = vs.client.Request(ctx, request, &vServerInfo)
+	_, err := vs.client.Call(ctx, "VServers.GetVServerInfo", params, &vServerInfo)
 	return vServerInfo, err
 }
 
 // MoveVServer Moves specified virtual server to new parent group
 func (vs *VServers) MoveVServer(ctx context.Context, lVServer int64, lNewParentGroup int64) (*WActionGUID, error) {
-	postData := []byte(fmt.Sprintf(`{"lVServer": %d, "lNewParentGroup" : %d}`, lVServer, lNewParentGroup))
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.MoveVServer", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lVServer": lVServer, "lNewParentGroup": lNewParentGroup}
 
 	wActionGUID := new(WActionGUID)
-	_, err = vs.client.Request(ctx, request, &wActionGUID)
+	_, err := vs.client.Call(ctx, "VServers.MoveVServer", postData, &wActionGUID)
 	return wActionGUID, err
 }
 
 // RecallCertAndCloseConnections Function recalls Network Agent certificate
 // from the specified virtual server and closes active connections from such Network Agents.
 func (vs *VServers) RecallCertAndCloseConnections(ctx context.Context, lVServer int64) error {
-	postData := []byte(fmt.Sprintf(`{"lVServer": %d}`, lVServer))
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.RecallCertAndCloseConnections", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"lVServer": lVServer}
 
-	_, err = vs.client.Request(ctx, request, nil)
+	_, err := vs.client.Call(ctx, "VServers.RecallCertAndCloseConnections", postData, nil)
 	return err
 }
 
@@ -239,17 +201,7 @@ type VSInfo struct {
 
 // UpdateVServerInfo Modifies attributes of the specified virtual server
 func (vs *VServers) UpdateVServerInfo(ctx context.Context, params UpdateVServerInfoParams) error {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return err
-	}
-
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.UpdateVServerInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
-
-	_, err = vs.client.Request(ctx, request, nil)
+	_, err := vs.client.Call(ctx, "VServers.UpdateVServerInfo", params, nil)
 	return err
 }
 
@@ -311,16 +263,6 @@ type KlsplPermissionValue struct {
 
 // SetPermissions Set ACL for the specified virtual server.
 func (vs *VServers) SetPermissions(ctx context.Context, params ACLParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers.SetPermissions", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vs.client.Request(ctx, request, nil)
+	raw, err := vs.client.Call(ctx, "VServers.SetPermissions", params, nil)
 	return raw, err
 }
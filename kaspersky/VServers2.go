@@ -25,10 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-	"net/http"
 )
 
 // VServers2 service to access Virtual servers processing.
@@ -94,13 +91,9 @@ type KlvsrvProdBuilds struct {
 
 // GetVServerStatistic Acquire info on virtual server. Returns info about the specified virtual server
 func (vs *VServers2) GetVServerStatistic(ctx context.Context, lVsId int) (*VServerStatistic, error) {
-	postData := []byte(fmt.Sprintf(`{"lVsId": %d}`, lVsId))
-	request, err := http.NewRequest("POST", vs.client.Server+"/api/v1.0/VServers2.GetVServerStatistic", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"lVsId": lVsId}
 
 	vServerStatistic := new(VServerStatistic)
-	_, err = vs.client.Request(ctx, request, &vServerStatistic)
+	_, err := vs.client.Call(ctx, "VServers2.GetVServerStatistic", postData, &vServerStatistic)
 	return vServerStatistic, err
 }
@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"net/http"
 )
 
 // VapmControlApi VAPM.
@@ -43,18 +39,7 @@ type PEulaIDParams struct {
 // AcceptEulas
 // Accepts given EULAs.
 func (vca *VapmControlApi) AcceptEulas(ctx context.Context, params PEulaIDParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.AcceptEulas",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.AcceptEulas", params, nil)
 	return raw, err
 }
 
@@ -62,26 +47,18 @@ func (vca *VapmControlApi) AcceptEulas(ctx context.Context, params PEulaIDParams
 // Cancel the files cleanup process initiated by DeleteFilesForUpdates() call.
 func (vca *VapmControlApi) CancelDeleteFilesForUpdates(ctx context.Context, wstrRequestId string) ([]byte,
 	error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.CancelDeleteFilesForUpdates", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.CancelDeleteFilesForUpdates", postData, nil)
 	return raw, err
 }
 
 // CancelDownloadPatch
 // Cancel the patch downloading started by DownloadPatchAsync().
 func (vca *VapmControlApi) CancelDownloadPatch(ctx context.Context, wstrRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.CancelDownloadPatch", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.CancelDownloadPatch", postData, nil)
 	return raw, err
 }
 
@@ -103,49 +80,27 @@ type EulasIDSValue struct {
 // ChangeApproval
 // Changes updates approval.
 func (vca *VapmControlApi) ChangeApproval(ctx context.Context, params EulasIDSForUpdates) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.ChangeApproval", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.ChangeApproval", params, nil)
 	return raw, err
 }
 
 // ChangeVulnerabilityIgnorance
 // Changes "ignore" state of a vulnerability.
 func (vca *VapmControlApi) ChangeVulnerabilityIgnorance(ctx context.Context, wstrVulnerabilityUid, wstrHostId string, bIgnore bool) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrVulnerabilityUid": "%s", "wstrHostId": "%s", "bIgnore": %v}`,
-		wstrVulnerabilityUid, wstrHostId, bIgnore))
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.ChangeVulnerabilityIgnorance", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"wstrVulnerabilityUid": wstrVulnerabilityUid,
+		"wstrHostId":           wstrHostId,
+		"bIgnore":              bIgnore,
 	}
 
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.ChangeVulnerabilityIgnorance", postData, nil)
 	return raw, err
 }
 
 // DeclineEulas
 // Decline given EULAs.
 func (vca *VapmControlApi) DeclineEulas(ctx context.Context, params PEulaIDParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.DeclineEulas",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.DeclineEulas", params, nil)
 	return raw, err
 }
 
@@ -167,72 +122,50 @@ type PUpdatesValue struct {
 // DeleteFilesForUpdates
 // Cleanup all the files in all the server storages containing the bodies of the given patches.
 func (vca *VapmControlApi) DeleteFilesForUpdates(ctx context.Context, params DeleteFilesForUpdatesParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.DeleteFilesForUpdates", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.DeleteFilesForUpdates", params, nil)
 	return raw, err
 }
 
 // DownloadPatchAsync
 // Download 3-party patch to save locally.
 func (vca *VapmControlApi) DownloadPatchAsync(ctx context.Context, llPatchGlbId, nLcid int64, wstrRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"llPatchGlbId": %d, "nLcid": %d, "wstrRequestId": "%s"}`, llPatchGlbId, nLcid,
-		wstrRequestId))
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.DownloadPatchAsync", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"llPatchGlbId":  llPatchGlbId,
+		"nLcid":         nLcid,
+		"wstrRequestId": wstrRequestId,
 	}
 
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.DownloadPatchAsync", postData, nil)
 	return raw, err
 }
 
 // GetAttributesSetVersionNum
 // Returns edition of supported attributes, EAttributesSetVersion.
 func (vca *VapmControlApi) GetAttributesSetVersionNum(ctx context.Context) (*PxgValInt, error) {
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetAttributesSetVersionNum", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	pxgValInt := new(PxgValInt)
-	_, err = vca.client.Request(ctx, request, &pxgValInt)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetAttributesSetVersionNum", nil, &pxgValInt)
 	return pxgValInt, err
 }
 
 // GetDownloadPatchDataChunk
 // Get the downloaded patch body chunk.
 func (vca *VapmControlApi) GetDownloadPatchDataChunk(ctx context.Context, wstrRequestId string, nStartPos, nSizeMax int64) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s", "nStartPos": %d, "nSizeMax": %d}`, wstrRequestId, nStartPos, nSizeMax))
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetDownloadPatchDataChunk", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
+	postData := map[string]interface{}{
+		"wstrRequestId": wstrRequestId,
+		"nStartPos":     nStartPos,
+		"nSizeMax":      nSizeMax,
 	}
 
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.GetDownloadPatchDataChunk", postData, nil)
 	return raw, err
 }
 
 // GetDownloadPatchResult
 // Get the information on the patch download result.
 func (vca *VapmControlApi) GetDownloadPatchResult(ctx context.Context, wstrRequestId string) ([]byte, error) {
-	postData := []byte(fmt.Sprintf(`{"wstrRequestId": "%s"}`, wstrRequestId))
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetDownloadPatchResult", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"wstrRequestId": wstrRequestId}
 
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.GetDownloadPatchResult", postData, nil)
 	return raw, err
 }
 
@@ -249,14 +182,10 @@ type PEULAParam struct {
 
 // GetEulaParams Requests EULA params.
 func (vca *VapmControlApi) GetEulaParams(ctx context.Context, nEulaId int64) (*PEULAParams, error) {
-	postData := []byte(fmt.Sprintf(`{"nEulaId": %d}`, nEulaId))
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetEulaParams", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nEulaId": nEulaId}
 
 	pEulaParams := new(PEULAParams)
-	_, err = vca.client.Request(ctx, request, &pEulaParams)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetEulaParams", postData, &pEulaParams)
 	return pEulaParams, err
 }
 
@@ -276,18 +205,8 @@ type EulasIDS struct {
 // GetEulasIdsForPatchPrerequisites
 // Requests the set of EULA ids for the distributives/patches which are required to install the given patch.
 func (vca *VapmControlApi) GetEulasIdsForPatchPrerequisites(ctx context.Context, params EulasIDSForPatchPrerequisitesParams) (*EulasIDS, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetEulasIdsForPatchPrerequisites", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	eulasIDSForPatchPrerequisites := new(EulasIDS)
-	_, err = vca.client.Request(ctx, request, &eulasIDSForPatchPrerequisites)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetEulasIdsForPatchPrerequisites", params, &eulasIDSForPatchPrerequisites)
 	return eulasIDSForPatchPrerequisites, err
 }
 
@@ -314,17 +233,8 @@ type EulasID struct {
 // GetEulasIdsForUpdates
 // Requests the set of EULA ids for the given set of updates.
 func (vca *VapmControlApi) GetEulasIdsForUpdates(ctx context.Context, params EulasIdsForUpdatesParams) (*EulasID, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetEulasIdsForUpdates", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
 	eulasID := new(EulasID)
-	_, err = vca.client.Request(ctx, request, &eulasID)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetEulasIdsForUpdates", params, &eulasID)
 	return eulasID, err
 }
 
@@ -336,17 +246,7 @@ type EulasIDSForVulnerabilitiesPatchesParams struct {
 // GetEulasIdsForVulnerabilitiesPatches Requests set of EULA ids for the given set of vulnerabilities.
 // Remark: not implemented
 func (vca *VapmControlApi) GetEulasIdsForVulnerabilitiesPatches(ctx context.Context, params EulasIDSForVulnerabilitiesPatchesParams) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetEulasIdsForVulnerabilitiesPatches", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.GetEulasIdsForVulnerabilitiesPatches", params, nil)
 	return raw, err
 }
 
@@ -396,18 +296,8 @@ type PEulasInfoValue struct {
 
 // GetEulasInfo Requests the set of EULA descriptors for the given set of updates.
 func (vca *VapmControlApi) GetEulasInfo(ctx context.Context, params PUpdates) (*PEulasInfo, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetEulasInfo", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
 	pEulasInfo := new(PEulasInfo)
-	_, err = vca.client.Request(ctx, request, &pEulasInfo)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetEulasInfo", params, &pEulasInfo)
 	return pEulasInfo, err
 }
 
@@ -419,13 +309,8 @@ type PendingRulesTasks struct {
 
 // GetPendingRulesTasks Get identities of VAPM tasks which rules are still being processed.
 func (vca *VapmControlApi) GetPendingRulesTasks(ctx context.Context) (*PendingRulesTasks, error) {
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetPendingRulesTasks", nil)
-	if err != nil {
-		return nil, err
-	}
-
 	pendingRulesTasks := new(PendingRulesTasks)
-	_, err = vca.client.Request(ctx, request, &pendingRulesTasks)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetPendingRulesTasks", nil, &pendingRulesTasks)
 	return pendingRulesTasks, err
 }
 
@@ -437,15 +322,10 @@ type SupportedLcids struct {
 
 // GetSupportedLcidsForPatchPrerequisites Gets all LCIDs supported by distributives/patches which are required to install the given patch.
 func (vca *VapmControlApi) GetSupportedLcidsForPatchPrerequisites(ctx context.Context, llPatchGlobalId, nOriginalLcid int64) (*SupportedLcids, error) {
-	postData := []byte(fmt.Sprintf(`{"llPatchGlobalId": %d, "nOriginalLcid": %d}`, llPatchGlobalId, nOriginalLcid))
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetSupportedLcidsForPatchPrerequisites", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"llPatchGlobalId": llPatchGlobalId, "nOriginalLcid": nOriginalLcid}
 
 	supportedLcids := new(SupportedLcids)
-	_, err = vca.client.Request(ctx, request, *supportedLcids)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetSupportedLcidsForPatchPrerequisites", postData, *supportedLcids)
 	return supportedLcids, err
 }
 
@@ -457,42 +337,21 @@ type PSupportedLanguages struct {
 
 // GetUpdateSupportedLanguagesFilter Get filter of supported languages for software update.
 func (vca *VapmControlApi) GetUpdateSupportedLanguagesFilter(ctx context.Context, nUpdateSource int64) (*PSupportedLanguages, error) {
-	postData := []byte(fmt.Sprintf(`{"nUpdateSource": %d}`, nUpdateSource))
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.GetUpdateSupportedLanguagesFilter", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
+	postData := map[string]interface{}{"nUpdateSource": nUpdateSource}
 
 	pSupportedLanguages := new(PSupportedLanguages)
-	_, err = vca.client.Request(ctx, request, &pSupportedLanguages)
+	_, err := vca.client.Call(ctx, "VapmControlApi.GetUpdateSupportedLanguagesFilter", postData, &pSupportedLanguages)
 	return pSupportedLanguages, err
 }
 
 // InitiateDownload Check if any updates have KLVAPM::DS_NEED_DOWNLOAD state, and start the download process if needed.
 func (vca *VapmControlApi) InitiateDownload(ctx context.Context) ([]byte, error) {
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.InitiateDownload",
-		nil)
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.InitiateDownload", nil, nil)
 	return raw, err
 }
 
 // SetPackagesToFixVulnerability Set custom packages as patches for a vulnerability.
 func (vca *VapmControlApi) SetPackagesToFixVulnerability(ctx context.Context, params interface{}) ([]byte, error) {
-	postData, err := json.Marshal(params)
-	if err != nil {
-		return nil, err
-	}
-
-	request, err := http.NewRequest("POST", vca.client.Server+"/api/v1.0/VapmControlApi.SetPackagesToFixVulnerability", bytes.NewBuffer(postData))
-	if err != nil {
-		return nil, err
-	}
-
-	raw, err := vca.client.Request(ctx, request, nil)
+	raw, err := vca.client.Call(ctx, "VapmControlApi.SetPackagesToFixVulnerability", params, nil)
 	return raw, err
 }
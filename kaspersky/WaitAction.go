@@ -0,0 +1,323 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultActionCheckDelay delay between CheckActionState calls if the server didn't ask for one.
+const defaultActionCheckDelay = time.Second
+
+// ActionError is returned by WaitAction when the async action has been finalized unsuccessfully.
+// Unwrap maps the error code to the sentinel errors the same way as KscError.
+type ActionError struct {
+	// GUID async action id
+	GUID string
+
+	// StateCode lStateCode of the last CheckActionState
+	StateCode int64
+
+	// Code error code, KLBLAG_ERROR_CODE
+	Code int64
+
+	// Subcode error subcode, KLBLAG_ERROR_SUBCODE
+	Subcode int64
+
+	// Module error module, KLBLAG_ERROR_MODULE
+	Module string
+
+	// File source file on the server side, KLBLAG_ERROR_FNAME
+	File string
+
+	// Line source line on the server side, KLBLAG_ERROR_LNUMBER
+	Line int64
+
+	// Message error message, KLBLAG_ERROR_MSG
+	Message string
+}
+
+func newActionError(guid string, res *ActionStateResult) *ActionError {
+	e := &ActionError{GUID: guid, StateCode: res.LStateCode}
+	if d := res.PStateData; d != nil {
+		e.Code = d.KlblagErrorCode
+		e.Subcode = d.KlblagErrorSubcode
+		e.Module = d.KlblagErrorModule
+		e.File = d.KlblagErrorFname
+		e.Line = d.KlblagErrorLnumber
+		e.Message = d.KlblagErrorMsg
+	}
+	return e
+}
+
+func (e *ActionError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "kaspersky: async action %s failed", e.GUID)
+	if e.Message != "" {
+		sb.WriteString(": " + e.Message)
+	}
+	if e.Module != "" || e.Code != 0 {
+		fmt.Fprintf(&sb, " (module: %s, code: %d, subcode: %d)", e.Module, e.Code, e.Subcode)
+	}
+	return sb.String()
+}
+
+// Unwrap returns the sentinel error matching the error code, or nil if there is no such sentinel.
+func (e *ActionError) Unwrap() error {
+	return (&KscError{Code: e.Code, Module: e.Module}).Unwrap()
+}
+
+// WaitOption changes how WaitAction waits for an async action.
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	progress func(state *ActionStateResult)
+	cancel   func(ctx context.Context) error
+}
+
+// WithProgress calls fn with the result of every CheckActionState, including the final one.
+func WithProgress(fn func(state *ActionStateResult)) WaitOption {
+	return func(o *waitOptions) {
+		o.progress = fn
+	}
+}
+
+// WithCancelAction calls fn to cancel the server-side operation if the context is canceled while waiting.
+// The options returned by the *CancelOption methods, e.g. HostGroup.FindHostsAsyncCancelOption, call
+// the matching cancel method.
+//
+// Without a cancel option canceling the context is client-side only: WaitAction stops waiting,
+// the operation keeps running on the server.
+func WithCancelAction(fn func(ctx context.Context) error) WaitOption {
+	return func(o *waitOptions) {
+		o.cancel = fn
+	}
+}
+
+// FindHostsAsyncCancelOption cancels HostGroup.FindHostsAsync strRequestId with HostGroup.FindHostsAsyncCancel
+// if WaitAction is canceled.
+func (hg *HostGroup) FindHostsAsyncCancelOption(strRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		return hg.FindHostsAsyncCancel(ctx, strRequestId)
+	})
+}
+
+// ExportHWInvStorageCancelOption cancels HWInvStorage.ExportHWInvStorage2 wstrAsyncId
+// with HWInvStorage.ExportHWInvStorageCancel if WaitAction is canceled.
+func (hw *HWInvStorage) ExportHWInvStorageCancelOption(wstrAsyncId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		return hw.ExportHWInvStorageCancel(ctx, wstrAsyncId)
+	})
+}
+
+// ImportHWInvStorageCancelOption cancels HWInvStorage.ImportHWInvStorage2 wstrAsyncId
+// with HWInvStorage.ImportHWInvStorageCancel if WaitAction is canceled.
+func (hw *HWInvStorage) ImportHWInvStorageCancelOption(wstrAsyncId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := hw.ImportHWInvStorageCancel(ctx, AsyncID{WstrAsyncID: wstrAsyncId})
+		return err
+	})
+}
+
+// CancelAsyncActionOption cancels HostTagsRulesApi.ExecuteRule wstrActionGuid
+// with HostTagsRulesApi.CancelAsyncAction if WaitAction is canceled.
+func (htra *HostTagsRulesApi) CancelAsyncActionOption(wstrActionGuid string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := htra.CancelAsyncAction(ctx, wstrActionGuid)
+		return err
+	})
+}
+
+// CancelDownloadDistributiveOption cancels KLEVerControl.DownloadDistributiveAsync wstrRequestId
+// with KLEVerControl.CancelDownloadDistributive if WaitAction is canceled.
+func (kvc *KLEVerControl) CancelDownloadDistributiveOption(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := kvc.CancelDownloadDistributive(ctx, wstrRequestId)
+		return err
+	})
+}
+
+// CancelGeneratePackage2Option cancels GeneratePackageAsync2 wstrRequestId
+// with MdmCertCtrlApi.CancelGeneratePackage2 if WaitAction is canceled.
+func (mca *MdmCertCtrlApi) CancelGeneratePackage2Option(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		return mca.CancelGeneratePackage2(ctx, wstrRequestId)
+	})
+}
+
+// CancelSetCertificate2Option cancels SetCertificateAsync2 wstrRequestId
+// with MdmCertCtrlApi.CancelSetCertificate2 if WaitAction is canceled.
+func (mca *MdmCertCtrlApi) CancelSetCertificate2Option(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		return mca.CancelSetCertificate2(ctx, wstrRequestId)
+	})
+}
+
+// CancelExportOption cancels MigrationData.Export wstrActionGuid with MigrationData.CancelExport
+// if WaitAction is canceled.
+func (md *MigrationData) CancelExportOption(wstrActionGuid string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := md.CancelExport(ctx, wstrActionGuid)
+		return err
+	})
+}
+
+// CancelCreateExecutablePkgOption cancels PackagesApi.CreateExecutablePkgAsync wstrRequestId
+// with PackagesApi.CancelCreateExecutablePkg if WaitAction is canceled.
+func (pa *PackagesApi) CancelCreateExecutablePkgOption(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := pa.CancelCreateExecutablePkg(ctx, wstrRequestId)
+		return err
+	})
+}
+
+// CancelGetExecutablePkgFileOption cancels PackagesApi.GetExecutablePkgFileAsync wstrRequestId
+// with PackagesApi.CancelGetExecutablePkgFile if WaitAction is canceled.
+func (pa *PackagesApi) CancelGetExecutablePkgFileOption(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := pa.CancelGetExecutablePkgFile(ctx, wstrRequestId)
+		return err
+	})
+}
+
+// CancelRecordNewPackageOption cancels PackagesApi.RecordNewPackageAsync wstrRequestId
+// with PackagesApi.CancelRecordNewPackage if WaitAction is canceled.
+func (pa *PackagesApi) CancelRecordNewPackageOption(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := pa.CancelRecordNewPackage(ctx, wstrRequestId)
+		return err
+	})
+}
+
+// CancelUpdateBasesInPackagesOption cancels PackagesApi.UpdateBasesInPackagesAsync wstrRequestId
+// with PackagesApi.CancelUpdateBasesInPackages if WaitAction is canceled.
+func (pa *PackagesApi) CancelUpdateBasesInPackagesOption(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := pa.CancelUpdateBasesInPackages(ctx, wstrRequestId)
+		return err
+	})
+}
+
+// RemoveUpdatesCancelOption cancels Updates.RemoveUpdates strRequestId with Updates.RemoveUpdatesCancel
+// if WaitAction is canceled.
+func (upd *Updates) RemoveUpdatesCancelOption(strRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		return upd.RemoveUpdatesCancel(ctx, strRequestId)
+	})
+}
+
+// CancelDeleteFilesForUpdatesOption cancels VapmControlApi.DeleteFilesForUpdates wstrRequestId
+// with VapmControlApi.CancelDeleteFilesForUpdates if WaitAction is canceled.
+func (vca *VapmControlApi) CancelDeleteFilesForUpdatesOption(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := vca.CancelDeleteFilesForUpdates(ctx, wstrRequestId)
+		return err
+	})
+}
+
+// CancelDownloadPatchOption cancels VapmControlApi.DownloadPatchAsync wstrRequestId
+// with VapmControlApi.CancelDownloadPatch if WaitAction is canceled.
+func (vca *VapmControlApi) CancelDownloadPatchOption(wstrRequestId string) WaitOption {
+	return WithCancelAction(func(ctx context.Context) error {
+		_, err := vca.CancelDownloadPatch(ctx, wstrRequestId)
+		return err
+	})
+}
+
+// WaitAction polls AsyncActionStateChecker.CheckActionState for the async action wstrActionGuid,
+// honoring lNextCheckDelay, until the action is finalized.
+//
+// It returns the final state if the action succeeded, or the final state and *ActionError if it failed.
+// If ctx is canceled, WaitAction returns the error of ctx. The server-side operation is canceled
+// only with a cancel option, see WithCancelAction, otherwise it keeps running on the server.
+//
+// CheckActionState is called without retries: the action is removed once it's finalized,
+// so a repeated call after a lost response would fail, and each call must honor lNextCheckDelay.
+// A call refused because the session has expired is still replayed once after re-authentication,
+// the server hasn't executed it.
+//
+//	action, _, err := client.HostGroup.RemoveGroup(ctx, 42, 1)
+//	if err != nil {
+//		...
+//	}
+//	_, err = client.AsyncActionStateChecker.WaitAction(ctx, action.WstrActionGUID,
+//		kaspersky.WithProgress(func(s *kaspersky.ActionStateResult) { log.Println("state", s.LStateCode) }))
+func (ac *AsyncActionStateChecker) WaitAction(ctx context.Context, wstrActionGuid string, opts ...WaitOption) (*ActionStateResult, error) {
+	o := new(waitOptions)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	pollCtx := WithCallOptions(ctx, withoutRetry())
+	for {
+		res, _, err := ac.CheckActionState(pollCtx, wstrActionGuid)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, o.cancelAction(ctx)
+			}
+			return nil, err
+		}
+		if o.progress != nil {
+			o.progress(res)
+		}
+
+		if res.BFinalized {
+			if !res.BSuccededFinalized {
+				return res, newActionError(wstrActionGuid, res)
+			}
+			return res, nil
+		}
+
+		delay := res.NextCheckDelay()
+		if delay <= 0 {
+			delay = defaultActionCheckDelay
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return res, o.cancelAction(ctx)
+		}
+	}
+}
+
+// cancelAction cancels the server-side operation after ctx is canceled and returns the error of ctx.
+func (o *waitOptions) cancelAction(ctx context.Context) error {
+	if o.cancel == nil {
+		return ctx.Err()
+	}
+
+	cancelCtx, cancel := context.WithTimeout(detach(ctx), releaseTimeout)
+	defer cancel()
+
+	if err := o.cancel(cancelCtx); err != nil {
+		return fmt.Errorf("%w (cancel action: %v)", ctx.Err(), err)
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,207 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package kaspersky
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// actionServer replies to CheckActionState with pending states, then with final.
+func actionServer(t *testing.T, pending int32, final string) *fakeServer {
+	t.Helper()
+
+	var checks int32
+	srv := newFakeServer(t)
+	srv.handle("AsyncActionStateChecker.CheckActionState", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&checks, 1) <= pending {
+			_, _ = w.Write([]byte(`{"bFinalized":false,"lStateCode":0,"lNextCheckDelay":20}`))
+			return
+		}
+		_, _ = w.Write([]byte(final))
+	})
+	return srv
+}
+
+func TestWaitAction(t *testing.T) {
+	srv := actionServer(t, 2, `{"bFinalized":true,"bSuccededFinalized":true,"lStateCode":1}`)
+	c := srv.client(Config{})
+
+	var states []*ActionStateResult
+	start := time.Now()
+	res, err := c.AsyncActionStateChecker.WaitAction(context.Background(), "g1",
+		WithProgress(func(s *ActionStateResult) { states = append(states, s) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.LStateCode != 1 || len(states) != 3 || states[2] != res {
+		t.Errorf("WaitAction() = %+v, progress %d times", res, len(states))
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("WaitAction() returned after %v, want lNextCheckDelay honored", d)
+	}
+}
+
+func TestWaitActionFailed(t *testing.T) {
+	srv := actionServer(t, 0, `{"bFinalized":true,"bSuccededFinalized":false,"lStateCode":2,`+
+		`"pStateData":{"KLBLAG_ERROR_CODE":1154,"KLBLAG_ERROR_MODULE":"KLSTD","KLBLAG_ERROR_MSG":"Access denied"}}`)
+	c := srv.client(Config{})
+
+	res, err := c.AsyncActionStateChecker.WaitAction(context.Background(), "g1")
+	var actionErr *ActionError
+	if !errors.As(err, &actionErr) || !errors.Is(err, ErrAccessDenied) || res == nil {
+		t.Fatalf("WaitAction() = %+v, %v, want *ActionError", res, err)
+	}
+	if want := "kaspersky: async action g1 failed: Access denied (module: KLSTD, code: 1154, subcode: 0)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestWaitActionCanceled(t *testing.T) {
+	srv := actionServer(t, 1<<30, "")
+	c := srv.client(Config{})
+
+	// without WithCancelAction canceling the context only stops waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.AsyncActionStateChecker.WaitAction(ctx, "g1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitAction() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var canceled int32
+	_, err := c.AsyncActionStateChecker.WaitAction(ctx, "g2", WithCancelAction(func(ctx context.Context) error {
+		atomic.AddInt32(&canceled, 1)
+		if ctx.Err() != nil {
+			t.Error("cancel action called with canceled context")
+		}
+		return c.HostGroup.FindHostsAsyncCancel(ctx, "g2")
+	}))
+	if !errors.Is(err, context.DeadlineExceeded) || atomic.LoadInt32(&canceled) != 1 {
+		t.Errorf("WaitAction() error = %v, cancel action called %d times", err, canceled)
+	}
+	if n := len(srv.received("HostGroup.FindHostsAsyncCancel")); n != 1 {
+		t.Errorf("FindHostsAsyncCancel called %d times, want 1", n)
+	}
+}
+
+func TestWaitActionNoRetry(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.handle("AsyncActionStateChecker.CheckActionState", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff, policy.MaxBackoff, policy.RetryMutating = time.Millisecond, time.Millisecond, true
+	c := srv.client(Config{RetryPolicy: policy})
+
+	ctx := WithCallOptions(context.Background(), Idempotent())
+	if _, err := c.AsyncActionStateChecker.WaitAction(ctx, "g1"); err == nil {
+		t.Fatal("WaitAction() succeeded on unavailable server")
+	}
+	if n := len(srv.received("AsyncActionStateChecker.CheckActionState")); n != 1 {
+		t.Errorf("CheckActionState sent %d times, want 1", n)
+	}
+
+	// the same call out of WaitAction is retried
+	if _, _, err := c.AsyncActionStateChecker.CheckActionState(ctx, "g1"); err == nil {
+		t.Fatal("CheckActionState() succeeded on unavailable server")
+	}
+	if n := len(srv.received("AsyncActionStateChecker.CheckActionState")); n != 1+policy.MaxAttempts {
+		t.Errorf("CheckActionState sent %d times in total, want %d", n, 1+policy.MaxAttempts)
+	}
+}
+
+func TestWaitActionCancelOptions(t *testing.T) {
+	srv := actionServer(t, 1<<30, "")
+	c := srv.client(Config{})
+
+	tests := []struct {
+		method string
+		opt    WaitOption
+		body   string
+	}{
+		{"HostGroup.FindHostsAsyncCancel", c.HostGroup.FindHostsAsyncCancelOption("r1"), `{"strRequestId":"r1"}`},
+		{"HWInvStorage.ExportHWInvStorageCancel", c.HWInvStorage.ExportHWInvStorageCancelOption("r1"), `{"wstrAsyncId":"r1"}`},
+		{"HWInvStorage.ImportHWInvStorageCancel", c.HWInvStorage.ImportHWInvStorageCancelOption("r1"), `{"wstrAsyncId":"r1"}`},
+		{"HostTagsRulesApi.CancelAsyncAction", c.HostTagsRulesAPI.CancelAsyncActionOption("r1"), `{"wstrActionGuid":"r1"}`},
+		{"KLEVerControl.CancelDownloadDistributive", c.KLEVerControl.CancelDownloadDistributiveOption("r1"), `{"wstrRequestId":"r1"}`},
+		{"MdmCertCtrlApi.CancelGeneratePackage2", c.MdmCertCtrlApi.CancelGeneratePackage2Option("r1"), `{"wstrRequestId":"r1"}`},
+		{"MdmCertCtrlApi.CancelSetCertificate2", c.MdmCertCtrlApi.CancelSetCertificate2Option("r1"), `{"wstrRequestId":"r1"}`},
+		{"MigrationData.CancelExport", c.MigrationData.CancelExportOption("r1"), `{"wstrActionGuid":"r1"}`},
+		{"PackagesApi.CancelCreateExecutablePkg", c.PackagesAPI.CancelCreateExecutablePkgOption("r1"), `{"wstrRequestId":"r1"}`},
+		{"PackagesApi.CancelGetExecutablePkgFile", c.PackagesAPI.CancelGetExecutablePkgFileOption("r1"), `{"wstrRequestId":"r1"}`},
+		{"PackagesApi.CancelRecordNewPackage", c.PackagesAPI.CancelRecordNewPackageOption("r1"), `{"wstrRequestId":"r1"}`},
+		{"PackagesApi.CancelUpdateBasesInPackages", c.PackagesAPI.CancelUpdateBasesInPackagesOption("r1"), `{"wstrRequestId":"r1"}`},
+		{"Updates.RemoveUpdatesCancel", c.Updates.RemoveUpdatesCancelOption("r1"), `{"strRequestId":"r1"}`},
+		{"VapmControlApi.CancelDeleteFilesForUpdates", c.VapmControlAPI.CancelDeleteFilesForUpdatesOption("r1"), `{"wstrRequestId":"r1"}`},
+		{"VapmControlApi.CancelDownloadPatch", c.VapmControlAPI.CancelDownloadPatchOption("r1"), `{"wstrRequestId":"r1"}`},
+	}
+	for _, tt := range tests {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := c.AsyncActionStateChecker.WaitAction(ctx, "g1", tt.opt); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: WaitAction() error = %v, want context.Canceled", tt.method, err)
+		}
+		got := srv.received(tt.method)
+		if len(got) != 1 || !jsonEqual(t, got[0].Body, []byte(tt.body)) {
+			t.Errorf("%s: sent %d times, want once with %s", tt.method, len(got), tt.body)
+		}
+	}
+}
+
+func TestWaitActionReAuth(t *testing.T) {
+	srv, expire := sessionServer(t)
+	srv.handle("AsyncActionStateChecker.CheckActionState", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-KSC-Session") == "token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"bFinalized":true,"bSuccededFinalized":true,"lStateCode":1}`))
+	})
+	c := srv.client(Config{UserName: "user", Password: "pass", XKscSession: true})
+
+	ctx := context.Background()
+	if err := c.Login(ctx, BasicAuth, ""); err != nil {
+		t.Fatal(err)
+	}
+	expire()
+
+	// the call refused for the expired session is replayed once after re-authentication
+	if _, err := c.AsyncActionStateChecker.WaitAction(ctx, "g1"); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(srv.received("Session.StartSession")); n != 2 {
+		t.Errorf("StartSession called %d times, want 2", n)
+	}
+	if n := len(srv.received("AsyncActionStateChecker.CheckActionState")); n != 2 {
+		t.Errorf("CheckActionState sent %d times, want 2", n)
+	}
+}
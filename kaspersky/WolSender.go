@@ -25,11 +25,7 @@
 package kaspersky
 
 import (
-	"bytes"
 	"context"
-	"fmt"
-
-	"net/http"
 )
 
 // WolSender service Wake-On-LAN signal sender.
@@ -38,13 +34,8 @@ type WolSender service
 // SendWolSignal Sends Wake-On-LAN signal to host.
 // The goal of this call is to wake target host up.
 func (ws *WolSender) SendWolSignal(ctx context.Context, szwHostId string) error {
-	postData := []byte(fmt.Sprintf(`{"szwHostId":"%s"}`, szwHostId))
-	request, err := http.NewRequest("POST", ws.client.Server+"/api/v1.0/WolSender.SendWolSignal",
-		bytes.NewBuffer(postData))
-	if err != nil {
-		return err
-	}
+	postData := map[string]interface{}{"szwHostId": szwHostId}
 
-	_, err = ws.client.Request(ctx, request, nil)
+	_, err := ws.client.Call(ctx, "WolSender.SendWolSignal", postData, nil)
 	return err
 }
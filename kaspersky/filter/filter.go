@@ -0,0 +1,453 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+// Package filter builds and parses KSC search filters, the LDAP-like (RFC 2254) filter strings
+// used by HostGroup.FindHosts, SrvView.ResetIterator, EventProcessingFactory and other find methods.
+//
+//	f := filter.And(
+//		filter.Eq("KLHST_WKS_DN", name),
+//		filter.Lt("KLHST_WKS_LAST_VISIBLE", time.Now().Add(-24*time.Hour)),
+//	)
+//	params.WstrFilter = f.String() // (&(KLHST_WKS_DN = "name")(KLHST_WKS_LAST_VISIBLE < T"2020-01-02 03:04:05"))
+//
+// Attribute names aren't quoted in the syntax, so they must consist of letters, digits, _, . and -.
+// Use Render to get an error for invalid filters, e.g. built from user input;
+// String renders invalid nodes as a syntax error, so KSC rejects the filter instead of matching more.
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter search filter node: *Comparison, *Logical, *Not or *None.
+type Filter interface {
+	// String renders the filter in KSC filter syntax.
+	String() string
+}
+
+// Comparison operators.
+const (
+	OpEq = "="
+	OpNe = "<>"
+	OpLt = "<"
+	OpLe = "<="
+	OpGt = ">"
+	OpGe = ">="
+)
+
+// Comparison compares the attribute with the value, e.g. (KLHST_WKS_STATUS_ID = 1).
+type Comparison struct {
+	Attr  string
+	Op    string
+	Value Value
+}
+
+func (c *Comparison) String() string {
+	if err := c.check(); err != nil {
+		return invalid(err)
+	}
+	return "(" + c.Attr + " " + c.Op + " " + c.Value.String() + ")"
+}
+
+func (c *Comparison) check() error {
+	if !validAttr(c.Attr) {
+		return fmt.Errorf("invalid attribute name %q", c.Attr)
+	}
+	switch c.Op {
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe:
+	default:
+		return fmt.Errorf("invalid operator %q", c.Op)
+	}
+	if c.Value == nil {
+		return fmt.Errorf("no value to compare %s with", c.Attr)
+	}
+	return nil
+}
+
+func validAttr(attr string) bool {
+	if attr == "" {
+		return false
+	}
+	for i := 0; i < len(attr); i++ {
+		if !isAttrChar(attr[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// invalid renders the error as a syntax error which can't be taken for a part of the filter.
+func invalid(err error) string {
+	return "(?" + strconv.Quote(err.Error()) + ")"
+}
+
+// Logical operators.
+const (
+	OpAnd = "&"
+	OpOr  = "|"
+)
+
+// Logical conjunction or disjunction of filters, e.g. (&(A = 1)(B = 2)).
+type Logical struct {
+	Op      string
+	Filters []Filter
+}
+
+func (l *Logical) String() string {
+	if err := l.check(); err != nil {
+		return invalid(err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("(" + l.Op)
+	for _, f := range l.Filters {
+		if f != nil {
+			sb.WriteString(f.String())
+		}
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func (l *Logical) check() error {
+	if l.Op != OpAnd && l.Op != OpOr {
+		return fmt.Errorf("invalid logical operator %q", l.Op)
+	}
+	for _, f := range l.Filters {
+		if f != nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("no filters in %q", l.Op)
+}
+
+// Not negation of the filter, e.g. (!(A = 1)).
+type Not struct {
+	Filter Filter
+}
+
+func (n *Not) String() string {
+	if err := n.check(); err != nil {
+		return invalid(err)
+	}
+	return "(!" + n.Filter.String() + ")"
+}
+
+func (n *Not) check() error {
+	if n.Filter == nil {
+		return errors.New("no filter to negate")
+	}
+	return nil
+}
+
+// ErrNoMatch is returned by Render for filters which match nothing, e.g. Or with no filters.
+// KSC filter syntax can't express such a filter, skip the search instead.
+var ErrNoMatch = errors.New("filter: matches nothing")
+
+// None filter which matches nothing, returned by Or with no filters and by Negate(nil).
+// It's rendered as a syntax error, so KSC rejects the search instead of matching everything,
+// and Render reports it with ErrNoMatch.
+type None struct{}
+
+func (*None) String() string {
+	return invalid(errors.New("matches nothing"))
+}
+
+// Render validates the filter and renders it in KSC filter syntax. nil filter is rendered as empty string.
+func Render(f Filter) (string, error) {
+	var err error
+	Walk(f, func(f Filter) {
+		var e error
+		switch f := f.(type) {
+		case *Comparison:
+			e = f.check()
+		case *Logical:
+			e = f.check()
+		case *Not:
+			e = f.check()
+		case *None:
+			if err == nil {
+				err = ErrNoMatch
+			}
+		}
+		if err == nil && e != nil {
+			err = fmt.Errorf("filter: %w", e)
+		}
+	})
+	if err != nil || f == nil {
+		return "", err
+	}
+	return f.String(), nil
+}
+
+// Value literal of a comparison: String, Pattern, Int, Uint or Time.
+type Value interface {
+	// String renders the literal in KSC filter syntax.
+	String() string
+
+	literal()
+}
+
+// String string literal, compared exactly.
+type String string
+
+func (String) literal() {}
+
+func (s String) String() string {
+	return `"` + escape(string(s), true) + `"`
+}
+
+// Pattern string literal in which * matches any sequence of characters and \* matches literal *.
+type Pattern string
+
+func (Pattern) literal() {}
+
+func (p Pattern) String() string {
+	return `"` + escape(string(p), false) + `"`
+}
+
+// Int integer literal.
+type Int int64
+
+func (Int) literal() {}
+
+func (i Int) String() string {
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// Uint integer literal above the range of Int.
+type Uint uint64
+
+func (Uint) literal() {}
+
+func (u Uint) String() string {
+	return strconv.FormatUint(uint64(u), 10)
+}
+
+// TimeLayout layout of datetime literals, the time is in UTC.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// Time datetime literal, e.g. T"2020-01-02 03:04:05".
+type Time time.Time
+
+func (Time) literal() {}
+
+func (t Time) String() string {
+	return `T"` + time.Time(t).UTC().Format(TimeLayout) + `"`
+}
+
+// escape escapes backslash and double quote. If the string is compared exactly * is escaped too,
+// otherwise \* escape of the pattern is kept.
+func escape(s string, exact bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && !exact && i+1 < len(s) && s[i+1] == '*':
+			sb.WriteString(`\*`)
+			i++
+			continue
+		case c == '\\' || c == '"' || (exact && c == '*'):
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(s[i])
+	}
+	return sb.String()
+}
+
+// ValueOf converts Go value to filter literal: strings to String, integers to Int (Uint above the range of Int),
+// bool to Int 1 or 0, time.Time to Time. Values which already are Value are returned as is, other values are formatted as String.
+func ValueOf(v interface{}) Value {
+	switch v := v.(type) {
+	case Value:
+		return v
+	case string:
+		return String(v)
+	case int:
+		return Int(v)
+	case int8:
+		return Int(v)
+	case int16:
+		return Int(v)
+	case int32:
+		return Int(v)
+	case int64:
+		return Int(v)
+	case uint:
+		return unsigned(uint64(v))
+	case uint8:
+		return Int(v)
+	case uint16:
+		return Int(v)
+	case uint32:
+		return Int(v)
+	case uint64:
+		return unsigned(v)
+	case bool:
+		if v {
+			return Int(1)
+		}
+		return Int(0)
+	case time.Time:
+		return Time(v)
+	}
+	return String(fmt.Sprint(v))
+}
+
+func unsigned(v uint64) Value {
+	if v > math.MaxInt64 {
+		return Uint(v)
+	}
+	return Int(v)
+}
+
+func compare(attr, op string, value interface{}) Filter {
+	return &Comparison{Attr: attr, Op: op, Value: ValueOf(value)}
+}
+
+// Eq attribute equals the value, see ValueOf for supported values.
+func Eq(attr string, value interface{}) Filter {
+	return compare(attr, OpEq, value)
+}
+
+// Ne attribute doesn't equal the value.
+func Ne(attr string, value interface{}) Filter {
+	return compare(attr, OpNe, value)
+}
+
+// Lt attribute is less than the value.
+func Lt(attr string, value interface{}) Filter {
+	return compare(attr, OpLt, value)
+}
+
+// Le attribute is less than or equal to the value.
+func Le(attr string, value interface{}) Filter {
+	return compare(attr, OpLe, value)
+}
+
+// Gt attribute is greater than the value.
+func Gt(attr string, value interface{}) Filter {
+	return compare(attr, OpGt, value)
+}
+
+// Ge attribute is greater than or equal to the value.
+func Ge(attr string, value interface{}) Filter {
+	return compare(attr, OpGe, value)
+}
+
+// Like attribute matches the pattern, * matches any sequence of characters and \* matches literal *.
+func Like(attr, pattern string) Filter {
+	return &Comparison{Attr: attr, Op: OpEq, Value: Pattern(pattern)}
+}
+
+// And all of the filters match. nil filters, which match everything, are skipped:
+// nil is returned if no filters are left and the filter itself if only one is left.
+// And with a *None filter is *None.
+func And(filters ...Filter) Filter {
+	list := make([]Filter, 0, len(filters))
+	for _, f := range filters {
+		switch f.(type) {
+		case nil:
+		case *None:
+			return f
+		default:
+			list = append(list, f)
+		}
+	}
+	return logical(OpAnd, list)
+}
+
+// Or any of the filters matches. nil and *None filters are skipped: *None is returned
+// if no filters are left, so Or of an empty list matches nothing, and the filter itself if only one is left.
+func Or(filters ...Filter) Filter {
+	list := make([]Filter, 0, len(filters))
+	for _, f := range filters {
+		switch f.(type) {
+		case nil, *None:
+		default:
+			list = append(list, f)
+		}
+	}
+	if len(list) == 0 {
+		return &None{}
+	}
+	return logical(OpOr, list)
+}
+
+func logical(op string, list []Filter) Filter {
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	}
+	return &Logical{Op: op, Filters: list}
+}
+
+// Negate the filter doesn't match. nil filter matches everything, so its negation is *None,
+// and the negation of *None is nil.
+func Negate(f Filter) Filter {
+	switch f.(type) {
+	case nil:
+		return &None{}
+	case *None:
+		return nil
+	}
+	return &Not{Filter: f}
+}
+
+// Walk calls fn for the filter and all filters nested in it, depth first.
+func Walk(f Filter, fn func(Filter)) {
+	if f == nil {
+		return
+	}
+
+	fn(f)
+	switch f := f.(type) {
+	case *Logical:
+		for _, child := range f.Filters {
+			Walk(child, fn)
+		}
+	case *Not:
+		Walk(f.Filter, fn)
+	}
+}
+
+// Attributes returns names of the attributes used in the filter, in order of first use.
+func Attributes(f Filter) []string {
+	var attrs []string
+	seen := make(map[string]bool)
+
+	Walk(f, func(f Filter) {
+		if c, ok := f.(*Comparison); ok && !seen[c.Attr] {
+			seen[c.Attr] = true
+			attrs = append(attrs, c.Attr)
+		}
+	})
+	return attrs
+}
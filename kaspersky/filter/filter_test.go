@@ -0,0 +1,198 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package filter
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestString(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 4, 4, 5, 0, time.FixedZone("UTC+1", 3600))
+	tests := []struct {
+		name   string
+		filter Filter
+		want   string
+	}{
+		{"eq string", Eq("KLHST_WKS_DN", `a "b" \ *`), `(KLHST_WKS_DN = "a \"b\" \\ \*")`},
+		{"like", Like("KLHST_WKS_DN", `web-*\*`), `(KLHST_WKS_DN = "web-*\*")`},
+		{"int", Ne("KLHST_WKS_STATUS_ID", 0), `(KLHST_WKS_STATUS_ID <> 0)`},
+		{"bool", Eq("KLHST_WKS_CTYPE", true), `(KLHST_WKS_CTYPE = 1)`},
+		{"uint64 above int64", Ge("A", uint64(math.MaxUint64)), `(A >= 18446744073709551615)`},
+		{"time in UTC", Lt("KLHST_WKS_LAST_VISIBLE", ts), `(KLHST_WKS_LAST_VISIBLE < T"2020-01-02 03:04:05")`},
+		{"and", And(Eq("A", 1), nil, Or(Gt("B", 2), Le("C", 3))), `(&(A = 1)(|(B > 2)(C <= 3)))`},
+		{"single", And(nil, Eq("A", 1)), `(A = 1)`},
+		{"not", Negate(Eq("A", 1)), `(!(A = 1))`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.String(); got != tt.want {
+				t.Errorf("String() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchNothing(t *testing.T) {
+	var hosts []Filter
+	tests := []struct {
+		name   string
+		filter Filter
+	}{
+		{"empty or", Or(hosts...)},
+		{"or of nil", Or(nil)},
+		{"negated nil", Negate(nil)},
+		{"negated and", Negate(And())},
+		{"and with none", And(Eq("A", 1), Or())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.filter.(*None); !ok {
+				t.Fatalf("filter = %#v, want *None", tt.filter)
+			}
+			if s, err := Render(tt.filter); s != "" || !errors.Is(err, ErrNoMatch) {
+				t.Errorf("Render() = %q, %v, want ErrNoMatch", s, err)
+			}
+			if s := tt.filter.String(); !strings.HasPrefix(s, "(?") {
+				t.Errorf("String() = %s, want syntax error", s)
+			}
+		})
+	}
+
+	if f := Or(Or(), Eq("A", 1)); f.String() != "(A = 1)" {
+		t.Errorf("Or(Or(), A = 1) = %v, want A = 1", f)
+	}
+	if f := Negate(Or()); f != nil {
+		t.Errorf("Negate(Or()) = %v, want nil", f)
+	}
+	// manually built trees with *None fail too
+	if _, err := Render(&Logical{Op: OpOr, Filters: []Filter{Eq("A", 1), &None{}}}); !errors.Is(err, ErrNoMatch) {
+		t.Errorf("Render() of nested None = %v, want ErrNoMatch", err)
+	}
+}
+
+func TestInvalidFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter Filter
+	}{
+		{"attribute injection", Eq("a)(b", 1)},
+		{"attribute with space", Eq("A B", 1)},
+		{"empty attribute", Eq("", 1)},
+		{"invalid operator", &Comparison{Attr: "A", Op: "~=", Value: Int(1)}},
+		{"no value", &Comparison{Attr: "A", Op: OpEq}},
+		{"empty not", &Not{}},
+		{"empty logical", &Logical{Op: OpAnd}},
+		{"invalid logical operator", &Logical{Op: "^", Filters: []Filter{Eq("A", 1)}}},
+		{"nested", Or(Eq("A", 1), Negate(Eq("a)(|(b", 1)))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(tt.filter); err == nil {
+				t.Error("Render() succeeded")
+			}
+
+			// String doesn't panic and renders a filter KSC rejects
+			s := tt.filter.String()
+			var syntaxErr *SyntaxError
+			if _, err := Parse(s); !errors.As(err, &syntaxErr) {
+				t.Errorf("Parse(%s) error = %v, want *SyntaxError", s, err)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	filters := []Filter{
+		Eq("KLHST_WKS_DN", `name with "quotes" and \ and *`),
+		Like("KLHST_WKS_DN", `web-*`),
+		Like("KLHST_WKS_DN", `literal\*star*`),
+		Ne("KLHST_WKS_STATUS_ID", -5),
+		Eq("A", uint64(math.MaxInt64)+1),
+		Gt("KLHST_WKS_LAST_VISIBLE", time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+		And(Eq("A", 1), Or(Eq("B", "x"), Negate(Le("C", 3))), Ge("D.E-F", 0)),
+	}
+	for _, f := range filters {
+		s, err := Render(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", s, err)
+		}
+		if !reflect.DeepEqual(got, f) {
+			t.Errorf("Parse(%s) = %#v, want %#v", s, got, f)
+		}
+		if got.String() != s {
+			t.Errorf("Parse(%s).String() = %s", s, got.String())
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse(` ( & ( A=1 )( B<>"x" ) ) `)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := got.String(); s != `(&(A = 1)(B <> "x"))` {
+		t.Errorf("Parse().String() = %s", s)
+	}
+	if attrs := Attributes(got); !reflect.DeepEqual(attrs, []string{"A", "B"}) {
+		t.Errorf("Attributes() = %v", attrs)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		filter string
+		msg    string
+		offset int
+	}{
+		{`(A = 99999999999999999999)`, `invalid integer "99999999999999999999"`, 5},
+		{`(A = -99999999999999999999)`, `invalid integer "-99999999999999999999"`, 5},
+		{`(A = T"2020-13-01 00:00:00")`, `invalid datetime`, 5},
+		{`(A = "x)`, `unterminated string`, 5},
+		{`(&)`, `expected filter after '&'`, 2},
+		{`(A = 1`, `expected ')', got end of filter`, 6},
+		{`(A ~ 1)`, `expected comparison operator`, 3},
+		{`(A = 1) x`, `unexpected "x" after filter`, 8},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.filter)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) || !strings.Contains(syntaxErr.Msg, tt.msg) || syntaxErr.Offset != tt.offset {
+			t.Errorf("Parse(%s) error = %v, want %q at offset %d", tt.filter, err, tt.msg, tt.offset)
+		}
+		if Validate(tt.filter) == nil {
+			t.Errorf("Validate(%s) succeeded", tt.filter)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) [2020] [Semchenko Aleksandr]
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+ */
+
+package filter
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyntaxError describes invalid filter string.
+type SyntaxError struct {
+	// Offset byte offset in the filter string where the error occurred
+	Offset int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("filter: %s at offset %d", e.Msg, e.Offset)
+}
+
+// Parse parses the filter string, e.g. `(&(KLHST_WKS_DN = "name")(KLHST_WKS_STATUS_ID <> 0))`.
+//
+// String literals containing unescaped * are parsed as Pattern. Invalid filters are reported as *SyntaxError.
+func Parse(s string) (Filter, error) {
+	p := &parser{s: s}
+
+	f, err := p.filter()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return nil, p.errorf("unexpected %q after filter", p.s[p.pos:])
+	}
+	return f, nil
+}
+
+// Validate reports whether the filter string is syntactically valid.
+func Validate(s string) error {
+	_, err := Parse(s)
+	return err
+}
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Offset: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) peek() byte {
+	if p.pos < len(p.s) {
+		return p.s[p.pos]
+	}
+	return 0
+}
+
+func (p *parser) expect(c byte) error {
+	p.skipSpaces()
+	if p.peek() != c {
+		if p.pos == len(p.s) {
+			return p.errorf("expected %q, got end of filter", c)
+		}
+		return p.errorf("expected %q, got %q", c, p.s[p.pos])
+	}
+	p.pos++
+	return nil
+}
+
+// filter := "(" ( "&" filter+ | "|" filter+ | "!" filter | comparison ) ")"
+func (p *parser) filter() (Filter, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+
+	var f Filter
+	switch c := p.peek(); c {
+	case '&', '|':
+		p.pos++
+		l := &Logical{Op: string(c)}
+		for {
+			p.skipSpaces()
+			if p.peek() != '(' {
+				break
+			}
+			child, err := p.filter()
+			if err != nil {
+				return nil, err
+			}
+			l.Filters = append(l.Filters, child)
+		}
+		if len(l.Filters) == 0 {
+			return nil, p.errorf("expected filter after %q", c)
+		}
+		f = l
+	case '!':
+		p.pos++
+		child, err := p.filter()
+		if err != nil {
+			return nil, err
+		}
+		f = &Not{Filter: child}
+	default:
+		c, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		f = c
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// comparison := attr op value
+func (p *parser) comparison() (*Comparison, error) {
+	start := p.pos
+	for p.pos < len(p.s) && isAttrChar(p.s[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected attribute name")
+	}
+	c := &Comparison{Attr: p.s[start:p.pos]}
+
+	p.skipSpaces()
+	for _, op := range []string{OpNe, OpLe, OpGe, OpEq, OpLt, OpGt} {
+		if strings.HasPrefix(p.s[p.pos:], op) {
+			c.Op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if c.Op == "" {
+		return nil, p.errorf("expected comparison operator")
+	}
+
+	p.skipSpaces()
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+	c.Value = value
+	return c, nil
+}
+
+func isAttrChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// value := string | "T" string | integer
+func (p *parser) value() (Value, error) {
+	switch c := p.peek(); {
+	case c == '"':
+		s, pattern, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		if pattern {
+			return Pattern(s), nil
+		}
+		return String(strings.Replace(s, `\*`, "*", -1)), nil
+	case c == 'T' && p.pos+1 < len(p.s) && p.s[p.pos+1] == '"':
+		start := p.pos
+		p.pos++
+		s, _, err := p.quoted()
+		if err != nil {
+			return nil, err
+		}
+		t, err := time.Parse(TimeLayout, s)
+		if err != nil {
+			p.pos = start
+			return nil, p.errorf("invalid datetime %q, expected %q", s, TimeLayout)
+		}
+		return Time(t), nil
+	case c == '-' || c == '+' || '0' <= c && c <= '9':
+		start := p.pos
+		p.pos++
+		for p.pos < len(p.s) && '0' <= p.s[p.pos] && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		text := p.s[start:p.pos]
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err == nil {
+			return Int(n), nil
+		}
+		if u, uErr := strconv.ParseUint(strings.TrimPrefix(text, "+"), 10, 64); uErr == nil && u > math.MaxInt64 {
+			return Uint(u), nil
+		}
+		p.pos = start
+		return nil, p.errorf("invalid integer %q", text)
+	case p.pos == len(p.s):
+		return nil, p.errorf("expected value, got end of filter")
+	}
+	return nil, p.errorf("expected value, got %q", p.s[p.pos])
+}
+
+// quoted reads the double quoted string, unescaping \\ and \" (but keeping \*),
+// and reports whether the string has unescaped *.
+func (p *parser) quoted() (s string, pattern bool, err error) {
+	start := p.pos
+	p.pos++ // opening quote
+
+	var sb strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch c {
+		case '"':
+			p.pos++
+			return sb.String(), pattern, nil
+		case '\\':
+			if p.pos+1 == len(p.s) {
+				break
+			}
+			next := p.s[p.pos+1]
+			if next == '*' {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(next)
+			p.pos += 2
+			continue
+		case '*':
+			pattern = true
+		}
+		sb.WriteByte(c)
+		p.pos++
+	}
+
+	p.pos = start
+	return "", false, p.errorf("unterminated string")
+}